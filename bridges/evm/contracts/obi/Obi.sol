@@ -51,7 +51,7 @@ library Obi {
 
     function decodeI16(Data memory data) internal pure returns (int16 value) {
         value = int16(decodeI8(data)) << 8;
-        value |= int16(decodeI8(data));
+        value |= int16(uint16(decodeU8(data)));
     }
 
     function decodeU32(Data memory data) internal pure returns (uint32 value) {
@@ -61,7 +61,7 @@ library Obi {
 
     function decodeI32(Data memory data) internal pure returns (int32 value) {
         value = int32(decodeI16(data)) << 16;
-        value |= int32(decodeI16(data));
+        value |= int32(uint32(decodeU16(data)));
     }
 
     function decodeU64(Data memory data) internal pure returns (uint64 value) {
@@ -71,7 +71,7 @@ library Obi {
 
     function decodeI64(Data memory data) internal pure returns (int64 value) {
         value = int64(decodeI32(data)) << 32;
-        value |= int64(decodeI32(data));
+        value |= int64(uint64(decodeU32(data)));
     }
 
     function decodeU128(Data memory data)
@@ -85,7 +85,7 @@ library Obi {
 
     function decodeI128(Data memory data) internal pure returns (int128 value) {
         value = int128(decodeI64(data)) << 64;
-        value |= int128(decodeI64(data));
+        value |= int128(uint128(decodeU64(data)));
     }
 
     function decodeU256(Data memory data)
@@ -99,7 +99,7 @@ library Obi {
 
     function decodeI256(Data memory data) internal pure returns (int256 value) {
         value = int256(decodeI128(data)) << 128;
-        value |= int256(decodeI128(data));
+        value |= int256(uint256(decodeU128(data)));
     }
 
     function decodeBool(Data memory data) internal pure returns (bool value) {
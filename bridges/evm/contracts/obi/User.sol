@@ -9,6 +9,7 @@ import {ResultDecoder} from "./Result.sol";
 
 contract ObiUser {
     using ResultDecoder for bytes;
+    using Obi for Obi.Data;
 
     function decode(bytes memory _data)
         public
@@ -17,4 +18,24 @@ contract ObiUser {
     {
         return _data.decodeResult();
     }
+
+    function decodeI16(bytes memory _data) public pure returns (int16) {
+        return Obi.from(_data).decodeI16();
+    }
+
+    function decodeI32(bytes memory _data) public pure returns (int32) {
+        return Obi.from(_data).decodeI32();
+    }
+
+    function decodeI64(bytes memory _data) public pure returns (int64) {
+        return Obi.from(_data).decodeI64();
+    }
+
+    function decodeI128(bytes memory _data) public pure returns (int128) {
+        return Obi.from(_data).decodeI128();
+    }
+
+    function decodeI256(bytes memory _data) public pure returns (int256) {
+        return Obi.from(_data).decodeI256();
+    }
 }
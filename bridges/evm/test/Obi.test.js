@@ -24,6 +24,40 @@ contract("Obi", () => {
       result[2].toString().should.eq("100");
     });
 
+    it("should decode signed integers with the high bit of the low half set", async () => {
+      (await this.forTest.decodeI16("0x0080")).toString().should.eq("128");
+      (await this.forTest.decodeI32("0x00008000")).toString().should.eq("32768");
+      (await this.forTest.decodeI64("0x0000000080000000")).toString().should.eq("2147483648");
+      (await this.forTest.decodeI128("0x00000000000000008000000000000000"))
+        .toString()
+        .should.eq("9223372036854775808");
+      (
+        await this.forTest.decodeI256(
+          "0x0000000000000000000000000000000080000000000000000000000000000000"
+        )
+      )
+        .toString()
+        .should.eq("170141183460469231731687303715884105728");
+    });
+
+    it("should decode negative signed integers", async () => {
+      (await this.forTest.decodeI16("0xffff")).toString().should.eq("-1");
+      (await this.forTest.decodeI64("0xffffffffffffffff")).toString().should.eq("-1");
+      (await this.forTest.decodeI128("0xffffffffffffffffffffffffffffffff"))
+        .toString()
+        .should.eq("-1");
+      (
+        await this.forTest.decodeI256(
+          "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+        )
+      )
+        .toString()
+        .should.eq("-1");
+      (await this.forTest.decodeI128("0xffffffffffffffff8000000000000000"))
+        .toString()
+        .should.eq("-9223372036854775808");
+    });
+
     it("should revert if invalid bytes", async () => {
       await expectRevert(
         this.forTest.decode("0x000000034254433200000000000064"),
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
@@ -34,6 +35,7 @@ import (
 	tmos "github.com/tendermint/tendermint/libs/os"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/bandprotocol/bandchain/chain/pkg/owasmcache"
 	"github.com/bandprotocol/bandchain/chain/x/oracle"
 	bandante "github.com/bandprotocol/bandchain/chain/x/oracle/ante"
 	bandsupply "github.com/bandprotocol/bandchain/chain/x/supply"
@@ -135,7 +137,8 @@ func SetBech32AddressPrefixesAndBip44CoinType(config *sdk.Config) {
 func NewBandApp(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool,
 	invCheckPeriod uint, skipUpgradeHeights map[int64]bool, home string,
-	disableFeelessReports bool, owasmCacheSize uint32, baseAppOptions ...func(*bam.BaseApp),
+	disableFeelessReports bool, owasmCacheSize uint32, compiledOwasmCacheSize int64,
+	baseAppOptions ...func(*bam.BaseApp),
 ) *BandApp {
 	cdc := MakeCodec()
 	bApp := bam.NewBaseApp(AppName, logger, db, auth.DefaultTxDecoder(cdc), baseAppOptions...)
@@ -158,6 +161,7 @@ func NewBandApp(
 	if err != nil {
 		panic(err)
 	}
+	compiledCache := newCompiledOwasmCache(logger, compiledOwasmCacheSize)
 	// Initialize params keeper and module subspaces.
 	app.ParamsKeeper = params.NewKeeper(cdc, keys[params.StoreKey], tKeys[params.TStoreKey])
 	authSubspace := app.ParamsKeeper.Subspace(auth.DefaultParamspace)
@@ -184,7 +188,7 @@ func NewBandApp(
 	app.CrisisKeeper = crisis.NewKeeper(crisisSubspace, invCheckPeriod, app.SupplyKeeper, auth.FeeCollectorName)
 	app.SlashingKeeper = slashing.NewKeeper(cdc, keys[slashing.StoreKey], &stakingKeeper, slashingSubspace)
 	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], cdc)
-	app.OracleKeeper = oracle.NewKeeper(cdc, keys[oracle.StoreKey], filepath.Join(viper.GetString(cli.HomeFlag), "files"), auth.FeeCollectorName, oracleSubspace, app.SupplyKeeper, &stakingKeeper, app.DistrKeeper, owasmVM)
+	app.OracleKeeper = oracle.NewKeeper(cdc, keys[oracle.StoreKey], filepath.Join(viper.GetString(cli.HomeFlag), "files"), auth.FeeCollectorName, oracleSubspace, app.SupplyKeeper, &stakingKeeper, app.DistrKeeper, owasmVM, compiledCache)
 	// Register the proposal types.
 	govRouter := gov.NewRouter()
 	govRouter.
@@ -357,3 +361,22 @@ func GetMaccPerms() map[string][]string {
 func (app *BandApp) AddHook(hook Hook) {
 	app.hooks = append(app.hooks, hook)
 }
+
+// newCompiledOwasmCache returns the persistent cache of compiled oracle scripts in the home
+// directory, or nil if the cache is disabled by a zero size. Entries are keyed on the version of
+// the linked Owasm VM, so that an upgrade never reuses code compiled by an older VM. Note that
+// the VM still builds the native module of each oracle script on its first run after a restart,
+// since its singlepass backend cannot serialize native modules.
+func newCompiledOwasmCache(logger log.Logger, size int64) *owasmcache.Cache {
+	if size <= 0 {
+		return nil
+	}
+	cache, err := owasmcache.New(filepath.Join(viper.GetString(cli.HomeFlag), "owasm-cache"), api.Version, size)
+	if err != nil {
+		panic(err)
+	}
+	if err := prometheus.Register(owasmcache.NewCollector(cache)); err != nil {
+		logger.Error("Failed to register compiled oracle script cache metrics", "err", err)
+	}
+	return cache
+}
@@ -0,0 +1,183 @@
+package bandevmbot
+
+// rawABI is the subset of the Bridge contract ABI used to keep its validator set up to date.
+var rawABI = []byte(`
+[
+  {
+    "inputs": [
+      {
+        "internalType": "address",
+        "name": "",
+        "type": "address"
+      }
+    ],
+    "name": "validatorPowers",
+    "outputs": [
+      {
+        "internalType": "uint256",
+        "name": "",
+        "type": "uint256"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [],
+    "name": "totalValidatorPower",
+    "outputs": [
+      {
+        "internalType": "uint256",
+        "name": "",
+        "type": "uint256"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {
+        "components": [
+          {
+            "internalType": "address",
+            "name": "addr",
+            "type": "address"
+          },
+          {
+            "internalType": "uint256",
+            "name": "power",
+            "type": "uint256"
+          }
+        ],
+        "internalType": "struct Bridge.ValidatorWithPower[]",
+        "name": "_validators",
+        "type": "tuple[]"
+      }
+    ],
+    "name": "updateValidatorPowers",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {
+        "components": [
+          {
+            "internalType": "bytes32",
+            "name": "accToGovStoresMerkleHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "mainAndMintStoresMerkleHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "oracleIAVLStateHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "paramsStoresMerkleHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "slashingToUpgradeStoresMerkleHash",
+            "type": "bytes32"
+          }
+        ],
+        "internalType": "struct MultiStore.Data",
+        "name": "_multiStore",
+        "type": "tuple"
+      },
+      {
+        "components": [
+          {
+            "internalType": "bytes32",
+            "name": "versionAndChainIdHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "uint64",
+            "name": "height",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint64",
+            "name": "timeSecond",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint32",
+            "name": "timeNanoSecond",
+            "type": "uint32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "lastBlockIDAndOther",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "nextValidatorHashAndConsensusHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "lastResultsHash",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "evidenceAndProposerHash",
+            "type": "bytes32"
+          }
+        ],
+        "internalType": "struct BlockHeaderMerkleParts.Data",
+        "name": "_merkleParts",
+        "type": "tuple"
+      },
+      {
+        "components": [
+          {
+            "internalType": "bytes32",
+            "name": "r",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "bytes32",
+            "name": "s",
+            "type": "bytes32"
+          },
+          {
+            "internalType": "uint8",
+            "name": "v",
+            "type": "uint8"
+          },
+          {
+            "internalType": "bytes",
+            "name": "signedDataPrefix",
+            "type": "bytes"
+          },
+          {
+            "internalType": "bytes",
+            "name": "signedDataSuffix",
+            "type": "bytes"
+          }
+        ],
+        "internalType": "struct TMSignature.Data[]",
+        "name": "_signatures",
+        "type": "tuple[]"
+      }
+    ],
+    "name": "relayOracleState",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]
+`)
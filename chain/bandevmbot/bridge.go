@@ -0,0 +1,132 @@
+package bandevmbot
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var bridgeABI abi.ABI
+
+func init() {
+	var err error
+	bridgeABI, err = abi.JSON(bytes.NewReader(rawABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Bridge is a BandChain bridge contract deployed on an EVM chain.
+type Bridge struct {
+	client  *ethclient.Client
+	address common.Address
+}
+
+// NewBridge returns the bridge contract at the given address, accessed through the given client.
+func NewBridge(client *ethclient.Client, address common.Address) Bridge {
+	return Bridge{client: client, address: address}
+}
+
+// call performs a read-only call of the given contract method and returns its single output.
+func (b Bridge) call(ctx context.Context, method string, args ...interface{}) (*big.Int, error) {
+	data, err := bridgeABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := b.client.CallContract(ctx, ethereum.CallMsg{To: &b.address, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out *big.Int
+	if err := bridgeABI.Unpack(&out, method, res); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidatorPower returns the voting power of the given validator address kept by the contract.
+func (b Bridge) ValidatorPower(ctx context.Context, addr common.Address) (*big.Int, error) {
+	return b.call(ctx, "validatorPowers", addr)
+}
+
+// TotalValidatorPower returns the total voting power kept by the contract.
+func (b Bridge) TotalValidatorPower(ctx context.Context) (*big.Int, error) {
+	return b.call(ctx, "totalValidatorPower")
+}
+
+// GetValidators returns the voting power kept by the contract of each of the given addresses,
+// omitting addresses without power. Returns an error if the contract holds voting power for
+// addresses outside of the given ones, since the update would then be incomplete.
+func (b Bridge) GetValidators(ctx context.Context, addrs []common.Address) ([]ValidatorWithPower, error) {
+	vals := []ValidatorWithPower{}
+	sum := big.NewInt(0)
+	for _, addr := range addrs {
+		power, err := b.ValidatorPower(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if power.Sign() == 0 {
+			continue
+		}
+		vals = append(vals, ValidatorWithPower{Addr: addr, Power: power})
+		sum.Add(sum, power)
+	}
+	total, err := b.TotalValidatorPower(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if total.Cmp(sum) != 0 {
+		return nil, fmt.Errorf("%w: contract total power %s, known validators power %s", ErrUnknownValidators, total, sum)
+	}
+	return vals, nil
+}
+
+// SignTransactions signs a transaction to the contract for each of the given calldata, using
+// consecutive pending nonces of the signer.
+func (b Bridge) SignTransactions(
+	ctx context.Context, key *ecdsa.PrivateKey, gasPrice *big.Int, gasLimit uint64, calldata ...[]byte,
+) ([]*ethtypes.Transaction, error) {
+	chainID, err := b.client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := b.client.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	if gasPrice == nil {
+		gasPrice, err = b.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	signer := ethtypes.NewEIP155Signer(chainID)
+	txs := make([]*ethtypes.Transaction, len(calldata))
+	for idx, data := range calldata {
+		tx := ethtypes.NewTransaction(nonce+uint64(idx), b.address, big.NewInt(0), gasLimit, gasPrice, data)
+		txs[idx], err = ethtypes.SignTx(tx, signer, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+// SendTransactions broadcasts the given signed transactions in order.
+func (b Bridge) SendTransactions(ctx context.Context, txs []*ethtypes.Transaction) error {
+	for _, tx := range txs {
+		if err := b.client.SendTransaction(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
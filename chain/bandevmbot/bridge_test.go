@@ -0,0 +1,196 @@
+package bandevmbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// mockEVM is a minimal EVM JSON-RPC server backed by the state of a single bridge contract.
+type mockEVM struct {
+	mtx      sync.Mutex
+	chainID  *big.Int
+	nonce    uint64
+	gasPrice *big.Int
+	powers   map[common.Address]*big.Int
+	total    *big.Int
+	sent     []*ethtypes.Transaction
+}
+
+type jsonRPCRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (m *mockEVM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var req jsonRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := m.handle(req)
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		res["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		res["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (m *mockEVM) handle(req jsonRPCRequest) (interface{}, error) {
+	switch req.Method {
+	case "eth_chainId":
+		return (*hexutil.Big)(m.chainID), nil
+	case "eth_gasPrice":
+		return (*hexutil.Big)(m.gasPrice), nil
+	case "eth_getTransactionCount":
+		return hexutil.Uint64(m.nonce), nil
+	case "eth_call":
+		var msg struct {
+			Data hexutil.Bytes `json:"data"`
+		}
+		if err := json.Unmarshal(req.Params[0], &msg); err != nil {
+			return nil, err
+		}
+		return m.call(msg.Data)
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		if err := json.Unmarshal(req.Params[0], &raw); err != nil {
+			return nil, err
+		}
+		var tx ethtypes.Transaction
+		if err := rlp.DecodeBytes(raw, &tx); err != nil {
+			return nil, err
+		}
+		m.sent = append(m.sent, &tx)
+		m.nonce++
+		return tx.Hash(), nil
+	default:
+		return nil, errors.New("method not supported: " + req.Method)
+	}
+}
+
+func (m *mockEVM) call(data []byte) (hexutil.Bytes, error) {
+	for name, method := range bridgeABI.Methods {
+		if !bytes.Equal(method.ID, data[:4]) {
+			continue
+		}
+		switch name {
+		case "validatorPowers":
+			args, err := method.Inputs.UnpackValues(data[4:])
+			if err != nil {
+				return nil, err
+			}
+			power, ok := m.powers[args[0].(common.Address)]
+			if !ok {
+				power = big.NewInt(0)
+			}
+			return method.Outputs.Pack(power)
+		case "totalValidatorPower":
+			return method.Outputs.Pack(m.total)
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func newMockBridge(t *testing.T, m *mockEVM) (Bridge, *httptest.Server) {
+	server := httptest.NewServer(m)
+	client, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	return NewBridge(client, common.HexToAddress("0x0d8152D22a05A3Cf2cE1c5bEfCc2F8658f75a59d")), server
+}
+
+func newMockEVM(vals ...ValidatorWithPower) *mockEVM {
+	m := &mockEVM{
+		chainID:  big.NewInt(42),
+		nonce:    7,
+		gasPrice: big.NewInt(1000000000),
+		powers:   make(map[common.Address]*big.Int),
+		total:    big.NewInt(0),
+	}
+	for _, val := range vals {
+		m.powers[val.Addr] = val.Power
+		m.total.Add(m.total, val.Power)
+	}
+	return m
+}
+
+func TestBridgeGetValidators(t *testing.T) {
+	v := mockValidators
+	m := newMockEVM(v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100))
+	bridge, server := newMockBridge(t, m)
+	defer server.Close()
+	ctx := context.Background()
+
+	power, err := bridge.ValidatorPower(ctx, v[1].addr)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), power)
+	total, err := bridge.TotalValidatorPower(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(400), total)
+
+	vals, err := bridge.GetValidators(ctx, []common.Address{v[0].addr, v[1].addr, v[2].addr, v[3].addr, v[4].addr})
+	require.NoError(t, err)
+	require.Equal(t, []ValidatorWithPower{v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100)}, vals)
+
+	// The contract holds power of a validator that the caller does not know about.
+	_, err = bridge.GetValidators(ctx, []common.Address{v[0].addr, v[1].addr, v[2].addr})
+	require.True(t, errors.Is(err, ErrUnknownValidators))
+}
+
+func TestBridgeSendValidatorSetUpdate(t *testing.T) {
+	v := mockValidators
+	m := newMockEVM(v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100))
+	bridge, server := newMockBridge(t, m)
+	defer server.Close()
+	ctx := context.Background()
+
+	addrs := []common.Address{v[0].addr, v[1].addr, v[2].addr, v[3].addr, v[4].addr}
+	current, err := bridge.GetValidators(ctx, addrs)
+	require.NoError(t, err)
+	next := []*tmtypes.Validator{v[0].tm(100), v[1].tm(150), v[2].tm(100), v[4].tm(50)}
+	update, err := NewValidatorSetUpdate(
+		42, mockBlockRelayProof(t, 42, v[0], v[1], v[2]), tmtypes.NewValidatorSet(next).Hash(), current, next,
+	)
+	require.NoError(t, err)
+	calldata, err := update.Calldata()
+	require.NoError(t, err)
+
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	txs, err := bridge.SignTransactions(ctx, owner, nil, 2000000, calldata...)
+	require.NoError(t, err)
+	require.NoError(t, bridge.SendTransactions(ctx, txs))
+
+	require.Len(t, m.sent, 2)
+	signer := ethtypes.NewEIP155Signer(m.chainID)
+	for idx, tx := range m.sent {
+		from, err := ethtypes.Sender(signer, tx)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(owner.PublicKey), from)
+		require.Equal(t, uint64(7+idx), tx.Nonce())
+		require.Equal(t, m.gasPrice, tx.GasPrice())
+		require.Equal(t, uint64(2000000), tx.Gas())
+		require.Equal(t, bridge.address, *tx.To())
+		require.Equal(t, calldata[idx], tx.Data())
+	}
+}
@@ -0,0 +1,231 @@
+package bandevmbot
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	sdkCtx "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/bandprotocol/bandchain/chain/app"
+)
+
+const (
+	flagRPCUri          = "rpc-uri"
+	flagContractAddress = "contract-address"
+	flagNodeUri         = "node-uri"
+	flagPrivKey         = "priv-key"
+	flagGasPrice        = "gas-price"
+	flagGasLimit        = "gas-limit"
+	flagPollInterval    = "poll-interval"
+	flagOutput          = "output"
+	flagOnce            = "once"
+)
+
+// Output modes of validator set updates.
+const (
+	OutputCalldata = "calldata" // Print the calldata of each transaction
+	OutputTx       = "tx"       // Print each transaction signed with the private key
+	OutputSend     = "send"     // Sign and broadcast each transaction
+)
+
+var logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+
+// updateOutput is the printed form of a validator set update.
+type updateOutput struct {
+	ValidatorSetUpdate
+	Calldata     []hexutil.Bytes `json:"calldata,omitempty"`
+	Transactions []hexutil.Bytes `json:"transactions,omitempty"`
+}
+
+// config holds the parsed command line flags.
+type config struct {
+	output   string
+	key      *ecdsa.PrivateKey
+	gasPrice *big.Int
+	gasLimit uint64
+}
+
+// processUpdate builds the validator set update of the contract, if any, and outputs it
+// according to the configured mode.
+func processUpdate(ctx context.Context, relayer *Relayer, cfg config) error {
+	update, err := relayer.GetValidatorSetUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if update.IsEmpty() {
+		logger.Info(fmt.Sprintf("Validator set is up to date as of block %d", update.BlockHeight))
+		return nil
+	}
+	logger.Info(fmt.Sprintf("Found %d validator changes as of block %d", len(update.Changes), update.BlockHeight))
+	calldata, err := update.Calldata()
+	if err != nil {
+		return err
+	}
+	out := updateOutput{ValidatorSetUpdate: update}
+	if cfg.output == OutputCalldata {
+		for _, data := range calldata {
+			out.Calldata = append(out.Calldata, data)
+		}
+		return printJSON(out)
+	}
+	txs, err := relayer.bridge.SignTransactions(ctx, cfg.key, cfg.gasPrice, cfg.gasLimit, calldata...)
+	if err != nil {
+		return err
+	}
+	if cfg.output == OutputTx {
+		for _, tx := range txs {
+			raw, err := rlp.EncodeToBytes(tx)
+			if err != nil {
+				return err
+			}
+			out.Transactions = append(out.Transactions, raw)
+		}
+		return printJSON(out)
+	}
+	if err := relayer.bridge.SendTransactions(ctx, txs); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		logger.Info(fmt.Sprintf("Sent transaction %s", tx.Hash().Hex()))
+	}
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
+
+func parseConfig(cmd *cobra.Command) (config, error) {
+	var cfg config
+	var err error
+	cfg.output, err = cmd.Flags().GetString(flagOutput)
+	if err != nil {
+		return config{}, err
+	}
+	switch cfg.output {
+	case OutputCalldata:
+		return cfg, nil
+	case OutputTx, OutputSend:
+	default:
+		return config{}, fmt.Errorf("unknown output %s, expect one of: %s, %s, %s", cfg.output, OutputCalldata, OutputTx, OutputSend)
+	}
+	privKey, err := cmd.Flags().GetString(flagPrivKey)
+	if err != nil {
+		return config{}, err
+	}
+	cfg.key, err = crypto.HexToECDSA(privKey)
+	if err != nil {
+		return config{}, err
+	}
+	gasPrice, err := cmd.Flags().GetUint64(flagGasPrice)
+	if err != nil {
+		return config{}, err
+	}
+	if gasPrice != 0 {
+		cfg.gasPrice = new(big.Int).SetUint64(gasPrice)
+	}
+	cfg.gasLimit, err = cmd.Flags().GetUint64(flagGasLimit)
+	if err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+func Main() {
+	cmd := &cobra.Command{
+		Use:   "bandevmbot",
+		Short: "Keep the validator set of an EVM bridge contract in line with BandChain",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Periodically check the validator set of BandChain and, when it differs from the one of the
+bridge contract, relay a block signed by the validators known to the contract together with
+the validator changes committed in that block.
+
+Example:
+$ bandevmbot --rpc-uri https://kovan.infura.io/v3/<project-id> --contract-address 0x0d8152D22a05A3Cf2cE1c5bEfCc2F8658f75a59d --node-uri http://localhost:26657 --output send --priv-key <hex-private-key> --poll-interval 1h
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := parseConfig(cmd)
+			if err != nil {
+				return err
+			}
+			rpcURI, err := cmd.Flags().GetString(flagRPCUri)
+			if err != nil {
+				return err
+			}
+			contractAddress, err := cmd.Flags().GetString(flagContractAddress)
+			if err != nil {
+				return err
+			}
+			if !common.IsHexAddress(contractAddress) {
+				return fmt.Errorf("invalid contract address: %s", contractAddress)
+			}
+			nodeURI, err := cmd.Flags().GetString(flagNodeUri)
+			if err != nil {
+				return err
+			}
+			interval, err := cmd.Flags().GetDuration(flagPollInterval)
+			if err != nil {
+				return err
+			}
+			once, err := cmd.Flags().GetBool(flagOnce)
+			if err != nil {
+				return err
+			}
+			node, err := rpchttp.New(nodeURI, "/websocket")
+			if err != nil {
+				return err
+			}
+			evmClient, err := ethclient.Dial(rpcURI)
+			if err != nil {
+				return err
+			}
+			cliCtx := sdkCtx.CLIContext{Client: node, TrustNode: true, Codec: app.MakeCodec()}
+			relayer := NewRelayer(cliCtx, NewBridge(evmClient, common.HexToAddress(contractAddress)))
+			for {
+				if err := processUpdate(context.Background(), relayer, cfg); err != nil {
+					if once {
+						return err
+					}
+					logger.Error(fmt.Sprintf("Failed to update validator set: %s", err))
+				}
+				if once {
+					return nil
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+	cmd.Flags().String(flagRPCUri, "http://localhost:8545", "RPC URI of the EVM chain")
+	cmd.Flags().String(flagContractAddress, "", "Address of the bridge contract")
+	cmd.Flags().String(flagNodeUri, "tcp://localhost:26657", "RPC URI of the BandChain node")
+	cmd.Flags().String(flagPrivKey, "", "Hex private key of the contract owner, required unless output is calldata")
+	cmd.Flags().Uint64(flagGasPrice, 0, "Gas price of the transactions, suggested by the EVM node if zero")
+	cmd.Flags().Uint64(flagGasLimit, 2000000, "Gas limit of each transaction")
+	cmd.Flags().Duration(flagPollInterval, time.Hour, "Interval between validator set checks")
+	cmd.Flags().String(flagOutput, OutputCalldata, "What to do with updates: calldata, tx or send")
+	cmd.Flags().Bool(flagOnce, false, "Check the validator set once and exit")
+
+	if err := cmd.Execute(); err != nil {
+		logger.Error(fmt.Sprintf("Failed executing: %s, exiting...", err))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+package bandevmbot
+
+import (
+	"context"
+
+	sdkCtx "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/ethereum/go-ethereum/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	bandclient "github.com/bandprotocol/bandchain/chain/client"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof"
+)
+
+// Relayer watches the validator set of BandChain and builds the updates needed to keep the
+// validator set of a bridge contract in line with it.
+type Relayer struct {
+	cliCtx sdkCtx.CLIContext
+	bridge Bridge
+	// Every validator address ever seen on BandChain, which may still have power in the contract.
+	known map[common.Address]bool
+}
+
+// NewRelayer creates a relayer reading BandChain through the given context, which must have a
+// node client and codec, and the contract through the given bridge.
+func NewRelayer(cliCtx sdkCtx.CLIContext, bridge Bridge) *Relayer {
+	return &Relayer{cliCtx: cliCtx, bridge: bridge, known: make(map[common.Address]bool)}
+}
+
+// remember adds the addresses of the given validators to the known addresses.
+func (r *Relayer) remember(vals []*tmtypes.Validator) error {
+	evmVals, err := getEVMValidators(vals)
+	if err != nil {
+		return err
+	}
+	for _, val := range evmVals {
+		r.known[val.Addr] = true
+	}
+	return nil
+}
+
+// GetValidatorSetUpdate returns the update from the validator set of the contract to the
+// validator set of BandChain after the latest committed block. The returned update is empty if
+// the contract is already up to date.
+func (r *Relayer) GetValidatorSetUpdate(ctx context.Context) (ValidatorSetUpdate, error) {
+	status, err := r.cliCtx.Client.Status()
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	// The latest block may not have its commit finalized yet, so relay the one before it.
+	height := status.SyncInfo.LatestBlockHeight - 1
+	_, current, err := bandclient.QueryValidators(r.cliCtx.Client, &height)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	nextHeight := height + 1
+	_, next, err := bandclient.QueryValidators(r.cliCtx.Client, &nextHeight)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	if err := r.remember(current); err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	if err := r.remember(next); err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	addrs := make([]common.Address, 0, len(r.known))
+	for addr := range r.known {
+		addrs = append(addrs, addr)
+	}
+	contractVals, err := r.bridge.GetValidators(ctx, addrs)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	nextVals, err := getEVMValidators(next)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	if len(getValidatorChanges(contractVals, nextVals)) == 0 {
+		return ValidatorSetUpdate{BlockHeight: uint64(height), Changes: []ValidatorWithPower{}}, nil
+	}
+	commit, err := r.cliCtx.Client.Commit(&height)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	relay, err := proof.GetBlockRelayProof(r.cliCtx, height)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	return NewValidatorSetUpdate(uint64(height), relay, commit.NextValidatorsHash, contractVals, next)
+}
@@ -0,0 +1,146 @@
+package bandevmbot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	bandclient "github.com/bandprotocol/bandchain/chain/client"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof/verifier"
+)
+
+var (
+	ErrValidatorsHashMismatch = errors.New("validators do not match the next validators hash of the block")
+	ErrUnknownValidators      = errors.New("contract holds voting power of unknown validators")
+)
+
+// ValidatorWithPower is the ValidatorWithPower struct of the bridge contract.
+type ValidatorWithPower struct {
+	Addr  common.Address `json:"addr"`
+	Power *big.Int       `json:"power"`
+}
+
+// getEVMValidators converts the given Tendermint validators to their EVM addresses and powers.
+func getEVMValidators(vals []*tmtypes.Validator) ([]ValidatorWithPower, error) {
+	evmVals, err := bandclient.NewEVMValidators(vals)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]ValidatorWithPower, len(evmVals))
+	for idx, val := range evmVals {
+		res[idx] = ValidatorWithPower{Addr: common.HexToAddress(val.Address), Power: big.NewInt(val.VotingPower)}
+	}
+	return res, nil
+}
+
+func toValidatorSet(vals []ValidatorWithPower) (verifier.ValidatorSet, error) {
+	res := make([]verifier.Validator, len(vals))
+	for idx, val := range vals {
+		if !val.Power.IsUint64() {
+			return verifier.ValidatorSet{}, fmt.Errorf("power of %s overflows uint64", val.Addr.Hex())
+		}
+		res[idx] = verifier.Validator{Address: val.Addr, Power: val.Power.Uint64()}
+	}
+	return verifier.NewValidatorSet(res)
+}
+
+// getValidatorChanges returns the validators whose power differs between the current and the
+// next sets, with power zero for validators that are no longer in the next set.
+func getValidatorChanges(current, next []ValidatorWithPower) []ValidatorWithPower {
+	powers := make(map[common.Address]*big.Int)
+	for _, val := range current {
+		powers[val.Addr] = val.Power
+	}
+	changes := []ValidatorWithPower{}
+	for _, val := range next {
+		if power, ok := powers[val.Addr]; !ok || power.Cmp(val.Power) != 0 {
+			changes = append(changes, val)
+		}
+		delete(powers, val.Addr)
+	}
+	for addr := range powers {
+		changes = append(changes, ValidatorWithPower{Addr: addr, Power: big.NewInt(0)})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Addr.Bytes(), changes[j].Addr.Bytes()) < 0
+	})
+	return changes
+}
+
+// ValidatorSetUpdate brings the validator set of a bridge contract in line with BandChain. The
+// relayed block is signed by the validators currently known to the contract and its header
+// commits to the next validator set, from which the changes are taken.
+type ValidatorSetUpdate struct {
+	BlockHeight     uint64                `json:"blockHeight"`
+	BlockRelayProof proof.BlockRelayProof `json:"blockRelayProof"`
+	Changes         []ValidatorWithPower  `json:"changes"`
+}
+
+// NewValidatorSetUpdate creates the update from the current validators of the contract to the
+// next validators committed in the block at the given height. It checks that the next validators
+// hash to nextValidatorsHash of the block and that the relayed block is signed by more than
+// two-thirds of the current voting power, so the relay transaction will not be rejected.
+func NewValidatorSetUpdate(
+	blockHeight uint64, relay proof.BlockRelayProof, nextValidatorsHash []byte,
+	current []ValidatorWithPower, next []*tmtypes.Validator,
+) (ValidatorSetUpdate, error) {
+	if !bytes.Equal(tmtypes.NewValidatorSet(next).Hash(), nextValidatorsHash) {
+		return ValidatorSetUpdate{}, ErrValidatorsHashMismatch
+	}
+	currentSet, err := toValidatorSet(current)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	if _, err := verifier.VerifyBlockRelay(blockHeight, relay, currentSet); err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	nextVals, err := getEVMValidators(next)
+	if err != nil {
+		return ValidatorSetUpdate{}, err
+	}
+	return ValidatorSetUpdate{
+		BlockHeight:     blockHeight,
+		BlockRelayProof: relay,
+		Changes:         getValidatorChanges(current, nextVals),
+	}, nil
+}
+
+// IsEmpty returns whether the contract already has the next validator set.
+func (u ValidatorSetUpdate) IsEmpty() bool {
+	return len(u.Changes) == 0
+}
+
+// RelayCalldata returns the calldata of relayOracleState with the relayed block.
+func (u ValidatorSetUpdate) RelayCalldata() ([]byte, error) {
+	args, err := u.BlockRelayProof.EncodeToEthData()
+	if err != nil {
+		return nil, err
+	}
+	id := bridgeABI.Methods["relayOracleState"].ID
+	return append(append([]byte{}, id...), args...), nil
+}
+
+// UpdateCalldata returns the calldata of updateValidatorPowers with the validator changes.
+func (u ValidatorSetUpdate) UpdateCalldata() ([]byte, error) {
+	return bridgeABI.Pack("updateValidatorPowers", u.Changes)
+}
+
+// Calldata returns the calldata of the relay and of the validator update, in the order they
+// must be executed.
+func (u ValidatorSetUpdate) Calldata() ([][]byte, error) {
+	relay, err := u.RelayCalldata()
+	if err != nil {
+		return nil, err
+	}
+	update, err := u.UpdateCalldata()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{relay, update}, nil
+}
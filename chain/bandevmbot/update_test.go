@@ -0,0 +1,172 @@
+package bandevmbot
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof/verifier"
+)
+
+type mockValidator struct {
+	key    *ecdsa.PrivateKey
+	pubKey secp256k1.PubKeySecp256k1
+	addr   common.Address
+}
+
+func newMockValidator(seed string) mockValidator {
+	priv := secp256k1.GenPrivKeySecp256k1([]byte(seed))
+	key, err := crypto.ToECDSA(priv[:])
+	if err != nil {
+		panic(err)
+	}
+	return mockValidator{key: key, pubKey: priv.PubKey().(secp256k1.PubKeySecp256k1), addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (v mockValidator) tm(power int64) *tmtypes.Validator {
+	return tmtypes.NewValidator(v.pubKey, power)
+}
+
+func (v mockValidator) evm(power int64) ValidatorWithPower {
+	return ValidatorWithPower{Addr: v.addr, Power: big.NewInt(power)}
+}
+
+var mockValidators = []mockValidator{
+	newMockValidator("validator1"),
+	newMockValidator("validator2"),
+	newMockValidator("validator3"),
+	newMockValidator("validator4"),
+	newMockValidator("validator5"),
+}
+
+// mockBlockRelayProof returns the relay proof of a block at the given height signed by the
+// given validators.
+func mockBlockRelayProof(t *testing.T, height uint64, signers ...mockValidator) proof.BlockRelayProof {
+	relay := proof.BlockRelayProof{
+		MultiStoreProof: proof.MultiStoreProof{
+			AccToGovStoresMerkleHash:          tmhash.Sum([]byte("accToGov")),
+			MainAndMintStoresMerkleHash:       tmhash.Sum([]byte("mainAndMint")),
+			OracleIAVLStateHash:               tmhash.Sum([]byte("oracle")),
+			ParamsStoresMerkleHash:            tmhash.Sum([]byte("params")),
+			SlashingToUpgradeStoresMerkleHash: tmhash.Sum([]byte("slashingToUpgrade")),
+		},
+		BlockHeaderMerkleParts: proof.BlockHeaderMerkleParts{
+			VersionAndChainIdHash:             tmhash.Sum([]byte("versionAndChainId")),
+			Height:                            height,
+			TimeSecond:                        1596632719,
+			TimeNanoSecond:                    123456789,
+			LastBlockIDAndOther:               tmhash.Sum([]byte("lastBlockIDAndOther")),
+			NextValidatorHashAndConsensusHash: tmhash.Sum([]byte("nextValidatorHashAndConsensus")),
+			LastResultsHash:                   tmhash.Sum([]byte("lastResults")),
+			EvidenceAndProposerHash:           tmhash.Sum([]byte("evidenceAndProposer")),
+		},
+	}
+	blockHash := relay.BlockHeaderMerkleParts.GetBlockHeader(relay.MultiStoreProof.GetAppHash())
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].addr.Bytes(), signers[j].addr.Bytes()) < 0
+	})
+	prefix, suffix := []byte("prefix"), []byte("suffix")
+	for _, signer := range signers {
+		msg := append(append(append([]byte{}, prefix...), blockHash...), suffix...)
+		sig, err := crypto.Sign(tmhash.Sum(msg), signer.key)
+		require.NoError(t, err)
+		relay.Signatures = append(relay.Signatures, proof.TMSignature{
+			R:                sig[:32],
+			S:                sig[32:64],
+			V:                sig[64] + 27,
+			SignedDataPrefix: prefix,
+			SignedDataSuffix: suffix,
+		})
+	}
+	return relay
+}
+
+func TestGetEVMValidators(t *testing.T) {
+	v := mockValidators
+	vals, err := getEVMValidators([]*tmtypes.Validator{v[1].tm(200), v[0].tm(100)})
+	require.NoError(t, err)
+	require.Equal(t, []ValidatorWithPower{v[1].evm(200), v[0].evm(100)}, vals)
+	// Only secp256k1 validators have EVM addresses.
+	_, err = getEVMValidators([]*tmtypes.Validator{tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 100)})
+	require.Error(t, err)
+}
+
+func TestGetValidatorChanges(t *testing.T) {
+	v := mockValidators
+	current := []ValidatorWithPower{v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100)}
+	next := []ValidatorWithPower{v[0].evm(100), v[1].evm(150), v[2].evm(100), v[4].evm(50)}
+	changes := getValidatorChanges(current, next)
+	expected := []ValidatorWithPower{v[1].evm(150), v[3].evm(0), v[4].evm(50)}
+	sort.Slice(expected, func(i, j int) bool {
+		return bytes.Compare(expected[i].Addr.Bytes(), expected[j].Addr.Bytes()) < 0
+	})
+	require.Equal(t, expected, changes)
+	require.Empty(t, getValidatorChanges(current, current))
+}
+
+func TestNewValidatorSetUpdate(t *testing.T) {
+	v := mockValidators
+	current := []ValidatorWithPower{v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100)}
+	next := []*tmtypes.Validator{v[0].tm(100), v[1].tm(150), v[2].tm(100), v[4].tm(50)}
+	nextHash := tmtypes.NewValidatorSet(next).Hash()
+	relay := mockBlockRelayProof(t, 42, v[0], v[1], v[2])
+
+	update, err := NewValidatorSetUpdate(42, relay, nextHash, current, next)
+	require.NoError(t, err)
+	require.False(t, update.IsEmpty())
+	require.Equal(t, uint64(42), update.BlockHeight)
+	require.Len(t, update.Changes, 3)
+
+	calldata, err := update.Calldata()
+	require.NoError(t, err)
+	require.Len(t, calldata, 2)
+	relayMethod := bridgeABI.Methods["relayOracleState"]
+	require.Equal(t, relayMethod.ID, calldata[0][:4])
+	relayArgs, err := relay.EncodeToEthData()
+	require.NoError(t, err)
+	require.Equal(t, relayArgs, calldata[0][4:])
+	updateMethod := bridgeABI.Methods["updateValidatorPowers"]
+	require.Equal(t, updateMethod.ID, calldata[1][:4])
+	values, err := updateMethod.Inputs.UnpackValues(calldata[1][4:])
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+
+	// Nothing to update once the contract has the next validator set.
+	nextEVM, err := getEVMValidators(next)
+	require.NoError(t, err)
+	update, err = NewValidatorSetUpdate(42, relay, nextHash, nextEVM, next)
+	require.NoError(t, err)
+	require.True(t, update.IsEmpty())
+}
+
+func TestNewValidatorSetUpdateFail(t *testing.T) {
+	v := mockValidators
+	current := []ValidatorWithPower{v[0].evm(100), v[1].evm(100), v[2].evm(100), v[3].evm(100)}
+	next := []*tmtypes.Validator{v[0].tm(100), v[1].tm(150), v[2].tm(100), v[4].tm(50)}
+	nextHash := tmtypes.NewValidatorSet(next).Hash()
+
+	// The next validators must match the hash committed in the block.
+	_, err := NewValidatorSetUpdate(42, mockBlockRelayProof(t, 42, v[0], v[1], v[2]), tmhash.Sum([]byte("wrong")), current, next)
+	require.Equal(t, ErrValidatorsHashMismatch, err)
+	// The relayed block must be signed by more than two-thirds of the current power.
+	_, err = NewValidatorSetUpdate(42, mockBlockRelayProof(t, 42, v[0], v[1]), nextHash, current, next)
+	require.True(t, errors.Is(err, verifier.ErrInsufficientVotingPower))
+	// Signatures of validators unknown to the contract do not count.
+	_, err = NewValidatorSetUpdate(42, mockBlockRelayProof(t, 42, v[0], v[1], v[4]), nextHash, current, next)
+	require.True(t, errors.Is(err, verifier.ErrInsufficientVotingPower))
+	// The relayed block must be at the given height.
+	_, err = NewValidatorSetUpdate(43, mockBlockRelayProof(t, 42, v[0], v[1], v[2]), nextHash, current, next)
+	require.True(t, errors.Is(err, verifier.ErrBlockHeightMismatch))
+}
@@ -1,15 +1,21 @@
 package rpc
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 )
 
+const validatorsPerPage = 100
+
 type ValidatorMinimal struct {
 	Address     string `json:"address"`
 	VotingPower int64  `json:"voting_power"`
@@ -20,46 +26,77 @@ type ValidatorsMinimal struct {
 	Validators  []ValidatorMinimal `json:"validators"`
 }
 
+// QueryValidators returns the height and all validators of BandChain at the given height, or at
+// the latest height if nil.
+func QueryValidators(node rpcclient.Client, height *int64) (int64, []*tmtypes.Validator, error) {
+	vals := []*tmtypes.Validator{}
+	for page := 1; ; page++ {
+		res, err := node.Validators(height, page, validatorsPerPage)
+		if err != nil {
+			return 0, nil, err
+		}
+		// Query the following pages at the same height as the first.
+		height = &res.BlockHeight
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			return res.BlockHeight, vals, nil
+		}
+	}
+}
+
+// NewEVMValidators converts the given Tendermint validators to their EVM addresses and voting
+// powers, in the same order.
+func NewEVMValidators(vals []*tmtypes.Validator) ([]ValidatorMinimal, error) {
+	res := make([]ValidatorMinimal, len(vals))
+	for idx, val := range vals {
+		pubKeyBytes, ok := val.PubKey.(secp256k1.PubKeySecp256k1)
+		if !ok {
+			return nil, fmt.Errorf("fail to cast pubkey of validator %s", val.Address)
+		}
+		pubkey, err := crypto.DecompressPubkey(pubKeyBytes[:])
+		if err != nil {
+			return nil, err
+		}
+		res[idx] = ValidatorMinimal{
+			Address:     crypto.PubkeyToAddress(*pubkey).String(),
+			VotingPower: val.VotingPower,
+		}
+	}
+	return res, nil
+}
+
+// GetEVMValidators returns the EVM addresses and voting powers of all validators at the height
+// given by the optional "height" query parameter, or at the latest height.
 func GetEVMValidators(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		var height *int64
+		if heightStr := r.FormValue("height"); heightStr != "" {
+			h, err := strconv.ParseInt(heightStr, 10, 64)
+			if err != nil || h <= 0 {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "height must be a positive integer")
+				return
+			}
+			height = &h
+		}
+
 		node, err := cliCtx.GetNode()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		// Get top 100 validators for now
-		validators, err := node.Validators(nil, 1, 100)
+		blockHeight, validators, err := QueryValidators(node, height)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		validatorsMinimal := ValidatorsMinimal{}
-		validatorsMinimal.BlockHeight = validators.BlockHeight
-		validatorsMinimal.Validators = []ValidatorMinimal{}
-
-		for _, validator := range validators.Validators {
-			pubKeyBytes, ok := validator.PubKey.(secp256k1.PubKeySecp256k1)
-			if !ok {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, "fail to cast pubkey")
-				return
-			}
-
-			if pubkey, err := crypto.DecompressPubkey(pubKeyBytes[:]); err != nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			} else {
-				validatorsMinimal.Validators = append(
-					validatorsMinimal.Validators,
-					ValidatorMinimal{
-						Address:     crypto.PubkeyToAddress(*pubkey).String(),
-						VotingPower: validator.VotingPower,
-					},
-				)
-			}
+		evmValidators, err := NewEVMValidators(validators)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
 		}
 
-		rest.PostProcessResponseBare(w, cliCtx, validatorsMinimal)
+		rest.PostProcessResponseBare(w, cliCtx, ValidatorsMinimal{BlockHeight: blockHeight, Validators: evmValidators})
 	}
 }
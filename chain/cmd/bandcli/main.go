@@ -24,6 +24,7 @@ import (
 
 	"github.com/bandprotocol/bandchain/chain/app"
 	bandclient "github.com/bandprotocol/bandchain/chain/client"
+	oraclecmd "github.com/bandprotocol/bandchain/chain/x/oracle/client/cli"
 )
 
 const flagCosmosHDPath = "cosmos-hd-path"
@@ -64,6 +65,7 @@ func main() {
 		client.ConfigCmd(app.DefaultCLIHome),
 		queryCmd(cdc),
 		txCmd(cdc),
+		oraclecmd.GetOfflineCmd(cdc),
 		flags.LineBreak,
 		lcd.ServeCommand(cdc, registerRoutes),
 		flags.LineBreak,
@@ -19,6 +19,8 @@ import (
 	"github.com/bandprotocol/go-owasm/api"
 )
 
+const flagStrictSchema = "strict-schema"
+
 // AddGenesisOracleScriptCmd returns add-oracle-script cobra Command.
 func AddGenesisOracleScriptCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
 	cmd := &cobra.Command{
@@ -41,6 +43,13 @@ func AddGenesisOracleScriptCmd(ctx *server.Context, cdc *codec.Codec, defaultNod
 			if err != nil {
 				return err
 			}
+			strictSchema, err := cmd.Flags().GetBool(flagStrictSchema)
+			if err != nil {
+				return err
+			}
+			if err := types.ValidateSchema(args[2], strictSchema); err != nil {
+				return err
+			}
 			filename := f.AddFile(compiledData)
 			owner, err := sdk.AccAddressFromBech32(args[4])
 			if err != nil {
@@ -53,7 +62,7 @@ func AddGenesisOracleScriptCmd(ctx *server.Context, cdc *codec.Codec, defaultNod
 			}
 			oracleGenState := oracle.GetGenesisStateFromAppState(cdc, appState)
 			oracleGenState.OracleScripts = append(oracleGenState.OracleScripts, types.NewOracleScript(
-				owner, args[0], args[1], filename, args[2], args[3],
+				owner, args[0], args[1], filename, args[2], args[3], strictSchema,
 			))
 			appState[oracle.ModuleName] = cdc.MustMarshalJSON(oracleGenState)
 			appStateJSON := cdc.MustMarshalJSON(appState)
@@ -62,5 +71,6 @@ func AddGenesisOracleScriptCmd(ctx *server.Context, cdc *codec.Codec, defaultNod
 		},
 	}
 	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().Bool(flagStrictSchema, false, "Reject calldata and fail results that do not match the schema")
 	return cmd
 }
@@ -22,6 +22,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
@@ -33,13 +34,16 @@ import (
 )
 
 const (
-	flagInvCheckPeriod        = "inv-check-period"
-	flagWithEmitter           = "with-emitter"
-	flagDisableFeelessReports = "disable-feeless-reports"
-	flagEnableFastSync        = "enable-fast-sync"
-	flagWithPricer            = "with-pricer"
-	flagWithRequestSearch     = "with-request-search"
-	flagWithOwasmCacheSize    = "oracle-script-cache-size"
+	flagInvCheckPeriod         = "inv-check-period"
+	flagWithEmitter            = "with-emitter"
+	flagDisableFeelessReports  = "disable-feeless-reports"
+	flagEnableFastSync         = "enable-fast-sync"
+	flagWithPricer             = "with-pricer"
+	flagPricerRetention        = "pricer-retention"
+	flagPricerConfig           = "pricer-config"
+	flagWithRequestSearch      = "with-request-search"
+	flagWithOwasmCacheSize     = "oracle-script-cache-size"
+	flagCompiledOwasmCacheSize = "oracle-script-compiled-cache-size"
 )
 
 var invCheckPeriod uint
@@ -65,6 +69,7 @@ func main() {
 	rootCmd.AddCommand(AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome))
 	rootCmd.AddCommand(AddGenesisDataSourceCmd(ctx, cdc, app.DefaultNodeHome))
 	rootCmd.AddCommand(AddGenesisOracleScriptCmd(ctx, cdc, app.DefaultNodeHome))
+	rootCmd.AddCommand(ReplayEmitterCmd(ctx, app.DefaultNodeHome))
 	rootCmd.AddCommand(flags.NewCompletionCmd(rootCmd, true))
 	rootCmd.AddCommand(debug.Cmd(cdc))
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
@@ -76,7 +81,10 @@ func main() {
 	rootCmd.PersistentFlags().Bool(flagEnableFastSync, false, "[Experimental] Enable fast sync mode")
 	rootCmd.PersistentFlags().String(flagWithRequestSearch, "", "[Experimental] Enable mode to save request in sql database")
 	rootCmd.PersistentFlags().String(flagWithPricer, "", "[Experimental] Enable mode to save price in level db")
+	rootCmd.PersistentFlags().String(flagPricerConfig, "", "[Experimental] Path to JSON file mapping oracle script IDs to price schemas")
+	rootCmd.PersistentFlags().Duration(flagPricerRetention, 0, "[Experimental] Duration to keep historical prices, 0 to keep forever")
 	rootCmd.PersistentFlags().Uint(flagWithOwasmCacheSize, 100, "[Experimental] Number of oracle scripts to cache")
+	rootCmd.PersistentFlags().Int64(flagCompiledOwasmCacheSize, 64*1024*1024, "[Experimental] Maximum size in bytes of compiled oracle scripts to keep on disk, 0 to disable")
 	err := executor.Execute()
 	if err != nil {
 		panic(err)
@@ -104,6 +112,7 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		viper.GetString(flags.FlagHome),
 		viper.GetBool(flagDisableFeelessReports),
 		viper.GetUint32(flagWithOwasmCacheSize),
+		viper.GetInt64(flagCompiledOwasmCacheSize),
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(viper.GetUint64(server.FlagHaltHeight)),
@@ -116,24 +125,40 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		bandApp.AddHook(emitter.NewHook(
 			bandApp.Codec(), bandApp.AccountKeeper, bandApp.BankKeeper, bandApp.SupplyKeeper,
 			bandApp.StakingKeeper, bandApp.MintKeeper, bandApp.DistrKeeper, bandApp.GovKeeper,
-			bandApp.OracleKeeper, viper.GetString(flagWithEmitter), viper.GetBool(flagEnableFastSync)))
+			bandApp.OracleKeeper, viper.GetString(flagWithEmitter), viper.GetBool(flagEnableFastSync),
+			filepath.Join(viper.GetString(cli.HomeFlag), "emitter")))
 	}
 	if viper.IsSet(flagWithRequestSearch) {
+		// Requesters of re-indexed requests are recovered through the RPC of this node.
+		blocks, err := rpchttp.New(viper.GetString("rpc.laddr"), "/websocket")
+		if err != nil {
+			panic(err)
+		}
 		bandApp.AddHook(request.NewHook(
 			bandApp.Codec(), bandApp.OracleKeeper,
-			viper.GetString(flagWithRequestSearch)))
+			viper.GetString(flagWithRequestSearch), bandApp.LastBlockHeight(), blocks))
 	}
-	if viper.IsSet(flagWithPricer) {
-		rawOids := strings.Split(viper.GetString(flagWithPricer), ",")
-		oids := make([]types.OracleScriptID, len(rawOids))
-		for idx, rawOid := range rawOids {
-			oid, err := strconv.ParseInt(rawOid, 10, 64)
+	if viper.IsSet(flagWithPricer) || viper.IsSet(flagPricerConfig) {
+		config := make(price.Config)
+		if viper.IsSet(flagPricerConfig) {
+			var err error
+			config, err = price.LoadConfig(viper.GetString(flagPricerConfig))
 			if err != nil {
 				panic(err)
 			}
-			oids[idx] = types.OracleScriptID(oid)
 		}
-		bandApp.AddHook(price.NewHook(bandApp.Codec(), bandApp.OracleKeeper, oids, filepath.Join(viper.GetString(cli.HomeFlag), "prices")))
+		// Oracle scripts given with --with-pricer use the standard price reference schema.
+		if rawOids := viper.GetString(flagWithPricer); rawOids != "" {
+			for _, rawOid := range strings.Split(rawOids, ",") {
+				oid, err := strconv.ParseInt(rawOid, 10, 64)
+				if err != nil {
+					panic(err)
+				}
+				config[types.OracleScriptID(oid)] = price.StandardSchemaConfig()
+			}
+		}
+		bandApp.AddHook(price.NewHook(bandApp.Codec(), bandApp.OracleKeeper, config,
+			filepath.Join(viper.GetString(cli.HomeFlag), "prices"), viper.GetDuration(flagPricerRetention)))
 	}
 	return bandApp
 }
@@ -145,7 +170,7 @@ func exportAppStateAndTMValidators(
 	newLogger.Info("Start exporting genesis file...")
 
 	if height != -1 {
-		bandApp := app.NewBandApp(newLogger, db, traceStore, false, uint(1), map[int64]bool{}, "", viper.GetBool(flagDisableFeelessReports), viper.GetUint32(flagWithOwasmCacheSize))
+		bandApp := app.NewBandApp(newLogger, db, traceStore, false, uint(1), map[int64]bool{}, "", viper.GetBool(flagDisableFeelessReports), viper.GetUint32(flagWithOwasmCacheSize), 0)
 		newLogger.Info("Setup store at specific height", "height", height)
 		err := bandApp.LoadHeight(height)
 		if err != nil {
@@ -155,6 +180,6 @@ func exportAppStateAndTMValidators(
 		return bandApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
 
-	bandApp := app.NewBandApp(newLogger, db, traceStore, true, uint(1), map[int64]bool{}, "", viper.GetBool(flagDisableFeelessReports), viper.GetUint32(flagWithOwasmCacheSize))
+	bandApp := app.NewBandApp(newLogger, db, traceStore, true, uint(1), map[int64]bool{}, "", viper.GetBool(flagDisableFeelessReports), viper.GetUint32(flagWithOwasmCacheSize), 0)
 	return bandApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
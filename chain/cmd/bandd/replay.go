@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/store/cachekv"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/hooks/emitter"
+)
+
+// overlayDB is a database that reads through to a base database but keeps all writes in
+// memory. Re-executing committed blocks on top of the application database then leaves the
+// node's state untouched, while nodes written during the replay can still be read back.
+type overlayDB struct {
+	store *cachekv.Store
+}
+
+func newOverlayDB(base dbm.DB) overlayDB {
+	return overlayDB{store: cachekv.NewStore(dbadapter.Store{DB: base})}
+}
+
+func (db overlayDB) Get(key []byte) ([]byte, error) {
+	return db.store.Get(key), nil
+}
+
+func (db overlayDB) Has(key []byte) (bool, error) {
+	return db.store.Has(key), nil
+}
+
+func (db overlayDB) Set(key, value []byte) error {
+	db.store.Set(key, value)
+	return nil
+}
+
+func (db overlayDB) SetSync(key, value []byte) error {
+	return db.Set(key, value)
+}
+
+func (db overlayDB) Delete(key []byte) error {
+	db.store.Delete(key)
+	return nil
+}
+
+func (db overlayDB) DeleteSync(key []byte) error {
+	return db.Delete(key)
+}
+
+func (db overlayDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return db.store.Iterator(start, end), nil
+}
+
+func (db overlayDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return db.store.ReverseIterator(start, end), nil
+}
+
+func (db overlayDB) NewBatch() dbm.Batch {
+	return &overlayBatch{db: db}
+}
+
+// Close does nothing, since the base database is closed by its owner.
+func (overlayDB) Close() error {
+	return nil
+}
+
+func (overlayDB) Print() error {
+	return nil
+}
+
+func (overlayDB) Stats() map[string]string {
+	return map[string]string{}
+}
+
+// overlayBatch is a batch of writes to an overlayDB, applied in order on write.
+type overlayBatch struct {
+	db  overlayDB
+	ops []func()
+}
+
+func (b *overlayBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, func() { b.db.store.Set(key, value) })
+}
+
+func (b *overlayBatch) Delete(key []byte) {
+	b.ops = append(b.ops, func() { b.db.store.Delete(key) })
+}
+
+func (b *overlayBatch) Write() error {
+	for _, op := range b.ops {
+		op()
+	}
+	b.ops = nil
+	return nil
+}
+
+func (b *overlayBatch) WriteSync() error { return b.Write() }
+func (b *overlayBatch) Close()           {}
+
+// ReplayEmitterCmd returns replay-emitter cobra Command.
+func ReplayEmitterCmd(ctx *server.Context, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-emitter [from-height] [to-height]",
+		Short: "Re-emit the Kafka messages of the given block height range by re-executing stored blocks",
+		Long: `Re-emit the Kafka messages of the given block height range. The application state at
+from-height - 1 is loaded from the node's database and the stored blocks are executed on top
+of it, so that the messages are rebuilt exactly as the emitter produced them. The node must be
+stopped and must not have pruned the state at from-height - 1. The node's state is not modified.
+If the range continues from the checkpoint of the node's emitter journal, the checkpoint is moved
+to to-height, so that the emitter resumes after the re-emitted blocks.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			to, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			if from < 2 {
+				return fmt.Errorf("from-height %d must be at least 2", from)
+			}
+			if from > to {
+				return fmt.Errorf("from-height %d must not exceed to-height %d", from, to)
+			}
+			if !viper.IsSet(flagWithEmitter) {
+				return fmt.Errorf("--%s must be specified", flagWithEmitter)
+			}
+			config := ctx.Config
+			home := viper.GetString(cli.HomeFlag)
+			blockStoreDB := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.DBDir())
+			defer blockStoreDB.Close()
+			blockStore := tmstore.NewBlockStore(blockStoreDB)
+			if to > blockStore.Height() {
+				return fmt.Errorf("to-height %d exceeds the latest stored block %d", to, blockStore.Height())
+			}
+			stateDB := dbm.NewDB("state", dbm.BackendType(config.DBBackend), config.DBDir())
+			defer stateDB.Close()
+			appDB, err := sdk.NewLevelDB("application", config.DBDir())
+			if err != nil {
+				return err
+			}
+			defer appDB.Close()
+			bandApp := app.NewBandApp(
+				ctx.Logger, newOverlayDB(appDB), nil, false, invCheckPeriod, map[int64]bool{}, home,
+				viper.GetBool(flagDisableFeelessReports),
+				viper.GetUint32(flagWithOwasmCacheSize),
+				viper.GetInt64(flagCompiledOwasmCacheSize),
+				baseapp.SetPruning(storetypes.PruneNothing),
+			)
+			if err := bandApp.LoadHeight(from - 1); err != nil {
+				return err
+			}
+			// The replay keeps its own journal, so that it never flushes or prunes the node's.
+			journalDir, err := ioutil.TempDir(home, "emitter-replay-")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(journalDir)
+			bandApp.AddHook(emitter.NewHook(
+				bandApp.Codec(), bandApp.AccountKeeper, bandApp.BankKeeper, bandApp.SupplyKeeper,
+				bandApp.StakingKeeper, bandApp.MintKeeper, bandApp.DistrKeeper, bandApp.GovKeeper,
+				bandApp.OracleKeeper, viper.GetString(flagWithEmitter), false, journalDir))
+			proxyApp := proxy.NewAppConnConsensus(abcicli.NewLocalClient(nil, bandApp))
+			for height := from; height <= to; height++ {
+				block := blockStore.LoadBlock(height)
+				if block == nil {
+					return fmt.Errorf("block %d is not stored", height)
+				}
+				appHash, err := sm.ExecCommitBlock(proxyApp, block, ctx.Logger, stateDB)
+				if err != nil {
+					return err
+				}
+				// The next block commits to the app hash, which confirms the block was re-executed
+				// exactly as before.
+				if next := blockStore.LoadBlockMeta(height + 1); next != nil && !bytes.Equal(next.Header.AppHash, appHash) {
+					return fmt.Errorf("app hash mismatch at height %d: expected %X, got %X", height, next.Header.AppHash, appHash)
+				}
+				ctx.Logger.Info("Re-emitted block", "height", height)
+			}
+			return advanceEmitterCheckpoint(filepath.Join(home, "emitter"), from, to)
+		},
+	}
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	return cmd
+}
+
+// advanceEmitterCheckpoint moves the checkpoint of the emitter journal in the given directory to
+// the last re-emitted block, if the re-emitted blocks continue from it. Otherwise the emitter of
+// the node would still report them as never emitted on start.
+func advanceEmitterCheckpoint(dir string, from, to int64) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	journal, err := emitter.NewJournal(dir)
+	if err != nil {
+		return err
+	}
+	defer journal.Close()
+	checkpoint, err := journal.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	if checkpoint < from-1 || checkpoint >= to {
+		return nil
+	}
+	return journal.SetCheckpoint(to)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/pkg/obi/obigen"
+	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+const (
+	flagSchema         = "schema"
+	flagOracleScriptID = "oracle-script-id"
+	flagLang           = "lang"
+	flagName           = "name"
+	flagPackage        = "package"
+	flagObiImport      = "obi-import"
+	flagOut            = "out"
+)
+
+func main() {
+	defaults := obigen.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:   "obigen",
+		Short: "Generate typed OBI encoding and decoding code for an oracle script schema",
+		Example: `obigen --schema "{symbol:string,multiplier:u64}/{px:u64}" --lang go --name Price
+obigen --oracle-script-id 1 --node tcp://localhost:26657 --lang solidity --out PriceObi.sol`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := getSchema(cmd)
+			if err != nil {
+				return err
+			}
+			lang, _ := cmd.Flags().GetString(flagLang)
+			opts := obigen.Options{}
+			opts.Name, _ = cmd.Flags().GetString(flagName)
+			opts.Package, _ = cmd.Flags().GetString(flagPackage)
+			opts.ObiImport, _ = cmd.Flags().GetString(flagObiImport)
+			code, err := obigen.Generate(lang, schema, opts)
+			if err != nil {
+				return err
+			}
+			out, _ := cmd.Flags().GetString(flagOut)
+			if out == "" {
+				_, err = os.Stdout.Write(code)
+				return err
+			}
+			return ioutil.WriteFile(out, code, 0644)
+		},
+	}
+	cmd.Flags().String(flagSchema, "", "Oracle script schema in \"input/output\" format")
+	cmd.Flags().Int64(flagOracleScriptID, 0, "ID of the oracle script to fetch the schema from, instead of --schema")
+	cmd.Flags().String(flagLang, obigen.LangGo, fmt.Sprintf("Target language (%s|%s|%s)",
+		obigen.LangGo, obigen.LangSolidity, obigen.LangTypeScript))
+	cmd.Flags().String(flagName, defaults.Name, "Base name of the generated types and functions")
+	cmd.Flags().String(flagPackage, defaults.Package, "Package name of generated Go code")
+	cmd.Flags().String(flagObiImport, defaults.ObiImport, "Import path of Obi.sol in generated Solidity code")
+	cmd.Flags().StringP(flagOut, "o", "", "Output file, or stdout if empty")
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to Tendermint RPC interface for this chain")
+	cmd.Flags().Bool(flags.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	viper.BindPFlag(flags.FlagNode, cmd.Flags().Lookup(flags.FlagNode))
+	viper.BindPFlag(flags.FlagTrustNode, cmd.Flags().Lookup(flags.FlagTrustNode))
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// getSchema returns the schema given by --schema, or fetches it from the chain by --oracle-script-id.
+func getSchema(cmd *cobra.Command) (string, error) {
+	schema, _ := cmd.Flags().GetString(flagSchema)
+	id, _ := cmd.Flags().GetInt64(flagOracleScriptID)
+	if (schema == "") == (id == 0) {
+		return "", fmt.Errorf("exactly one of --%s and --%s must be given", flagSchema, flagOracleScriptID)
+	}
+	if schema != "" {
+		return schema, nil
+	}
+	cliCtx := context.NewCLIContext().WithCodec(app.MakeCodec())
+	oracleScript, err := clientcmn.QueryOracleScript(types.StoreKey, cliCtx, types.OracleScriptID(id))
+	if err != nil {
+		return "", err
+	}
+	return oracleScript.Schema, nil
+}
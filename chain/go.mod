@@ -3,6 +3,9 @@ module github.com/bandprotocol/bandchain/chain
 go 1.13
 
 require (
+	github.com/ChainSafe/go-schnorrkel v0.0.0-20200405005733-88cbf1b4c40d
+	github.com/DataDog/zstd v1.4.1
+	github.com/andybalholm/brotli v1.0.4
 	github.com/bandprotocol/go-owasm v0.0.0-20210311072328-a6859c27139c
 	github.com/cosmos/cosmos-sdk v0.39.2
 	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
@@ -14,6 +17,7 @@ require (
 	github.com/golang/protobuf v1.4.2
 	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
 	github.com/gorilla/mux v1.7.4
+	github.com/gtank/merlin v0.1.1
 	github.com/hashicorp/golang-lru v0.5.4
 	github.com/kyokomi/emoji v2.2.4+incompatible
 	github.com/levigross/grequests v0.0.0-20190908174114-253788527a1a
@@ -38,3 +42,5 @@ require (
 )
 
 replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.2-alpha.regen.1
+
+replace github.com/bandprotocol/go-owasm => ../go-owasm
@@ -0,0 +1,172 @@
+package emitter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/bandprotocol/bandchain/chain/hooks/common"
+)
+
+var (
+	// CheckpointKey is the key to the last block height fully flushed to Kafka.
+	CheckpointKey = []byte("checkpoint")
+	// BlockMessagesPrefix is the key prefix for the journal of messages emitted in each block.
+	BlockMessagesPrefix = []byte{0x01}
+)
+
+const (
+	// HeaderHeight is the Kafka header key carrying the block height of a message.
+	HeaderHeight = "height"
+	// HeaderSequence is the Kafka header key carrying the index of a message within its block.
+	HeaderSequence = "seq"
+)
+
+// TaggedMessage is a message together with its position in the emitted stream. Downstream
+// consumers can use (Height, Sequence) to deduplicate messages that are emitted more than once.
+type TaggedMessage struct {
+	Height   int64         `json:"height"`
+	Sequence uint64        `json:"seq"`
+	Key      string        `json:"key"`
+	Value    common.JsDict `json:"value"`
+}
+
+// KafkaMessage converts the tagged message to a Kafka message with height and sequence headers.
+func (msg TaggedMessage) KafkaMessage() kafka.Message {
+	res, _ := json.Marshal(msg.Value) // Error must always be nil.
+	return kafka.Message{
+		Key:   []byte(msg.Key),
+		Value: res,
+		Headers: []kafka.Header{
+			{Key: HeaderHeight, Value: []byte(strconv.FormatInt(msg.Height, 10))},
+			{Key: HeaderSequence, Value: []byte(strconv.FormatUint(msg.Sequence, 10))},
+		},
+	}
+}
+
+// TagMessages assigns the given block height and sequential indexes to the list of messages.
+func TagMessages(height int64, msgs []common.Message) []TaggedMessage {
+	tagged := make([]TaggedMessage, len(msgs))
+	for idx, msg := range msgs {
+		tagged[idx] = TaggedMessage{Height: height, Sequence: uint64(idx), Key: msg.Key, Value: msg.Value}
+	}
+	return tagged
+}
+
+// Journal persists the messages of each block until they are fully written to Kafka, together
+// with the checkpoint of the last block height that was fully written. Blocks at or below the
+// checkpoint are pruned, so the journal only holds blocks whose write may not have completed.
+type Journal struct {
+	db *leveldb.DB
+}
+
+// NewJournal opens (or creates) the emitter journal at the given directory.
+func NewJournal(dir string) (*Journal, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{db: db}, nil
+}
+
+// Close releases the underlying database.
+func (j *Journal) Close() error {
+	return j.db.Close()
+}
+
+func blockMessagesKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(append([]byte{}, BlockMessagesPrefix...), bz...)
+}
+
+// SaveBlock stores the messages of the given block height, replacing any previous entry.
+func (j *Journal) SaveBlock(height int64, msgs []TaggedMessage) error {
+	bz, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	return j.db.Put(blockMessagesKey(height), bz, nil)
+}
+
+// GetBlock returns the messages stored for the given block height.
+func (j *Journal) GetBlock(height int64) ([]TaggedMessage, error) {
+	bz, err := j.db.Get(blockMessagesKey(height), nil)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []TaggedMessage
+	if err := json.Unmarshal(bz, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// GetPendingHeights returns the heights of all blocks in the journal in ascending order. These
+// are the blocks above the checkpoint, which may not have been fully written to Kafka.
+func (j *Journal) GetPendingHeights() ([]int64, error) {
+	it := j.db.NewIterator(util.BytesPrefix(BlockMessagesPrefix), nil)
+	defer it.Release()
+	heights := []int64{}
+	for it.Next() {
+		heights = append(heights, int64(binary.BigEndian.Uint64(it.Key()[len(BlockMessagesPrefix):])))
+	}
+	return heights, it.Error()
+}
+
+// SetCheckpoint records the given height as the last block fully flushed to Kafka, and prunes
+// the messages of all blocks at or below it.
+func (j *Journal) SetCheckpoint(height int64) error {
+	batch := new(leveldb.Batch)
+	it := j.db.NewIterator(&util.Range{Start: BlockMessagesPrefix, Limit: blockMessagesKey(height + 1)}, nil)
+	for it.Next() {
+		batch.Delete(append([]byte{}, it.Key()...))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	batch.Put(CheckpointKey, bz)
+	return j.db.Write(batch, nil)
+}
+
+// GetCheckpoint returns the last block height fully flushed to Kafka, or -1 if none exists.
+func (j *Journal) GetCheckpoint() (int64, error) {
+	bz, err := j.db.Get(CheckpointKey, nil)
+	if err == leveldb.ErrNotFound {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// FlushPending writes the messages of all pending blocks to Kafka in order, checkpointing after
+// each block. It returns the resulting checkpoint. This finishes the writes that were interrupted
+// before the node stopped.
+func (j *Journal) FlushPending(writer MessageWriter) (int64, error) {
+	heights, err := j.GetPendingHeights()
+	if err != nil {
+		return 0, err
+	}
+	for _, height := range heights {
+		msgs, err := j.GetBlock(height)
+		if err != nil {
+			return 0, err
+		}
+		if err := writeMessages(writer, msgs); err != nil {
+			return 0, err
+		}
+		if err := j.SetCheckpoint(height); err != nil {
+			return 0, err
+		}
+	}
+	return j.GetCheckpoint()
+}
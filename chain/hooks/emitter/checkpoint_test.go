@@ -0,0 +1,174 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/bandprotocol/bandchain/chain/hooks/common"
+)
+
+// fakeWriter records the messages written to it, or fails every write if err is set.
+type fakeWriter struct {
+	msgs []kafka.Message
+	err  error
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func newTestJournal(t *testing.T) (*Journal, string, func()) {
+	dir, err := ioutil.TempDir("", "emitter")
+	require.NoError(t, err)
+	journal, err := NewJournal(dir)
+	require.NoError(t, err)
+	return journal, dir, func() {
+		journal.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestHook(t *testing.T) (*Hook, *fakeWriter, func()) {
+	journal, _, cleanup := newTestJournal(t)
+	writer := &fakeWriter{}
+	checkpoint, err := journal.FlushPending(writer)
+	require.NoError(t, err)
+	return &Hook{writer: writer, journal: journal, checkpoint: checkpoint}, writer, cleanup
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestTaggedMessageKafkaMessage(t *testing.T) {
+	msgs := TagMessages(10, []common.Message{
+		{Key: "NEW_BLOCK", Value: common.JsDict{"height": 10}},
+		{Key: "COMMIT", Value: common.JsDict{"height": 10}},
+	})
+	require.Equal(t, []TaggedMessage{
+		{Height: 10, Sequence: 0, Key: "NEW_BLOCK", Value: common.JsDict{"height": 10}},
+		{Height: 10, Sequence: 1, Key: "COMMIT", Value: common.JsDict{"height": 10}},
+	}, msgs)
+	msg := msgs[1].KafkaMessage()
+	require.Equal(t, []byte("COMMIT"), msg.Key)
+	require.Equal(t, []byte(`{"height":10}`), msg.Value)
+	require.Equal(t, "10", header(msg, HeaderHeight))
+	require.Equal(t, "1", header(msg, HeaderSequence))
+}
+
+func TestJournalCheckpointPrunesBlocks(t *testing.T) {
+	journal, dir, cleanup := newTestJournal(t)
+	defer cleanup()
+	checkpoint, err := journal.GetCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), checkpoint)
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, journal.SaveBlock(height, TagMessages(height, []common.Message{{Key: "COMMIT"}})))
+	}
+	require.NoError(t, journal.SetCheckpoint(2))
+	// Blocks at or below the checkpoint are pruned.
+	heights, err := journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, heights)
+	_, err = journal.GetBlock(2)
+	require.Equal(t, leveldb.ErrNotFound, err)
+	msgs, err := journal.GetBlock(3)
+	require.NoError(t, err)
+	require.Equal(t, TagMessages(3, []common.Message{{Key: "COMMIT"}}), msgs)
+	// The checkpoint and pending blocks survive reopening the journal.
+	require.NoError(t, journal.Close())
+	journal, err = NewJournal(dir)
+	require.NoError(t, err)
+	defer journal.Close()
+	checkpoint, err = journal.GetCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), checkpoint)
+	heights, err = journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, heights)
+}
+
+func TestJournalFlushPending(t *testing.T) {
+	journal, _, cleanup := newTestJournal(t)
+	defer cleanup()
+	require.NoError(t, journal.SetCheckpoint(4))
+	require.NoError(t, journal.SaveBlock(5, TagMessages(5, []common.Message{{Key: "NEW_BLOCK"}, {Key: "COMMIT"}})))
+	// Failed writes keep the block pending.
+	_, err := journal.FlushPending(&fakeWriter{err: errors.New("kafka is down")})
+	require.Error(t, err)
+	heights, err := journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, heights)
+	writer := &fakeWriter{}
+	checkpoint, err := journal.FlushPending(writer)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), checkpoint)
+	require.Len(t, writer.msgs, 2)
+	require.Equal(t, "5", header(writer.msgs[1], HeaderHeight))
+	require.Equal(t, "1", header(writer.msgs[1], HeaderSequence))
+	heights, err = journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Empty(t, heights)
+}
+
+func TestFlushMessages(t *testing.T) {
+	h, writer, cleanup := newTestHook(t)
+	defer cleanup()
+	h.Write("COMMIT", common.JsDict{"height": 1})
+	h.FlushMessages(1)
+	require.Len(t, writer.msgs, 1)
+	require.Equal(t, int64(1), h.checkpoint)
+	checkpoint, err := h.journal.GetCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), checkpoint)
+	heights, err := h.journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Empty(t, heights)
+	// A failed write panics and leaves the block in the journal for the next start.
+	writer.err = errors.New("kafka is down")
+	h.msgs = []common.Message{{Key: "COMMIT", Value: common.JsDict{"height": 2}}}
+	require.Panics(t, func() { h.FlushMessages(2) })
+	require.Equal(t, int64(1), h.checkpoint)
+	heights, err = h.journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, heights)
+	// Re-emitting a block at or below the checkpoint does not journal it or move the checkpoint.
+	writer.err = nil
+	h.FlushMessages(1)
+	require.Len(t, writer.msgs, 2)
+	require.Equal(t, int64(1), h.checkpoint)
+	heights, err = h.journal.GetPendingHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, heights)
+	// Messages of the start state are never checkpointed.
+	h.FlushMessages(-1)
+	require.Equal(t, int64(1), h.checkpoint)
+}
+
+func TestCheckContinuity(t *testing.T) {
+	h, _, cleanup := newTestHook(t)
+	defer cleanup()
+	// Without a checkpoint, the emitter may start at any height.
+	require.NoError(t, h.checkContinuity(100))
+	h.checkpoint = 10
+	require.NoError(t, h.checkContinuity(10))
+	require.NoError(t, h.checkContinuity(11))
+	require.EqualError(t, h.checkContinuity(13),
+		"emitter: blocks 11 to 12 were never emitted; re-emit them with replay-emitter or start in fast sync mode")
+}
@@ -2,7 +2,7 @@ package emitter
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -32,11 +32,15 @@ type Hook struct {
 	cdc       *codec.Codec
 	txDecoder sdk.TxDecoder
 	// Main Kafka writer instance.
-	writer *kafka.Writer
+	writer MessageWriter
+	// Journal of messages not yet fully flushed and the last fully flushed block height.
+	journal    *Journal
+	checkpoint int64 // The last block height fully flushed to Kafka, or -1 if none.
 	// Temporary variables that are reset on every block.
 	accsInBlock    map[string]bool  // The accounts that need balance update at the end of block.
 	accsInTx       map[string]bool  // The accounts related to the current processing transaction.
 	msgs           []common.Message // The list of all messages to publish for this block.
+	height         int64            // The height of the block being processed.
 	emitStartState bool             // If emitStartState is true will emit all non historical state to Kafka
 
 	accountKeeper auth.AccountKeeper
@@ -49,23 +53,28 @@ type Hook struct {
 	oracleKeeper  oracle.Keeper
 }
 
-// NewHook creates an emitter hook instance that will be added in Band App.
+// NewHook creates an emitter hook instance that will be added in Band App. Blocks left in the
+// journal at journalDir by an interrupted flush are written to Kafka first.
 func NewHook(
 	cdc *codec.Codec, accountKeeper auth.AccountKeeper, bankKeeper bank.Keeper, supplyKeeper supply.Keeper,
 	stakingKeeper staking.Keeper, mintKeeper mint.Keeper, distrKeeper distr.Keeper, govKeeper gov.Keeper,
-	oracleKeeper keeper.Keeper, kafkaURI string, emitStartState bool,
+	oracleKeeper keeper.Keeper, kafkaURI string, emitStartState bool, journalDir string,
 ) *Hook {
-	paths := strings.SplitN(kafkaURI, "@", 2)
+	writer := newKafkaWriter(kafkaURI)
+	journal, err := NewJournal(journalDir)
+	if err != nil {
+		panic(err)
+	}
+	checkpoint, err := journal.FlushPending(writer)
+	if err != nil {
+		panic(err)
+	}
 	return &Hook{
-		cdc:       cdc,
-		txDecoder: auth.DefaultTxDecoder(cdc),
-		writer: kafka.NewWriter(kafka.WriterConfig{
-			Brokers:      paths[1:],
-			Topic:        paths[0],
-			Balancer:     &kafka.LeastBytes{},
-			BatchTimeout: 1 * time.Millisecond,
-			// Async:    true, // TODO: We may be able to enable async mode on replay
-		}),
+		cdc:            cdc,
+		txDecoder:      auth.DefaultTxDecoder(cdc),
+		writer:         writer,
+		journal:        journal,
+		checkpoint:     checkpoint,
 		accountKeeper:  accountKeeper,
 		bankKeeper:     bankKeeper,
 		supplyKeeper:   supplyKeeper,
@@ -78,6 +87,23 @@ func NewHook(
 	}
 }
 
+// MessageWriter publishes messages to Kafka. It is implemented by *kafka.Writer.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// newKafkaWriter creates a Kafka writer from the given "topic@broker" URI.
+func newKafkaWriter(kafkaURI string) *kafka.Writer {
+	paths := strings.SplitN(kafkaURI, "@", 2)
+	return kafka.NewWriter(kafka.WriterConfig{
+		Brokers:      paths[1:],
+		Topic:        paths[0],
+		Balancer:     &kafka.LeastBytes{},
+		BatchTimeout: 1 * time.Millisecond,
+		// Async:    true, // TODO: We may be able to enable async mode on replay
+	})
+}
+
 // AddAccountsInBlock adds the given accounts to the list of accounts to update balances end-of-block.
 func (h *Hook) AddAccountsInBlock(accs ...sdk.AccAddress) {
 	for _, acc := range accs {
@@ -97,17 +123,48 @@ func (h *Hook) Write(key string, val common.JsDict) {
 	h.msgs = append(h.msgs, common.Message{Key: key, Value: val})
 }
 
-// FlushMessages publishes all pending messages to Kafka. Blocks until completion.
-func (h *Hook) FlushMessages() {
-	kafkaMsgs := make([]kafka.Message, len(h.msgs))
-	for idx, msg := range h.msgs {
-		res, _ := json.Marshal(msg.Value) // Error must always be nil.
-		kafkaMsgs[idx] = kafka.Message{Key: []byte(msg.Key), Value: res}
-	}
-	err := h.writer.WriteMessages(context.Background(), kafkaMsgs...)
-	if err != nil {
+// FlushMessages publishes all pending messages of the given block height to Kafka. Blocks until
+// completion. Messages of heights above the checkpoint are journaled before publishing, and the
+// height is checkpointed once Kafka acknowledges the write. Heights at or below the checkpoint are
+// blocks that the node re-executes after a restart, and are published again without journaling.
+func (h *Hook) FlushMessages(height int64) {
+	msgs := TagMessages(height, h.msgs)
+	journaled := height > h.checkpoint
+	if journaled {
+		if err := h.journal.SaveBlock(height, msgs); err != nil {
+			panic(err)
+		}
+	}
+	if err := writeMessages(h.writer, msgs); err != nil {
 		panic(err)
 	}
+	if journaled {
+		if err := h.journal.SetCheckpoint(height); err != nil {
+			panic(err)
+		}
+		h.checkpoint = height
+	}
+}
+
+// checkContinuity returns an error if the block at the given height would leave a gap of blocks
+// that were never emitted after the checkpoint, e.g. if the node ran without the emitter.
+func (h *Hook) checkContinuity(height int64) error {
+	if h.checkpoint < 0 || height <= h.checkpoint+1 {
+		return nil
+	}
+	return fmt.Errorf(
+		"emitter: blocks %d to %d were never emitted; re-emit them with replay-emitter or start in fast sync mode",
+		h.checkpoint+1, height-1,
+	)
+}
+
+// writeMessages publishes the given tagged messages to Kafka. Blocks until completion.
+func writeMessages(writer MessageWriter, msgs []TaggedMessage) error {
+	kafkaMsgs := make([]kafka.Message, len(msgs))
+	for idx, msg := range msgs {
+		kafkaMsgs[idx] = msg.KafkaMessage()
+	}
+	return writer.WriteMessages(context.Background(), kafkaMsgs...)
 }
 
 // AfterInitChain specify actions need to do after chain initialization (app.Hook interface).
@@ -216,7 +273,7 @@ func (h *Hook) AfterInitChain(ctx sdk.Context, req abci.RequestInitChain, res ab
 		h.emitSetOracleScript(types.OracleScriptID(idx+1), os, nil)
 	}
 	h.Write("COMMIT", common.JsDict{"height": 0})
-	h.FlushMessages()
+	h.FlushMessages(0)
 }
 
 func (h *Hook) emitNonHistoricalState(ctx sdk.Context) {
@@ -225,7 +282,7 @@ func (h *Hook) emitNonHistoricalState(ctx sdk.Context) {
 	h.emitGovModule(ctx)
 	h.emitOracleModule(ctx)
 	h.Write("COMMIT", common.JsDict{"height": -1})
-	h.FlushMessages()
+	h.FlushMessages(-1)
 	h.msgs = []common.Message{}
 }
 
@@ -234,10 +291,14 @@ func (h *Hook) AfterBeginBlock(ctx sdk.Context, req abci.RequestBeginBlock, res
 	h.accsInBlock = make(map[string]bool)
 	h.accsInTx = make(map[string]bool)
 	h.msgs = []common.Message{}
+	h.height = req.Header.GetHeight()
 	if h.emitStartState {
 		h.emitStartState = false
 		h.emitNonHistoricalState(ctx)
 	} else {
+		if err := h.checkContinuity(h.height); err != nil {
+			panic(err)
+		}
 		for _, val := range req.GetLastCommitInfo().Votes {
 			validator := h.stakingKeeper.ValidatorByConsAddr(ctx, val.GetValidator().Address)
 			h.Write("NEW_VALIDATOR_VOTE", common.JsDict{
@@ -347,5 +408,5 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 
 // BeforeCommit specify actions need to do before commit block (app.Hook interface).
 func (h *Hook) BeforeCommit() {
-	h.FlushMessages()
+	h.FlushMessages(h.height)
 }
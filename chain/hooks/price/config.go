@@ -0,0 +1,157 @@
+package price
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// StandardSchema is the OBI schema of standard price reference oracle scripts.
+const StandardSchema = "{symbols:[string],multiplier:u64}/{pxs:[u64]}"
+
+// SchemaConfig describes how to extract prices from requests of an oracle script. SymbolsField
+// names the input field holding a string or a list of strings, PricesField names the output
+// field holding an integer or a list of integers, and MultiplierField names the input field
+// holding the multiplier. If MultiplierField is empty, Multiplier is used as a constant.
+type SchemaConfig struct {
+	Schema          string `json:"schema"`
+	SymbolsField    string `json:"symbols_field"`
+	PricesField     string `json:"prices_field"`
+	MultiplierField string `json:"multiplier_field,omitempty"`
+	Multiplier      uint64 `json:"multiplier,omitempty"`
+}
+
+// Config maps oracle script IDs to the schema configs used to decode their requests.
+type Config map[types.OracleScriptID]SchemaConfig
+
+// StandardSchemaConfig returns the schema config of standard price reference oracle scripts.
+func StandardSchemaConfig() SchemaConfig {
+	return SchemaConfig{
+		Schema:          StandardSchema,
+		SymbolsField:    "symbols",
+		PricesField:     "pxs",
+		MultiplierField: "multiplier",
+	}
+}
+
+// LoadConfig reads the JSON config file at the given path.
+func LoadConfig(path string) (Config, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(bz, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// priceSchema is a schema config with parsed input and output types.
+type priceSchema struct {
+	SchemaConfig
+	input  obi.Type
+	output obi.Type
+}
+
+func newPriceSchema(config SchemaConfig) (priceSchema, error) {
+	input, output, err := obi.ParseOracleScriptSchema(config.Schema)
+	if err != nil {
+		return priceSchema{}, err
+	}
+	if err := checkField(input, config.SymbolsField, obi.KindString); err != nil {
+		return priceSchema{}, err
+	}
+	if err := checkField(output, config.PricesField, obi.KindU8, obi.KindU16, obi.KindU32, obi.KindU64); err != nil {
+		return priceSchema{}, err
+	}
+	if config.MultiplierField != "" {
+		if err := checkField(input, config.MultiplierField, obi.KindU8, obi.KindU16, obi.KindU32, obi.KindU64); err != nil {
+			return priceSchema{}, err
+		}
+	}
+	return priceSchema{SchemaConfig: config, input: input, output: output}, nil
+}
+
+// checkField verifies that the struct type has the field of one of the given kinds, or a vector of them.
+func checkField(t obi.Type, name string, kinds ...obi.Kind) error {
+	field, ok := t.FieldByName(name)
+	if !ok {
+		return fmt.Errorf("field %s not found in schema %s", name, t)
+	}
+	if field.Kind == obi.KindVector {
+		field = *field.Elem
+	}
+	for _, kind := range kinds {
+		if field.Kind == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("field %s has unexpected type %s", name, field)
+}
+
+// toUint64 converts a decoded unsigned integer value to uint64.
+func toUint64(v interface{}) (uint64, error) {
+	switch v := v.(type) {
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("expect unsigned integer, got %T", v)
+	}
+}
+
+// extractPrices decodes the calldata and result against the schema and returns the symbols,
+// their prices, and the multiplier.
+func (s priceSchema) extractPrices(calldata, result []byte) ([]string, []uint64, uint64, error) {
+	rawInput, err := obi.DecodeValue(calldata, s.input)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	rawOutput, err := obi.DecodeValue(result, s.output)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	input := rawInput.(map[string]interface{})
+	output := rawOutput.(map[string]interface{})
+	symbols := []string{}
+	switch v := input[s.SymbolsField].(type) {
+	case string:
+		symbols = append(symbols, v)
+	case []interface{}:
+		for _, each := range v {
+			symbols = append(symbols, each.(string))
+		}
+	}
+	rawPxs, ok := output[s.PricesField].([]interface{})
+	if !ok {
+		rawPxs = []interface{}{output[s.PricesField]}
+	}
+	pxs := []uint64{}
+	for _, each := range rawPxs {
+		px, err := toUint64(each)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		pxs = append(pxs, px)
+	}
+	if len(symbols) != len(pxs) {
+		return nil, nil, 0, fmt.Errorf("got %d prices for %d symbols", len(pxs), len(symbols))
+	}
+	multiplier := s.Multiplier
+	if s.MultiplierField != "" {
+		multiplier, err = toUint64(input[s.MultiplierField])
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	return symbols, pxs, multiplier, nil
+}
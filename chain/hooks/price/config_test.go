@@ -0,0 +1,99 @@
+package price
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pricer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{
+  "1": {"schema": "{symbols:[string],multiplier:u64}/{pxs:[u64]}", "symbols_field": "symbols", "prices_field": "pxs", "multiplier_field": "multiplier"},
+  "8": {"schema": "{symbol:string}/{px:u32}", "symbols_field": "symbol", "prices_field": "px", "multiplier": 100}
+}`), 0644))
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		1: StandardSchemaConfig(),
+		8: SchemaConfig{Schema: "{symbol:string}/{px:u32}", SymbolsField: "symbol", PricesField: "px", Multiplier: 100},
+	}, config)
+	// Missing and malformed files.
+	_, err = LoadConfig(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"one": {}}`), 0644))
+	_, err = LoadConfig(path)
+	require.Error(t, err)
+}
+
+func TestNewPriceSchemaInvalid(t *testing.T) {
+	for _, config := range []SchemaConfig{
+		{Schema: "{symbols:[string]", SymbolsField: "symbols", PricesField: "pxs"},
+		{Schema: StandardSchema, SymbolsField: "symbol", PricesField: "pxs"},
+		{Schema: StandardSchema, SymbolsField: "symbols", PricesField: "px"},
+		{Schema: "{symbols:[u64]}/{pxs:[u64]}", SymbolsField: "symbols", PricesField: "pxs"},
+		{Schema: "{symbols:[string]}/{pxs:[i64]}", SymbolsField: "symbols", PricesField: "pxs"},
+		{Schema: "{symbols:[string],multiplier:string}/{pxs:[u64]}", SymbolsField: "symbols", PricesField: "pxs", MultiplierField: "multiplier"},
+	} {
+		_, err := newPriceSchema(config)
+		require.Error(t, err, config.Schema)
+	}
+}
+
+func TestExtractPricesStandardSchema(t *testing.T) {
+	schema, err := newPriceSchema(StandardSchemaConfig())
+	require.NoError(t, err)
+	calldata := obi.MustEncode(struct {
+		Symbols    []string
+		Multiplier uint64
+	}{[]string{"BTC", "ETH"}, 1000})
+	result := obi.MustEncode(struct{ Pxs []uint64 }{[]uint64{50000000, 2000000}})
+	symbols, pxs, multiplier, err := schema.extractPrices(calldata, result)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC", "ETH"}, symbols)
+	require.Equal(t, []uint64{50000000, 2000000}, pxs)
+	require.Equal(t, uint64(1000), multiplier)
+	// Prices must match symbols one to one.
+	result = obi.MustEncode(struct{ Pxs []uint64 }{[]uint64{50000000}})
+	_, _, _, err = schema.extractPrices(calldata, result)
+	require.Error(t, err)
+	// Requests that do not decode against the schema are rejected.
+	_, _, _, err = schema.extractPrices([]byte("beeb"), result)
+	require.Error(t, err)
+	_, _, _, err = schema.extractPrices(calldata, []byte("beeb"))
+	require.Error(t, err)
+}
+
+func TestExtractPricesSingleSymbol(t *testing.T) {
+	schema, err := newPriceSchema(SchemaConfig{
+		Schema: "{symbol:string}/{px:u32}", SymbolsField: "symbol", PricesField: "px", Multiplier: 100,
+	})
+	require.NoError(t, err)
+	symbols, pxs, multiplier, err := schema.extractPrices(
+		obi.MustEncode(struct{ Symbol string }{"BTC"}), obi.MustEncode(struct{ Px uint32 }{5000}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC"}, symbols)
+	require.Equal(t, []uint64{5000}, pxs)
+	require.Equal(t, uint64(100), multiplier)
+}
+
+func TestToUint64(t *testing.T) {
+	for _, v := range []interface{}{uint8(42), uint16(42), uint32(42), uint64(42)} {
+		val, err := toUint64(v)
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), val)
+	}
+	for _, v := range []interface{}{int64(42), "42", nil} {
+		_, err := toUint64(v)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,165 @@
+package price
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// historyPrefix returns the key prefix of all historical prices of the given symbol and counts.
+func historyPrefix(askCount, minCount uint64, symbol string) []byte {
+	return []byte(fmt.Sprintf("h%d,%d,%s,", askCount, minCount, symbol))
+}
+
+// historyKey returns the key of the historical price at the given resolve time from the given
+// request. Requests resolved at the same time are ordered by request ID.
+func historyKey(askCount, minCount uint64, symbol string, resolveTime int64, reqID types.RequestID) []byte {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz, uint64(resolveTime))
+	binary.BigEndian.PutUint64(bz[8:], uint64(reqID))
+	return append(historyPrefix(askCount, minCount, symbol), bz...)
+}
+
+// historyRange returns the key range covering resolve times in [from, to).
+func historyRange(askCount, minCount uint64, symbol string, from, to int64) *util.Range {
+	return &util.Range{
+		Start: historyKey(askCount, minCount, symbol, from, 0),
+		Limit: historyKey(askCount, minCount, symbol, to, 0),
+	}
+}
+
+// putHistory stores the price in the time-indexed history and prunes entries older than the retention.
+func (h *Hook) putHistory(askCount, minCount uint64, price Price) error {
+	key := historyKey(askCount, minCount, price.Symbol, price.ResolveTime, price.RequestID)
+	err := h.db.Put(key, h.cdc.MustMarshalBinaryBare(price), nil)
+	if err != nil {
+		return err
+	}
+	if h.retention <= 0 {
+		return nil
+	}
+	cutoff := price.ResolveTime - int64(h.retention.Seconds())
+	if cutoff <= 0 {
+		return nil
+	}
+	batch := new(leveldb.Batch)
+	it := h.db.NewIterator(historyRange(askCount, minCount, price.Symbol, 0, cutoff), nil)
+	for it.Next() {
+		batch.Delete(append([]byte{}, it.Key()...))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return h.db.Write(batch, nil)
+}
+
+// getPriceAt returns the latest price with resolve time at or before the given timestamp. Of prices
+// resolved at the same time, the one from the latest request is returned.
+func (h *Hook) getPriceAt(askCount, minCount uint64, symbol string, timestamp int64) (Price, error) {
+	it := h.db.NewIterator(historyRange(askCount, minCount, symbol, 0, timestamp+1), nil)
+	defer it.Release()
+	if !it.Last() {
+		if err := it.Error(); err != nil {
+			return Price{}, err
+		}
+		return Price{}, fmt.Errorf("no price of %s with %d/%d counts at or before %d", symbol, minCount, askCount, timestamp)
+	}
+	var price Price
+	h.cdc.MustUnmarshalBinaryBare(it.Value(), &price)
+	return price, nil
+}
+
+// getPrices returns all prices with resolve time in [from, to], ordered by resolve time.
+func (h *Hook) getPrices(askCount, minCount uint64, symbol string, from, to int64) ([]Price, error) {
+	it := h.db.NewIterator(historyRange(askCount, minCount, symbol, from, to+1), nil)
+	defer it.Release()
+	prices := []Price{}
+	for it.Next() {
+		var price Price
+		h.cdc.MustUnmarshalBinaryBare(it.Value(), &price)
+		prices = append(prices, price)
+	}
+	return prices, it.Error()
+}
+
+// getOHLC returns the open/high/low/close buckets of the given interval over [from, to].
+// Buckets without any price are omitted.
+func (h *Hook) getOHLC(askCount, minCount uint64, symbol string, from, to, interval int64) ([]OHLC, error) {
+	if interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	prices, err := h.getPrices(askCount, minCount, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	buckets := []OHLC{}
+	for _, price := range prices {
+		start := from + (price.ResolveTime-from)/interval*interval
+		if len(buckets) == 0 || buckets[len(buckets)-1].StartTime != start {
+			buckets = append(buckets, OHLC{
+				Symbol:     symbol,
+				Multiplier: price.Multiplier,
+				Open:       price.Px,
+				High:       price.Px,
+				Low:        price.Px,
+				Close:      price.Px,
+				StartTime:  start,
+				EndTime:    start + interval,
+			})
+			continue
+		}
+		bucket := &buckets[len(buckets)-1]
+		if price.Px > bucket.High {
+			bucket.High = price.Px
+		}
+		if price.Px < bucket.Low {
+			bucket.Low = price.Px
+		}
+		bucket.Close = price.Px
+		bucket.Multiplier = price.Multiplier
+	}
+	return buckets, nil
+}
+
+// getTWAP returns the time-weighted average price over [from, to]. Each price holds until the
+// next one is resolved. If no price exists at or before from, the window starts at the first
+// price resolved within it.
+func (h *Hook) getTWAP(askCount, minCount uint64, symbol string, from, to int64) (TWAP, error) {
+	if from >= to {
+		return TWAP{}, errors.New("window start must be before window end")
+	}
+	prices, err := h.getPrices(askCount, minCount, symbol, from+1, to)
+	if err != nil {
+		return TWAP{}, err
+	}
+	if prev, err := h.getPriceAt(askCount, minCount, symbol, from); err == nil {
+		prev.ResolveTime = from
+		prices = append([]Price{prev}, prices...)
+	}
+	if len(prices) == 0 {
+		return TWAP{}, fmt.Errorf("no price of %s with %d/%d counts in window [%d, %d]", symbol, minCount, askCount, from, to)
+	}
+	start := prices[0].ResolveTime
+	if start == to {
+		last := prices[len(prices)-1]
+		return NewTWAP(symbol, last.Multiplier, last.Px, start, to), nil
+	}
+	sum := new(big.Int)
+	for idx, price := range prices {
+		end := to
+		if idx+1 < len(prices) {
+			end = prices[idx+1].ResolveTime
+		}
+		weighted := new(big.Int).SetUint64(price.Px)
+		sum.Add(sum, weighted.Mul(weighted, big.NewInt(end-price.ResolveTime)))
+	}
+	avg := sum.Quo(sum, big.NewInt(to-start))
+	return NewTWAP(symbol, prices[len(prices)-1].Multiplier, avg.Uint64(), start, to), nil
+}
@@ -0,0 +1,139 @@
+package price
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func newHistoryHook(t *testing.T) (*Hook, func()) {
+	dir, err := ioutil.TempDir("", "prices")
+	require.NoError(t, err)
+	db, err := leveldb.OpenFile(dir, nil)
+	require.NoError(t, err)
+	h := &Hook{cdc: codec.New(), db: db}
+	return h, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// putPrices stores prices of BTC with 4/3 counts resolved at the given times, by request ID.
+func putPrices(t *testing.T, h *Hook, pxs map[types.RequestID]uint64, times map[types.RequestID]int64) {
+	for id, px := range pxs {
+		require.NoError(t, h.putHistory(4, 3, NewPrice("BTC", 100, px, id, times[id])))
+	}
+}
+
+func TestGetPriceAt(t *testing.T) {
+	h, cleanup := newHistoryHook(t)
+	defer cleanup()
+	_, err := h.getPriceAt(4, 3, "BTC", 1000)
+	require.Error(t, err)
+	putPrices(t, h, map[types.RequestID]uint64{1: 10}, map[types.RequestID]int64{1: 1000})
+	_, err = h.getPriceAt(4, 3, "BTC", 999)
+	require.Error(t, err)
+	price, err := h.getPriceAt(4, 3, "BTC", 1000)
+	require.NoError(t, err)
+	require.Equal(t, NewPrice("BTC", 100, 10, 1, 1000), price)
+	price, err = h.getPriceAt(4, 3, "BTC", 5000)
+	require.NoError(t, err)
+	require.Equal(t, NewPrice("BTC", 100, 10, 1, 1000), price)
+	// Other counts and symbols are separate histories.
+	_, err = h.getPriceAt(4, 2, "BTC", 1000)
+	require.Error(t, err)
+	_, err = h.getPriceAt(4, 3, "ETH", 1000)
+	require.Error(t, err)
+}
+
+func TestPricesResolvedAtSameTime(t *testing.T) {
+	h, cleanup := newHistoryHook(t)
+	defer cleanup()
+	putPrices(t, h, map[types.RequestID]uint64{3: 30, 2: 20}, map[types.RequestID]int64{3: 1000, 2: 1000})
+	prices, err := h.getPrices(4, 3, "BTC", 1000, 1000)
+	require.NoError(t, err)
+	require.Equal(t, []Price{NewPrice("BTC", 100, 20, 2, 1000), NewPrice("BTC", 100, 30, 3, 1000)}, prices)
+	price, err := h.getPriceAt(4, 3, "BTC", 1000)
+	require.NoError(t, err)
+	require.Equal(t, NewPrice("BTC", 100, 30, 3, 1000), price)
+}
+
+func TestGetOHLC(t *testing.T) {
+	h, cleanup := newHistoryHook(t)
+	defer cleanup()
+	_, err := h.getOHLC(4, 3, "BTC", 1000, 2000, 0)
+	require.Error(t, err)
+	// Empty window.
+	buckets, err := h.getOHLC(4, 3, "BTC", 1000, 2000, 100)
+	require.NoError(t, err)
+	require.Equal(t, []OHLC{}, buckets)
+	// Single point.
+	putPrices(t, h, map[types.RequestID]uint64{1: 10}, map[types.RequestID]int64{1: 1050})
+	buckets, err = h.getOHLC(4, 3, "BTC", 1000, 2000, 100)
+	require.NoError(t, err)
+	require.Equal(t, []OHLC{{"BTC", 100, 10, 10, 10, 10, 1000, 1100}}, buckets)
+	// Prices at both window edges are included, and a price at the end of a bucket belongs to the next one.
+	putPrices(t, h,
+		map[types.RequestID]uint64{2: 5, 3: 30, 4: 20, 5: 40, 6: 50, 7: 60},
+		map[types.RequestID]int64{2: 1000, 3: 1060, 4: 1099, 5: 1100, 6: 2000, 7: 2001},
+	)
+	buckets, err = h.getOHLC(4, 3, "BTC", 1000, 2000, 100)
+	require.NoError(t, err)
+	require.Equal(t, []OHLC{
+		{"BTC", 100, 5, 30, 5, 20, 1000, 1100},
+		{"BTC", 100, 40, 40, 40, 40, 1100, 1200},
+		{"BTC", 100, 50, 50, 50, 50, 2000, 2100},
+	}, buckets)
+}
+
+func TestGetTWAP(t *testing.T) {
+	h, cleanup := newHistoryHook(t)
+	defer cleanup()
+	_, err := h.getTWAP(4, 3, "BTC", 1000, 1000)
+	require.Error(t, err)
+	// Empty window.
+	_, err = h.getTWAP(4, 3, "BTC", 1000, 2000)
+	require.Error(t, err)
+	// Single point in the window, so the window starts at that point.
+	putPrices(t, h, map[types.RequestID]uint64{1: 10}, map[types.RequestID]int64{1: 1500})
+	twap, err := h.getTWAP(4, 3, "BTC", 1000, 2000)
+	require.NoError(t, err)
+	require.Equal(t, NewTWAP("BTC", 100, 10, 1500, 2000), twap)
+	// Single point at the window end.
+	twap, err = h.getTWAP(4, 3, "BTC", 1000, 1500)
+	require.NoError(t, err)
+	require.Equal(t, NewTWAP("BTC", 100, 10, 1500, 1500), twap)
+	// A price before the window holds from the window start.
+	putPrices(t, h, map[types.RequestID]uint64{2: 40, 3: 20}, map[types.RequestID]int64{2: 500, 3: 1000})
+	twap, err = h.getTWAP(4, 3, "BTC", 900, 2000)
+	require.NoError(t, err)
+	// 40 over [900, 1000), 20 over [1000, 1500) and 10 over [1500, 2000].
+	require.Equal(t, NewTWAP("BTC", 100, (40*100+20*500+10*500)/1100, 900, 2000), twap)
+	// A price at the window start holds from there.
+	twap, err = h.getTWAP(4, 3, "BTC", 1000, 2000)
+	require.NoError(t, err)
+	require.Equal(t, NewTWAP("BTC", 100, 15, 1000, 2000), twap)
+}
+
+func TestQueryMalformedArguments(t *testing.T) {
+	h, cleanup := newHistoryHook(t)
+	defer cleanup()
+	for _, path := range []string{
+		"band/prices/BTC/four/3",
+		"band/price_symbols/4/three",
+		"band/price_at/BTC/4/3/now",
+		"band/price_ohlc/BTC/4/3/1000/2000/",
+		"band/price_twap/BTC/4/3/yesterday/2000",
+	} {
+		res, stop := h.ApplyQuery(abci.RequestQuery{Path: path})
+		require.True(t, stop)
+		require.False(t, res.IsOK(), path)
+	}
+}
@@ -3,7 +3,9 @@ package price
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,7 +14,6 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/bandprotocol/bandchain/chain/hooks/common"
-	"github.com/bandprotocol/bandchain/chain/pkg/obi"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/keeper"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 )
@@ -20,16 +21,21 @@ import (
 // Hook uses levelDB to store the latest price of standard price reference.
 type Hook struct {
 	cdc          *codec.Codec
-	stdOs        map[types.OracleScriptID]bool
+	schemas      map[types.OracleScriptID]priceSchema
 	oracleKeeper keeper.Keeper
 	db           *leveldb.DB
+	retention    time.Duration // How long to keep historical prices, zero means forever.
 }
 
 // NewHook creates a price hook instance that will be added in Band App.
-func NewHook(cdc *codec.Codec, oracleKeeper keeper.Keeper, oids []types.OracleScriptID, priceDBDir string) *Hook {
-	stdOs := make(map[types.OracleScriptID]bool)
-	for _, oid := range oids {
-		stdOs[oid] = true
+func NewHook(cdc *codec.Codec, oracleKeeper keeper.Keeper, config Config, priceDBDir string, retention time.Duration) *Hook {
+	schemas := make(map[types.OracleScriptID]priceSchema)
+	for oid, schemaConfig := range config {
+		schema, err := newPriceSchema(schemaConfig)
+		if err != nil {
+			panic(fmt.Errorf("invalid price schema config of oracle script %d: %w", oid, err))
+		}
+		schemas[oid] = schema
 	}
 	db, err := leveldb.OpenFile(priceDBDir, nil)
 	if err != nil {
@@ -37,9 +43,10 @@ func NewHook(cdc *codec.Codec, oracleKeeper keeper.Keeper, oids []types.OracleSc
 	}
 	return &Hook{
 		cdc:          cdc,
-		stdOs:        stdOs,
+		schemas:      schemas,
 		oracleKeeper: oracleKeeper,
 		db:           db,
+		retention:    retention,
 	}
 }
 
@@ -68,18 +75,25 @@ func (h *Hook) AfterEndBlock(ctx sdk.Context, req abci.RequestEndBlock, res abci
 
 			if result.ResponsePacketData.ResolveStatus == types.ResolveStatus_Success {
 				// Check that we need to store data to db
-				if h.stdOs[result.RequestPacketData.OracleScriptID] {
-					var input Input
-					var output Output
-					obi.MustDecode(result.RequestPacketData.Calldata, &input)
-					obi.MustDecode(result.ResponsePacketData.Result, &output)
-					for idx, symbol := range input.Symbols {
-						price := NewPrice(symbol, input.Multiplier, output.Pxs[idx], result.ResponsePacketData.RequestID, result.ResponsePacketData.ResolveTime)
-						err := h.db.Put([]byte(fmt.Sprintf("%d,%d,%s", result.RequestPacketData.AskCount, result.RequestPacketData.MinCount, symbol)),
-							h.cdc.MustMarshalBinaryBare(price), nil)
-						if err != nil {
-							panic(err)
-						}
+				schema, ok := h.schemas[result.RequestPacketData.OracleScriptID]
+				if !ok {
+					break
+				}
+				symbols, pxs, multiplier, err := schema.extractPrices(result.RequestPacketData.Calldata, result.ResponsePacketData.Result)
+				if err != nil {
+					// The request does not match the configured schema, so it cannot be a price reference.
+					break
+				}
+				for idx, symbol := range symbols {
+					price := NewPrice(symbol, multiplier, pxs[idx], result.ResponsePacketData.RequestID, result.ResponsePacketData.ResolveTime)
+					err := h.db.Put([]byte(fmt.Sprintf("%d,%d,%s", result.RequestPacketData.AskCount, result.RequestPacketData.MinCount, symbol)),
+						h.cdc.MustMarshalBinaryBare(price), nil)
+					if err != nil {
+						panic(err)
+					}
+					err = h.putHistory(result.RequestPacketData.AskCount, result.RequestPacketData.MinCount, price)
+					if err != nil {
+						panic(err)
 					}
 				}
 			}
@@ -99,8 +113,10 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 				return common.QueryResultError(errors.New("no route for prices query specified")), true
 			}
 			symbol := paths[2]
-			askCount := common.Atoui(paths[3])
-			minCount := common.Atoui(paths[4])
+			askCount, minCount, err := parseCounts(paths[3], paths[4])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
 			bz, err := h.db.Get([]byte(fmt.Sprintf("%d,%d,%s", askCount, minCount, symbol)), nil)
 			if err != nil {
 				return common.QueryResultError(fmt.Errorf(
@@ -113,8 +129,10 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 			if len(paths) < 4 {
 				return common.QueryResultError(errors.New("no route for symbol prices query specified")), true
 			}
-			askCount := common.Atoui(paths[2])
-			minCount := common.Atoui(paths[3])
+			askCount, minCount, err := parseCounts(paths[2], paths[3])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
 
 			prefix := []byte(fmt.Sprintf("%d,%d,", askCount, minCount))
 			it := h.db.NewIterator(util.BytesPrefix(prefix), nil)
@@ -131,6 +149,60 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 
 			bz := h.cdc.MustMarshalBinaryBare(symbols)
 			return common.QueryResultSuccess(bz, req.Height), true
+		case "price_at":
+			if len(paths) < 6 {
+				return common.QueryResultError(errors.New("no route for price at query specified")), true
+			}
+			symbol := paths[2]
+			askCount, minCount, err := parseCounts(paths[3], paths[4])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			args, err := parseInts(paths[5:6], "timestamp")
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			price, err := h.getPriceAt(askCount, minCount, symbol, args[0])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			return common.QueryResultSuccess(h.cdc.MustMarshalBinaryBare(price), req.Height), true
+		case "price_ohlc":
+			if len(paths) < 8 {
+				return common.QueryResultError(errors.New("no route for price ohlc query specified")), true
+			}
+			symbol := paths[2]
+			askCount, minCount, err := parseCounts(paths[3], paths[4])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			args, err := parseInts(paths[5:8], "from", "to", "interval")
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			buckets, err := h.getOHLC(askCount, minCount, symbol, args[0], args[1], args[2])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			return common.QueryResultSuccess(h.cdc.MustMarshalBinaryBare(buckets), req.Height), true
+		case "price_twap":
+			if len(paths) < 7 {
+				return common.QueryResultError(errors.New("no route for price twap query specified")), true
+			}
+			symbol := paths[2]
+			askCount, minCount, err := parseCounts(paths[3], paths[4])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			args, err := parseInts(paths[5:7], "from", "to")
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			twap, err := h.getTWAP(askCount, minCount, symbol, args[0], args[1])
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			return common.QueryResultSuccess(h.cdc.MustMarshalBinaryBare(twap), req.Height), true
 		default:
 			return abci.ResponseQuery{}, false
 		}
@@ -139,5 +211,31 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 	}
 }
 
+// parseCounts parses the ask count and min count arguments of a price query.
+func parseCounts(rawAskCount, rawMinCount string) (uint64, uint64, error) {
+	askCount, err := strconv.ParseUint(rawAskCount, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ask count: %s", rawAskCount)
+	}
+	minCount, err := strconv.ParseUint(rawMinCount, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid min count: %s", rawMinCount)
+	}
+	return askCount, minCount, nil
+}
+
+// parseInts parses the given integer arguments of a price query, using names in error messages.
+func parseInts(raws []string, names ...string) ([]int64, error) {
+	values := make([]int64, len(raws))
+	for idx, raw := range raws {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", names[idx], raw)
+		}
+		values[idx] = value
+	}
+	return values, nil
+}
+
 // BeforeCommit specify actions need to do before commit block (app.Hook interface).
 func (h *Hook) BeforeCommit() {}
@@ -2,15 +2,6 @@ package price
 
 import "github.com/bandprotocol/bandchain/chain/x/oracle/types"
 
-type Input struct {
-	Symbols    []string `json:"symbols"`
-	Multiplier uint64   `json:"multiplier"`
-}
-
-type Output struct {
-	Pxs []uint64 `json:"pxs"`
-}
-
 type Price struct {
 	Symbol      string          `json:"symbol"`
 	Multiplier  uint64          `json:"multiplier"`
@@ -28,3 +19,36 @@ func NewPrice(symbol string, multiplier uint64, px uint64, reqID types.RequestID
 		ResolveTime: resolveTime,
 	}
 }
+
+// OHLC is the open, high, low and close prices of a symbol resolved in [StartTime, EndTime).
+type OHLC struct {
+	Symbol     string `json:"symbol"`
+	Multiplier uint64 `json:"multiplier"`
+	Open       uint64 `json:"open"`
+	High       uint64 `json:"high"`
+	Low        uint64 `json:"low"`
+	Close      uint64 `json:"close"`
+	StartTime  int64  `json:"start_time"`
+	EndTime    int64  `json:"end_time"`
+}
+
+// TWAP is the time-weighted average price of a symbol over [StartTime, EndTime], where each price
+// holds until the next one is resolved.
+type TWAP struct {
+	Symbol     string `json:"symbol"`
+	Multiplier uint64 `json:"multiplier"`
+	Px         uint64 `json:"px"`
+	StartTime  int64  `json:"start_time"`
+	EndTime    int64  `json:"end_time"`
+}
+
+// NewTWAP creates a new TWAP instance.
+func NewTWAP(symbol string, multiplier uint64, px uint64, startTime int64, endTime int64) TWAP {
+	return TWAP{
+		Symbol:     symbol,
+		Multiplier: multiplier,
+		Px:         px,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+}
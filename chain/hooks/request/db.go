@@ -2,10 +2,17 @@ package request
 
 import (
 	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 )
 
+// MaxSearchLimit is the maximum number of requests returned by a single search query.
+const MaxSearchLimit = 100
+
 type Request struct {
 	RequestID      types.RequestID      `db:"request_id, primarykey" json:"request_id"`
 	OracleScriptID types.OracleScriptID `db:"oracle_script_id" json:"oracle_script_id"`
@@ -13,32 +20,201 @@ type Request struct {
 	MinCount       uint64               `db:"min_count" json:"min_count"`
 	AskCount       uint64               `db:"ask_count" json:"ask_count"`
 	ResolveTime    int64                `db:"resolve_time" json:"resolve_time"`
+	Requester      string               `db:"requester" json:"requester"`
+	ClientID       string               `db:"client_id" json:"client_id"`
+	ResolveStatus  types.ResolveStatus  `db:"resolve_status" json:"resolve_status"`
+	RequestTime    int64                `db:"request_time" json:"request_time"`
+	Result         *string              `db:"result" json:"result"`
+	RequestHeight  int64                `db:"request_height" json:"request_height"`
+	ResolveHeight  int64                `db:"resolve_height" json:"resolve_height"`
+}
+
+type Report struct {
+	RequestID       types.RequestID `db:"request_id" json:"-"`
+	Validator       string          `db:"validator" json:"validator"`
+	InBeforeResolve bool            `db:"in_before_resolve" json:"in_before_resolve"`
+	RawReports      []RawReport     `db:"-" json:"raw_reports"`
+}
+
+type RawReport struct {
+	RequestID  types.RequestID  `db:"request_id" json:"-"`
+	Validator  string           `db:"validator" json:"-"`
+	ExternalID types.ExternalID `db:"external_id" json:"external_id"`
+	ExitCode   uint32           `db:"exit_code" json:"exit_code"`
+	Data       string           `db:"data" json:"data"`
+}
+
+// SearchParams filters requests by requester, client ID, resolve status and request time range
+// [FromTime, ToTime]. Zero values disable the corresponding filter.
+type SearchParams struct {
+	Requester     string               `json:"requester"`
+	ClientID      string               `json:"client_id"`
+	ResolveStatus *types.ResolveStatus `json:"resolve_status"`
+	FromTime      int64                `json:"from_time"`
+	ToTime        int64                `json:"to_time"`
+	Offset        int64                `json:"offset"`
+	Limit         int64                `json:"limit"`
 }
 
-func (h *Hook) insertRequest(requestID types.RequestID, oracleScriptID types.OracleScriptID, calldata []byte, askCount uint64, minCount uint64, resolveTime int64) {
+func (h *Hook) insertRequest(
+	requestID types.RequestID, oracleScriptID types.OracleScriptID, calldata []byte, askCount uint64,
+	minCount uint64, requester sdk.AccAddress, clientID string, requestTime int64, requestHeight int64,
+) {
 	err := h.trans.Insert(&Request{
 		RequestID:      requestID,
 		OracleScriptID: oracleScriptID,
 		Calldata:       hex.EncodeToString(calldata),
 		MinCount:       minCount,
 		AskCount:       askCount,
-		ResolveTime:    resolveTime,
+		Requester:      requester.String(),
+		ClientID:       clientID,
+		ResolveStatus:  types.ResolveStatus_Open,
+		RequestTime:    requestTime,
+		RequestHeight:  requestHeight,
 	})
 	if err != nil {
 		panic(err)
 	}
 }
 
+func (h *Hook) resolveRequest(
+	requestID types.RequestID, req types.Request, res types.OracleResponsePacketData, reports []types.Report,
+	resolveHeight int64,
+) {
+	obj, err := h.trans.Get(Request{}, requestID)
+	if err != nil {
+		panic(err)
+	}
+	// Requests not created by MsgRequestData (i.e. from IBC) are first seen on resolve.
+	request, ok := obj.(*Request)
+	if !ok {
+		request = &Request{
+			RequestID:      requestID,
+			OracleScriptID: req.OracleScriptID,
+			Calldata:       hex.EncodeToString(req.Calldata),
+			MinCount:       req.MinCount,
+			AskCount:       uint64(len(req.RequestedValidators)),
+			ClientID:       req.ClientID,
+			RequestTime:    req.RequestTime.Unix(),
+			RequestHeight:  req.RequestHeight,
+		}
+	}
+	request.ResolveStatus = res.ResolveStatus
+	request.ResolveHeight = resolveHeight
+	request.ResolveTime = res.ResolveTime
+	if res.ResolveStatus == types.ResolveStatus_Success {
+		result := hex.EncodeToString(res.Result)
+		request.Result = &result
+	}
+	if ok {
+		_, err = h.trans.Update(request)
+	} else {
+		err = h.trans.Insert(request)
+	}
+	if err != nil {
+		panic(err)
+	}
+	for _, report := range reports {
+		err := h.trans.Insert(&Report{
+			RequestID:       requestID,
+			Validator:       report.Validator.String(),
+			InBeforeResolve: report.InBeforeResolve,
+		})
+		if err != nil {
+			panic(err)
+		}
+		for _, rawReport := range report.RawReports {
+			err := h.trans.Insert(&RawReport{
+				RequestID:  requestID,
+				Validator:  report.Validator.String(),
+				ExternalID: rawReport.ExternalID,
+				ExitCode:   rawReport.ExitCode,
+				Data:       hex.EncodeToString(rawReport.Data),
+			})
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
 func (h *Hook) getMultiRequestID(oid types.OracleScriptID, calldata string, askCount uint64, minCount uint64, limit int64) []types.RequestID {
 	var requests []Request
-	h.dbMap.Select(&requests,
+	d := h.dbMap.Dialect
+	h.dbMap.Select(&requests, fmt.Sprintf(
 		`select * from request
-where oracle_script_id = ? and calldata = ? and min_count = ? and ask_count = ?
-order by resolve_time desc limit ?`,
-		oid, calldata, minCount, askCount, limit)
+where oracle_script_id = %s and calldata = %s and min_count = %s and ask_count = %s and resolve_status = %s
+order by resolve_time desc limit %s`,
+		d.BindVar(0), d.BindVar(1), d.BindVar(2), d.BindVar(3), d.BindVar(4), d.BindVar(5)),
+		oid, calldata, minCount, askCount, types.ResolveStatus_Success, limit)
 	requestIDs := make([]types.RequestID, len(requests))
 	for idx, request := range requests {
 		requestIDs[idx] = request.RequestID
 	}
 	return requestIDs
 }
+
+func (h *Hook) searchRequests(params SearchParams) ([]Request, error) {
+	if params.Limit <= 0 || params.Limit > MaxSearchLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", MaxSearchLimit)
+	}
+	if params.Offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative")
+	}
+	conds := []string{}
+	args := []interface{}{}
+	addCond := func(format string, arg interface{}) {
+		conds = append(conds, fmt.Sprintf(format, h.dbMap.Dialect.BindVar(len(args))))
+		args = append(args, arg)
+	}
+	if params.Requester != "" {
+		addCond("requester = %s", params.Requester)
+	}
+	if params.ClientID != "" {
+		addCond("client_id = %s", params.ClientID)
+	}
+	if params.ResolveStatus != nil {
+		addCond("resolve_status = %s", *params.ResolveStatus)
+	}
+	if params.FromTime != 0 {
+		addCond("request_time >= %s", params.FromTime)
+	}
+	if params.ToTime != 0 {
+		addCond("request_time <= %s", params.ToTime)
+	}
+	query := "select * from request"
+	if len(conds) != 0 {
+		query += " where " + strings.Join(conds, " and ")
+	}
+	query += fmt.Sprintf(" order by request_id desc limit %s offset %s",
+		h.dbMap.Dialect.BindVar(len(args)), h.dbMap.Dialect.BindVar(len(args)+1))
+	args = append(args, params.Limit, params.Offset)
+	requests := []Request{}
+	_, err := h.dbMap.Select(&requests, query, args...)
+	return requests, err
+}
+
+func (h *Hook) getReports(requestID types.RequestID) ([]Report, error) {
+	d := h.dbMap.Dialect
+	reports := []Report{}
+	_, err := h.dbMap.Select(&reports, fmt.Sprintf(
+		"select * from report where request_id = %s order by validator", d.BindVar(0)), requestID)
+	if err != nil {
+		return nil, err
+	}
+	var rawReports []RawReport
+	_, err = h.dbMap.Select(&rawReports, fmt.Sprintf(
+		"select * from raw_report where request_id = %s order by validator, external_id", d.BindVar(0)), requestID)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range reports {
+		reports[idx].RawReports = []RawReport{}
+		for _, rawReport := range rawReports {
+			if rawReport.Validator == reports[idx].Validator {
+				reports[idx].RawReports = append(reports[idx].RawReports, rawReport)
+			}
+		}
+	}
+	return reports, nil
+}
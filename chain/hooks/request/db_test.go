@@ -0,0 +1,116 @@
+package request
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// newSearchHook returns a hook with requests 1 to 4 indexed: requests 1 and 3 by Alice with
+// client ID "alice", requests 2 and 4 by Bob, and request 4 resolved successfully.
+func newSearchHook(t *testing.T) (*Hook, func()) {
+	app, ctx, k := testapp.CreateTestInput(true)
+	connStr, cleanup := mustCreateTempDB(t)
+	h := NewHook(app.Codec(), k, connStr, 0, nil)
+	h.AfterBeginBlock(ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 1}}, abci.ResponseBeginBlock{})
+	h.insertRequest(1, 1, []byte("beeb"), 2, 1, testapp.Alice.Address, "alice", 100, 1)
+	h.insertRequest(2, 1, []byte("beeb"), 2, 1, testapp.Bob.Address, "bob", 200, 1)
+	h.insertRequest(3, 1, []byte("beeb"), 2, 1, testapp.Alice.Address, "alice", 300, 1)
+	h.insertRequest(4, 1, []byte("beeb"), 2, 1, testapp.Bob.Address, "bob", 400, 1)
+	h.resolveRequest(4, types.Request{}, types.NewOracleResponsePacketData(
+		"bob", 4, 2, 400, 410, types.ResolveStatus_Success, []byte("result"),
+	), []types.Report{
+		types.NewReport(testapp.Validator2.ValAddress, false, []types.RawReport{
+			types.NewRawReport(2, 0, []byte("data2")), types.NewRawReport(1, 0, []byte("data1")),
+		}),
+		types.NewReport(testapp.Validator1.ValAddress, true, []types.RawReport{
+			types.NewRawReport(1, 1, []byte("data3")),
+		}),
+	}, 2)
+	h.BeforeCommit()
+	return h, cleanup
+}
+
+func requestIDs(requests []Request) []types.RequestID {
+	ids := []types.RequestID{}
+	for _, request := range requests {
+		ids = append(ids, request.RequestID)
+	}
+	return ids
+}
+
+func TestSearchRequests(t *testing.T) {
+	h, cleanup := newSearchHook(t)
+	defer cleanup()
+	success := types.ResolveStatus_Success
+	open := types.ResolveStatus_Open
+	testCases := []struct {
+		params   SearchParams
+		expected []types.RequestID
+	}{
+		{SearchParams{Limit: 10}, []types.RequestID{4, 3, 2, 1}},
+		{SearchParams{Limit: 2}, []types.RequestID{4, 3}},
+		{SearchParams{Limit: 2, Offset: 3}, []types.RequestID{1}},
+		{SearchParams{Requester: testapp.Alice.Address.String(), Limit: 10}, []types.RequestID{3, 1}},
+		{SearchParams{ClientID: "bob", Limit: 10}, []types.RequestID{4, 2}},
+		{SearchParams{ResolveStatus: &success, Limit: 10}, []types.RequestID{4}},
+		{SearchParams{ResolveStatus: &open, ClientID: "bob", Limit: 10}, []types.RequestID{2}},
+		{SearchParams{FromTime: 200, ToTime: 300, Limit: 10}, []types.RequestID{3, 2}},
+		{SearchParams{ClientID: "carol", Limit: 10}, []types.RequestID{}},
+	}
+	for _, tc := range testCases {
+		requests, err := h.searchRequests(tc.params)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, requestIDs(requests))
+	}
+	_, err := h.searchRequests(SearchParams{Limit: 0})
+	require.Error(t, err)
+	_, err = h.searchRequests(SearchParams{Limit: MaxSearchLimit + 1})
+	require.Error(t, err)
+	_, err = h.searchRequests(SearchParams{Limit: 10, Offset: -1})
+	require.Error(t, err)
+}
+
+func TestGetReports(t *testing.T) {
+	h, cleanup := newSearchHook(t)
+	defer cleanup()
+	reports, err := h.getReports(4)
+	require.NoError(t, err)
+	val1, val2 := testapp.Validator1.ValAddress.String(), testapp.Validator2.ValAddress.String()
+	expected := []Report{
+		{RequestID: 4, Validator: val1, InBeforeResolve: true, RawReports: []RawReport{
+			{RequestID: 4, Validator: val1, ExternalID: 1, ExitCode: 1, Data: "6461746133"},
+		}},
+		{RequestID: 4, Validator: val2, InBeforeResolve: false, RawReports: []RawReport{
+			{RequestID: 4, Validator: val2, ExternalID: 1, ExitCode: 0, Data: "6461746131"},
+			{RequestID: 4, Validator: val2, ExternalID: 2, ExitCode: 0, Data: "6461746132"},
+		}},
+	}
+	if val2 < val1 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	require.Equal(t, expected, reports)
+	reports, err = h.getReports(1)
+	require.NoError(t, err)
+	require.Equal(t, []Report{}, reports)
+}
+
+func TestQueryRequestReports(t *testing.T) {
+	h, cleanup := newSearchHook(t)
+	defer cleanup()
+	res, stop := h.ApplyQuery(abci.RequestQuery{Path: "band/request_reports/4"})
+	require.True(t, stop)
+	require.True(t, res.IsOK())
+	var reports []Report
+	require.NoError(t, json.Unmarshal(res.Value, &reports))
+	require.Len(t, reports, 2)
+	// A malformed request ID is an error rather than a panic.
+	res, stop = h.ApplyQuery(abci.RequestQuery{Path: "band/request_reports/abc"})
+	require.True(t, stop)
+	require.False(t, res.IsOK())
+}
@@ -0,0 +1,139 @@
+package request
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-gorp/gorp"
+)
+
+// migration is a versioned schema change of the request search database. Migrations are
+// applied in order and each version is recorded in the schema_version table once applied.
+type migration struct {
+	version int
+	// statements returns the statements to apply. The executor can be used to inspect the
+	// existing schema, but must not change it.
+	statements func(exec gorp.SqlExecutor, d gorp.Dialect) ([]string, error)
+}
+
+// sqlType returns the column type of the given Go value in the given SQL dialect.
+func sqlType(d gorp.Dialect, v interface{}, maxsize int) string {
+	return d.ToSqlType(reflect.TypeOf(v), maxsize, false)
+}
+
+var migrations = []migration{
+	{
+		// Version 1 is the original request table, previously created by gorp from the model.
+		// Databases created before migrations existed already have the table and its index.
+		version: 1,
+		statements: func(exec gorp.SqlExecutor, d gorp.Dialect) ([]string, error) {
+			statements := []string{
+				fmt.Sprintf(`create table if not exists request (
+request_id %s not null primary key, oracle_script_id %s, calldata %s, min_count %s, ask_count %s, resolve_time %s)`,
+					sqlType(d, int64(0), 0), sqlType(d, int64(0), 0), sqlType(d, "", 0),
+					sqlType(d, uint64(0), 0), sqlType(d, uint64(0), 0), sqlType(d, int64(0), 0)),
+			}
+			// MySQL lacks "create index if not exists", so the index is looked up instead.
+			createIndex := "create index if not exists"
+			if _, ok := d.(gorp.MySQLDialect); ok {
+				exists, err := exec.SelectInt(`select count(*) from information_schema.statistics
+where table_schema = database() and table_name = 'request'
+and index_name = 'ix_calldata_min_count_ask_count_oracle_script_id_resolve_time'`)
+				if err != nil {
+					return nil, err
+				}
+				if exists != 0 {
+					return statements, nil
+				}
+				createIndex = "create index"
+			}
+			return append(statements, createIndex+` ix_calldata_min_count_ask_count_oracle_script_id_resolve_time
+on request (calldata, min_count, ask_count, oracle_script_id, resolve_time)`), nil
+		},
+	},
+	{
+		// Version 2 indexes requester, client ID, resolve status, result and reports. Only
+		// successful requests were indexed before, so existing rows are marked as such.
+		version: 2,
+		statements: func(exec gorp.SqlExecutor, d gorp.Dialect) ([]string, error) {
+			return []string{
+				fmt.Sprintf("alter table request add column requester %s not null default ''", sqlType(d, "", 64)),
+				fmt.Sprintf("alter table request add column client_id %s not null default ''", sqlType(d, "", 128)),
+				fmt.Sprintf("alter table request add column resolve_status %s not null default 0", sqlType(d, int32(0), 0)),
+				fmt.Sprintf("alter table request add column request_time %s not null default 0", sqlType(d, int64(0), 0)),
+				"alter table request add column result text",
+				"update request set resolve_status = 1",
+				"create index ix_requester_request_time on request (requester, request_time)",
+				"create index ix_client_id_request_time on request (client_id, request_time)",
+				"create index ix_resolve_status_request_time on request (resolve_status, request_time)",
+				"create index ix_request_time on request (request_time)",
+				fmt.Sprintf(`create table report (
+request_id %s not null, validator %s not null, in_before_resolve %s not null, primary key (request_id, validator))`,
+					sqlType(d, int64(0), 0), sqlType(d, "", 64), sqlType(d, false, 0)),
+				fmt.Sprintf(`create table raw_report (
+request_id %s not null, validator %s not null, external_id %s not null, exit_code %s not null, data text not null,
+primary key (request_id, validator, external_id))`,
+					sqlType(d, int64(0), 0), sqlType(d, "", 64), sqlType(d, int64(0), 0), sqlType(d, int64(0), 0)),
+			}, nil
+		},
+	},
+	{
+		// Version 3 records the heights at which rows change and the last indexed block height,
+		// so that the database can be reconciled with the chain after a crash.
+		version: 3,
+		statements: func(exec gorp.SqlExecutor, d gorp.Dialect) ([]string, error) {
+			return []string{
+				fmt.Sprintf("alter table request add column request_height %s not null default 0", sqlType(d, int64(0), 0)),
+				fmt.Sprintf("alter table request add column resolve_height %s not null default 0", sqlType(d, int64(0), 0)),
+				fmt.Sprintf("create table indexed_height (height %s not null)", sqlType(d, int64(0), 0)),
+			}, nil
+		},
+	},
+}
+
+// getSchemaVersion returns the latest applied migration version, creating the version table if needed.
+func getSchemaVersion(dbMap *gorp.DbMap) (int, error) {
+	_, err := dbMap.Exec(fmt.Sprintf("create table if not exists schema_version (version %s not null)", sqlType(dbMap.Dialect, int32(0), 0)))
+	if err != nil {
+		return 0, err
+	}
+	version, err := dbMap.SelectInt("select coalesce(max(version), 0) from schema_version")
+	return int(version), err
+}
+
+// migrate applies all migrations newer than the current schema version, each in its own transaction.
+func migrate(dbMap *gorp.DbMap) error {
+	current, err := getSchemaVersion(dbMap)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		trans, err := dbMap.Begin()
+		if err != nil {
+			return err
+		}
+		statements, err := m.statements(trans, dbMap.Dialect)
+		if err != nil {
+			trans.Rollback()
+			return fmt.Errorf("migration %d failed: %w", m.version, err)
+		}
+		for _, statement := range statements {
+			if _, err := trans.Exec(statement); err != nil {
+				trans.Rollback()
+				return fmt.Errorf("migration %d failed: %w", m.version, err)
+			}
+		}
+		_, err = trans.Exec(fmt.Sprintf("insert into schema_version (version) values (%s)", dbMap.Dialect.BindVar(0)), m.version)
+		if err != nil {
+			trans.Rollback()
+			return err
+		}
+		if err := trans.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package request
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/go-gorp/gorp"
+	"github.com/stretchr/testify/require"
+)
+
+func mustOpenSqlite(t *testing.T, connStr string) *gorp.DbMap {
+	db, err := sql.Open("sqlite3", connStr[len("sqlite3://"):])
+	require.NoError(t, err)
+	return &gorp.DbMap{Db: db, Dialect: gorp.SqliteDialect{}}
+}
+
+func TestMigrateNewDatabase(t *testing.T) {
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	dbMap := initDb(connStr)
+	version, err := getSchemaVersion(dbMap)
+	require.NoError(t, err)
+	require.Equal(t, len(migrations), version)
+	// Migrating again is a no-op.
+	require.NoError(t, migrate(dbMap))
+	count, err := dbMap.SelectInt("select count(*) from schema_version")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(migrations)), count)
+}
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	// Databases created before migrations existed have the table and index made by gorp.
+	dbMap := mustOpenSqlite(t, connStr)
+	_, err := dbMap.Exec(`create table request (request_id integer not null primary key, oracle_script_id integer,
+calldata varchar(255), min_count integer, ask_count integer, resolve_time integer)`)
+	require.NoError(t, err)
+	_, err = dbMap.Exec(`create index ix_calldata_min_count_ask_count_oracle_script_id_resolve_time
+on request (calldata, min_count, ask_count, oracle_script_id, resolve_time)`)
+	require.NoError(t, err)
+	_, err = dbMap.Exec("insert into request values (1, 1, 'beeb', 1, 2, 1581589790)")
+	require.NoError(t, err)
+	dbMap.Db.Close()
+
+	dbMap = initDb(connStr)
+	version, err := getSchemaVersion(dbMap)
+	require.NoError(t, err)
+	require.Equal(t, len(migrations), version)
+	// Only successful requests were indexed before.
+	var requests []Request
+	_, err = dbMap.Select(&requests, "select * from request")
+	require.NoError(t, err)
+	require.Equal(t, []Request{{
+		RequestID: 1, OracleScriptID: 1, Calldata: "beeb", MinCount: 1, AskCount: 2,
+		ResolveTime: 1581589790, ResolveStatus: 1,
+	}}, requests)
+}
+
+func TestMigrateFailureRollsBack(t *testing.T) {
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	dbMap := initDb(connStr)
+	original := migrations
+	defer func() { migrations = original }()
+	migrations = append(migrations, migration{
+		version: len(original) + 1,
+		statements: func(exec gorp.SqlExecutor, d gorp.Dialect) ([]string, error) {
+			return []string{"create table extra (id integer)", "alter table missing add column id integer"}, nil
+		},
+	})
+	require.Error(t, migrate(dbMap))
+	version, err := getSchemaVersion(dbMap)
+	require.NoError(t, err)
+	require.Equal(t, len(original), version)
+	_, err = dbMap.Exec("select * from extra")
+	require.Error(t, err)
+}
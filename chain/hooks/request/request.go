@@ -2,11 +2,14 @@ package request
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/go-gorp/gorp"
 
 	// DB driver
@@ -15,6 +18,7 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	"github.com/bandprotocol/bandchain/chain/hooks/common"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/keeper"
@@ -24,9 +28,24 @@ import (
 // Hook inherits from Band app hook to save latest request into SQL database.
 type Hook struct {
 	cdc          *codec.Codec
+	txDecoder    sdk.TxDecoder
 	oracleKeeper keeper.Keeper
 	dbMap        *gorp.DbMap
 	trans        *gorp.Transaction
+	height       int64 // The height of the block being indexed.
+	// If set, requests changed after this height are re-indexed from state in the next block.
+	reindexFrom *int64
+	// Blocks to recover requesters of re-indexed requests from, or nil to leave them empty.
+	blocks BlockSource
+	// Request heights of re-indexed requests, by ID, whose requesters are not recovered yet.
+	missingRequesters map[types.RequestID]int64
+}
+
+// BlockSource provides past blocks and their execution results, such as the Tendermint RPC client
+// of the node. It is used to recover requesters, which are not part of the oracle state.
+type BlockSource interface {
+	Block(height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(height *int64) (*ctypes.ResultBlockResults, error)
 }
 
 func getDB(driverName string, dataSourceName string) *sql.DB {
@@ -53,27 +72,39 @@ func initDb(connStr string) *gorp.DbMap {
 	default:
 		panic(fmt.Sprintf("unknown driver %s", connStrs[0]))
 	}
-	indexName := "ix_calldata_min_count_ask_count_oracle_script_id_resolve_time"
-	dbMap.AddTableWithName(Request{}, "request").AddIndex(indexName, "Btree", []string{"calldata", "min_count", "ask_count", "oracle_script_id", "resolve_time"})
-	err := dbMap.CreateTablesIfNotExists()
+	dbMap.AddTableWithName(Request{}, "request")
+	dbMap.AddTableWithName(Report{}, "report").SetKeys(false, "request_id", "validator")
+	dbMap.AddTableWithName(RawReport{}, "raw_report").SetKeys(false, "request_id", "validator", "external_id")
+	err := migrate(dbMap)
 	if err != nil {
 		panic(err)
 	}
-	err = dbMap.CreateIndex()
-	// Check error if it's not creating existed index, panic the process.
-	if err != nil && err.Error() != fmt.Sprintf("index %s already exists", indexName) {
-		panic(err)
-	}
 	return dbMap
 }
 
-// NewHook creates a request hook instance that will be added in Band App.
-func NewHook(cdc *codec.Codec, oracleKeeper keeper.Keeper, connStr string) *Hook {
-	return &Hook{
-		cdc:          cdc,
-		oracleKeeper: oracleKeeper,
-		dbMap:        initDb(connStr),
+// NewHook creates a request hook instance that will be added in Band App. The database is
+// reconciled with the given last committed height of the app: changes indexed after it are
+// rolled back, and missing heights are re-indexed from the oracle state in the next block, with
+// requesters recovered from the given block source.
+func NewHook(
+	cdc *codec.Codec, oracleKeeper keeper.Keeper, connStr string, lastHeight int64, blocks BlockSource,
+) *Hook {
+	h := &Hook{
+		cdc:               cdc,
+		txDecoder:         auth.DefaultTxDecoder(cdc),
+		oracleKeeper:      oracleKeeper,
+		dbMap:             initDb(connStr),
+		blocks:            blocks,
+		missingRequesters: make(map[types.RequestID]int64),
+	}
+	indexedHeight, ok := getIndexedHeight(h.dbMap)
+	if ok && indexedHeight > lastHeight {
+		rollback(h.dbMap, lastHeight)
 	}
+	if ok && indexedHeight < lastHeight {
+		h.reindexFrom = &indexedHeight
+	}
+	return h
 }
 
 // AfterInitChain specify actions need to do after chain initialization (app.Hook interface).
@@ -87,10 +118,46 @@ func (h *Hook) AfterBeginBlock(ctx sdk.Context, req abci.RequestBeginBlock, res
 		panic(err)
 	}
 	h.trans = trans
+	h.height = req.Header.GetHeight()
+	if h.reindexFrom != nil {
+		h.reindex(ctx, *h.reindexFrom)
+		h.reindexFrom = nil
+	}
+	if len(h.missingRequesters) != 0 {
+		h.recoverRequesters()
+	}
 }
 
 // AfterDeliverTx specify actions need to do after transaction has been processed (app.Hook interface).
 func (h *Hook) AfterDeliverTx(ctx sdk.Context, req abci.RequestDeliverTx, res abci.ResponseDeliverTx) {
+	if ctx.BlockHeight() == 0 {
+		return
+	}
+	h.forEachRequest(req.Tx, res, func(reqID types.RequestID, msg types.MsgRequestData) {
+		h.insertRequest(reqID, msg.OracleScriptID, msg.Calldata, msg.AskCount, msg.MinCount,
+			msg.Sender, msg.ClientID, ctx.BlockTime().Unix(), ctx.BlockHeight())
+	})
+}
+
+// forEachRequest calls fn with each MsgRequestData of the given transaction, if it succeeded,
+// and the ID of the request that the message created.
+func (h *Hook) forEachRequest(
+	txBytes []byte, res abci.ResponseDeliverTx, fn func(types.RequestID, types.MsgRequestData),
+) {
+	if !res.IsOK() {
+		return
+	}
+	tx, err := h.txDecoder(txBytes)
+	if err != nil {
+		return
+	}
+	logs, _ := sdk.ParseABCILogs(res.Log) // Error must always be nil if res.IsOK is true.
+	for idx, msg := range tx.GetMsgs() {
+		if msg, ok := msg.(types.MsgRequestData); ok {
+			evMap := common.ParseEvents(logs[idx].Events)
+			fn(types.RequestID(common.Atoi(evMap[types.EventTypeRequest+"."+types.AttributeKeyID][0])), msg)
+		}
+	}
 }
 
 // AfterEndBlock specify actions need to do after end block period (app.Hook interface).
@@ -102,11 +169,8 @@ func (h *Hook) AfterEndBlock(ctx sdk.Context, req abci.RequestEndBlock, res abci
 		case types.EventTypeResolve:
 			reqID := types.RequestID(common.Atoi(evMap[types.EventTypeResolve+"."+types.AttributeKeyID][0]))
 			result := h.oracleKeeper.MustGetResult(ctx, reqID)
-			if result.ResponsePacketData.ResolveStatus == types.ResolveStatus_Success {
-				h.insertRequest(reqID, result.RequestPacketData.OracleScriptID, result.RequestPacketData.Calldata,
-					result.RequestPacketData.AskCount, result.RequestPacketData.MinCount,
-					result.ResponsePacketData.ResolveTime)
-			}
+			h.resolveRequest(reqID, h.oracleKeeper.MustGetRequest(ctx, reqID), result.ResponsePacketData,
+				h.oracleKeeper.GetReports(ctx, reqID), ctx.BlockHeight())
 		default:
 			break
 		}
@@ -133,6 +197,37 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 				return common.QueryResultError(err), true
 			}
 			return common.QueryResultSuccess(bz, req.Height), true
+		case "search_requests":
+			var params SearchParams
+			if err := json.Unmarshal(req.Data, &params); err != nil {
+				return common.QueryResultError(err), true
+			}
+			requests, err := h.searchRequests(params)
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			bz, err := json.Marshal(requests)
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			return common.QueryResultSuccess(bz, req.Height), true
+		case "request_reports":
+			if len(paths) != 3 {
+				return common.QueryResultError(fmt.Errorf("expect 3 arguments given %d", len(paths))), true
+			}
+			id, err := strconv.ParseInt(paths[2], 10, 64)
+			if err != nil {
+				return common.QueryResultError(fmt.Errorf("invalid request id: %s", paths[2])), true
+			}
+			reports, err := h.getReports(types.RequestID(id))
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			bz, err := json.Marshal(reports)
+			if err != nil {
+				return common.QueryResultError(err), true
+			}
+			return common.QueryResultSuccess(bz, req.Height), true
 		default:
 			return abci.ResponseQuery{}, false
 		}
@@ -143,8 +238,10 @@ func (h *Hook) ApplyQuery(req abci.RequestQuery) (res abci.ResponseQuery, stop b
 
 // BeforeCommit specify actions need to do before commit block (app.Hook interface).
 func (h *Hook) BeforeCommit() {
+	setIndexedHeight(h.trans, h.dbMap.Dialect, h.height)
 	err := h.trans.Commit()
 	if err != nil {
-		h.trans.Rollback()
+		// Crash so that the missing block is re-indexed on restart instead of silently skipped.
+		panic(err)
 	}
 }
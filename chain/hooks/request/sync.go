@@ -0,0 +1,162 @@
+package request
+
+import (
+	"database/sql"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/go-gorp/gorp"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// getIndexedHeight returns the last block height fully indexed into the database. The second
+// return value is false if the height was never recorded, e.g. for databases indexed before
+// heights were tracked.
+func getIndexedHeight(dbMap *gorp.DbMap) (int64, bool) {
+	height, err := dbMap.SelectNullInt("select max(height) from indexed_height")
+	if err != nil {
+		panic(err)
+	}
+	return height.Int64, height.Valid
+}
+
+// setIndexedHeight records the given height as the last indexed block in the given transaction.
+func setIndexedHeight(exec gorp.SqlExecutor, d gorp.Dialect, height int64) {
+	if _, err := exec.Exec("delete from indexed_height"); err != nil {
+		panic(err)
+	}
+	if _, err := exec.Exec(fmt.Sprintf("insert into indexed_height (height) values (%s)", d.BindVar(0)), height); err != nil {
+		panic(err)
+	}
+}
+
+// rollback reverts all changes indexed after the given height. Requests created later are
+// deleted, and requests resolved later are reopened with their reports removed.
+func rollback(dbMap *gorp.DbMap, height int64) {
+	trans, err := dbMap.Begin()
+	if err != nil {
+		panic(err)
+	}
+	d := dbMap.Dialect
+	changed := fmt.Sprintf("select request_id from request where request_height > %s or resolve_height > %s", d.BindVar(0), d.BindVar(1))
+	for _, statement := range []string{
+		fmt.Sprintf("delete from raw_report where request_id in (%s)", changed),
+		fmt.Sprintf("delete from report where request_id in (%s)", changed),
+	} {
+		if _, err := trans.Exec(statement, height, height); err != nil {
+			trans.Rollback()
+			panic(err)
+		}
+	}
+	if _, err := trans.Exec(fmt.Sprintf("delete from request where request_height > %s", d.BindVar(0)), height); err != nil {
+		trans.Rollback()
+		panic(err)
+	}
+	_, err = trans.Exec(fmt.Sprintf(
+		"update request set resolve_status = %s, resolve_time = 0, result = null, resolve_height = 0 where resolve_height > %s",
+		d.BindVar(0), d.BindVar(1)), types.ResolveStatus_Open, height)
+	if err != nil {
+		trans.Rollback()
+		panic(err)
+	}
+	setIndexedHeight(trans, d, height)
+	if err := trans.Commit(); err != nil {
+		panic(err)
+	}
+}
+
+// reindex indexes requests created or resolved after the given height from the oracle state.
+// It must be called inside the block transaction. Requesters are not part of the oracle state,
+// so new requests are queued to have their requesters recovered from their transactions.
+func (h *Hook) reindex(ctx sdk.Context, height int64) {
+	// Requests that were open at the given height may have been resolved since.
+	var openRequests []Request
+	_, err := h.trans.Select(&openRequests, fmt.Sprintf(
+		"select * from request where resolve_status = %s", h.dbMap.Dialect.BindVar(0)), types.ResolveStatus_Open)
+	if err != nil && err != sql.ErrNoRows {
+		panic(err)
+	}
+	for _, request := range openRequests {
+		h.indexResolvedRequest(ctx, request.RequestID)
+	}
+	// Requests are created in order, so new requests are the ones after the last request created
+	// at or before the given height.
+	firstID := types.RequestID(h.oracleKeeper.GetRequestCount(ctx) + 1)
+	for firstID > 1 && h.oracleKeeper.MustGetRequest(ctx, firstID-1).RequestHeight > height {
+		firstID--
+	}
+	for id := firstID; id <= types.RequestID(h.oracleKeeper.GetRequestCount(ctx)); id++ {
+		h.missingRequesters[id] = h.oracleKeeper.MustGetRequest(ctx, id).RequestHeight
+		if h.oracleKeeper.HasResult(ctx, id) {
+			h.indexResolvedRequest(ctx, id)
+			continue
+		}
+		req := h.oracleKeeper.MustGetRequest(ctx, id)
+		h.insertRequest(id, req.OracleScriptID, req.Calldata, uint64(len(req.RequestedValidators)), req.MinCount,
+			nil, req.ClientID, req.RequestTime.Unix(), req.RequestHeight)
+	}
+}
+
+// indexResolvedRequest indexes the result and reports of the request if it is already resolved.
+// The resolve height is not part of the oracle state, so the current block height is used.
+func (h *Hook) indexResolvedRequest(ctx sdk.Context, id types.RequestID) {
+	result, err := h.oracleKeeper.GetResult(ctx, id)
+	if err != nil {
+		return
+	}
+	h.resolveRequest(id, h.oracleKeeper.MustGetRequest(ctx, id), result.ResponsePacketData,
+		h.oracleKeeper.GetReports(ctx, id), ctx.BlockHeight())
+}
+
+// recoverRequesters fills in the requesters of re-indexed requests from the transactions that
+// created them. It must be called inside the block transaction. If the blocks cannot be fetched
+// yet, e.g. while the node replays blocks on startup before its RPC server runs, it retries in
+// the next block.
+func (h *Hook) recoverRequesters() {
+	if h.blocks == nil {
+		return
+	}
+	senders := make(map[int64]map[types.RequestID]sdk.AccAddress)
+	for id, height := range h.missingRequesters {
+		if _, ok := senders[height]; !ok {
+			heightSenders, err := h.getRequestSenders(height)
+			if err != nil {
+				return
+			}
+			senders[height] = heightSenders
+		}
+		// Requests from IBC have no transaction and keep their empty requester.
+		if sender, ok := senders[height][id]; ok {
+			_, err := h.trans.Exec(fmt.Sprintf("update request set requester = %s where request_id = %s",
+				h.dbMap.Dialect.BindVar(0), h.dbMap.Dialect.BindVar(1)), sender.String(), id)
+			if err != nil {
+				panic(err)
+			}
+		}
+		delete(h.missingRequesters, id)
+	}
+}
+
+// getRequestSenders returns the senders of the successful MsgRequestData in the block at the
+// given height, by the ID of the request they created.
+func (h *Hook) getRequestSenders(height int64) (map[types.RequestID]sdk.AccAddress, error) {
+	block, err := h.blocks.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := h.blocks.BlockResults(&height)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.TxsResults) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("block %d has %d txs but %d results", height, len(block.Block.Txs), len(results.TxsResults))
+	}
+	senders := make(map[types.RequestID]sdk.AccAddress)
+	for idx, tx := range block.Block.Txs {
+		h.forEachRequest(tx, *results.TxsResults[idx], func(id types.RequestID, msg types.MsgRequestData) {
+			senders[id] = msg.Sender
+		})
+	}
+	return senders, nil
+}
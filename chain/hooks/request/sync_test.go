@@ -0,0 +1,156 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	bandapp "github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/keeper"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// mockBlockSource serves blocks with the given request transactions, or fails if unavailable.
+type mockBlockSource struct {
+	unavailable bool
+	txs         map[int64][]tmtypes.Tx
+	results     map[int64][]*abci.ResponseDeliverTx
+}
+
+func (s *mockBlockSource) Block(height *int64) (*ctypes.ResultBlock, error) {
+	if s.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultBlock{Block: &tmtypes.Block{Data: tmtypes.Data{Txs: s.txs[*height]}}}, nil
+}
+
+func (s *mockBlockSource) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	if s.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultBlockResults{Height: *height, TxsResults: s.results[*height]}, nil
+}
+
+// addRequestTx adds a transaction to the block at the given height that creates the given request.
+func (s *mockBlockSource) addRequestTx(app *bandapp.BandApp, height int64, id types.RequestID, sender sdk.AccAddress) {
+	msg := types.NewMsgRequestData(1, []byte("calldata"), 1, 1, "client", sender, nil, nil)
+	tx, err := auth.DefaultTxEncoder(app.Codec())(auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, ""))
+	if err != nil {
+		panic(err)
+	}
+	log := sdk.ABCIMessageLogs{sdk.NewABCIMessageLog(0, "", sdk.Events{sdk.NewEvent(
+		types.EventTypeRequest, sdk.NewAttribute(types.AttributeKeyID, fmt.Sprintf("%d", id)),
+	)})}
+	s.txs[height] = append(s.txs[height], tx)
+	s.results[height] = append(s.results[height], &abci.ResponseDeliverTx{Log: log.String()})
+}
+
+func newMockBlockSource() *mockBlockSource {
+	return &mockBlockSource{
+		txs:     make(map[int64][]tmtypes.Tx),
+		results: make(map[int64][]*abci.ResponseDeliverTx),
+	}
+}
+
+func mustCreateTempDB(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "request")
+	require.NoError(t, err)
+	return "sqlite3://" + filepath.Join(dir, "request.db"), func() { os.RemoveAll(dir) }
+}
+
+// addRequest adds a request created at the given height to the oracle state only.
+func addRequest(ctx sdk.Context, k keeper.Keeper, height int64) types.RequestID {
+	return k.AddRequest(ctx, types.NewRequest(
+		1, []byte("calldata"), []sdk.ValAddress{testapp.Validator1.ValAddress}, 1, height,
+		testapp.ParseTime(1581589790), "client", nil,
+	))
+}
+
+// indexBlock runs the hook through the block at the given height.
+func indexBlock(ctx sdk.Context, h *Hook, height int64) {
+	ctx = ctx.WithBlockHeight(height)
+	h.AfterBeginBlock(ctx, abci.RequestBeginBlock{Header: abci.Header{Height: height}}, abci.ResponseBeginBlock{})
+	h.AfterEndBlock(ctx, abci.RequestEndBlock{Height: height}, abci.ResponseEndBlock{})
+	h.BeforeCommit()
+}
+
+func mustGetRequest(t *testing.T, h *Hook, id types.RequestID) *Request {
+	obj, err := h.dbMap.Get(Request{}, id)
+	require.NoError(t, err)
+	if obj == nil {
+		return nil
+	}
+	return obj.(*Request)
+}
+
+func TestReindexRecoversRequester(t *testing.T) {
+	app, ctx, k := testapp.CreateTestInput(true)
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	blocks := newMockBlockSource()
+	h := NewHook(app.Codec(), k, connStr, 0, blocks)
+	indexBlock(ctx, h, 1)
+	// The app commits blocks 2 and 3, but the database transactions of both blocks are lost.
+	id := addRequest(ctx, k, 2)
+	blocks.addRequestTx(app, 2, id, testapp.Alice.Address)
+	// On restart, the request is re-indexed from the oracle state with its requester.
+	h = NewHook(app.Codec(), k, connStr, 3, blocks)
+	indexBlock(ctx, h, 4)
+	request := mustGetRequest(t, h, id)
+	require.NotNil(t, request)
+	require.Equal(t, testapp.Alice.Address.String(), request.Requester)
+	require.Equal(t, int64(2), request.RequestHeight)
+	height, ok := getIndexedHeight(h.dbMap)
+	require.True(t, ok)
+	require.Equal(t, int64(4), height)
+}
+
+func TestReindexRetriesUnavailableBlocks(t *testing.T) {
+	app, ctx, k := testapp.CreateTestInput(true)
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	blocks := newMockBlockSource()
+	h := NewHook(app.Codec(), k, connStr, 0, blocks)
+	indexBlock(ctx, h, 1)
+	id := addRequest(ctx, k, 2)
+	blocks.addRequestTx(app, 2, id, testapp.Alice.Address)
+	// The node replays block 3 before its RPC server runs, so the requester is unknown at first.
+	blocks.unavailable = true
+	h = NewHook(app.Codec(), k, connStr, 2, blocks)
+	indexBlock(ctx, h, 3)
+	require.Equal(t, "", mustGetRequest(t, h, id).Requester)
+	blocks.unavailable = false
+	indexBlock(ctx, h, 4)
+	require.Equal(t, testapp.Alice.Address.String(), mustGetRequest(t, h, id).Requester)
+}
+
+func TestRollbackAfterDatabaseCommit(t *testing.T) {
+	app, ctx, k := testapp.CreateTestInput(true)
+	connStr, cleanup := mustCreateTempDB(t)
+	defer cleanup()
+	blocks := newMockBlockSource()
+	h := NewHook(app.Codec(), k, connStr, 0, blocks)
+	indexBlock(ctx, h, 1)
+	// The database commits block 2 with a new request, but the app crashes before committing it.
+	h.AfterBeginBlock(ctx.WithBlockHeight(2), abci.RequestBeginBlock{Header: abci.Header{Height: 2}}, abci.ResponseBeginBlock{})
+	h.insertRequest(1, 1, []byte("calldata"), 1, 1, testapp.Alice.Address, "client", 1581589790, 2)
+	h.BeforeCommit()
+	require.NotNil(t, mustGetRequest(t, h, 1))
+	// On restart, the changes after the last committed height are rolled back.
+	h = NewHook(app.Codec(), k, connStr, 1, blocks)
+	require.Nil(t, mustGetRequest(t, h, 1))
+	height, ok := getIndexedHeight(h.dbMap)
+	require.True(t, ok)
+	require.Equal(t, int64(1), height)
+}
@@ -1,15 +1,48 @@
 package filecache
 
 import (
+	"container/list"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/peterbourgon/diskv"
 )
 
+// CorruptedFileError is returned when the content of a file does not match the sha256 sum of
+// its name. The corrupted file is removed, so it can be added again.
+type CorruptedFileError struct {
+	Filename string
+	Hash     string
+}
+
+func (e *CorruptedFileError) Error() string {
+	return fmt.Sprintf("corrupted file %s: content hash %s", e.Filename, e.Hash)
+}
+
 type Cache struct {
 	fileCache *diskv.Diskv
+	lru       *lru // Nil if the cache has no maximum size.
+}
+
+// lru tracks the files of a cache with a maximum size in least recently used order.
+type lru struct {
+	mtx     sync.Mutex
+	maxSize int64
+	size    int64
+	files   *list.List // Front is the most recently used file.
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	filename string
+	size     int64
 }
 
 // New creates and returns a new file-backed data caching instance.
@@ -23,6 +56,36 @@ func New(basePath string) Cache {
 	}
 }
 
+// NewWithMaxSize creates and returns a new file-backed data caching instance that keeps at most
+// maxSize bytes of files on disk, removing the least recently used files beyond that. Files
+// already in the directory are ordered by their modification time.
+func NewWithMaxSize(basePath string, maxSize int64) (Cache, error) {
+	c := New(basePath)
+	c.lru = &lru{
+		maxSize: maxSize,
+		files:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	infos, err := ioutil.ReadDir(basePath)
+	if err != nil && !os.IsNotExist(err) {
+		return Cache{}, err
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		c.lru.entries[info.Name()] = c.lru.files.PushFront(&lruEntry{filename: info.Name(), size: info.Size()})
+		c.lru.size += info.Size()
+	}
+	c.lru.mtx.Lock()
+	defer c.lru.mtx.Unlock()
+	c.evict()
+	return c, nil
+}
+
 func getFilename(data []byte) string {
 	hash := sha256.Sum256(data)
 	return hex.EncodeToString(hash[:])
@@ -34,18 +97,22 @@ func (c Cache) AddFile(data []byte) string {
 	if !c.fileCache.Has(filename) {
 		c.fileCache.Write(filename, data)
 	}
+	c.touch(filename, int64(len(data)))
 	return filename
 }
 
-// GetFile loads the file from the file storage. Returns error if the file does not exist.
+// GetFile loads the file from the file storage. Returns error if the file does not exist, or
+// CorruptedFileError if its content does not match its name.
 func (c Cache) GetFile(filename string) ([]byte, error) {
 	data, err := c.fileCache.Read(filename)
 	if err != nil {
 		return nil, err
 	}
-	if getFilename(data) != filename { // Perform integrity check for safety. NEVER EXPECT TO HIT.
-		return nil, errors.New("Inconsistent filecache content")
+	if hash := getFilename(data); hash != filename {
+		c.erase(filename)
+		return nil, &CorruptedFileError{Filename: filename, Hash: hash}
 	}
+	c.touch(filename, int64(len(data)))
 	return data, nil
 }
 
@@ -57,3 +124,53 @@ func (c Cache) MustGetFile(filename string) []byte {
 	}
 	return data
 }
+
+// touch marks the file as the most recently used, evicting other files if the cache grows
+// beyond its maximum size.
+func (c Cache) touch(filename string, size int64) {
+	if c.lru == nil {
+		return
+	}
+	c.lru.mtx.Lock()
+	defer c.lru.mtx.Unlock()
+	if elem, ok := c.lru.entries[filename]; ok {
+		c.lru.files.MoveToFront(elem)
+	} else {
+		c.lru.entries[filename] = c.lru.files.PushFront(&lruEntry{filename: filename, size: size})
+		c.lru.size += size
+	}
+	// Keep the order across restarts, where it is restored from modification times.
+	now := time.Now()
+	os.Chtimes(filepath.Join(c.fileCache.BasePath, filename), now, now)
+	c.evict()
+}
+
+// evict removes least recently used files, except the most recently used one, until the cache
+// fits in its maximum size. Must be called with the lock held.
+func (c Cache) evict() {
+	for c.lru.size > c.lru.maxSize && c.lru.files.Len() > 1 {
+		c.removeEntry(c.lru.files.Back())
+	}
+}
+
+func (c Cache) removeEntry(elem *list.Element) {
+	entry := c.lru.files.Remove(elem).(*lruEntry)
+	delete(c.lru.entries, entry.filename)
+	c.lru.size -= entry.size
+	c.fileCache.Erase(entry.filename)
+}
+
+// erase removes the file from the file storage.
+func (c Cache) erase(filename string) {
+	if c.lru == nil {
+		c.fileCache.Erase(filename)
+		return
+	}
+	c.lru.mtx.Lock()
+	defer c.lru.mtx.Unlock()
+	if elem, ok := c.lru.entries[filename]; ok {
+		c.removeEntry(elem)
+	} else {
+		c.fileCache.Erase(filename)
+	}
+}
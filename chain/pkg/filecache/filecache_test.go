@@ -1,10 +1,12 @@
 package filecache_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -241,4 +243,74 @@ func TestGetFileInconsistentContent(t *testing.T) {
 	err = ioutil.WriteFile(filepath, []byte("INCONSISTENT"), 0666) // Not consistent with name
 	_, err = f.GetFile(filename)
 	require.Error(t, err)
+	var corrupted *filecache.CorruptedFileError
+	require.True(t, errors.As(err, &corrupted))
+	require.Equal(t, filename, corrupted.Filename)
+	// The corrupted file is removed, so it can be fetched and added again.
+	_, err = os.Stat(filepath)
+	require.True(t, os.IsNotExist(err))
+	_, err = f.GetFile(filename)
+	require.False(t, errors.As(err, &corrupted))
+}
+
+func TestMaxSizeEvictLeastRecentlyUsed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filecache")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	f, err := filecache.NewWithMaxSize(dir, 10)
+	require.NoError(t, err)
+	filename1 := f.AddFile([]byte("AAAA"))
+	filename2 := f.AddFile([]byte("BBBB"))
+	_, err = f.GetFile(filename1)
+	require.NoError(t, err)
+	// Adding the third file goes over 10 bytes, so the second file is evicted.
+	filename3 := f.AddFile([]byte("CCCC"))
+	_, err = f.GetFile(filename2)
+	require.Error(t, err)
+	content, err := f.GetFile(filename1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("AAAA"), content)
+	content, err = f.GetFile(filename3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("CCCC"), content)
+	_, err = os.Stat(filepath.Join(dir, filename2))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestMaxSizeExistingFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filecache")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	f := filecache.New(dir)
+	filename1 := f.AddFile([]byte("AAAA"))
+	filename2 := f.AddFile([]byte("BBBB"))
+	filename3 := f.AddFile([]byte("CCCC"))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, filename2), old, old))
+
+	// Reopening with a smaller size evicts the file that was used the longest time ago.
+	f, err = filecache.NewWithMaxSize(dir, 8)
+	require.NoError(t, err)
+	_, err = f.GetFile(filename2)
+	require.Error(t, err)
+	_, err = f.GetFile(filename1)
+	require.NoError(t, err)
+	_, err = f.GetFile(filename3)
+	require.NoError(t, err)
 }
@@ -4,27 +4,115 @@ import (
 	"bytes"
 	gz "compress/gzip"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+
+	"github.com/DataDog/zstd"
+	"github.com/andybalholm/brotli"
+)
+
+// Format is a compression format of uploaded files.
+type Format int
+
+const (
+	// None means that the input is not in any known compression format.
+	None Format = iota
+	Gzip
+	Zstd
+	Brotli
 )
 
 // Magic bytes to identify gzip. See https://www.ietf.org/rfc/rfc1952.txt section 2.3.1.
 var gzipIdent = []byte("\x1F\x8B\x08")
 
+// Magic bytes to identify zstd. See https://www.rfc-editor.org/rfc/rfc8878.txt section 3.1.1.
+var zstdIdent = []byte("\x28\xB5\x2F\xFD")
+
+// Brotli streams have no magic bytes of their own (RFC 7932), so brotli input must start with
+// this prefix ("βρ" in UTF-8), which is stripped before decompression.
+var brotliIdent = []byte("\xCE\xB2\xCF\x81")
+
+// ParseFormat returns the compression format of the given name: gzip, zstd or brotli.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "gzip":
+		return Gzip, nil
+	case "zstd":
+		return Zstd, nil
+	case "brotli":
+		return Brotli, nil
+	default:
+		return None, fmt.Errorf("unknown compression format: %s", name)
+	}
+}
+
+// String implements fmt.Stringer interface.
+func (f Format) String() string {
+	switch f {
+	case Gzip:
+		return "gzip"
+	case Zstd:
+		return "zstd"
+	case Brotli:
+		return "brotli"
+	default:
+		return "none"
+	}
+}
+
+// Detect returns the compression format of the input from its magic bytes, or None if the
+// input is not compressed in a known format.
+func Detect(src []byte) Format {
+	switch {
+	case bytes.HasPrefix(src, gzipIdent):
+		return Gzip
+	case bytes.HasPrefix(src, zstdIdent):
+		return Zstd
+	case bytes.HasPrefix(src, brotliIdent):
+		return Brotli
+	default:
+		return None
+	}
+}
+
 // IsGzipped returns true iff the input is gzipped.
 func IsGzipped(src []byte) bool {
-	return bytes.Equal(gzipIdent, src[0:3])
+	return Detect(src) == Gzip
 }
 
-// Uncompress performs gzip uncompression and returns the result. Returns error if the
-// input file is not in gzipped format or if the result's size exceeds maxSize.
-func Uncompress(src []byte, maxSize int64) ([]byte, error) {
-	zr, err := gz.NewReader(bytes.NewReader(src))
-	if err != nil {
-		return nil, err
+// IsCompressed returns true iff the input is compressed in one of the known formats.
+func IsCompressed(src []byte) bool {
+	return Detect(src) != None
+}
+
+// Uncompress detects the compression format of the input and returns the uncompressed result.
+// Returns error if the input is not in a known format, if its size exceeds maxCompressedSize,
+// or if the result's size exceeds maxSize. Decompression stops as soon as the result grows
+// beyond maxSize, so highly compressed input cannot exhaust memory.
+func Uncompress(src []byte, maxCompressedSize int64, maxSize int64) ([]byte, error) {
+	if int64(len(src)) > maxCompressedSize {
+		return nil, errors.New("compressed file exceeds maxCompressedSize")
+	}
+	var r io.Reader
+	switch Detect(src) {
+	case Gzip:
+		zr, err := gz.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		zr.Multistream(false)
+		r = zr
+	case Zstd:
+		zr := zstd.NewReader(bytes.NewReader(src))
+		defer zr.Close()
+		r = zr
+	case Brotli:
+		r = brotli.NewReader(bytes.NewReader(src[len(brotliIdent):]))
+	default:
+		return nil, errors.New("unknown compression format")
 	}
-	zr.Multistream(false)
-	uncompressed, err := ioutil.ReadAll(io.LimitReader(zr, maxSize+1))
+	uncompressed, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
 	if err != nil {
 		return nil, err
 	}
@@ -33,3 +121,31 @@ func Uncompress(src []byte, maxSize int64) ([]byte, error) {
 	}
 	return uncompressed, nil
 }
+
+// Compress compresses the input in the given format, in a way that Uncompress detects.
+func Compress(src []byte, format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch format {
+	case Gzip:
+		zw, err := gz.NewWriterLevel(&buf, gz.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+	case Zstd:
+		w = zstd.NewWriterLevel(&buf, zstd.BestCompression)
+	case Brotli:
+		buf.Write(brotliIdent)
+		w = brotli.NewWriterLevel(&buf, brotli.BestCompression)
+	default:
+		return nil, fmt.Errorf("cannot compress in format: %s", format)
+	}
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
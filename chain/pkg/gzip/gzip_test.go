@@ -17,14 +17,14 @@ func TestUncompress(t *testing.T) {
 	zw.Close()
 	gzipFile := buf.Bytes()
 
-	accFile, err := gzip.Uncompress(gzipFile, 10)
+	accFile, err := gzip.Uncompress(gzipFile, 999, 10)
 	require.NoError(t, err)
 	require.Equal(t, file1, accFile)
 
-	accFile, err = gzip.Uncompress(gzipFile, 2)
+	accFile, err = gzip.Uncompress(gzipFile, 999, 2)
 	require.Error(t, err)
 
-	_, err = gzip.Uncompress(file1, 999)
+	_, err = gzip.Uncompress(file1, 999, 999)
 	require.Error(t, err)
 }
 
@@ -37,4 +37,52 @@ func TestIsGzip(t *testing.T) {
 	gzipFile := buf.Bytes()
 	require.True(t, gzip.IsGzipped(gzipFile))
 	require.False(t, gzip.IsGzipped(file1))
+	require.False(t, gzip.IsGzipped([]byte{0x1F}))
+	require.False(t, gzip.IsGzipped(nil))
+}
+
+func TestCompressUncompress(t *testing.T) {
+	file1 := []byte("file file file file file file file file")
+	for _, format := range []gzip.Format{gzip.Gzip, gzip.Zstd, gzip.Brotli} {
+		compressed, err := gzip.Compress(file1, format)
+		require.NoError(t, err, format.String())
+		require.Equal(t, format, gzip.Detect(compressed), format.String())
+		require.True(t, gzip.IsCompressed(compressed), format.String())
+
+		accFile, err := gzip.Uncompress(compressed, 999, 100)
+		require.NoError(t, err, format.String())
+		require.Equal(t, file1, accFile, format.String())
+
+		// Output and compressed input are bounded separately.
+		_, err = gzip.Uncompress(compressed, 999, 10)
+		require.Error(t, err, format.String())
+		_, err = gzip.Uncompress(compressed, int64(len(compressed)-1), 100)
+		require.Error(t, err, format.String())
+	}
+	_, err := gzip.Compress(file1, gzip.None)
+	require.Error(t, err)
+}
+
+func TestUncompressBomb(t *testing.T) {
+	bomb := make([]byte, 10*1024*1024)
+	for _, format := range []gzip.Format{gzip.Gzip, gzip.Zstd, gzip.Brotli} {
+		compressed, err := gzip.Compress(bomb, format)
+		require.NoError(t, err, format.String())
+		require.True(t, len(compressed) < 64*1024, format.String())
+		accFile, err := gzip.Uncompress(compressed, 64*1024, 1024)
+		require.Error(t, err, format.String())
+		require.Len(t, accFile, 1025, format.String())
+	}
+}
+
+func TestDetect(t *testing.T) {
+	require.Equal(t, gzip.None, gzip.Detect([]byte("file")))
+	require.Equal(t, gzip.None, gzip.Detect(nil))
+	require.False(t, gzip.IsCompressed([]byte("file")))
+
+	format, err := gzip.ParseFormat("zstd")
+	require.NoError(t, err)
+	require.Equal(t, gzip.Zstd, format)
+	_, err = gzip.ParseFormat("lzma")
+	require.Error(t, err)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 )
 
@@ -12,7 +13,33 @@ func decodeImpl(data []byte, v interface{}) ([]byte, error) {
 	if rv.Kind() != reflect.Ptr || rv.IsNil() {
 		return nil, errors.New("obi: decode into non-ptr type")
 	}
-	ev := rv.Elem()
+	return decodeReflect(data, rv.Elem(), defaultFieldOptions)
+}
+
+func decodeReflect(data []byte, ev reflect.Value, opts fieldOptions) ([]byte, error) {
+	if ev.Type() == bigIntType {
+		rem := data
+		if opts.optional {
+			present, optRem, err := DecodeBool(data)
+			if err != nil {
+				return nil, err
+			}
+			if !present {
+				ev.Set(reflect.Zero(ev.Type()))
+				return optRem, nil
+			}
+			rem = optRem
+		}
+		val, rem, err := decodeInteger(rem, opts.bigKind)
+		if err != nil {
+			return nil, err
+		}
+		ev.Set(reflect.ValueOf(val))
+		return rem, nil
+	}
+	if err := opts.check(ev.Type()); err != nil {
+		return nil, err
+	}
 	switch ev.Kind() {
 	case reflect.Uint8:
 		val, rem, err := DecodeUnsigned8(data)
@@ -46,10 +73,40 @@ func decodeImpl(data []byte, v interface{}) ([]byte, error) {
 		val, rem, err := DecodeSigned64(data)
 		ev.SetInt(int64(val))
 		return rem, err
+	case reflect.Bool:
+		val, rem, err := DecodeBool(data)
+		ev.SetBool(val)
+		return rem, err
 	case reflect.String:
 		val, rem, err := DecodeString(data)
 		ev.SetString(val)
 		return rem, err
+	case reflect.Ptr:
+		present, rem, err := DecodeBool(data)
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			ev.Set(reflect.Zero(ev.Type()))
+			return rem, nil
+		}
+		elem := reflect.New(ev.Type().Elem())
+		rem, err = decodeReflect(rem, elem.Elem(), defaultFieldOptions)
+		if err != nil {
+			return nil, err
+		}
+		ev.Set(elem)
+		return rem, nil
+	case reflect.Array:
+		rem := data
+		for idx := 0; idx < ev.Len(); idx++ {
+			var err error
+			rem, err = decodeReflect(rem, ev.Index(idx), opts.elem())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return rem, nil
 	case reflect.Slice:
 		if ev.Type().Elem().Kind() == reflect.Uint8 {
 			val, rem, err := DecodeBytes(data)
@@ -63,7 +120,7 @@ func decodeImpl(data []byte, v interface{}) ([]byte, error) {
 		slice := reflect.MakeSlice(ev.Type(), int(length), int(length))
 		for idx := 0; idx < int(length); idx++ {
 			var err error
-			rem, err = decodeImpl(rem, slice.Index(idx).Addr().Interface())
+			rem, err = decodeReflect(rem, slice.Index(idx), opts.elem())
 			if err != nil {
 				return nil, err
 			}
@@ -73,8 +130,11 @@ func decodeImpl(data []byte, v interface{}) ([]byte, error) {
 	case reflect.Struct:
 		rem := data
 		for idx := 0; idx < ev.NumField(); idx++ {
-			var err error
-			rem, err = decodeImpl(rem, ev.Field(idx).Addr().Interface())
+			_, fieldOpts, err := parseFieldTag(ev.Type().Field(idx).Tag.Get("obi"))
+			if err != nil {
+				return nil, err
+			}
+			rem, err = decodeReflect(rem, ev.Field(idx), fieldOpts)
 			if err != nil {
 				return nil, err
 			}
@@ -165,6 +225,39 @@ func DecodeSigned64(data []byte) (int64, []byte, error) {
 	return int64(unsigned), rem, err
 }
 
+// DecodeUnsigned128 decodes the input bytes into an unsigned 128-bit integer and returns the remaining bytes.
+func DecodeUnsigned128(data []byte) (*big.Int, []byte, error) {
+	return decodeInteger(data, KindU128)
+}
+
+// DecodeUnsigned256 decodes the input bytes into an unsigned 256-bit integer and returns the remaining bytes.
+func DecodeUnsigned256(data []byte) (*big.Int, []byte, error) {
+	return decodeInteger(data, KindU256)
+}
+
+// DecodeSigned128 decodes the input bytes into a signed 128-bit integer and returns the remaining bytes.
+func DecodeSigned128(data []byte) (*big.Int, []byte, error) {
+	return decodeInteger(data, KindI128)
+}
+
+// DecodeSigned256 decodes the input bytes into a signed 256-bit integer and returns the remaining bytes.
+func DecodeSigned256(data []byte) (*big.Int, []byte, error) {
+	return decodeInteger(data, KindI256)
+}
+
+// DecodeBool decodes the input bytes into `bool` and returns the remaining bytes. Only 0x00 and
+// 0x01 are valid encodings.
+func DecodeBool(data []byte) (bool, []byte, error) {
+	val, rem, err := DecodeUnsigned8(data)
+	if err != nil {
+		return false, nil, err
+	}
+	if val > 1 {
+		return false, nil, fmt.Errorf("obi: invalid bool value %d", val)
+	}
+	return val == 1, rem, nil
+}
+
 // DecodeBytes decodes the input bytes and returns bytes result and the remaining bytes.
 func DecodeBytes(data []byte) ([]byte, []byte, error) {
 	length, rem, err := DecodeUnsigned32(data)
@@ -1,6 +1,8 @@
 package obi
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -284,9 +286,9 @@ func TestDecodeIntoNonPointer(t *testing.T) {
 }
 
 func TestUnsupportedType(t *testing.T) {
-	var actual bool
+	var actual float64
 	byteArray := []byte{0x6, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6}
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustDecode(byteArray, &actual) })
+	require.PanicsWithError(t, "obi: unsupported value type: float64", func() { MustDecode(byteArray, &actual) })
 }
 
 func TestNotAllDataConsumed(t *testing.T) {
@@ -294,3 +296,93 @@ func TestNotAllDataConsumed(t *testing.T) {
 	byteArray := []byte{0x0, 0x0, 0x0, 0x6, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0x10}
 	require.PanicsWithError(t, "obi: not all data was consumed while decoding", func() { MustDecode(byteArray, &actual) })
 }
+
+func TestDecodeBool(t *testing.T) {
+	var actual bool
+	require.NoError(t, Decode([]byte{0x1}, &actual))
+	require.True(t, actual)
+	require.NoError(t, Decode([]byte{0x0}, &actual))
+	require.False(t, actual)
+	require.PanicsWithError(t, "obi: invalid bool value 2", func() { MustDecode([]byte{0x2}, &actual) })
+}
+
+func TestDecodeFixedArray(t *testing.T) {
+	var actual [2]int16
+	require.NoError(t, Decode([]byte{0x0, 0x1, 0xff, 0xfe}, &actual))
+	require.Equal(t, [2]int16{1, -2}, actual)
+	require.PanicsWithError(t, "obi: out of range", func() { MustDecode([]byte{0x0, 0x1, 0xff}, &actual) })
+}
+
+func TestDecodeOptional(t *testing.T) {
+	var actual *uint16
+	require.NoError(t, Decode([]byte{0x1, 0x1, 0x2}, &actual))
+	require.Equal(t, uint16(258), *actual)
+	require.NoError(t, Decode([]byte{0x0}, &actual))
+	require.Nil(t, actual)
+	require.PanicsWithError(t, "obi: invalid bool value 3", func() { MustDecode([]byte{0x3}, &actual) })
+}
+
+func TestDecodeBigInteger(t *testing.T) {
+	v, rem, err := DecodeSigned128(append(bytes.Repeat([]byte{0xff}, 15), 0xfe))
+	require.NoError(t, err)
+	require.Empty(t, rem)
+	require.Equal(t, big.NewInt(-2), v)
+	v, _, err = DecodeUnsigned256(bytes.Repeat([]byte{0xff}, 32))
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)), v)
+	_, _, err = DecodeUnsigned128(make([]byte, 15))
+	require.EqualError(t, err, "obi: out of range")
+}
+
+func TestDecodeBigIntegerElements(t *testing.T) {
+	type Data struct {
+		X []*big.Int    `obi:"x,i128"`
+		Y [1][]*big.Int `obi:"y,u128"`
+	}
+	expected := Data{[]*big.Int{big.NewInt(-1)}, [1][]*big.Int{{big.NewInt(2)}}}
+	var actual Data
+	require.NoError(t, Decode(MustEncode(expected), &actual))
+	require.Equal(t, expected, actual)
+	var wrong struct {
+		X []uint64 `obi:"x,i128"`
+	}
+	require.EqualError(t, Decode([]byte{0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}, &wrong),
+		"obi: i128 option requires *big.Int, got uint64")
+	var optional struct {
+		X string `obi:"x,optional"`
+	}
+	require.EqualError(t, Decode([]byte{0x0}, &optional), "obi: optional option requires *big.Int, got string; use a pointer instead")
+}
+
+func TestDecodeExtendedStruct(t *testing.T) {
+	px := uint64(7)
+	note := "hi"
+	fee := big.NewInt(9)
+	expected := ExtendedData{
+		Flag:     false,
+		Pair:     [2]int8{3, -4},
+		Hash:     [4]byte{0xde, 0xad, 0xbe, 0xef},
+		Px:       &px,
+		Note:     &note,
+		Supply:   new(big.Int).Lsh(big.NewInt(1), 200),
+		Debt:     big.NewInt(-100),
+		MaybeFee: fee,
+	}
+	var actual ExtendedData
+	require.NoError(t, Decode(MustEncode(expected), &actual))
+	require.Equal(t, expected, actual)
+	// Absent optional fields decode to nil.
+	actual = ExtendedData{}
+	require.NoError(t, Decode(MustEncode(ExtendedData{Supply: big.NewInt(0), Debt: big.NewInt(0)}), &actual))
+	require.Nil(t, actual.Px)
+	require.Nil(t, actual.Note)
+	require.Nil(t, actual.MaybeFee)
+	require.Equal(t, 0, actual.Supply.Sign())
+	require.Equal(t, 0, actual.Debt.Sign())
+	// Present zero values are kept.
+	zero, empty := uint64(0), ""
+	expected = ExtendedData{Px: &zero, Note: &empty, Supply: big.NewInt(1), Debt: big.NewInt(1), MaybeFee: big.NewInt(1)}
+	actual = ExtendedData{}
+	require.NoError(t, Decode(MustEncode(expected), &actual))
+	require.Equal(t, expected, actual)
+}
@@ -2,13 +2,37 @@ package obi
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 )
 
 // Encode uses obi encoding scheme to encode the given input into bytes.
 func encodeImpl(v interface{}) ([]byte, error) {
-	rv := reflect.ValueOf(v)
+	return encodeReflect(reflect.ValueOf(v), defaultFieldOptions)
+}
+
+func encodeReflect(rv reflect.Value, opts fieldOptions) ([]byte, error) {
+	if rv.IsValid() && rv.Type() == bigIntType {
+		if rv.IsNil() {
+			if opts.optional {
+				return EncodeBool(false), nil
+			}
+			return nil, errors.New("obi: nil big integer")
+		}
+		res, err := encodeInteger(rv.Interface().(*big.Int), opts.bigKind)
+		if err != nil {
+			return nil, err
+		}
+		if opts.optional {
+			return append(EncodeBool(true), res...), nil
+		}
+		return res, nil
+	}
+	if err := opts.check(rv.Type()); err != nil {
+		return nil, err
+	}
 	switch rv.Kind() {
 	case reflect.Uint8:
 		return EncodeUnsigned8(uint8(rv.Uint())), nil
@@ -26,8 +50,29 @@ func encodeImpl(v interface{}) ([]byte, error) {
 		return EncodeSigned32(int32(rv.Int())), nil
 	case reflect.Int64:
 		return EncodeSigned64(int64(rv.Int())), nil
+	case reflect.Bool:
+		return EncodeBool(rv.Bool()), nil
 	case reflect.String:
 		return EncodeString(rv.String()), nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return EncodeBool(false), nil
+		}
+		res, err := encodeReflect(rv.Elem(), defaultFieldOptions)
+		if err != nil {
+			return nil, err
+		}
+		return append(EncodeBool(true), res...), nil
+	case reflect.Array:
+		res := []byte{}
+		for idx := 0; idx < rv.Len(); idx++ {
+			each, err := encodeReflect(rv.Index(idx), opts.elem())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, each...)
+		}
+		return res, nil
 	case reflect.Slice:
 		if rv.Type().Elem().Kind() == reflect.Uint8 {
 			return EncodeBytes(rv.Bytes()), nil
@@ -35,7 +80,7 @@ func encodeImpl(v interface{}) ([]byte, error) {
 
 		res := EncodeUnsigned32(uint32(rv.Len()))
 		for idx := 0; idx < rv.Len(); idx++ {
-			each, err := Encode(rv.Index(idx).Interface())
+			each, err := encodeReflect(rv.Index(idx), opts.elem())
 			if err != nil {
 				return nil, err
 			}
@@ -45,7 +90,11 @@ func encodeImpl(v interface{}) ([]byte, error) {
 	case reflect.Struct:
 		res := []byte{}
 		for idx := 0; idx < rv.NumField(); idx++ {
-			each, err := Encode(rv.Field(idx).Interface())
+			_, fieldOpts, err := parseFieldTag(rv.Type().Field(idx).Tag.Get("obi"))
+			if err != nil {
+				return nil, err
+			}
+			each, err := encodeReflect(rv.Field(idx), fieldOpts)
 			if err != nil {
 				return nil, err
 			}
@@ -125,6 +174,34 @@ func EncodeSigned64(v int64) []byte {
 	return EncodeUnsigned64(uint64(v))
 }
 
+// EncodeUnsigned128 takes an unsigned 128-bit integer and encodes it into a byte array
+func EncodeUnsigned128(v *big.Int) ([]byte, error) {
+	return encodeInteger(v, KindU128)
+}
+
+// EncodeUnsigned256 takes an unsigned 256-bit integer and encodes it into a byte array
+func EncodeUnsigned256(v *big.Int) ([]byte, error) {
+	return encodeInteger(v, KindU256)
+}
+
+// EncodeSigned128 takes a signed 128-bit integer and encodes it into a byte array
+func EncodeSigned128(v *big.Int) ([]byte, error) {
+	return encodeInteger(v, KindI128)
+}
+
+// EncodeSigned256 takes a signed 256-bit integer and encodes it into a byte array
+func EncodeSigned256(v *big.Int) ([]byte, error) {
+	return encodeInteger(v, KindI256)
+}
+
+// EncodeBool takes a `bool` variable and encodes it into a byte array
+func EncodeBool(v bool) []byte {
+	if v {
+		return []byte{0x1}
+	}
+	return []byte{0x0}
+}
+
 // EncodeBytes takes a `[]byte` variable and encodes it into a byte array
 func EncodeBytes(v []byte) []byte {
 	return append(EncodeUnsigned32(uint32(len(v))), v...)
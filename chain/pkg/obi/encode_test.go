@@ -1,6 +1,8 @@
 package obi
 
 import (
+	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,7 +28,7 @@ type ExampleData struct {
 }
 
 type InvalidStruct struct {
-	IsBool bool
+	IsFloat float64
 }
 
 func TestEncodeBytes(t *testing.T) {
@@ -54,9 +56,9 @@ func TestEncodeBytesMulti(t *testing.T) {
 
 func TestEncodeStructFail(t *testing.T) {
 	invalid := InvalidStruct{
-		IsBool: true,
+		IsFloat: 1.5,
 	}
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustEncode(invalid) })
+	require.PanicsWithError(t, "obi: unsupported value type: float64", func() { MustEncode(invalid) })
 }
 
 // Uint8
@@ -176,8 +178,8 @@ func TestEncodeSlice(t *testing.T) {
 }
 
 func TestEncodeSliceFail(t *testing.T) {
-	testSlice := []bool{true, false, true, true}
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustEncode(testSlice) })
+	testSlice := []float32{1, 0, 1, 1}
+	require.PanicsWithError(t, "obi: unsupported value type: float32", func() { MustEncode(testSlice) })
 }
 
 func TestEncodeByteArray(t *testing.T) {
@@ -187,13 +189,161 @@ func TestEncodeByteArray(t *testing.T) {
 }
 
 func TestEncodeNotSupported(t *testing.T) {
-	notSupportBool := true
-	byteArray, err := Encode(notSupportBool)
-	require.EqualError(t, err, "obi: unsupported value type: bool")
+	notSupportMap := map[string]uint8{"a": 1}
+	byteArray, err := Encode(notSupportMap)
+	require.EqualError(t, err, "obi: unsupported value type: map")
 	require.Nil(t, byteArray)
 }
 
 func TestEncodeNotSupport(t *testing.T) {
-	notSupportBool := true
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustEncode(notSupportBool) })
+	notSupportMap := map[string]uint8{"a": 1}
+	require.PanicsWithError(t, "obi: unsupported value type: map", func() { MustEncode(notSupportMap) })
+}
+
+type ExtendedData struct {
+	Flag     bool     `obi:"flag"`
+	Pair     [2]int8  `obi:"pair"`
+	Hash     [4]byte  `obi:"hash"`
+	Px       *uint64  `obi:"px"`
+	Note     *string  `obi:"note"`
+	Supply   *big.Int `obi:"supply"`
+	Debt     *big.Int `obi:"debt,i128"`
+	MaybeFee *big.Int `obi:"maybe_fee,u128,optional"`
+}
+
+func TestEncodeBool(t *testing.T) {
+	require.Equal(t, []byte{0x1}, MustEncode(true))
+	require.Equal(t, []byte{0x0}, MustEncode(false))
+}
+
+func TestEncodeFixedArray(t *testing.T) {
+	// Fixed-length arrays are encoded as their elements without a length prefix.
+	require.Equal(t, []byte{0x0, 0x1, 0xff, 0xfe}, MustEncode([2]int16{1, -2}))
+	require.Equal(t, []byte{0xab, 0xcd}, MustEncode([2]byte{0xab, 0xcd}))
+	require.Equal(t, []byte{}, MustEncode([0]uint32{}))
+}
+
+func TestEncodeOptional(t *testing.T) {
+	// Optionals are encoded as 0x00 for none, or 0x01 followed by the value.
+	px := uint16(258)
+	require.Equal(t, []byte{0x1, 0x1, 0x2}, MustEncode(&px))
+	require.Equal(t, []byte{0x0}, MustEncode((*uint16)(nil)))
+}
+
+func TestEncodeBigInteger(t *testing.T) {
+	// 128 and 256-bit integers are encoded as big-endian two's complement.
+	data, err := EncodeUnsigned128(big.NewInt(258))
+	require.NoError(t, err)
+	require.Equal(t, append(make([]byte, 14), 0x1, 0x2), data)
+	data, err = EncodeSigned128(big.NewInt(-2))
+	require.NoError(t, err)
+	require.Equal(t, append(bytes.Repeat([]byte{0xff}, 15), 0xfe), data)
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	data, err = EncodeUnsigned256(max)
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{0xff}, 32), data)
+	min := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	data, err = EncodeSigned256(min)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{0x80}, make([]byte, 31)...), data)
+	_, err = EncodeUnsigned256(new(big.Int).Add(max, big.NewInt(1)))
+	require.EqualError(t, err, "obi: value "+new(big.Int).Add(max, big.NewInt(1)).String()+" out of range of u256")
+	_, err = EncodeUnsigned128(big.NewInt(-1))
+	require.EqualError(t, err, "obi: value -1 out of range of u128")
+	_, err = EncodeSigned128(new(big.Int).Lsh(big.NewInt(1), 127))
+	require.Error(t, err)
+}
+
+func TestEncodeExtendedStruct(t *testing.T) {
+	px := uint64(1)
+	data := MustEncode(ExtendedData{
+		Flag:     true,
+		Pair:     [2]int8{-1, 1},
+		Hash:     [4]byte{0x1, 0x2, 0x3, 0x4},
+		Px:       &px,
+		Note:     nil,
+		Supply:   big.NewInt(5),
+		Debt:     big.NewInt(-1),
+		MaybeFee: nil,
+	})
+	expected := []byte{0x1, 0xff, 0x1, 0x1, 0x2, 0x3, 0x4, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0}
+	expected = append(expected, append(make([]byte, 31), 0x5)...)
+	expected = append(expected, bytes.Repeat([]byte{0xff}, 16)...)
+	expected = append(expected, 0x0)
+	require.Equal(t, expected, data)
+}
+
+func TestEncodeTagOptionFail(t *testing.T) {
+	require.PanicsWithError(t, "obi: u128 option requires *big.Int, got uint64", func() {
+		MustEncode(struct {
+			X uint64 `obi:"x,u128"`
+		}{})
+	})
+	require.PanicsWithError(t, `obi: unknown option "opt" in tag "x,opt"`, func() {
+		MustEncode(struct {
+			X uint64 `obi:"x,opt"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: nil big integer", func() {
+		MustEncode(struct {
+			X *big.Int `obi:"x"`
+		}{})
+	})
+	// Optional fields other than big integers must be pointers, so that zero values are kept.
+	require.PanicsWithError(t, "obi: optional option requires *big.Int, got uint64; use a pointer instead", func() {
+		MustEncode(struct {
+			X uint64 `obi:"x,optional"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: optional option requires *big.Int, got *uint64; use a pointer instead", func() {
+		MustEncode(struct {
+			X *uint64 `obi:"x,optional"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: optional option requires *big.Int, got []*big.Int; use a pointer instead", func() {
+		MustEncode(struct {
+			X []*big.Int `obi:"x,u128,optional"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: u128 option requires *big.Int, got uint64", func() {
+		MustEncode(struct {
+			X []uint64 `obi:"x,u128"`
+		}{[]uint64{1}})
+	})
+	require.PanicsWithError(t, "obi: u128 option requires *big.Int, got []uint8", func() {
+		MustEncode(struct {
+			X []byte `obi:"x,u128"`
+		}{})
+	})
+}
+
+func TestEncodeOptionalZeroValues(t *testing.T) {
+	// Zero values of optional fields are encoded as present.
+	zero, empty, no := uint64(0), "", false
+	require.Equal(t, []byte{0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0},
+		MustEncode(struct {
+			A *uint64 `obi:"a"`
+			B *string `obi:"b"`
+			C *bool   `obi:"c"`
+		}{&zero, &empty, &no}))
+	require.Equal(t, append([]byte{0x1}, make([]byte, 16)...), MustEncode(struct {
+		X *big.Int `obi:"x,u128,optional"`
+	}{big.NewInt(0)}))
+}
+
+func TestEncodeBigIntegerElements(t *testing.T) {
+	// Integer options apply to the elements of slices and arrays.
+	data := MustEncode(struct {
+		X []*big.Int    `obi:"x,i128"`
+		Y [1][]*big.Int `obi:"y,u128"`
+	}{[]*big.Int{big.NewInt(-1)}, [1][]*big.Int{{big.NewInt(2)}}})
+	expected := append([]byte{0x0, 0x0, 0x0, 0x1}, bytes.Repeat([]byte{0xff}, 16)...)
+	expected = append(expected, 0x0, 0x0, 0x0, 0x1)
+	expected = append(expected, append(make([]byte, 15), 0x2)...)
+	require.Equal(t, expected, data)
+	require.PanicsWithError(t, "obi: value -1 out of range of u128", func() {
+		MustEncode(struct {
+			X []*big.Int `obi:"x,u128"`
+		}{[]*big.Int{big.NewInt(-1)}})
+	})
 }
@@ -0,0 +1,64 @@
+package obi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// integerBits maps integer kinds to their bit sizes.
+var integerBits = map[Kind]uint{
+	KindU8: 8, KindU16: 16, KindU32: 32, KindU64: 64, KindU128: 128, KindU256: 256,
+	KindI8: 8, KindI16: 16, KindI32: 32, KindI64: 64, KindI128: 128, KindI256: 256,
+}
+
+// isSigned returns whether the integer kind is signed.
+func isSigned(kind Kind) bool {
+	switch kind {
+	case KindI8, KindI16, KindI32, KindI64, KindI128, KindI256:
+		return true
+	default:
+		return false
+	}
+}
+
+// integerRange returns the inclusive range of the integer kind.
+func integerRange(kind Kind) (*big.Int, *big.Int) {
+	size := integerBits[kind]
+	if isSigned(kind) {
+		max := new(big.Int).Lsh(big.NewInt(1), size-1)
+		return new(big.Int).Neg(max), max.Sub(max, big.NewInt(1))
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), size)
+	return big.NewInt(0), max.Sub(max, big.NewInt(1))
+}
+
+// encodeInteger encodes the integer as big-endian two's complement of the size of the kind.
+func encodeInteger(v *big.Int, kind Kind) ([]byte, error) {
+	min, max := integerRange(kind)
+	if v.Cmp(min) < 0 || v.Cmp(max) > 0 {
+		return nil, fmt.Errorf("obi: value %s out of range of %s", v, primitiveNames[kind])
+	}
+	size := integerBits[kind]
+	x := new(big.Int).Set(v)
+	if x.Sign() < 0 {
+		x.Add(x, new(big.Int).Lsh(big.NewInt(1), size))
+	}
+	res := make([]byte, size/8)
+	bz := x.Bytes()
+	copy(res[len(res)-len(bz):], bz)
+	return res, nil
+}
+
+// decodeInteger decodes a big-endian two's complement integer of the size of the kind.
+func decodeInteger(data []byte, kind Kind) (*big.Int, []byte, error) {
+	size := integerBits[kind]
+	if uint(len(data)) < size/8 {
+		return nil, nil, errors.New("obi: out of range")
+	}
+	v := new(big.Int).SetBytes(data[:size/8])
+	if isSigned(kind) && data[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), size))
+	}
+	return v, data[size/8:], nil
+}
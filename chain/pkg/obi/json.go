@@ -0,0 +1,215 @@
+package obi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// In JSON form, integers are numbers or decimal strings (to keep 64-bit precision for clients
+// that read numbers as doubles), bytes are hex strings, vectors and fixed-length arrays are
+// arrays, structs are objects, and absent optionals are null. Decoded integers are numbers,
+// except 64, 128 and 256-bit integers which are decimal strings. Decoded structs keep the field
+// order of the schema. Optional struct fields may be omitted from the input.
+
+func valueFromJSONImpl(raw json.RawMessage, t Type) (interface{}, error) {
+	switch t.Kind {
+	case KindU8, KindU16, KindU32, KindU64, KindI8, KindI16, KindI32, KindI64:
+		var num json.Number
+		if err := json.Unmarshal(raw, &num); err != nil {
+			return nil, fmt.Errorf("obi: expect integer for %s, got %s", t, raw)
+		}
+		if isSigned(t.Kind) {
+			v, err := strconv.ParseInt(num.String(), 10, int(integerBits[t.Kind]))
+			if err != nil {
+				return nil, fmt.Errorf("obi: invalid %s value %s", t, num)
+			}
+			return v, nil
+		}
+		v, err := strconv.ParseUint(num.String(), 10, int(integerBits[t.Kind]))
+		if err != nil {
+			return nil, fmt.Errorf("obi: invalid %s value %s", t, num)
+		}
+		return v, nil
+	case KindU128, KindU256, KindI128, KindI256:
+		var num json.Number
+		if err := json.Unmarshal(raw, &num); err != nil {
+			return nil, fmt.Errorf("obi: expect integer for %s, got %s", t, raw)
+		}
+		v, ok := new(big.Int).SetString(num.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("obi: invalid %s value %s", t, num)
+		}
+		return v, nil
+	case KindBool:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("obi: expect bool, got %s", raw)
+		}
+		return b, nil
+	case KindOption:
+		if string(bytes.TrimSpace(raw)) == "null" {
+			return nil, nil
+		}
+		return valueFromJSONImpl(raw, *t.Elem)
+	case KindString:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("obi: expect string, got %s", raw)
+		}
+		return s, nil
+	case KindBytes:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("obi: expect hex string for bytes, got %s", raw)
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("obi: invalid hex string %q", s)
+		}
+		return b, nil
+	case KindVector, KindArray:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil || elems == nil {
+			return nil, fmt.Errorf("obi: expect array for %s, got %s", t, raw)
+		}
+		res := make([]interface{}, len(elems))
+		for idx, elem := range elems {
+			v, err := valueFromJSONImpl(elem, *t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			res[idx] = v
+		}
+		return res, nil
+	case KindStruct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+			return nil, fmt.Errorf("obi: expect object for %s, got %s", t, raw)
+		}
+		res := make(map[string]interface{}, len(fields))
+		for name, fieldRaw := range fields {
+			fieldType, ok := t.FieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("obi: unknown field %s", name)
+			}
+			v, err := valueFromJSONImpl(fieldRaw, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			res[name] = v
+		}
+		for _, field := range t.Fields {
+			if _, ok := res[field.Name]; !ok && field.Type.Kind == KindOption {
+				res[field.Name] = nil
+			}
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("obi: unsupported schema kind: %d", t.Kind)
+	}
+}
+
+// ValueFromJSON converts the given JSON document into a generic value of the schema type,
+// suitable for EncodeValue.
+func ValueFromJSON(data []byte, t Type) (interface{}, error) {
+	return valueFromJSONImpl(json.RawMessage(data), t)
+}
+
+func writeJSONImpl(buf *bytes.Buffer, v interface{}, t Type) error {
+	switch t.Kind {
+	case KindVector, KindArray:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("obi: expect vector for %s, got %T", t, v)
+		}
+		buf.WriteByte('[')
+		for idx, elem := range elems {
+			if idx != 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONImpl(buf, elem, *t.Elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case KindStruct:
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("obi: expect struct for %s, got %T", t, v)
+		}
+		buf.WriteByte('{')
+		for idx, field := range t.Fields {
+			if idx != 0 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(field.Name)
+			buf.Write(name)
+			buf.WriteByte(':')
+			if err := writeJSONImpl(buf, fields[field.Name], field.Type); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case KindBytes:
+		b, ok := v.([]byte)
+		if !ok {
+			return fmt.Errorf("obi: expect bytes, got %T", v)
+		}
+		v = hex.EncodeToString(b)
+	case KindU64, KindI64:
+		v = fmt.Sprintf("%d", v)
+	case KindU128, KindU256, KindI128, KindI256:
+		x, ok := v.(*big.Int)
+		if !ok {
+			return fmt.Errorf("obi: expect *big.Int for %s, got %T", t, v)
+		}
+		v = x.String()
+	case KindOption:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSONImpl(buf, v, *t.Elem)
+	}
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(bz)
+	return nil
+}
+
+// ValueToJSON converts the given generic value of the schema type, as returned by DecodeValue,
+// into a JSON document.
+func ValueToJSON(v interface{}, t Type) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := writeJSONImpl(buf, v, t); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeJSON encodes the given JSON document against the schema type into OBI bytes.
+func EncodeJSON(data []byte, t Type) ([]byte, error) {
+	v, err := ValueFromJSON(data, t)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeValue(v, t)
+}
+
+// DecodeJSON decodes the given OBI bytes against the schema type into a JSON document.
+func DecodeJSON(data []byte, t Type) ([]byte, error) {
+	v, err := DecodeValue(data, t)
+	if err != nil {
+		return nil, err
+	}
+	return ValueToJSON(v, t)
+}
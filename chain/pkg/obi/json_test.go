@@ -0,0 +1,73 @@
+package obi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	data, err := EncodeJSON([]byte(`{"symbol":"BTC","px":"9000","in":{"a":1,"b":2},"arr":[-1,2]}`),
+		MustParseSchema(MustGetSchema(ExampleData{})))
+	require.NoError(t, err)
+	require.Equal(t, MustEncode(ExampleData{Symbol: "BTC", Px: 9000, In: Inner{A: 1, B: 2}, Arr: []int16{-1, 2}}), data)
+}
+
+func TestEncodeJSONBytes(t *testing.T) {
+	data, err := EncodeJSON([]byte(`["abcd","0x01",""]`), MustParseSchema("[bytes]"))
+	require.NoError(t, err)
+	require.Equal(t, MustEncode([][]byte{{0xab, 0xcd}, {0x01}, {}}), data)
+}
+
+func TestEncodeJSONFail(t *testing.T) {
+	_, err := EncodeJSON([]byte(`256`), MustParseSchema("u8"))
+	require.EqualError(t, err, "obi: invalid u8 value 256")
+	_, err = EncodeJSON([]byte(`1.5`), MustParseSchema("u64"))
+	require.EqualError(t, err, "obi: invalid u64 value 1.5")
+	_, err = EncodeJSON([]byte(`-1`), MustParseSchema("u32"))
+	require.EqualError(t, err, "obi: invalid u32 value -1")
+	_, err = EncodeJSON([]byte(`"zz"`), MustParseSchema("bytes"))
+	require.EqualError(t, err, `obi: invalid hex string "zz"`)
+	_, err = EncodeJSON([]byte(`null`), MustParseSchema("[u8]"))
+	require.EqualError(t, err, "obi: expect array for [u8], got null")
+	_, err = EncodeJSON([]byte(`{"a":1,"c":2}`), MustParseSchema("{a:u8,b:u8}"))
+	require.EqualError(t, err, "obi: unknown field c")
+	_, err = EncodeJSON([]byte(`{"a":1}`), MustParseSchema("{a:u8,b:u8}"))
+	require.EqualError(t, err, "obi: expect 2 fields for {a:u8,b:u8}, got 1")
+}
+
+func TestDecodeJSON(t *testing.T) {
+	data := MustEncode(ExampleData{Symbol: "BTC", Px: 9000, In: Inner{A: 1, B: 2}, Arr: []int16{-1, 2}})
+	js, err := DecodeJSON(data, MustParseSchema(MustGetSchema(ExampleData{})))
+	require.NoError(t, err)
+	require.Equal(t, `{"symbol":"BTC","px":"9000","in":{"a":1,"b":2},"arr":[-1,2]}`, string(js))
+}
+
+func TestDecodeJSONRoundTrip(t *testing.T) {
+	schema := MustParseSchema("{b:bytes,s:[string],x:i64,y:u64}")
+	js := `{"b":"00ff","s":["a\"b",""],"x":"-9223372036854775808","y":"18446744073709551615"}`
+	data, err := EncodeJSON([]byte(js), schema)
+	require.NoError(t, err)
+	out, err := DecodeJSON(data, schema)
+	require.NoError(t, err)
+	require.Equal(t, js, string(out))
+	// 64-bit integers are accepted as numbers too, but always decode to strings.
+	data, err = EncodeJSON([]byte(`{"b":"","s":[],"x":-1,"y":1}`), schema)
+	require.NoError(t, err)
+	out, err = DecodeJSON(data, schema)
+	require.NoError(t, err)
+	require.Equal(t, `{"b":"","s":[],"x":"-1","y":"1"}`, string(out))
+}
+
+func TestJSONExtendedTypes(t *testing.T) {
+	schema := MustParseSchema("{a:bool,b:[u8;2],c:?i64,d:u128,e:?string}")
+	data, err := EncodeJSON([]byte(`{"a":true,"b":[1,2],"c":null,"d":340282366920938463463374607431768211455}`), schema)
+	require.NoError(t, err)
+	out, err := DecodeJSON(data, schema)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":true,"b":[1,2],"c":null,"d":"340282366920938463463374607431768211455","e":null}`, string(out))
+	_, err = EncodeJSON([]byte(`{"a":true,"b":[1,2],"d":"340282366920938463463374607431768211456"}`), schema)
+	require.EqualError(t, err, "obi: value 340282366920938463463374607431768211456 out of range of u128")
+	_, err = EncodeJSON([]byte(`{"a":1,"b":[1,2],"d":0}`), schema)
+	require.EqualError(t, err, "obi: expect bool, got 1")
+}
@@ -0,0 +1,4 @@
+// Package example holds the Go code generated by obigen for its golden tests, so that the
+// generated code is compiled and tested against pkg/obi. Run "go test ./pkg/obi/obigen -update"
+// to regenerate it.
+package example
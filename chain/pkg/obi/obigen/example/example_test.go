@@ -0,0 +1,104 @@
+package example
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+func TestSchemas(t *testing.T) {
+	for _, tc := range []struct {
+		schema        string
+		input, output interface{}
+	}{
+		{PriceSchema, PriceInput{}, PriceOutput{}},
+		{QuoteSchema, QuoteInput{}, QuoteOutput{}},
+		{TradeSchema, TradeInput{}, TradeOutput{}},
+	} {
+		input, output, err := obi.ParseOracleScriptSchema(tc.schema)
+		require.NoError(t, err)
+		require.Equal(t, input.String(), obi.MustGetSchema(tc.input))
+		require.Equal(t, output.String(), obi.MustGetSchema(tc.output))
+	}
+}
+
+// checkRoundTrip checks that the generated encoding of v matches the encoding of the given JSON
+// value against the schema type, and that it decodes back to v.
+func checkRoundTrip(
+	t *testing.T, schema string, js string, v interface{},
+	encode func() ([]byte, error), decode func([]byte) (interface{}, error),
+) {
+	expected, err := obi.EncodeJSON([]byte(js), obi.MustParseSchema(schema))
+	require.NoError(t, err)
+	data, err := encode()
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+	decoded, err := decode(data)
+	require.NoError(t, err)
+	require.Equal(t, v, decoded)
+}
+
+func TestPriceRoundTrip(t *testing.T) {
+	input := PriceInput{
+		Symbols: []string{"BTC", "ETH"}, Multiplier: 100, Salt: [4]uint8{1, 2, 3, 4}, Strict: true, Ratio: big.NewInt(-5),
+	}
+	checkRoundTrip(t, "{symbols:[string],multiplier:u64,salt:[u8;4],strict:bool,ratio:i128}",
+		`{"symbols":["BTC","ETH"],"multiplier":100,"salt":[1,2,3,4],"strict":true,"ratio":-5}`, input,
+		func() ([]byte, error) { return EncodePriceInput(input) },
+		func(data []byte) (interface{}, error) { return DecodePriceInput(data) },
+	)
+	output := PriceOutput{
+		Rates:       []uint64{1, 2},
+		Details:     []PriceOutputDetails{{Symbol: "BTC", Px: big.NewInt(9000), Volume: -1}},
+		RequestHash: []byte{0xab},
+	}
+	checkRoundTrip(t, "{rates:[u64],details:[{symbol:string,px:u256,volume:i32}],request_hash:bytes}",
+		`{"rates":[1,2],"details":[{"symbol":"BTC","px":9000,"volume":-1}],"request_hash":"ab"}`, output,
+		func() ([]byte, error) { return EncodePriceOutput(output) },
+		func(data []byte) (interface{}, error) { return DecodePriceOutput(data) },
+	)
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	minPx := uint64(0)
+	input := QuoteInput{Symbol: "BTC", MinPx: &minPx, Fee: nil}
+	checkRoundTrip(t, "{symbol:string,min_px:?u64,fee:?u128}", `{"symbol":"BTC","min_px":0,"fee":null}`, input,
+		func() ([]byte, error) { return EncodeQuoteInput(input) },
+		func(data []byte) (interface{}, error) { return DecodeQuoteInput(data) },
+	)
+	input = QuoteInput{Symbol: "BTC", MinPx: nil, Fee: big.NewInt(25)}
+	checkRoundTrip(t, "{symbol:string,min_px:?u64,fee:?u128}", `{"symbol":"BTC","min_px":null,"fee":25}`, input,
+		func() ([]byte, error) { return EncodeQuoteInput(input) },
+		func(data []byte) (interface{}, error) { return DecodeQuoteInput(data) },
+	)
+	zero, neg := int16(0), int16(-1)
+	output := QuoteOutput{Px: &QuoteOutputPx{Value: 9000, Ts: 1581589790}, History: []*int16{&zero, nil, &neg}}
+	checkRoundTrip(t, "{px:?{value:u64,ts:i64},history:[?i16]}",
+		`{"px":{"value":9000,"ts":1581589790},"history":[0,null,-1]}`, output,
+		func() ([]byte, error) { return EncodeQuoteOutput(output) },
+		func(data []byte) (interface{}, error) { return DecodeQuoteOutput(data) },
+	)
+}
+
+func TestTradeRoundTrip(t *testing.T) {
+	input := TradeInput{Pair: TradeInputPair{Base: "BTC", Quote: "USD"}, MinPx: nil, Window: [2]uint32{10, 20}}
+	checkRoundTrip(t, "{pair:{base:string,quote:string},min_px:?u64,window:[u32;2]}",
+		`{"pair":{"base":"BTC","quote":"USD"},"min_px":null,"window":[10,20]}`, input,
+		func() ([]byte, error) { return EncodeTradeInput(input) },
+		func(data []byte) (interface{}, error) { return DecodeTradeInput(data) },
+	)
+	output := TradeOutput{
+		Px:      nil,
+		Quotes:  []TradeOutputQuotes{{Pair: TradeOutputQuotesPair{Base: "BTC", Quote: "USD"}, Pxs: []uint64{1, 2}}},
+		Matrix:  [][2]int32{{1, -1}, {2, -2}},
+		Volumes: [][]*big.Int{{big.NewInt(1)}, {}},
+	}
+	checkRoundTrip(t, "{px:?{value:u64,ts:i64},quotes:[{pair:{base:string,quote:string},pxs:[u64]}],matrix:[[i32;2]],volumes:[[u128]]}",
+		`{"px":null,"quotes":[{"pair":{"base":"BTC","quote":"USD"},"pxs":[1,2]}],"matrix":[[1,-1],[2,-2]],"volumes":[[1],[]]}`, output,
+		func() ([]byte, error) { return EncodeTradeOutput(output) },
+		func(data []byte) (interface{}, error) { return DecodeTradeOutput(data) },
+	)
+}
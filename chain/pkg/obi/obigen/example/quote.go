@@ -0,0 +1,55 @@
+// Code generated by obigen. DO NOT EDIT.
+
+package example
+
+import (
+	"math/big"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+// QuoteSchema is the OBI schema of the Quote oracle script.
+const QuoteSchema = "{symbol:string,min_px:?u64,fee:?u128}/{px:?{value:u64,ts:i64},history:[?i16]}"
+
+// QuoteInput is an OBI struct of the Quote oracle script.
+type QuoteInput struct {
+	Symbol string   `obi:"symbol"`
+	MinPx  *uint64  `obi:"min_px"`
+	Fee    *big.Int `obi:"fee,u128,optional"`
+}
+
+// QuoteOutput is an OBI struct of the Quote oracle script.
+type QuoteOutput struct {
+	Px      *QuoteOutputPx `obi:"px"`
+	History []*int16       `obi:"history"`
+}
+
+// QuoteOutputPx is an OBI struct of the Quote oracle script.
+type QuoteOutputPx struct {
+	Value uint64 `obi:"value"`
+	Ts    int64  `obi:"ts"`
+}
+
+// EncodeQuoteInput encodes the given QuoteInput into OBI bytes.
+func EncodeQuoteInput(v QuoteInput) ([]byte, error) {
+	return obi.Encode(v)
+}
+
+// DecodeQuoteInput decodes the given OBI bytes into QuoteInput.
+func DecodeQuoteInput(data []byte) (QuoteInput, error) {
+	var v QuoteInput
+	err := obi.Decode(data, &v)
+	return v, err
+}
+
+// EncodeQuoteOutput encodes the given QuoteOutput into OBI bytes.
+func EncodeQuoteOutput(v QuoteOutput) ([]byte, error) {
+	return obi.Encode(v)
+}
+
+// DecodeQuoteOutput decodes the given OBI bytes into QuoteOutput.
+func DecodeQuoteOutput(data []byte) (QuoteOutput, error) {
+	var v QuoteOutput
+	err := obi.Decode(data, &v)
+	return v, err
+}
@@ -0,0 +1,75 @@
+// Code generated by obigen. DO NOT EDIT.
+
+package example
+
+import (
+	"math/big"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+// TradeSchema is the OBI schema of the Trade oracle script.
+const TradeSchema = "{pair:{base:string,quote:string},min_px:?u64,window:[u32;2]}/{px:?{value:u64,ts:i64},quotes:[{pair:{base:string,quote:string},pxs:[u64]}],matrix:[[i32;2]],volumes:[[u128]]}"
+
+// TradeInput is an OBI struct of the Trade oracle script.
+type TradeInput struct {
+	Pair   TradeInputPair `obi:"pair"`
+	MinPx  *uint64        `obi:"min_px"`
+	Window [2]uint32      `obi:"window"`
+}
+
+// TradeInputPair is an OBI struct of the Trade oracle script.
+type TradeInputPair struct {
+	Base  string `obi:"base"`
+	Quote string `obi:"quote"`
+}
+
+// TradeOutput is an OBI struct of the Trade oracle script.
+type TradeOutput struct {
+	Px      *TradeOutputPx      `obi:"px"`
+	Quotes  []TradeOutputQuotes `obi:"quotes"`
+	Matrix  [][2]int32          `obi:"matrix"`
+	Volumes [][]*big.Int        `obi:"volumes,u128"`
+}
+
+// TradeOutputPx is an OBI struct of the Trade oracle script.
+type TradeOutputPx struct {
+	Value uint64 `obi:"value"`
+	Ts    int64  `obi:"ts"`
+}
+
+// TradeOutputQuotes is an OBI struct of the Trade oracle script.
+type TradeOutputQuotes struct {
+	Pair TradeOutputQuotesPair `obi:"pair"`
+	Pxs  []uint64              `obi:"pxs"`
+}
+
+// TradeOutputQuotesPair is an OBI struct of the Trade oracle script.
+type TradeOutputQuotesPair struct {
+	Base  string `obi:"base"`
+	Quote string `obi:"quote"`
+}
+
+// EncodeTradeInput encodes the given TradeInput into OBI bytes.
+func EncodeTradeInput(v TradeInput) ([]byte, error) {
+	return obi.Encode(v)
+}
+
+// DecodeTradeInput decodes the given OBI bytes into TradeInput.
+func DecodeTradeInput(data []byte) (TradeInput, error) {
+	var v TradeInput
+	err := obi.Decode(data, &v)
+	return v, err
+}
+
+// EncodeTradeOutput encodes the given TradeOutput into OBI bytes.
+func EncodeTradeOutput(v TradeOutput) ([]byte, error) {
+	return obi.Encode(v)
+}
+
+// DecodeTradeOutput decodes the given OBI bytes into TradeOutput.
+func DecodeTradeOutput(data []byte) (TradeOutput, error) {
+	var v TradeOutput
+	err := obi.Decode(data, &v)
+	return v, err
+}
@@ -0,0 +1,109 @@
+package obigen
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+// goType returns the Go type and the obi tag options of a struct field of type t, and whether it
+// uses math/big. Name is the type name used if t is a struct. Big integers are *big.Int with their
+// kind given by tag, which also applies to elements of vectors and arrays. As *big.Int is already
+// a pointer, optional big integers are selected by tag too, so they are only supported as fields.
+func goType(name string, t obi.Type) (string, string, bool, error) {
+	if size, _ := integerSize(t.Kind); size > 64 {
+		return "*big.Int", "," + t.String(), true, nil
+	}
+	switch t.Kind {
+	case obi.KindString:
+		return "string", "", false, nil
+	case obi.KindBytes:
+		return "[]byte", "", false, nil
+	case obi.KindBool:
+		return "bool", "", false, nil
+	case obi.KindVector, obi.KindArray:
+		elem, opts, big, err := goType(name, *t.Elem)
+		if err != nil {
+			return "", "", false, err
+		}
+		if strings.HasSuffix(opts, ",optional") {
+			return "", "", false, fmt.Errorf("obigen: %s is only supported as a struct field in Go", t.Elem)
+		}
+		if t.Kind == obi.KindVector {
+			return "[]" + elem, opts, big, nil
+		}
+		return fmt.Sprintf("[%d]%s", t.Len, elem), opts, big, nil
+	case obi.KindOption:
+		elem, opts, big, err := goType(name, *t.Elem)
+		if err != nil || opts == "" {
+			return "*" + elem, "", big, err
+		}
+		if size, _ := integerSize(t.Elem.Kind); size <= 64 {
+			return "", "", false, fmt.Errorf("obigen: %s is not supported in Go", t)
+		}
+		return elem, opts + ",optional", big, nil
+	case obi.KindStruct:
+		return name, "", false, nil
+	default:
+		// Remaining kinds are integers of at most 64 bits.
+		size, signed := integerSize(t.Kind)
+		if signed {
+			return fmt.Sprintf("int%d", size), "", false, nil
+		}
+		return fmt.Sprintf("uint%d", size), "", false, nil
+	}
+}
+
+func generateGo(schema string, input, output obi.Type, opts Options) ([]byte, error) {
+	structs := append(collectStructs(opts.Name+"Input", input), collectStructs(opts.Name+"Output", output)...)
+	usesBig := false
+	body := &writer{}
+	for _, s := range structs {
+		body.line("")
+		body.line("// %s is an OBI struct of the %s oracle script.", s.name, opts.Name)
+		body.line("type %s struct {", s.name)
+		for _, field := range s.Fields {
+			typ, tagOpts, big, err := goType(s.name+pascalCase(field.Name), field.Type)
+			if err != nil {
+				return nil, err
+			}
+			usesBig = usesBig || big
+			body.line("\t%s %s `obi:\"%s%s\"`", pascalCase(field.Name), typ, field.Name, tagOpts)
+		}
+		body.line("}")
+	}
+	for _, root := range []string{opts.Name + "Input", opts.Name + "Output"} {
+		body.line("")
+		body.line("// Encode%s encodes the given %s into OBI bytes.", root, root)
+		body.line("func Encode%s(v %s) ([]byte, error) {", root, root)
+		body.line("\treturn obi.Encode(v)")
+		body.line("}")
+		body.line("")
+		body.line("// Decode%s decodes the given OBI bytes into %s.", root, root)
+		body.line("func Decode%s(data []byte) (%s, error) {", root, root)
+		body.line("\tvar v %s", root)
+		body.line("\terr := obi.Decode(data, &v)")
+		body.line("\treturn v, err")
+		body.line("}")
+	}
+
+	w := &writer{}
+	w.line("// %s", header)
+	w.line("")
+	w.line("package %s", opts.Package)
+	w.line("")
+	w.line("import (")
+	if usesBig {
+		w.line("\t\"math/big\"")
+		w.line("")
+	}
+	w.line("\t\"github.com/bandprotocol/bandchain/chain/pkg/obi\"")
+	w.line(")")
+	w.line("")
+	w.line("// %sSchema is the OBI schema of the %s oracle script.", opts.Name, opts.Name)
+	w.line("const %sSchema = %q", opts.Name, schema)
+	w.WriteString(body.String())
+	return format.Source([]byte(w.String()))
+}
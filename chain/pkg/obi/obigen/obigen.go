@@ -0,0 +1,163 @@
+// Package obigen generates typed OBI encoding and decoding code for oracle script schemas.
+package obigen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+// Supported target languages.
+const (
+	LangGo         = "go"
+	LangSolidity   = "solidity"
+	LangTypeScript = "typescript"
+)
+
+// header is the first line of every generated file, following the Go convention recognized by tools.
+const header = "Code generated by obigen. DO NOT EDIT."
+
+// Options configures the generated code.
+type Options struct {
+	// Name is the base name of the generated types and functions, such as "Price".
+	Name string
+	// Package is the package name of generated Go code.
+	Package string
+	// ObiImport is the import path of Obi.sol in generated Solidity code.
+	ObiImport string
+}
+
+// DefaultOptions returns the options used when none are given.
+func DefaultOptions() Options {
+	return Options{
+		Name:      "OracleScript",
+		Package:   "oraclescript",
+		ObiImport: "./Obi.sol",
+	}
+}
+
+// Generate generates code in the given language for the oracle script schema in "input/output" format.
+func Generate(lang string, schema string, opts Options) ([]byte, error) {
+	input, output, err := obi.ParseOracleScriptSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	if input.Kind != obi.KindStruct || output.Kind != obi.KindStruct {
+		return nil, fmt.Errorf("obigen: input and output schemas must be structs")
+	}
+	if !isIdentifier(opts.Name) {
+		return nil, fmt.Errorf("obigen: invalid name %q", opts.Name)
+	}
+	switch lang {
+	case LangGo:
+		return generateGo(schema, input, output, opts)
+	case LangSolidity:
+		return generateSolidity(schema, input, output, opts)
+	case LangTypeScript:
+		return generateTypeScript(schema, input, output, opts)
+	default:
+		return nil, fmt.Errorf("obigen: unsupported language %q", lang)
+	}
+}
+
+// namedStruct is a struct type of the schema together with its generated name.
+type namedStruct struct {
+	name string
+	obi.Type
+}
+
+// collectStructs returns the struct types in t, including t itself, in depth-first order. Nested
+// structs are named after their path from the root, e.g. field "details" of "PriceOutput" is
+// named "PriceOutputDetails".
+func collectStructs(name string, t obi.Type) []namedStruct {
+	res := []namedStruct{}
+	var walk func(name string, t obi.Type)
+	walk = func(name string, t obi.Type) {
+		switch t.Kind {
+		case obi.KindStruct:
+			res = append(res, namedStruct{name: name, Type: t})
+			for _, field := range t.Fields {
+				walk(name+pascalCase(field.Name), field.Type)
+			}
+		case obi.KindVector, obi.KindArray, obi.KindOption:
+			walk(name, *t.Elem)
+		}
+	}
+	walk(name, t)
+	return res
+}
+
+// pascalCase converts snake_case or camelCase names to PascalCase.
+func pascalCase(name string) string {
+	s := &strings.Builder{}
+	for _, part := range strings.Split(name, "_") {
+		if part != "" {
+			s.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return s.String()
+}
+
+func isIdentifier(name string) bool {
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// integerSize returns the size in bits and signedness of the integer kind, or 0 if not an integer.
+func integerSize(kind obi.Kind) (int, bool) {
+	switch kind {
+	case obi.KindU8:
+		return 8, false
+	case obi.KindU16:
+		return 16, false
+	case obi.KindU32:
+		return 32, false
+	case obi.KindU64:
+		return 64, false
+	case obi.KindU128:
+		return 128, false
+	case obi.KindU256:
+		return 256, false
+	case obi.KindI8:
+		return 8, true
+	case obi.KindI16:
+		return 16, true
+	case obi.KindI32:
+		return 32, true
+	case obi.KindI64:
+		return 64, true
+	case obi.KindI128:
+		return 128, true
+	case obi.KindI256:
+		return 256, true
+	default:
+		return 0, false
+	}
+}
+
+// writer accumulates indented lines of generated code.
+type writer struct {
+	strings.Builder
+	indent string
+}
+
+func (w *writer) line(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	if line != "" {
+		w.WriteString(w.indent)
+		w.WriteString(line)
+	}
+	w.WriteString("\n")
+}
+
+func (w *writer) in(unit string) { w.indent += unit }
+
+func (w *writer) out(unit string) { w.indent = w.indent[:len(w.indent)-len(unit)] }
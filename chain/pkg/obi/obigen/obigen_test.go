@@ -0,0 +1,77 @@
+package obigen
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const (
+	exampleSchema  = "{symbols:[string],multiplier:u64,salt:[u8;4],strict:bool,ratio:i128}/{rates:[u64],details:[{symbol:string,px:u256,volume:i32}],request_hash:bytes}"
+	optionalSchema = "{symbol:string,min_px:?u64,fee:?u128}/{px:?{value:u64,ts:i64},history:[?i16]}"
+	nestedSchema   = "{pair:{base:string,quote:string},min_px:?u64,window:[u32;2]}/{px:?{value:u64,ts:i64},quotes:[{pair:{base:string,quote:string},pxs:[u64]}],matrix:[[i32;2]],volumes:[[u128]]}"
+)
+
+// checkGolden compares the generated code with the golden file at the given path. Go code is
+// checked against the example package, which compiles it and tests it against pkg/obi.
+func checkGolden(t *testing.T, lang, schema, name, path string) {
+	opts := DefaultOptions()
+	opts.Name = name
+	opts.Package = "example"
+	code, err := Generate(lang, schema, opts)
+	require.NoError(t, err)
+	if *update {
+		require.NoError(t, ioutil.WriteFile(path, code, 0644))
+	}
+	expected, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(code))
+}
+
+func TestGenerateGo(t *testing.T) {
+	checkGolden(t, LangGo, exampleSchema, "Price", filepath.Join("example", "price.go"))
+	checkGolden(t, LangGo, optionalSchema, "Quote", filepath.Join("example", "quote.go"))
+	checkGolden(t, LangGo, nestedSchema, "Trade", filepath.Join("example", "trade.go"))
+}
+
+func TestGenerateSolidity(t *testing.T) {
+	checkGolden(t, LangSolidity, exampleSchema, "Price", filepath.Join("testdata", "example.sol.golden"))
+	checkGolden(t, LangSolidity, nestedSchema, "Trade", filepath.Join("testdata", "nested.sol.golden"))
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	checkGolden(t, LangTypeScript, exampleSchema, "Price", filepath.Join("testdata", "example.ts.golden"))
+	checkGolden(t, LangTypeScript, optionalSchema, "Quote", filepath.Join("testdata", "optional.ts.golden"))
+	checkGolden(t, LangTypeScript, nestedSchema, "Trade", filepath.Join("testdata", "nested.ts.golden"))
+}
+
+func TestGenerateFail(t *testing.T) {
+	opts := DefaultOptions()
+	for _, tc := range []struct {
+		lang   string
+		schema string
+		name   string
+		err    string
+	}{
+		{LangGo, "{a:u8}", "Price", "obi: oracle script schema must be in input/output format"},
+		{LangGo, "u8/{a:u8}", "Price", "obigen: input and output schemas must be structs"},
+		{LangGo, "{a:u8}/{b:u8}", "1Price", `obigen: invalid name "1Price"`},
+		{"rust", "{a:u8}/{b:u8}", "Price", `obigen: unsupported language "rust"`},
+		{LangGo, "{a:?[u128]}/{b:u8}", "Price", "obigen: ?[u128] is not supported in Go"},
+		{LangGo, "{a:??u128}/{b:u8}", "Price", "obigen: ??u128 is not supported in Go"},
+		{LangGo, "{a:[?u256]}/{b:u8}", "Price", "obigen: ?u256 is only supported as a struct field in Go"},
+		{LangSolidity, optionalSchema, "Quote", "obigen: ?i16 is only supported as a struct field in Solidity"},
+		{LangSolidity, "{a:??u8}/{b:u8}", "Price", "obigen: ?u8 is only supported as a struct field in Solidity"},
+		{LangSolidity, "{a:?u8,has_a:bool}/{b:u8}", "Price", "obigen: field has_a of Input conflicts with the flag of optional field a"},
+		{LangSolidity, "{a:{}}/{b:u8}", "Price", "obigen: empty struct InputA is not supported in Solidity"},
+	} {
+		opts.Name = tc.name
+		_, err := Generate(tc.lang, tc.schema, opts)
+		require.EqualError(t, err, tc.err, tc.schema)
+	}
+}
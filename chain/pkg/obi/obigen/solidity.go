@@ -0,0 +1,303 @@
+package obigen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+const solIndent = "    "
+
+// solidityType returns the Solidity type of a value of type t. Name is the type name used if t is
+// a struct. Optionals have no natural Solidity counterpart, so only optional struct fields are
+// supported, as the value together with a "has_" flag field.
+func solidityType(name string, t obi.Type) (string, error) {
+	if size, signed := integerSize(t.Kind); size != 0 {
+		if signed {
+			return fmt.Sprintf("int%d", size), nil
+		}
+		return fmt.Sprintf("uint%d", size), nil
+	}
+	switch t.Kind {
+	case obi.KindString:
+		return "string", nil
+	case obi.KindBytes:
+		return "bytes", nil
+	case obi.KindBool:
+		return "bool", nil
+	case obi.KindVector:
+		elem, err := solidityType(name, *t.Elem)
+		return elem + "[]", err
+	case obi.KindArray:
+		elem, err := solidityType(name, *t.Elem)
+		return fmt.Sprintf("%s[%d]", elem, t.Len), err
+	case obi.KindStruct:
+		return name, nil
+	case obi.KindOption:
+		return "", fmt.Errorf("obigen: %s is only supported as a struct field in Solidity", t)
+	default:
+		return "", fmt.Errorf("obigen: %s is not supported in Solidity", t)
+	}
+}
+
+// solidityFlag returns the name of the flag field telling whether the given optional field is
+// present, or an error if the struct already has a field of that name.
+func solidityFlag(s namedStruct, field obi.Field) (string, error) {
+	flag := "has_" + field.Name
+	if _, ok := s.FieldByName(flag); ok {
+		return "", fmt.Errorf("obigen: field %s of %s conflicts with the flag of optional field %s", flag, s.name, field.Name)
+	}
+	return flag, nil
+}
+
+// solidityFunc generates the body of a decode or encode function, numbering loop variables.
+type solidityFunc struct {
+	*writer
+	vars int
+}
+
+func (f *solidityFunc) newVar() int {
+	f.vars++
+	return f.vars - 1
+}
+
+// decodeValue generates statements that decode a value of type t into the given lvalue.
+func (f *solidityFunc) decodeValue(lvalue, name string, t obi.Type) error {
+	if size, signed := integerSize(t.Kind); size != 0 {
+		prefix := "U"
+		if signed {
+			prefix = "I"
+		}
+		f.line("%s = data.decode%s%d();", lvalue, prefix, size)
+		return nil
+	}
+	switch t.Kind {
+	case obi.KindString:
+		f.line("%s = data.decodeString();", lvalue)
+	case obi.KindBytes:
+		f.line("%s = data.decodeBytes();", lvalue)
+	case obi.KindBool:
+		f.line("%s = data.decodeBool();", lvalue)
+	case obi.KindStruct:
+		f.line("%s = _decode%s(data);", lvalue, name)
+	case obi.KindVector:
+		elem, err := solidityType(name, *t.Elem)
+		if err != nil {
+			return err
+		}
+		v := f.newVar()
+		f.line("uint32 length%d = data.decodeU32();", v)
+		f.line("%s = new %s[](length%d);", lvalue, elem, v)
+		f.line("for (uint256 i%d = 0; i%d < length%d; i%d++) {", v, v, v, v)
+		f.in(solIndent)
+		if err := f.decodeValue(fmt.Sprintf("%s[i%d]", lvalue, v), name, *t.Elem); err != nil {
+			return err
+		}
+		f.out(solIndent)
+		f.line("}")
+	case obi.KindArray:
+		v := f.newVar()
+		f.line("for (uint256 i%d = 0; i%d < %d; i%d++) {", v, v, t.Len, v)
+		f.in(solIndent)
+		if err := f.decodeValue(fmt.Sprintf("%s[i%d]", lvalue, v), name, *t.Elem); err != nil {
+			return err
+		}
+		f.out(solIndent)
+		f.line("}")
+	default:
+		return fmt.Errorf("obigen: %s is not supported in Solidity", t)
+	}
+	return nil
+}
+
+// decodeField generates statements that decode the given field of the struct into result.
+func (f *solidityFunc) decodeField(s namedStruct, field obi.Field) error {
+	name := s.name + pascalCase(field.Name)
+	if field.Type.Kind != obi.KindOption {
+		return f.decodeValue("result."+field.Name, name, field.Type)
+	}
+	flag, err := solidityFlag(s, field)
+	if err != nil {
+		return err
+	}
+	f.line("result.%s = data.decodeBool();", flag)
+	f.line("if (result.%s) {", flag)
+	f.in(solIndent)
+	if err := f.decodeValue("result."+field.Name, name, *field.Type.Elem); err != nil {
+		return err
+	}
+	f.out(solIndent)
+	f.line("}")
+	return nil
+}
+
+// encodeField generates statements that append the encoding of the given field of value to result.
+func (f *solidityFunc) encodeField(s namedStruct, field obi.Field) error {
+	name := s.name + pascalCase(field.Name)
+	if field.Type.Kind != obi.KindOption {
+		return f.encodeValue("value."+field.Name, name, field.Type)
+	}
+	flag, err := solidityFlag(s, field)
+	if err != nil {
+		return err
+	}
+	f.line("result = abi.encodePacked(result, value.%s);", flag)
+	f.line("if (value.%s) {", flag)
+	f.in(solIndent)
+	if err := f.encodeValue("value."+field.Name, name, *field.Type.Elem); err != nil {
+		return err
+	}
+	f.out(solIndent)
+	f.line("}")
+	return nil
+}
+
+// encodeValue generates statements that append the encoding of the given value to result.
+func (f *solidityFunc) encodeValue(value, name string, t obi.Type) error {
+	if size, _ := integerSize(t.Kind); size != 0 {
+		f.line("result = abi.encodePacked(result, %s);", value)
+		return nil
+	}
+	switch t.Kind {
+	case obi.KindString:
+		f.line("result = abi.encodePacked(result, uint32(bytes(%s).length), %s);", value, value)
+	case obi.KindBytes:
+		f.line("result = abi.encodePacked(result, uint32(%s.length), %s);", value, value)
+	case obi.KindBool:
+		f.line("result = abi.encodePacked(result, %s);", value)
+	case obi.KindStruct:
+		f.line("result = abi.encodePacked(result, _encode%s(%s));", name, value)
+	case obi.KindVector, obi.KindArray:
+		v := f.newVar()
+		length := fmt.Sprintf("%s.length", value)
+		if t.Kind == obi.KindVector {
+			f.line("result = abi.encodePacked(result, uint32(%s));", length)
+		} else {
+			length = fmt.Sprint(t.Len)
+		}
+		f.line("for (uint256 i%d = 0; i%d < %s; i%d++) {", v, v, length, v)
+		f.in(solIndent)
+		if err := f.encodeValue(fmt.Sprintf("%s[i%d]", value, v), name, *t.Elem); err != nil {
+			return err
+		}
+		f.out(solIndent)
+		f.line("}")
+	default:
+		return fmt.Errorf("obigen: %s is not supported in Solidity", t)
+	}
+	return nil
+}
+
+func generateSolidity(schema string, input, output obi.Type, opts Options) ([]byte, error) {
+	w := &writer{}
+	w.line("// SPDX-License-Identifier: Apache-2.0")
+	w.line("// %s", header)
+	w.line("")
+	w.line("pragma solidity 0.6.11;")
+	w.line("")
+	w.line("import %q;", opts.ObiImport)
+	w.line("")
+	w.line("/// @dev OBI encoding and decoding of the %s oracle script with schema", opts.Name)
+	w.line("/// %s", schema)
+	w.line("library %sObi {", opts.Name)
+	w.in(solIndent)
+	w.line("using Obi for Obi.Data;")
+
+	structs := append(collectStructs("Input", input), collectStructs("Output", output)...)
+	for _, s := range structs {
+		w.line("")
+		w.line("struct %s {", s.name)
+		w.in(solIndent)
+		if len(s.Fields) == 0 {
+			return nil, fmt.Errorf("obigen: empty struct %s is not supported in Solidity", s.name)
+		}
+		for _, field := range s.Fields {
+			t := field.Type
+			if t.Kind == obi.KindOption {
+				flag, err := solidityFlag(s, field)
+				if err != nil {
+					return nil, err
+				}
+				w.line("bool %s;", flag)
+				t = *t.Elem
+			}
+			typ, err := solidityType(s.name+pascalCase(field.Name), t)
+			if err != nil {
+				return nil, err
+			}
+			w.line("%s %s;", typ, field.Name)
+		}
+		w.out(solIndent)
+		w.line("}")
+	}
+
+	for _, root := range []string{"Input", "Output"} {
+		w.line("")
+		w.line("function decode%s(bytes memory _data)", root)
+		w.in(solIndent)
+		w.line("internal")
+		w.line("pure")
+		w.line("returns (%s memory result)", root)
+		w.out(solIndent)
+		w.line("{")
+		w.in(solIndent)
+		w.line("Obi.Data memory data = Obi.from(_data);")
+		w.line("result = _decode%s(data);", root)
+		w.line("require(data.finished(), \"Obi: Not all data was consumed\");")
+		w.out(solIndent)
+		w.line("}")
+		w.line("")
+		w.line("function encode%s(%s memory value)", root, root)
+		w.in(solIndent)
+		w.line("internal")
+		w.line("pure")
+		w.line("returns (bytes memory)")
+		w.out(solIndent)
+		w.line("{")
+		w.in(solIndent)
+		w.line("return _encode%s(value);", root)
+		w.out(solIndent)
+		w.line("}")
+	}
+
+	for _, s := range structs {
+		w.line("")
+		w.line("function _decode%s(Obi.Data memory data)", s.name)
+		w.in(solIndent)
+		w.line("private")
+		w.line("pure")
+		w.line("returns (%s memory result)", s.name)
+		w.out(solIndent)
+		w.line("{")
+		w.in(solIndent)
+		f := &solidityFunc{writer: w}
+		for _, field := range s.Fields {
+			if err := f.decodeField(s, field); err != nil {
+				return nil, err
+			}
+		}
+		w.out(solIndent)
+		w.line("}")
+		w.line("")
+		w.line("function _encode%s(%s memory value)", s.name, s.name)
+		w.in(solIndent)
+		w.line("private")
+		w.line("pure")
+		w.line("returns (bytes memory result)")
+		w.out(solIndent)
+		w.line("{")
+		w.in(solIndent)
+		f = &solidityFunc{writer: w}
+		for _, field := range s.Fields {
+			if err := f.encodeField(s, field); err != nil {
+				return nil, err
+			}
+		}
+		w.out(solIndent)
+		w.line("}")
+	}
+	w.out(solIndent)
+	w.line("}")
+	return []byte(strings.TrimLeft(w.String(), "\n")), nil
+}
@@ -0,0 +1,288 @@
+package obigen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+)
+
+const tsIndent = "  "
+
+// tsRuntime is the minimal OBI reader and writer embedded in generated TypeScript code, so that
+// the generated module has no dependencies other than Node's Buffer.
+const tsRuntime = `class ObiWriter {
+  private chunks: Buffer[] = []
+
+  writeInteger(value: number | bigint, size: number, signed: boolean): void {
+    const bits = BigInt(size * 8)
+    const one = BigInt(1)
+    const min = signed ? -(one << (bits - one)) : BigInt(0)
+    const max = (signed ? one << (bits - one) : one << bits) - one
+    let v = BigInt(value)
+    if (v < min || v > max) {
+      throw new Error(` + "`Obi: value ${value} out of range`" + `)
+    }
+    if (v < BigInt(0)) {
+      v += one << bits
+    }
+    const buff = Buffer.alloc(size)
+    for (let i = size - 1; i >= 0; i--) {
+      buff[i] = Number(v & BigInt(0xff))
+      v >>= BigInt(8)
+    }
+    this.chunks.push(buff)
+  }
+
+  writeBool(value: boolean): void {
+    this.chunks.push(Buffer.from([value ? 1 : 0]))
+  }
+
+  writeBytes(value: Buffer): void {
+    this.writeInteger(value.length, 4, false)
+    this.chunks.push(value)
+  }
+
+  writeString(value: string): void {
+    this.writeBytes(Buffer.from(value, 'utf8'))
+  }
+
+  writeVector<T>(value: T[], writeItem: (item: T) => void): void {
+    this.writeInteger(value.length, 4, false)
+    value.forEach(writeItem)
+  }
+
+  writeArray<T>(value: T[], length: number, writeItem: (item: T) => void): void {
+    if (value.length !== length) {
+      throw new Error(` + "`Obi: expect ${length} elements, got ${value.length}`" + `)
+    }
+    value.forEach(writeItem)
+  }
+
+  writeOption<T>(value: T | null, writeValue: (value: T) => void): void {
+    this.writeBool(value !== null)
+    if (value !== null) {
+      writeValue(value)
+    }
+  }
+
+  finish(): Buffer {
+    return Buffer.concat(this.chunks)
+  }
+}
+
+class ObiReader {
+  private readonly data: Buffer
+  private offset = 0
+
+  constructor(data: Buffer) {
+    this.data = data
+  }
+
+  private take(size: number): Buffer {
+    if (this.offset + size > this.data.length) {
+      throw new Error('Obi: out of range')
+    }
+    const res = this.data.slice(this.offset, this.offset + size)
+    this.offset += size
+    return res
+  }
+
+  readBigInteger(size: number, signed: boolean): bigint {
+    const bits = BigInt(size * 8)
+    const one = BigInt(1)
+    let v = BigInt(0)
+    for (const b of this.take(size)) {
+      v = (v << BigInt(8)) | BigInt(b)
+    }
+    if (signed && v >= one << (bits - one)) {
+      v -= one << bits
+    }
+    return v
+  }
+
+  readInteger(size: number, signed: boolean): number {
+    return Number(this.readBigInteger(size, signed))
+  }
+
+  readBool(): boolean {
+    const b = this.take(1)[0]
+    if (b > 1) {
+      throw new Error(` + "`Obi: invalid bool value ${b}`" + `)
+    }
+    return b === 1
+  }
+
+  readBytes(): Buffer {
+    return Buffer.from(this.take(this.readInteger(4, false)))
+  }
+
+  readString(): string {
+    return this.readBytes().toString('utf8')
+  }
+
+  readVector<T>(readItem: () => T): T[] {
+    return this.readArray(this.readInteger(4, false), readItem)
+  }
+
+  readArray<T>(length: number, readItem: () => T): T[] {
+    const res: T[] = []
+    for (let i = 0; i < length; i++) {
+      res.push(readItem())
+    }
+    return res
+  }
+
+  readOption<T>(readValue: () => T): T | null {
+    return this.readBool() ? readValue() : null
+  }
+
+  finish(): void {
+    if (this.offset !== this.data.length) {
+      throw new Error('Obi: not all data was consumed')
+    }
+  }
+}
+`
+
+// tsType returns the TypeScript type of a value of type t. Name is the type name used if t is a
+// struct. Integers of up to 32 bits are numbers and larger integers are bigints.
+func tsType(name string, t obi.Type) string {
+	if size, _ := integerSize(t.Kind); size != 0 {
+		if size <= 32 {
+			return "number"
+		}
+		return "bigint"
+	}
+	switch t.Kind {
+	case obi.KindString:
+		return "string"
+	case obi.KindBytes:
+		return "Buffer"
+	case obi.KindBool:
+		return "boolean"
+	case obi.KindVector, obi.KindArray:
+		elem := tsType(name, *t.Elem)
+		if t.Elem.Kind == obi.KindOption {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case obi.KindOption:
+		return tsType(name, *t.Elem) + " | null"
+	default:
+		return name
+	}
+}
+
+// tsWrite returns the statement writing the given value of type t with writer w.
+func tsWrite(value, name string, t obi.Type, depth int) string {
+	if size, signed := integerSize(t.Kind); size != 0 {
+		return fmt.Sprintf("w.writeInteger(%s, %d, %t)", value, size/8, signed)
+	}
+	item := fmt.Sprintf("item%d", depth)
+	switch t.Kind {
+	case obi.KindString:
+		return fmt.Sprintf("w.writeString(%s)", value)
+	case obi.KindBytes:
+		return fmt.Sprintf("w.writeBytes(%s)", value)
+	case obi.KindBool:
+		return fmt.Sprintf("w.writeBool(%s)", value)
+	case obi.KindVector:
+		return fmt.Sprintf("w.writeVector(%s, (%s) => %s)", value, item, tsWrite(item, name, *t.Elem, depth+1))
+	case obi.KindArray:
+		return fmt.Sprintf("w.writeArray(%s, %d, (%s) => %s)", value, t.Len, item, tsWrite(item, name, *t.Elem, depth+1))
+	case obi.KindOption:
+		return fmt.Sprintf("w.writeOption(%s, (%s) => %s)", value, item, tsWrite(item, name, *t.Elem, depth+1))
+	default:
+		return fmt.Sprintf("write%s(w, %s)", name, value)
+	}
+}
+
+// tsRead returns the expression reading a value of type t with reader r.
+func tsRead(name string, t obi.Type) string {
+	if size, signed := integerSize(t.Kind); size != 0 {
+		if size <= 32 {
+			return fmt.Sprintf("r.readInteger(%d, %t)", size/8, signed)
+		}
+		return fmt.Sprintf("r.readBigInteger(%d, %t)", size/8, signed)
+	}
+	switch t.Kind {
+	case obi.KindString:
+		return "r.readString()"
+	case obi.KindBytes:
+		return "r.readBytes()"
+	case obi.KindBool:
+		return "r.readBool()"
+	case obi.KindVector:
+		return fmt.Sprintf("r.readVector(() => %s)", tsRead(name, *t.Elem))
+	case obi.KindArray:
+		return fmt.Sprintf("r.readArray(%d, () => %s)", t.Len, tsRead(name, *t.Elem))
+	case obi.KindOption:
+		return fmt.Sprintf("r.readOption(() => %s)", tsRead(name, *t.Elem))
+	default:
+		return fmt.Sprintf("read%s(r)", name)
+	}
+}
+
+func generateTypeScript(schema string, input, output obi.Type, opts Options) ([]byte, error) {
+	w := &writer{}
+	w.line("// %s", header)
+	w.line("")
+	w.line("export const %sSchema = '%s'", strings.ToLower(opts.Name[:1])+opts.Name[1:], schema)
+	w.line("")
+	w.WriteString(tsRuntime)
+
+	structs := append(collectStructs(opts.Name+"Input", input), collectStructs(opts.Name+"Output", output)...)
+	for _, s := range structs {
+		w.line("")
+		w.line("export interface %s {", s.name)
+		w.in(tsIndent)
+		for _, field := range s.Fields {
+			w.line("%s: %s", field.Name, tsType(s.name+pascalCase(field.Name), field.Type))
+		}
+		w.out(tsIndent)
+		w.line("}")
+	}
+	for _, s := range structs {
+		w.line("")
+		w.line("function write%s(w: ObiWriter, value: %s): void {", s.name, s.name)
+		w.in(tsIndent)
+		for _, field := range s.Fields {
+			w.line("%s", tsWrite("value."+field.Name, s.name+pascalCase(field.Name), field.Type, 0))
+		}
+		w.out(tsIndent)
+		w.line("}")
+		w.line("")
+		w.line("function read%s(r: ObiReader): %s {", s.name, s.name)
+		w.in(tsIndent)
+		w.line("return {")
+		w.in(tsIndent)
+		for _, field := range s.Fields {
+			w.line("%s: %s,", field.Name, tsRead(s.name+pascalCase(field.Name), field.Type))
+		}
+		w.out(tsIndent)
+		w.line("}")
+		w.out(tsIndent)
+		w.line("}")
+	}
+	for _, root := range []string{opts.Name + "Input", opts.Name + "Output"} {
+		w.line("")
+		w.line("export function encode%s(value: %s): Buffer {", root, root)
+		w.in(tsIndent)
+		w.line("const w = new ObiWriter()")
+		w.line("write%s(w, value)", root)
+		w.line("return w.finish()")
+		w.out(tsIndent)
+		w.line("}")
+		w.line("")
+		w.line("export function decode%s(data: Buffer): %s {", root, root)
+		w.in(tsIndent)
+		w.line("const r = new ObiReader(data)")
+		w.line("const value = read%s(r)", root)
+		w.line("r.finish()")
+		w.line("return value")
+		w.out(tsIndent)
+		w.line("}")
+	}
+	return []byte(w.String()), nil
+}
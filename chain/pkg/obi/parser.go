@@ -0,0 +1,272 @@
+package obi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an OBI schema type.
+type Kind int
+
+const (
+	KindU8 Kind = iota
+	KindU16
+	KindU32
+	KindU64
+	KindI8
+	KindI16
+	KindI32
+	KindI64
+	KindString
+	KindBytes
+	KindVector
+	KindStruct
+	KindBool
+	KindU128
+	KindU256
+	KindI128
+	KindI256
+	KindArray
+	KindOption
+)
+
+var primitiveNames = map[Kind]string{
+	KindU8:     "u8",
+	KindU16:    "u16",
+	KindU32:    "u32",
+	KindU64:    "u64",
+	KindI8:     "i8",
+	KindI16:    "i16",
+	KindI32:    "i32",
+	KindI64:    "i64",
+	KindString: "string",
+	KindBytes:  "bytes",
+	KindBool:   "bool",
+	KindU128:   "u128",
+	KindU256:   "u256",
+	KindI128:   "i128",
+	KindI256:   "i256",
+}
+
+var primitiveKinds = make(map[string]Kind)
+
+func init() {
+	for kind, name := range primitiveNames {
+		primitiveKinds[name] = kind
+	}
+}
+
+// Type is a node of a parsed OBI schema. Elem is set for vectors, fixed-length arrays and
+// optionals, Len for fixed-length arrays, and Fields for structs.
+type Type struct {
+	Kind   Kind
+	Elem   *Type
+	Len    int
+	Fields []Field
+}
+
+// Field is a named member of an OBI struct type.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// String returns the compact OBI schema of the type, in the same format as GetSchema.
+func (t Type) String() string {
+	s := &strings.Builder{}
+	t.writeSchema(s)
+	return s.String()
+}
+
+func (t Type) writeSchema(s *strings.Builder) {
+	switch t.Kind {
+	case KindVector:
+		s.WriteString("[")
+		t.Elem.writeSchema(s)
+		s.WriteString("]")
+	case KindArray:
+		s.WriteString("[")
+		t.Elem.writeSchema(s)
+		s.WriteString(";")
+		s.WriteString(strconv.Itoa(t.Len))
+		s.WriteString("]")
+	case KindOption:
+		s.WriteString("?")
+		t.Elem.writeSchema(s)
+	case KindStruct:
+		s.WriteString("{")
+		for idx, field := range t.Fields {
+			if idx != 0 {
+				s.WriteString(",")
+			}
+			s.WriteString(field.Name)
+			s.WriteString(":")
+			field.Type.writeSchema(s)
+		}
+		s.WriteString("}")
+	default:
+		s.WriteString(primitiveNames[t.Kind])
+	}
+}
+
+// FieldByName returns the type of the struct field with the given name.
+func (t Type) FieldByName(name string) (Type, bool) {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field.Type, true
+		}
+	}
+	return Type{}, false
+}
+
+type schemaParser struct {
+	schema string
+	pos    int
+}
+
+func (p *schemaParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("obi: invalid schema at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *schemaParser) peek() byte {
+	if p.pos >= len(p.schema) {
+		return 0
+	}
+	return p.schema[p.pos]
+}
+
+func (p *schemaParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expect '%c'", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *schemaParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.schema) {
+		c := p.schema[p.pos]
+		if !(c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')) {
+			break
+		}
+		p.pos++
+	}
+	return p.schema[start:p.pos]
+}
+
+func (p *schemaParser) parseType() (Type, error) {
+	switch p.peek() {
+	case '[':
+		p.pos++
+		elem, err := p.parseType()
+		if err != nil {
+			return Type{}, err
+		}
+		if p.peek() == ';' {
+			p.pos++
+			length, err := strconv.Atoi(p.ident())
+			if err != nil || length < 0 {
+				return Type{}, p.errorf("expect array length")
+			}
+			if err := p.expect(']'); err != nil {
+				return Type{}, err
+			}
+			return Type{Kind: KindArray, Elem: &elem, Len: length}, nil
+		}
+		if err := p.expect(']'); err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: KindVector, Elem: &elem}, nil
+	case '?':
+		p.pos++
+		elem, err := p.parseType()
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: KindOption, Elem: &elem}, nil
+	case '{':
+		p.pos++
+		fields := []Field{}
+		names := make(map[string]bool)
+		// An empty struct has no fields and encodes to no bytes.
+		if p.peek() != '}' {
+			for {
+				name := p.ident()
+				if name == "" {
+					return Type{}, p.errorf("expect field name")
+				}
+				if names[name] {
+					return Type{}, p.errorf("duplicate field %s", name)
+				}
+				names[name] = true
+				if err := p.expect(':'); err != nil {
+					return Type{}, err
+				}
+				fieldType, err := p.parseType()
+				if err != nil {
+					return Type{}, err
+				}
+				fields = append(fields, Field{Name: name, Type: fieldType})
+				if p.peek() != ',' {
+					break
+				}
+				p.pos++
+			}
+		}
+		if err := p.expect('}'); err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: KindStruct, Fields: fields}, nil
+	default:
+		name := p.ident()
+		kind, ok := primitiveKinds[name]
+		if !ok {
+			return Type{}, p.errorf("unknown type %q", name)
+		}
+		return Type{Kind: kind}, nil
+	}
+}
+
+// ParseSchema parses the given compact OBI schema of an individual type, such as
+// "{symbols:[string],multiplier:u64}". Whitespace is not allowed.
+func ParseSchema(schema string) (Type, error) {
+	p := &schemaParser{schema: schema}
+	t, err := p.parseType()
+	if err != nil {
+		return Type{}, err
+	}
+	if p.pos != len(schema) {
+		return Type{}, p.errorf("unexpected trailing characters")
+	}
+	return t, nil
+}
+
+// MustParseSchema parses the given compact OBI schema. Panics on error.
+func MustParseSchema(schema string) Type {
+	t, err := ParseSchema(schema)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ParseOracleScriptSchema parses the schema of an oracle script in "input/output" format and
+// returns the input and the output types.
+func ParseOracleScriptSchema(schema string) (Type, Type, error) {
+	parts := strings.Split(schema, "/")
+	if len(parts) != 2 {
+		return Type{}, Type{}, errors.New("obi: oracle script schema must be in input/output format")
+	}
+	input, err := ParseSchema(parts[0])
+	if err != nil {
+		return Type{}, Type{}, err
+	}
+	output, err := ParseSchema(parts[1])
+	if err != nil {
+		return Type{}, Type{}, err
+	}
+	return input, output, nil
+}
@@ -0,0 +1,79 @@
+package obi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchemaPrimitive(t *testing.T) {
+	for _, schema := range []string{"u8", "u16", "u32", "u64", "i8", "i16", "i32", "i64", "string", "bytes"} {
+		require.Equal(t, schema, MustParseSchema(schema).String())
+	}
+}
+
+func TestParseSchemaNested(t *testing.T) {
+	schema := "{symbol:string,px:u64,in:{a:u8,b:u8},arr:[i16]}"
+	parsed := MustParseSchema(schema)
+	require.Equal(t, KindStruct, parsed.Kind)
+	require.Len(t, parsed.Fields, 4)
+	require.Equal(t, "in", parsed.Fields[2].Name)
+	require.Equal(t, KindStruct, parsed.Fields[2].Type.Kind)
+	arr, ok := parsed.FieldByName("arr")
+	require.True(t, ok)
+	require.Equal(t, KindVector, arr.Kind)
+	require.Equal(t, KindI16, arr.Elem.Kind)
+	require.Equal(t, schema, parsed.String())
+	require.Equal(t, "[{}]", MustParseSchema("[{}]").String())
+	// GetSchema and ParseSchema must agree on the same type.
+	require.Equal(t, MustGetSchema(ExampleData{}), MustParseSchema(MustGetSchema(ExampleData{})).String())
+}
+
+func TestParseSchemaFail(t *testing.T) {
+	for schema, msg := range map[string]string{
+		"":                  `obi: invalid schema at position 0: unknown type ""`,
+		"u65":               `obi: invalid schema at position 3: unknown type "u65"`,
+		"[u8":               "obi: invalid schema at position 3: expect ']'",
+		"{a:u8,}":           "obi: invalid schema at position 6: expect field name",
+		"{a u8}":            "obi: invalid schema at position 2: expect ':'",
+		"{a:u8,a:u16}":      "obi: invalid schema at position 7: duplicate field a",
+		"{a:u8}}":           "obi: invalid schema at position 6: unexpected trailing characters",
+		"{a:u8, b:u8}":      "obi: invalid schema at position 6: expect field name",
+		"{symbols:[string]": "obi: invalid schema at position 17: expect '}'",
+	} {
+		_, err := ParseSchema(schema)
+		require.EqualError(t, err, msg, schema)
+	}
+}
+
+func TestParseOracleScriptSchema(t *testing.T) {
+	input, output, err := ParseOracleScriptSchema("{symbol:string,multiplier:u8}/{px:u64}")
+	require.NoError(t, err)
+	require.Equal(t, "{symbol:string,multiplier:u8}", input.String())
+	require.Equal(t, "{px:u64}", output.String())
+	_, _, err = ParseOracleScriptSchema("{symbol:string}")
+	require.EqualError(t, err, "obi: oracle script schema must be in input/output format")
+	_, _, err = ParseOracleScriptSchema("{symbol:string}/{px:u64")
+	require.EqualError(t, err, "obi: invalid schema at position 7: expect '}'")
+}
+
+func TestParseSchemaExtended(t *testing.T) {
+	for _, schema := range []string{"bool", "u128", "u256", "i128", "i256", "[u8;32]", "?u64", "[?{a:bool,b:[i256;0]};3]", "??string"} {
+		require.Equal(t, schema, MustParseSchema(schema).String())
+	}
+	arr := MustParseSchema("[u8;32]")
+	require.Equal(t, KindArray, arr.Kind)
+	require.Equal(t, 32, arr.Len)
+	require.Equal(t, KindU8, arr.Elem.Kind)
+	for schema, msg := range map[string]string{
+		"[u8;]":   "obi: invalid schema at position 4: expect array length",
+		"[u8;-1]": "obi: invalid schema at position 4: expect array length",
+		"[u8;2":   "obi: invalid schema at position 5: expect ']'",
+		"?":       `obi: invalid schema at position 1: unknown type ""`,
+	} {
+		_, err := ParseSchema(schema)
+		require.EqualError(t, err, msg, schema)
+	}
+	// GetSchema and ParseSchema must agree on the extended types too.
+	require.Equal(t, MustGetSchema(ExtendedData{}), MustParseSchema(MustGetSchema(ExtendedData{})).String())
+}
@@ -3,11 +3,80 @@ package obi
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 )
 
-func getSchemaImpl(s *strings.Builder, t reflect.Type) error {
+var bigIntType = reflect.TypeOf((*big.Int)(nil))
+
+// fieldOptions are the options given after the field name in an obi struct tag, such as
+// `obi:"fee,u128,optional"` or `obi:"supply,u128"`.
+type fieldOptions struct {
+	// optional makes a *big.Int field optional, with nil encoded as none. Other optional fields
+	// are pointers, since the zero value of a non-pointer could not be told apart from none.
+	optional bool
+	// bigKind is the integer kind of a *big.Int field, or of the *big.Int elements of a slice or
+	// array field. It defaults to u256.
+	bigKind    Kind
+	bigKindSet bool
+}
+
+var defaultFieldOptions = fieldOptions{bigKind: KindU256}
+
+// parseFieldTag parses the given obi struct tag into the field name and its options.
+func parseFieldTag(tag string) (string, fieldOptions, error) {
+	parts := strings.Split(tag, ",")
+	opts := defaultFieldOptions
+	for _, option := range parts[1:] {
+		switch option {
+		case "optional":
+			opts.optional = true
+		case "u128", "u256", "i128", "i256":
+			if opts.bigKindSet {
+				return "", fieldOptions{}, fmt.Errorf("obi: multiple integer options in tag %q", tag)
+			}
+			opts.bigKind = primitiveKinds[option]
+			opts.bigKindSet = true
+		default:
+			return "", fieldOptions{}, fmt.Errorf("obi: unknown option %q in tag %q", option, tag)
+		}
+	}
+	return parts[0], opts, nil
+}
+
+// check returns an error if the options are set on a value of type t that is not a *big.Int.
+// Integer options are passed on to the elements of slices and arrays, and checked there.
+func (opts fieldOptions) check(t reflect.Type) error {
+	if t == bigIntType {
+		return nil
+	}
+	if opts.optional {
+		return fmt.Errorf("obi: optional option requires *big.Int, got %s; use a pointer instead", t)
+	}
+	isElems := t.Kind() == reflect.Array || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8)
+	if opts.bigKindSet && !isElems {
+		return fmt.Errorf("obi: %s option requires *big.Int, got %s", primitiveNames[opts.bigKind], t)
+	}
+	return nil
+}
+
+// elem returns the options of the elements of a slice or array with these options.
+func (opts fieldOptions) elem() fieldOptions {
+	return fieldOptions{bigKind: opts.bigKind, bigKindSet: opts.bigKindSet}
+}
+
+func getSchemaImpl(s *strings.Builder, t reflect.Type, opts fieldOptions) error {
+	if t == bigIntType {
+		if opts.optional {
+			s.WriteString("?")
+		}
+		s.WriteString(primitiveNames[opts.bigKind])
+		return nil
+	}
+	if err := opts.check(t); err != nil {
+		return err
+	}
 	switch t.Kind() {
 	case reflect.Uint8:
 		s.WriteString("u8")
@@ -33,16 +102,30 @@ func getSchemaImpl(s *strings.Builder, t reflect.Type) error {
 	case reflect.Int64:
 		s.WriteString("i64")
 		return nil
+	case reflect.Bool:
+		s.WriteString("bool")
+		return nil
 	case reflect.String:
 		s.WriteString("string")
 		return nil
+	case reflect.Ptr:
+		s.WriteString("?")
+		return getSchemaImpl(s, t.Elem(), defaultFieldOptions)
+	case reflect.Array:
+		s.WriteString("[")
+		err := getSchemaImpl(s, t.Elem(), opts.elem())
+		if err != nil {
+			return err
+		}
+		s.WriteString(fmt.Sprintf(";%d]", t.Len()))
+		return nil
 	case reflect.Slice:
 		if t.Elem().Kind() == reflect.Uint8 {
 			s.WriteString("bytes")
 			return nil
 		}
 		s.WriteString("[")
-		err := getSchemaImpl(s, t.Elem())
+		err := getSchemaImpl(s, t.Elem(), opts.elem())
 		if err != nil {
 			return err
 		}
@@ -55,16 +138,20 @@ func getSchemaImpl(s *strings.Builder, t reflect.Type) error {
 		s.WriteString("{")
 		for idx := 0; idx < t.NumField(); idx++ {
 			field := t.Field(idx)
-			name, ok := field.Tag.Lookup("obi")
+			tag, ok := field.Tag.Lookup("obi")
 			if !ok {
 				return fmt.Errorf("obi: no obi tag found for field %s of %s", field.Name, t.Name())
 			}
+			name, fieldOpts, err := parseFieldTag(tag)
+			if err != nil {
+				return err
+			}
 			if idx != 0 {
 				s.WriteString(",")
 			}
 			s.WriteString(name)
 			s.WriteString(":")
-			err := getSchemaImpl(s, field.Type)
+			err = getSchemaImpl(s, field.Type, fieldOpts)
 			if err != nil {
 				return err
 			}
@@ -79,7 +166,7 @@ func getSchemaImpl(s *strings.Builder, t reflect.Type) error {
 // GetSchema returns the compact OBI individual schema of the given value.
 func GetSchema(v interface{}) (string, error) {
 	s := &strings.Builder{}
-	err := getSchemaImpl(s, reflect.TypeOf(v))
+	err := getSchemaImpl(s, reflect.TypeOf(v), defaultFieldOptions)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package obi
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,8 +14,8 @@ type NoOBITagStruct struct {
 }
 
 type NotSupportedStruct struct {
-	IsValid bool   `obi:"isValid"`
-	Test    string `obi:"test"`
+	Ratio float64 `obi:"ratio"`
+	Test  string  `obi:"test"`
 }
 
 type AllData struct {
@@ -65,7 +66,7 @@ func TestMissingOBISchemaFail(t *testing.T) {
 }
 
 func TestUnsupportedTypeFail(t *testing.T) {
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustGetSchema(NotSupportedStruct{}) })
+	require.PanicsWithError(t, "obi: unsupported value type: float64", func() { MustGetSchema(NotSupportedStruct{}) })
 }
 
 func TestSchemaSupportedNumberTypeSuccess(t *testing.T) {
@@ -77,8 +78,8 @@ func TestSchemaSupportedNumberAliasTypeSuccess(t *testing.T) {
 }
 
 func TestSchemaInvalidSliceFail(t *testing.T) {
-	invalidSlice := []bool{false, false, true, true}
-	require.PanicsWithError(t, "obi: unsupported value type: bool", func() { MustGetSchema(invalidSlice) })
+	invalidSlice := []float32{0, 0, 1, 1}
+	require.PanicsWithError(t, "obi: unsupported value type: float32", func() { MustGetSchema(invalidSlice) })
 }
 
 func TestSchemaByteArraySuccess(t *testing.T) {
@@ -89,3 +90,37 @@ func TestSchemaByteArraySuccess(t *testing.T) {
 func TestSchemaByteArrayInStructSuccess(t *testing.T) {
 	require.Equal(t, "{byteArray:bytes}", MustGetSchema(ByteArrayStruct{}))
 }
+
+func TestSchemaExtendedTypes(t *testing.T) {
+	require.Equal(t,
+		"{flag:bool,pair:[i8;2],hash:[u8;4],px:?u64,note:?string,supply:u256,debt:i128,maybe_fee:?u128}",
+		MustGetSchema(ExtendedData{}))
+	require.Equal(t, "[?bool;3]", MustGetSchema([3]*bool{}))
+	require.Equal(t, "{x:[i128],y:[[u128];2]}", MustGetSchema(struct {
+		X []*big.Int    `obi:"x,i128"`
+		Y [2][]*big.Int `obi:"y,u128"`
+	}{}))
+}
+
+func TestSchemaTagOptionFail(t *testing.T) {
+	require.PanicsWithError(t, "obi: i256 option requires *big.Int, got string", func() {
+		MustGetSchema(struct {
+			X string `obi:"x,i256"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: optional option requires *big.Int, got bool; use a pointer instead", func() {
+		MustGetSchema(struct {
+			X bool `obi:"x,optional"`
+		}{})
+	})
+	require.PanicsWithError(t, "obi: i128 option requires *big.Int, got string", func() {
+		MustGetSchema(struct {
+			X []string `obi:"x,i128"`
+		}{})
+	})
+	require.PanicsWithError(t, `obi: multiple integer options in tag "x,u128,u256"`, func() {
+		MustGetSchema(struct {
+			X *big.Int `obi:"x,u128,u256"`
+		}{})
+	})
+}
@@ -0,0 +1,308 @@
+package obi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Values decoded against a schema Type use the following Go types: uint8, uint16, uint32, uint64,
+// int8, int16, int32 and int64 for integers up to 64 bits, *big.Int for 128 and 256-bit integers,
+// bool for booleans, string for strings, []byte for bytes, []interface{} for vectors and
+// fixed-length arrays, map[string]interface{} for structs, and nil or the value for optionals.
+// Encoding accepts the same types, except that integers may be of any Go integer type or
+// *big.Int as long as the value fits.
+
+// toBigInt converts a Go integer value to *big.Int.
+func toBigInt(v interface{}) (*big.Int, bool) {
+	switch v := v.(type) {
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case int8:
+		return big.NewInt(int64(v)), true
+	case int16:
+		return big.NewInt(int64(v)), true
+	case int32:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case int:
+		return big.NewInt(int64(v)), true
+	case *big.Int:
+		return v, v != nil
+	default:
+		return nil, false
+	}
+}
+
+func encodeValueImpl(v interface{}, t Type) ([]byte, error) {
+	switch t.Kind {
+	case KindU8, KindU16, KindU32, KindU64, KindI8, KindI16, KindI32, KindI64, KindU128, KindU256, KindI128, KindI256:
+		x, ok := toBigInt(v)
+		if !ok {
+			return nil, fmt.Errorf("obi: expect integer for %s, got %T", t, v)
+		}
+		return encodeInteger(x, t.Kind)
+	case KindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("obi: expect bool, got %T", v)
+		}
+		return EncodeBool(b), nil
+	case KindString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("obi: expect string, got %T", v)
+		}
+		return EncodeString(s), nil
+	case KindBytes:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("obi: expect bytes, got %T", v)
+		}
+		return EncodeBytes(b), nil
+	case KindVector:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("obi: expect vector for %s, got %T", t, v)
+		}
+		res := EncodeUnsigned32(uint32(len(elems)))
+		for _, elem := range elems {
+			each, err := encodeValueImpl(elem, *t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, each...)
+		}
+		return res, nil
+	case KindArray:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("obi: expect array for %s, got %T", t, v)
+		}
+		if len(elems) != t.Len {
+			return nil, fmt.Errorf("obi: expect %d elements for %s, got %d", t.Len, t, len(elems))
+		}
+		res := []byte{}
+		for _, elem := range elems {
+			each, err := encodeValueImpl(elem, *t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, each...)
+		}
+		return res, nil
+	case KindOption:
+		if v == nil {
+			return EncodeBool(false), nil
+		}
+		res, err := encodeValueImpl(v, *t.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return append(EncodeBool(true), res...), nil
+	case KindStruct:
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("obi: expect struct for %s, got %T", t, v)
+		}
+		if len(fields) != len(t.Fields) {
+			return nil, fmt.Errorf("obi: expect %d fields for %s, got %d", len(t.Fields), t, len(fields))
+		}
+		res := []byte{}
+		for _, field := range t.Fields {
+			fieldValue, ok := fields[field.Name]
+			if !ok {
+				return nil, fmt.Errorf("obi: missing field %s", field.Name)
+			}
+			each, err := encodeValueImpl(fieldValue, field.Type)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, each...)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("obi: unsupported schema kind: %d", t.Kind)
+	}
+}
+
+// EncodeValue encodes the given generic value against the schema type into bytes.
+func EncodeValue(v interface{}, t Type) ([]byte, error) {
+	return encodeValueImpl(v, t)
+}
+
+// MustEncodeValue encodes the given generic value against the schema type. Panics on error.
+func MustEncodeValue(v interface{}, t Type) []byte {
+	res, err := EncodeValue(v, t)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func decodeValueImpl(data []byte, t Type) (interface{}, []byte, error) {
+	switch t.Kind {
+	case KindU8:
+		return DecodeUnsigned8(data)
+	case KindU16:
+		return DecodeUnsigned16(data)
+	case KindU32:
+		return DecodeUnsigned32(data)
+	case KindU64:
+		return DecodeUnsigned64(data)
+	case KindI8:
+		return DecodeSigned8(data)
+	case KindI16:
+		return DecodeSigned16(data)
+	case KindI32:
+		return DecodeSigned32(data)
+	case KindI64:
+		return DecodeSigned64(data)
+	case KindU128, KindU256, KindI128, KindI256:
+		return decodeInteger(data, t.Kind)
+	case KindBool:
+		return DecodeBool(data)
+	case KindString:
+		return DecodeString(data)
+	case KindBytes:
+		return DecodeBytes(data)
+	case KindVector:
+		length, rem, err := DecodeUnsigned32(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := checkLength(uint64(length), *t.Elem, rem); err != nil {
+			return nil, nil, err
+		}
+		res := make([]interface{}, length)
+		for idx := range res {
+			res[idx], rem, err = decodeValueImpl(rem, *t.Elem)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, rem, nil
+	case KindArray:
+		if err := checkLength(uint64(t.Len), *t.Elem, data); err != nil {
+			return nil, nil, err
+		}
+		res := make([]interface{}, t.Len)
+		rem := data
+		for idx := range res {
+			var err error
+			res[idx], rem, err = decodeValueImpl(rem, *t.Elem)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, rem, nil
+	case KindOption:
+		present, rem, err := DecodeBool(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !present {
+			return nil, rem, nil
+		}
+		return decodeValueImpl(rem, *t.Elem)
+	case KindStruct:
+		res := make(map[string]interface{}, len(t.Fields))
+		rem := data
+		for _, field := range t.Fields {
+			var err error
+			res[field.Name], rem, err = decodeValueImpl(rem, field.Type)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, rem, nil
+	default:
+		return nil, nil, fmt.Errorf("obi: unsupported schema kind: %d", t.Kind)
+	}
+}
+
+// maxZeroSizeLength is the maximum number of elements of a vector or array whose elements encode
+// to zero bytes, such as [u8;0] or {}. Their length cannot be bounded by the data size.
+const maxZeroSizeLength = 1024
+
+// checkLength returns an error if a vector or array of the given length and element type cannot
+// be decoded from the given data. It must pass before allocating the decoded elements.
+func checkLength(length uint64, elem Type, data []byte) error {
+	size := MinSize(elem)
+	if size == 0 {
+		if length > maxZeroSizeLength {
+			return fmt.Errorf("obi: length %d of zero-size elements exceeds %d", length, maxZeroSizeLength)
+		}
+		return nil
+	}
+	if length > uint64(len(data))/size {
+		return errors.New("obi: out of range")
+	}
+	return nil
+}
+
+// minSize returns the minimum number of bytes that a value of the type encodes to, saturating at
+// math.MaxUint64.
+func MinSize(t Type) uint64 {
+	switch t.Kind {
+	case KindU8, KindI8, KindBool, KindOption:
+		return 1
+	case KindU16, KindI16:
+		return 2
+	case KindU32, KindI32, KindString, KindBytes, KindVector:
+		return 4
+	case KindU64, KindI64:
+		return 8
+	case KindU128, KindU256, KindI128, KindI256:
+		return uint64(integerBits[t.Kind] / 8)
+	case KindArray:
+		elem := MinSize(*t.Elem)
+		if elem != 0 && uint64(t.Len) > math.MaxUint64/elem {
+			return math.MaxUint64
+		}
+		return uint64(t.Len) * elem
+	case KindStruct:
+		size := uint64(0)
+		for _, field := range t.Fields {
+			fieldSize := MinSize(field.Type)
+			if size > math.MaxUint64-fieldSize {
+				return math.MaxUint64
+			}
+			size += fieldSize
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// DecodeValue decodes the given bytes against the schema type into a generic value.
+func DecodeValue(data []byte, t Type) (interface{}, error) {
+	v, rem, err := decodeValueImpl(data, t)
+	if err != nil {
+		return nil, err
+	}
+	if len(rem) != 0 {
+		return nil, errors.New("obi: not all data was consumed while decoding")
+	}
+	return v, nil
+}
+
+// MustDecodeValue decodes the given bytes against the schema type. Panics on error.
+func MustDecodeValue(data []byte, t Type) interface{} {
+	v, err := DecodeValue(data, t)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
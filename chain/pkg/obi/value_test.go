@@ -0,0 +1,185 @@
+package obi
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeValue(t *testing.T) {
+	data := MustEncode(ExampleData{Symbol: "BTC", Px: 9000, In: Inner{A: 1, B: 2}, Arr: []int16{-1, 2}})
+	v, err := DecodeValue(data, MustParseSchema(MustGetSchema(ExampleData{})))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"symbol": "BTC",
+		"px":     uint64(9000),
+		"in":     map[string]interface{}{"a": uint8(1), "b": uint8(2)},
+		"arr":    []interface{}{int16(-1), int16(2)},
+	}, v)
+}
+
+func TestDecodeValueBytes(t *testing.T) {
+	v, err := DecodeValue([]byte{0x0, 0x0, 0x0, 0x2, 0xab, 0xcd}, MustParseSchema("bytes"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xab, 0xcd}, v)
+}
+
+func TestDecodeValueFail(t *testing.T) {
+	_, err := DecodeValue([]byte{0x0, 0x0, 0x0, 0x1}, MustParseSchema("u64"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{0xff, 0xff, 0xff, 0xff}, MustParseSchema("[u8]"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{0x1, 0x2}, MustParseSchema("u8"))
+	require.EqualError(t, err, "obi: not all data was consumed while decoding")
+	require.PanicsWithError(t, "obi: out of range", func() { MustDecodeValue([]byte{}, MustParseSchema("{a:u8}")) })
+}
+
+func TestDecodeValueVectorLength(t *testing.T) {
+	// Lengths are bounded by the minimum size of the elements.
+	_, err := DecodeValue([]byte{0x0, 0x0, 0x0, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, MustParseSchema("[u32]"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{0x0, 0x0, 0x0, 0x2, 0x1, 0x2, 0x3}, MustParseSchema("[[u8;2]]"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{0x0, 0x0, 0x0, 0x2, 0x1, 0x0, 0x0, 0x0}, MustParseSchema("[{a:u8,b:[u8]}]"))
+	require.EqualError(t, err, "obi: out of range")
+	v, err := DecodeValue([]byte{0x0, 0x0, 0x0, 0x2, 0x1, 0x2}, MustParseSchema("[[u8;1]]"))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]interface{}{uint8(1)}, []interface{}{uint8(2)}}, v)
+}
+
+func TestValueZeroSizeElements(t *testing.T) {
+	testCases := []struct {
+		schema string
+		value  interface{}
+	}{
+		{"[[u8;0]]", []interface{}{[]interface{}{}, []interface{}{}, []interface{}{}}},
+		{"[{}]", []interface{}{map[string]interface{}{}, map[string]interface{}{}}},
+		{"{a:[{}],b:u8}", map[string]interface{}{"a": []interface{}{map[string]interface{}{}}, "b": uint8(7)}},
+		{"[[u8;0]]", []interface{}{}},
+	}
+	for _, tc := range testCases {
+		schema := MustParseSchema(tc.schema)
+		data, err := EncodeValue(tc.value, schema)
+		require.NoError(t, err)
+		v, err := DecodeValue(data, schema)
+		require.NoError(t, err, tc.schema)
+		require.Equal(t, tc.value, v)
+	}
+	v, err := DecodeValue([]byte{0x0, 0x0, 0x0, 0x2}, MustParseSchema("[{}]"))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{map[string]interface{}{}, map[string]interface{}{}}, v)
+}
+
+func TestDecodeValueLengthBeforeAllocation(t *testing.T) {
+	// Vectors and arrays of zero-size elements are capped, as no data bounds their length.
+	_, err := DecodeValue([]byte{0xff, 0xff, 0xff, 0xff}, MustParseSchema("[{}]"))
+	require.EqualError(t, err, "obi: length 4294967295 of zero-size elements exceeds 1024")
+	_, err = DecodeValue([]byte{}, MustParseSchema("[[u8;0];2000000000]"))
+	require.EqualError(t, err, "obi: length 2000000000 of zero-size elements exceeds 1024")
+	v, err := DecodeValue([]byte{0x0, 0x0, 0x4, 0x0}, MustParseSchema("[{}]"))
+	require.NoError(t, err)
+	require.Len(t, v, 1024)
+	// Fixed arrays must fit in the remaining data, even when their size overflows.
+	_, err = DecodeValue([]byte{}, MustParseSchema("[u8;2000000000]"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{0x0, 0x0, 0x0, 0x1}, MustParseSchema("[[u64;4611686018427387904]]"))
+	require.EqualError(t, err, "obi: out of range")
+	_, err = DecodeValue([]byte{}, MustParseSchema("[{a:[u64;4611686018427387904],b:[u64;4611686018427387904]};2]"))
+	require.EqualError(t, err, "obi: out of range")
+}
+
+func TestEncodeValue(t *testing.T) {
+	schema := MustParseSchema(MustGetSchema(ExampleData{}))
+	data, err := EncodeValue(map[string]interface{}{
+		"symbol": "BTC",
+		"px":     9000,
+		"in":     map[string]interface{}{"a": uint8(1), "b": 2},
+		"arr":    []interface{}{-1, int64(2)},
+	}, schema)
+	require.NoError(t, err)
+	require.Equal(t, MustEncode(ExampleData{Symbol: "BTC", Px: 9000, In: Inner{A: 1, B: 2}, Arr: []int16{-1, 2}}), data)
+}
+
+func TestEncodeValueRoundTrip(t *testing.T) {
+	schema := MustParseSchema("{a:[bytes],b:i64,c:u64,d:string}")
+	v := map[string]interface{}{
+		"a": []interface{}{[]byte{0x1}, []byte{}},
+		"b": int64(math.MinInt64),
+		"c": uint64(math.MaxUint64),
+		"d": "",
+	}
+	require.Equal(t, v, MustDecodeValue(MustEncodeValue(v, schema), schema))
+}
+
+func TestEncodeValueIntegerRange(t *testing.T) {
+	for _, tc := range []struct {
+		schema string
+		value  interface{}
+		ok     bool
+	}{
+		{"u8", 255, true},
+		{"u8", 256, false},
+		{"u8", -1, false},
+		{"u64", uint64(math.MaxUint64), true},
+		{"u64", int64(-1), false},
+		{"i8", -128, true},
+		{"i8", -129, false},
+		{"i8", 127, true},
+		{"i8", uint8(128), false},
+		{"i32", int64(math.MinInt32), true},
+		{"i32", int64(math.MaxInt32 + 1), false},
+		{"i64", uint64(math.MaxInt64), true},
+		{"i64", uint64(math.MaxInt64 + 1), false},
+	} {
+		_, err := EncodeValue(tc.value, MustParseSchema(tc.schema))
+		require.Equal(t, tc.ok, err == nil, "%s %v", tc.schema, tc.value)
+	}
+	require.Equal(t, []byte{0xff, 0xfe}, MustEncodeValue(-2, MustParseSchema("i16")))
+}
+
+func TestEncodeValueFail(t *testing.T) {
+	_, err := EncodeValue("1", MustParseSchema("u8"))
+	require.EqualError(t, err, "obi: expect integer for u8, got string")
+	_, err = EncodeValue([]byte("a"), MustParseSchema("string"))
+	require.EqualError(t, err, "obi: expect string, got []uint8")
+	_, err = EncodeValue([]string{"a"}, MustParseSchema("[string]"))
+	require.EqualError(t, err, "obi: expect vector for [string], got []string")
+	_, err = EncodeValue(map[string]interface{}{"a": 1}, MustParseSchema("{b:u8}"))
+	require.EqualError(t, err, "obi: missing field b")
+	_, err = EncodeValue(map[string]interface{}{"a": 1, "b": 2}, MustParseSchema("{a:u8}"))
+	require.EqualError(t, err, "obi: expect 1 fields for {a:u8}, got 2")
+	require.PanicsWithError(t, "obi: value 300 out of range of u8", func() { MustEncodeValue(300, MustParseSchema("u8")) })
+}
+
+func TestValueExtendedTypes(t *testing.T) {
+	px := uint64(7)
+	data := MustEncode(ExtendedData{
+		Flag: true, Pair: [2]int8{3, -4}, Hash: [4]byte{0xde, 0xad, 0xbe, 0xef}, Px: &px,
+		Supply: big.NewInt(1), Debt: big.NewInt(-100),
+	})
+	schema := MustParseSchema(MustGetSchema(ExtendedData{}))
+	v := MustDecodeValue(data, schema)
+	require.Equal(t, map[string]interface{}{
+		"flag":      true,
+		"pair":      []interface{}{int8(3), int8(-4)},
+		"hash":      []interface{}{uint8(0xde), uint8(0xad), uint8(0xbe), uint8(0xef)},
+		"px":        uint64(7),
+		"note":      nil,
+		"supply":    big.NewInt(1),
+		"debt":      big.NewInt(-100),
+		"maybe_fee": nil,
+	}, v)
+	require.Equal(t, data, MustEncodeValue(v, schema))
+}
+
+func TestEncodeValueExtendedFail(t *testing.T) {
+	_, err := EncodeValue([]interface{}{1}, MustParseSchema("[u8;2]"))
+	require.EqualError(t, err, "obi: expect 2 elements for [u8;2], got 1")
+	_, err = EncodeValue(1, MustParseSchema("bool"))
+	require.EqualError(t, err, "obi: expect bool, got int")
+	_, err = EncodeValue(-1, MustParseSchema("?u256"))
+	require.EqualError(t, err, "obi: value -1 out of range of u256")
+	require.Equal(t, []byte{0x0}, MustEncodeValue(nil, MustParseSchema("?u256")))
+}
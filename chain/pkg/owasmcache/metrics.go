@@ -0,0 +1,62 @@
+package owasmcache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type cacheCollector struct {
+	cache             *Cache
+	hitCountDesc      *prometheus.Desc
+	missCountDesc     *prometheus.Desc
+	evictionCountDesc *prometheus.Desc
+	sizeGaugeDesc     *prometheus.Desc
+	entryGaugeDesc    *prometheus.Desc
+}
+
+// NewCollector returns a Prometheus collector of the hit, miss and size metrics of the cache.
+func NewCollector(cache *Cache) prometheus.Collector {
+	return &cacheCollector{
+		cache: cache,
+		hitCountDesc: prometheus.NewDesc(
+			"owasm_compiled_cache_hit_total",
+			"Number of compiled oracle script lookups found in the cache",
+			nil, nil),
+		missCountDesc: prometheus.NewDesc(
+			"owasm_compiled_cache_miss_total",
+			"Number of compiled oracle script lookups not found in the cache",
+			nil, nil),
+		evictionCountDesc: prometheus.NewDesc(
+			"owasm_compiled_cache_eviction_total",
+			"Number of compiled oracle scripts evicted from the cache",
+			nil, nil),
+		sizeGaugeDesc: prometheus.NewDesc(
+			"owasm_compiled_cache_size_bytes",
+			"Total size of compiled oracle scripts in the cache",
+			nil, nil),
+		entryGaugeDesc: prometheus.NewDesc(
+			"owasm_compiled_cache_entry_count",
+			"Number of compiled oracle scripts in the cache",
+			nil, nil),
+	}
+}
+
+func (collector cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.hitCountDesc
+	ch <- collector.missCountDesc
+	ch <- collector.evictionCountDesc
+	ch <- collector.sizeGaugeDesc
+	ch <- collector.entryGaugeDesc
+}
+
+func (collector cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(collector.hitCountDesc, prometheus.CounterValue,
+		float64(collector.cache.Hits()))
+	ch <- prometheus.MustNewConstMetric(collector.missCountDesc, prometheus.CounterValue,
+		float64(collector.cache.Misses()))
+	ch <- prometheus.MustNewConstMetric(collector.evictionCountDesc, prometheus.CounterValue,
+		float64(collector.cache.Evictions()))
+	ch <- prometheus.MustNewConstMetric(collector.sizeGaugeDesc, prometheus.GaugeValue,
+		float64(collector.cache.Size()))
+	ch <- prometheus.MustNewConstMetric(collector.entryGaugeDesc, prometheus.GaugeValue,
+		float64(collector.cache.Len()))
+}
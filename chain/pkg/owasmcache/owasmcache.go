@@ -0,0 +1,236 @@
+package owasmcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const tmpPrefix = ".tmp-"
+
+// Cache is a persistent cache of compiled oracle script code, keyed by the sha256 hash of the
+// Wasm code and the version of the Owasm VM that compiled it. Entries are kept on disk under a
+// directory per VM version, so that they survive node restarts, and the least recently used
+// entries are evicted once the total size of compiled code exceeds the maximum. Each file is
+// named after the hashes of both the Wasm code and the compiled code, and is verified against
+// them on every read, so that a corrupted entry is never returned.
+type Cache struct {
+	// Counters are first to be 64-bit aligned for atomic access.
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	mtx      sync.Mutex
+	dir      string
+	maxBytes int64
+	size     int64
+	lru      *list.List // Front is the most recently used entry.
+	entries  map[string]*list.Element
+}
+
+type entry struct {
+	key  string
+	sum  string // Hex sha256 hash of the compiled code.
+	size int64
+}
+
+// fileName returns the name of the file holding the entry on disk.
+func (e *entry) fileName() string {
+	return e.key + "-" + e.sum
+}
+
+// New creates a cache of at most maxBytes of compiled code in the given directory, for code
+// compiled by the given VM version. Entries of other VM versions are removed from disk.
+func New(dir string, version string, maxBytes int64) (*Cache, error) {
+	versionHash := sha256.Sum256([]byte(version))
+	versionDir := hex.EncodeToString(versionHash[:])
+	if err := os.MkdirAll(filepath.Join(dir, versionDir), 0755); err != nil {
+		return nil, err
+	}
+	// Compiled code of any other VM version is stale, since the VM may compile differently.
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.Name() != versionDir {
+			if err := os.RemoveAll(filepath.Join(dir, info.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c := &Cache{
+		dir:      filepath.Join(dir, versionDir),
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load restores the entries on disk, using modification times as the last time of use.
+func (c *Cache) load() error {
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(info.Name(), tmpPrefix) {
+			// Left behind by a crash while adding an entry.
+			os.Remove(filepath.Join(c.dir, info.Name()))
+			continue
+		}
+		parts := strings.Split(info.Name(), "-")
+		if len(parts) != 2 {
+			// Not an entry of the cache, e.g. one written by an older node.
+			os.Remove(filepath.Join(c.dir, info.Name()))
+			continue
+		}
+		if elem, ok := c.entries[parts[0]]; ok {
+			c.remove(elem)
+		}
+		c.entries[parts[0]] = c.lru.PushFront(&entry{key: parts[0], sum: parts[1], size: info.Size()})
+		c.size += info.Size()
+	}
+	c.evict()
+	return nil
+}
+
+func getHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// Get returns the compiled code of the given Wasm code and whether it is in the cache.
+func (c *Cache) Get(code []byte) ([]byte, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[getHash(code)]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	compiled, err := ioutil.ReadFile(filepath.Join(c.dir, e.fileName()))
+	if err != nil || getHash(compiled) != e.sum {
+		// The file is gone, unreadable or corrupted, so drop the entry and let the caller
+		// compile again.
+		c.remove(elem)
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(filepath.Join(c.dir, e.fileName()), now, now)
+	c.lru.MoveToFront(elem)
+	atomic.AddUint64(&c.hits, 1)
+	return compiled, true
+}
+
+// Add saves the compiled code of the given Wasm code, evicting least recently used entries if
+// the cache grows beyond its maximum size. Compiled code larger than the maximum is not saved.
+func (c *Cache) Add(code []byte, compiled []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	key := getHash(code)
+	if _, ok := c.entries[key]; ok || int64(len(compiled)) > c.maxBytes {
+		return nil
+	}
+	// Write to a temporary file first, so that a crash never leaves a partial entry behind.
+	tmp, err := ioutil.TempFile(c.dir, tmpPrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(compiled); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	e := &entry{key: key, sum: getHash(compiled), size: int64(len(compiled))}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, e.fileName())); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.size += int64(len(compiled))
+	c.evict()
+	return nil
+}
+
+// GetOrCompile returns the compiled code of the given Wasm code from the cache, or compiles it
+// with the given function and saves the result on a miss. Failing to save is not an error.
+func (c *Cache) GetOrCompile(code []byte, compile func([]byte) ([]byte, error)) ([]byte, error) {
+	if compiled, ok := c.Get(code); ok {
+		return compiled, nil
+	}
+	compiled, err := compile(code)
+	if err != nil {
+		return nil, err
+	}
+	c.Add(code, compiled)
+	return compiled, nil
+}
+
+// evict removes least recently used entries until the cache fits in its maximum size.
+func (c *Cache) evict() {
+	for c.size > c.maxBytes {
+		c.remove(c.lru.Back())
+		atomic.AddUint64(&c.evictions, 1)
+	}
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	e := c.lru.Remove(elem).(*entry)
+	delete(c.entries, e.key)
+	c.size -= e.size
+	os.Remove(filepath.Join(c.dir, e.fileName()))
+}
+
+// Size returns the total size in bytes of compiled code in the cache.
+func (c *Cache) Size() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.size
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.lru.Len()
+}
+
+// Hits returns the number of lookups that found compiled code in the cache.
+func (c *Cache) Hits() uint64 {
+	return atomic.LoadUint64(&c.hits)
+}
+
+// Misses returns the number of lookups that did not find compiled code in the cache.
+func (c *Cache) Misses() uint64 {
+	return atomic.LoadUint64(&c.misses)
+}
+
+// Evictions returns the number of entries evicted to keep the cache within its maximum size.
+func (c *Cache) Evictions() uint64 {
+	return atomic.LoadUint64(&c.evictions)
+}
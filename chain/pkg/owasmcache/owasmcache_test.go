@@ -0,0 +1,147 @@
+package owasmcache_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/owasmcache"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "owasmcache")
+	require.NoError(t, err)
+	return dir
+}
+
+func TestGetOrCompile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	cache, err := owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+
+	compileCount := 0
+	compile := func(code []byte) ([]byte, error) {
+		compileCount++
+		return append([]byte("compiled-"), code...), nil
+	}
+	compiled, err := cache.GetOrCompile([]byte("code"), compile)
+	require.NoError(t, err)
+	require.Equal(t, []byte("compiled-code"), compiled)
+	compiled, err = cache.GetOrCompile([]byte("code"), compile)
+	require.NoError(t, err)
+	require.Equal(t, []byte("compiled-code"), compiled)
+	require.Equal(t, 1, compileCount)
+	require.Equal(t, uint64(1), cache.Hits())
+	require.Equal(t, uint64(1), cache.Misses())
+	require.Equal(t, int64(13), cache.Size())
+
+	_, err = cache.GetOrCompile([]byte("bad"), func(code []byte) ([]byte, error) {
+		return nil, errors.New("compile error")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestEvictLeastRecentlyUsed(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	cache, err := owasmcache.New(dir, "v1", 10)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Add([]byte("a"), []byte("aaaa")))
+	require.NoError(t, cache.Add([]byte("b"), []byte("bbbb")))
+	_, ok := cache.Get([]byte("a"))
+	require.True(t, ok)
+	// Adding c goes over 10 bytes, so b as the least recently used is evicted.
+	require.NoError(t, cache.Add([]byte("c"), []byte("cccc")))
+	_, ok = cache.Get([]byte("b"))
+	require.False(t, ok)
+	compiled, ok := cache.Get([]byte("a"))
+	require.True(t, ok)
+	require.Equal(t, []byte("aaaa"), compiled)
+	_, ok = cache.Get([]byte("c"))
+	require.True(t, ok)
+	require.Equal(t, int64(8), cache.Size())
+	require.Equal(t, uint64(1), cache.Evictions())
+
+	// Compiled code larger than the whole cache is never saved.
+	require.NoError(t, cache.Add([]byte("d"), []byte("ddddddddddd")))
+	_, ok = cache.Get([]byte("d"))
+	require.False(t, ok)
+}
+
+func TestPersistAcrossRestart(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	cache, err := owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	require.NoError(t, cache.Add([]byte("a"), []byte("aaaa")))
+
+	cache, err = owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	compiled, ok := cache.Get([]byte("a"))
+	require.True(t, ok)
+	require.Equal(t, []byte("aaaa"), compiled)
+	require.Equal(t, int64(4), cache.Size())
+}
+
+func TestDropCorruptedEntry(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	cache, err := owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	require.NoError(t, cache.Add([]byte("a"), []byte("aaaa")))
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.NoError(t, ioutil.WriteFile(files[0], []byte("abcd"), 0644))
+
+	// The corrupted entry is never returned, and is removed from disk.
+	_, ok := cache.Get([]byte("a"))
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+	require.Equal(t, int64(0), cache.Size())
+	_, err = os.Stat(files[0])
+	require.True(t, os.IsNotExist(err))
+
+	// The same holds for a corrupted entry found after a restart.
+	require.NoError(t, cache.Add([]byte("a"), []byte("aaaa")))
+	files, err = filepath.Glob(filepath.Join(dir, "*", "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.NoError(t, ioutil.WriteFile(files[0], []byte("abcd"), 0644))
+	cache, err = owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	_, ok = cache.Get([]byte("a"))
+	require.False(t, ok)
+	compiled, err := cache.GetOrCompile([]byte("a"), func(code []byte) ([]byte, error) {
+		return []byte("aaaa"), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaa"), compiled)
+}
+
+func TestInvalidateOnVersionChange(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	cache, err := owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	require.NoError(t, cache.Add([]byte("a"), []byte("aaaa")))
+
+	cache, err = owasmcache.New(dir, "v2", 1024)
+	require.NoError(t, err)
+	_, ok := cache.Get([]byte("a"))
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+
+	// Going back to the old version does not bring back its removed entries.
+	cache, err = owasmcache.New(dir, "v1", 1024)
+	require.NoError(t, err)
+	_, ok = cache.Get([]byte("a"))
+	require.False(t, ok)
+}
@@ -0,0 +1,139 @@
+package vrf
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/gtank/merlin"
+)
+
+const (
+	// PrivateKeySize is the size in bytes of a VRF private key.
+	PrivateKeySize = 32
+	// PublicKeySize is the size in bytes of a VRF public key.
+	PublicKeySize = 32
+	// OutputSize is the size in bytes of a VRF output.
+	OutputSize = 32
+	// ProofSize is the size in bytes of a VRF proof.
+	ProofSize = 64
+	// HashSize is the size in bytes of the random value derived from a VRF output.
+	HashSize = sha256.Size
+)
+
+// Domain separation label of the transcripts of all VRF messages.
+const transcriptLabel = "BandChainVRF"
+
+// PrivateKey is a VRF private key, the schnorrkel mini secret key of a sr25519 key pair.
+type PrivateKey []byte
+
+// GenerateKey returns a new random VRF private key.
+func GenerateKey() (PrivateKey, error) {
+	key := make([]byte, PrivateKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (key PrivateKey) miniSecretKey() (*schnorrkel.MiniSecretKey, error) {
+	if len(key) != PrivateKeySize {
+		return nil, errors.New("invalid VRF private key size")
+	}
+	var raw [PrivateKeySize]byte
+	copy(raw[:], key)
+	return schnorrkel.NewMiniSecretKeyFromRaw(raw)
+}
+
+// PublicKey returns the VRF public key of the private key.
+func (key PrivateKey) PublicKey() ([]byte, error) {
+	msk, err := key.miniSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	pub := msk.Public().Encode()
+	return pub[:], nil
+}
+
+// Prove returns the VRF output of the message under the private key, together with the proof
+// that anyone with the public key can check with Verify.
+func (key PrivateKey) Prove(msg []byte) (output []byte, proof []byte, err error) {
+	msk, err := key.miniSecretKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	inout, vrfProof, err := msk.ExpandEd25519().VrfSign(newTranscript(msg))
+	if err != nil {
+		return nil, nil, err
+	}
+	out := inout.Output().Encode()
+	prf := vrfProof.Encode()
+	return out[:], prf[:], nil
+}
+
+// ValidatePublicKey returns error if the bytes are not a valid VRF public key.
+func ValidatePublicKey(pubKey []byte) error {
+	_, err := decodePublicKey(pubKey)
+	return err
+}
+
+// Verify returns nil iff the output and proof were produced by Prove on the message with the
+// private key of the given public key.
+func Verify(pubKey []byte, msg []byte, output []byte, proof []byte) error {
+	pub, err := decodePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	if len(output) != OutputSize {
+		return errors.New("invalid VRF output size")
+	}
+	if len(proof) != ProofSize {
+		return errors.New("invalid VRF proof size")
+	}
+	var rawOutput [OutputSize]byte
+	copy(rawOutput[:], output)
+	var out schnorrkel.VrfOutput
+	if err := out.Decode(rawOutput); err != nil {
+		return err
+	}
+	var rawProof [ProofSize]byte
+	copy(rawProof[:], proof)
+	var prf schnorrkel.VrfProof
+	if err := prf.Decode(rawProof); err != nil {
+		return err
+	}
+	ok, err := pub.VrfVerify(newTranscript(msg), out.AttachInput(pub, newTranscript(msg)), &prf)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid VRF proof")
+	}
+	return nil
+}
+
+// Hash returns the random value of a VRF output, uniformly distributed over HashSize bytes.
+func Hash(output []byte) []byte {
+	hash := sha256.Sum256(output)
+	return hash[:]
+}
+
+func decodePublicKey(pubKey []byte) (*schnorrkel.PublicKey, error) {
+	if len(pubKey) != PublicKeySize {
+		return nil, errors.New("invalid VRF public key size")
+	}
+	var raw [PublicKeySize]byte
+	copy(raw[:], pubKey)
+	var pub schnorrkel.PublicKey
+	if err := pub.Decode(raw); err != nil {
+		return nil, err
+	}
+	return &pub, nil
+}
+
+func newTranscript(msg []byte) *merlin.Transcript {
+	t := merlin.NewTranscript(transcriptLabel)
+	t.AppendMessage([]byte("msg"), msg)
+	return t
+}
@@ -0,0 +1,70 @@
+package vrf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
+)
+
+func TestProveAndVerify(t *testing.T) {
+	key, err := vrf.GenerateKey()
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	require.NoError(t, vrf.ValidatePublicKey(pubKey))
+
+	output, proof, err := key.Prove([]byte("message"))
+	require.NoError(t, err)
+	require.Len(t, output, vrf.OutputSize)
+	require.Len(t, proof, vrf.ProofSize)
+	require.NoError(t, vrf.Verify(pubKey, []byte("message"), output, proof))
+	require.Len(t, vrf.Hash(output), vrf.HashSize)
+
+	// The output is unique to the key and message, even though proofs are randomized.
+	output2, proof2, err := key.Prove([]byte("message"))
+	require.NoError(t, err)
+	require.Equal(t, output, output2)
+	require.NotEqual(t, proof, proof2)
+	output3, _, err := key.Prove([]byte("other message"))
+	require.NoError(t, err)
+	require.NotEqual(t, output, output3)
+}
+
+func TestVerifyFail(t *testing.T) {
+	key, err := vrf.GenerateKey()
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	output, proof, err := key.Prove([]byte("message"))
+	require.NoError(t, err)
+
+	require.Error(t, vrf.Verify(pubKey, []byte("other message"), output, proof))
+	otherKey, err := vrf.GenerateKey()
+	require.NoError(t, err)
+	otherPubKey, err := otherKey.PublicKey()
+	require.NoError(t, err)
+	require.Error(t, vrf.Verify(otherPubKey, []byte("message"), output, proof))
+	otherOutput, otherProof, err := otherKey.Prove([]byte("message"))
+	require.NoError(t, err)
+	require.Error(t, vrf.Verify(pubKey, []byte("message"), otherOutput, proof))
+	require.Error(t, vrf.Verify(pubKey, []byte("message"), output, otherProof))
+	require.Error(t, vrf.Verify(pubKey, []byte("message"), output[:31], proof))
+	require.Error(t, vrf.Verify(pubKey, []byte("message"), output, proof[:63]))
+	require.Error(t, vrf.Verify(pubKey[:31], []byte("message"), output, proof))
+}
+
+func TestInvalidKey(t *testing.T) {
+	_, err := vrf.PrivateKey([]byte("short")).PublicKey()
+	require.Error(t, err)
+	_, _, err = vrf.PrivateKey([]byte("short")).Prove([]byte("message"))
+	require.Error(t, err)
+	require.Error(t, vrf.ValidatePublicKey([]byte("short")))
+	// Not the canonical encoding of a ristretto255 point.
+	invalid := make([]byte, vrf.PublicKeySize)
+	for i := range invalid {
+		invalid[i] = 0xff
+	}
+	require.Error(t, vrf.ValidatePublicKey(invalid))
+}
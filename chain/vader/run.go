@@ -14,7 +14,6 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	httpclient "github.com/tendermint/tendermint/rpc/client/http"
 
-	pricetypes "github.com/bandprotocol/bandchain/chain/hooks/price"
 	"github.com/bandprotocol/bandchain/chain/pkg/filecache"
 	"github.com/bandprotocol/bandchain/chain/pkg/obi"
 	oracletypes "github.com/bandprotocol/bandchain/chain/x/oracle/types"
@@ -22,6 +21,12 @@ import (
 
 const BandPriceMultiplier uint64 = 1000000000 // 1e9
 
+// priceInput is the calldata of standard price reference oracle scripts.
+type priceInput struct {
+	Symbols    []string `json:"symbols"`
+	Multiplier uint64   `json:"multiplier"`
+}
+
 func runImpl(c *Context, l *Logger) error {
 	//l.Info(":rocket: Starting WebSocket subscriber")
 	//err := c.client.Start()
@@ -41,7 +46,7 @@ func runImpl(c *Context, l *Logger) error {
 	fmt.Println("hi")
 	for {
 		fmt.Println("Hey :)")
-		input := pricetypes.Input{
+		input := priceInput{
 			Symbols:    c.symbols,
 			Multiplier: BandPriceMultiplier,
 		}
@@ -49,7 +54,7 @@ func runImpl(c *Context, l *Logger) error {
 		// TODO: change to some better system obviously
 		clientID := string(time.Now().Unix())
 
-		msg := oracletypes.NewMsgRequestData(oracletypes.OracleScriptID(c.oracleScriptID), calldata, c.askCount, c.minCount, clientID, c.requester)
+		msg := oracletypes.NewMsgRequestData(oracletypes.OracleScriptID(c.oracleScriptID), calldata, c.askCount, c.minCount, clientID, c.requester, nil, nil)
 		gasLimit := estimateGas(c, msg)
 
 		hash, err := signAndBroadcast(c, c.keys[0], []sdk.Msg{msg}, gasLimit, "")
@@ -7,17 +7,17 @@ import (
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 )
 
-// handleBeginBlock re-calculates and saves the rolling seed value based on block hashes.
+// handleBeginBlock distributes the oracle rewards of the previous block.
 func handleBeginBlock(ctx sdk.Context, k Keeper, req abci.RequestBeginBlock) {
-	// Update rolling seed used for pseudorandom oracle provider selection.
-	rollingSeed := k.GetRollingSeed(ctx)
-	k.SetRollingSeed(ctx, append(rollingSeed[1:], req.GetHash()[0]))
 	// Reward a portion of block rewards (inflation + tx fee) to active oracle validators.
 	k.AllocateTokens(ctx, req.LastCommitInfo.GetVotes())
 }
 
 // handleEndBlock cleans up the state during end block. See comment in the implementation!
 func handleEndBlock(ctx sdk.Context, k Keeper) {
+	// Finalize the random value of this block from the VRF outputs of validators. It becomes the
+	// rolling seed used for pseudorandom oracle provider selection from the next block onward.
+	k.FinalizeRandomness(ctx)
 	// Loops through all requests in the resolvable list to resolve all of them!
 	for _, reqID := range k.GetPendingResolveList(ctx) {
 		k.ResolveRequest(ctx, reqID)
@@ -1,6 +1,7 @@
 package oracle_test
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"testing"
 
@@ -26,19 +27,29 @@ func TestRollingSeedCorrect(t *testing.T) {
 	app, ctx, k := testapp.CreateTestInput(false)
 	// Initially rolling seed should be all zeros.
 	require.Equal(t, fromHex("0000000000000000000000000000000000000000000000000000000000000000"), k.GetRollingSeed(ctx))
-	// Every begin block, the rolling seed should get updated.
-	app.BeginBlocker(ctx, abci.RequestBeginBlock{
-		Hash: fromHex("0100000000000000000000000000000000000000000000000000000000000000"),
-	})
-	require.Equal(t, fromHex("0000000000000000000000000000000000000000000000000000000000000001"), k.GetRollingSeed(ctx))
-	app.BeginBlocker(ctx, abci.RequestBeginBlock{
-		Hash: fromHex("0200000000000000000000000000000000000000000000000000000000000000"),
+	// Every end block, the rolling seed should get updated. Without VRF contributions, the
+	// previous seed is hashed with the previous block hash.
+	ctx = ctx.WithBlockHeader(abci.Header{
+		Height:      1,
+		LastBlockId: abci.BlockID{Hash: fromHex("0100000000000000000000000000000000000000000000000000000000000000")},
 	})
-	require.Equal(t, fromHex("0000000000000000000000000000000000000000000000000000000000000102"), k.GetRollingSeed(ctx))
-	app.BeginBlocker(ctx, abci.RequestBeginBlock{
-		Hash: fromHex("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+	app.EndBlocker(ctx, abci.RequestEndBlock{Height: 1})
+	seed1 := sha256.Sum256(fromHex(
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0100000000000000000000000000000000000000000000000000000000000000"))
+	require.Equal(t, seed1[:], k.GetRollingSeed(ctx))
+	ctx = ctx.WithBlockHeader(abci.Header{
+		Height:      2,
+		LastBlockId: abci.BlockID{Hash: fromHex("0200000000000000000000000000000000000000000000000000000000000000")},
 	})
-	require.Equal(t, fromHex("00000000000000000000000000000000000000000000000000000000000102ff"), k.GetRollingSeed(ctx))
+	app.EndBlocker(ctx, abci.RequestEndBlock{Height: 2})
+	seed2 := sha256.Sum256(append(seed1[:], fromHex("0200000000000000000000000000000000000000000000000000000000000000")...))
+	require.Equal(t, seed2[:], k.GetRollingSeed(ctx))
+	// Blocks without VRF contributions have no random value of their own.
+	_, err := k.GetRandomness(ctx, 1)
+	require.Error(t, err)
+	_, err = k.GetRandomness(ctx, 2)
+	require.Error(t, err)
 }
 
 func TestAllocateTokensCalledOnBeginBlock(t *testing.T) {
@@ -31,6 +31,8 @@ type (
 	MsgActivate              = types.MsgActivate
 	MsgAddReporter           = types.MsgAddReporter
 	MsgRemoveReporter        = types.MsgRemoveReporter
+	MsgSetVRFKey             = types.MsgSetVRFKey
+	MsgSubmitVRF             = types.MsgSubmitVRF
 	OracleRequestPacketData  = types.OracleRequestPacketData
 	OracleResponsePacketData = types.OracleResponsePacketData
 )
@@ -24,7 +24,7 @@ func TestSuccessRequestOracleData(t *testing.T) {
 
 	ctx = ctx.WithBlockHeight(4).WithBlockTime(time.Unix(1581589790, 0))
 	handler := oracle.NewHandler(k)
-	requestMsg := types.NewMsgRequestData(types.OracleScriptID(1), []byte("calldata"), 3, 2, "app_test", testapp.Alice.Address)
+	requestMsg := types.NewMsgRequestData(types.OracleScriptID(1), []byte("calldata"), 3, 2, "app_test", testapp.Alice.Address, nil, nil)
 	res, err := handler(ctx, requestMsg)
 	require.NotNil(t, res)
 	require.NoError(t, err)
@@ -112,7 +112,7 @@ func TestExpiredRequestOracleData(t *testing.T) {
 
 	ctx = ctx.WithBlockHeight(4).WithBlockTime(time.Unix(1581589790, 0))
 	handler := oracle.NewHandler(k)
-	requestMsg := types.NewMsgRequestData(types.OracleScriptID(1), []byte("calldata"), 3, 2, "app_test", testapp.Alice.Address)
+	requestMsg := types.NewMsgRequestData(types.OracleScriptID(1), []byte("calldata"), 3, 2, "app_test", testapp.Alice.Address, nil, nil)
 	res, err := handler(ctx, requestMsg)
 	require.NotNil(t, res)
 	require.NoError(t, err)
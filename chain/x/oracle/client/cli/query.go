@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -36,6 +37,7 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetQueryCmdReporters(storeKey, cdc),
 		GetQueryActiveValidators(storeKey, cdc),
 		GetQueryPendingRequests(storeKey, cdc),
+		GetQueryCmdRandomness(storeKey, cdc),
 	)...)
 	return oracleCmd
 }
@@ -52,6 +54,36 @@ func printOutput(cliCtx context.CLIContext, cdc *codec.Codec, bz []byte, out int
 	return cliCtx.PrintOutput(out)
 }
 
+// printRequestOutput prints the request query response, with calldata and result decoded
+// into JSON using the oracle script schema if the decode flag is set.
+func printRequestOutput(cmd *cobra.Command, cliCtx context.CLIContext, route string, cdc *codec.Codec, bz []byte) error {
+	decode, err := cmd.Flags().GetBool(flagDecode)
+	if err != nil {
+		return err
+	}
+	if !decode {
+		return printOutput(cliCtx, cdc, bz, &types.QueryRequestResult{})
+	}
+	bz, err = clientcmn.DecodeRequestResponse(route, cliCtx, bz)
+	if err != nil {
+		return err
+	}
+	var result types.QueryResult
+	if err := json.Unmarshal(bz, &result); err != nil {
+		return err
+	}
+	if result.Status != http.StatusOK {
+		return cliCtx.PrintOutput(result.Result)
+	}
+	out := &bytes.Buffer{}
+	if err := json.Indent(out, result.Result, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(cmd.OutOrStdout())
+	return err
+}
+
 // GetQueryCmdParams implements the query parameters command.
 func GetQueryCmdParams(route string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -118,7 +150,7 @@ func GetQueryCmdOracleScript(route string, cdc *codec.Codec) *cobra.Command {
 
 // GetQueryCmdRequest implements the query request command.
 func GetQueryCmdRequest(route string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:  "request [id]",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -127,14 +159,16 @@ func GetQueryCmdRequest(route string, cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return printOutput(cliCtx, cdc, bz, &types.QueryRequestResult{})
+			return printRequestOutput(cmd, cliCtx, route, cdc, bz)
 		},
 	}
+	cmd.Flags().Bool(flagDecode, false, "Decode calldata and result into JSON using the oracle script schema")
+	return cmd
 }
 
 // GetQueryCmdRequestSearch implements the search request command.
 func GetQueryCmdRequestSearch(route string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:  "request-search [oracle-script-id] [calldata] [ask-count] [min-count]",
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -143,9 +177,11 @@ func GetQueryCmdRequestSearch(route string, cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return printOutput(cliCtx, cdc, bz, &types.QueryRequestResult{})
+			return printRequestOutput(cmd, cliCtx, route, cdc, bz)
 		},
 	}
+	cmd.Flags().Bool(flagDecode, false, "Decode calldata and result into JSON using the oracle script schema")
+	return cmd
 }
 
 // GetQueryCmdValidatorStatus implements the query reporter list of validator command.
@@ -218,3 +254,23 @@ func GetQueryPendingRequests(route string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetQueryCmdRandomness implements the query randomness command.
+func GetQueryCmdRandomness(route string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:  "randomness [height]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			path := fmt.Sprintf("custom/%s/%s", route, types.QueryRandomness)
+			if len(args) == 1 {
+				path += "/" + args[0]
+			}
+			bz, _, err := cliCtx.Query(path)
+			if err != nil {
+				return err
+			}
+			return printOutput(cliCtx, cdc, bz, &types.QueryRandomnessResult{})
+		},
+	}
+}
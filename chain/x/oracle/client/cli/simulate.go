@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/spf13/cobra"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	owasm "github.com/bandprotocol/go-owasm/api"
+)
+
+const (
+	flagReports        = "reports"
+	flagMaxRawRequests = "max-raw-requests"
+)
+
+// GetOfflineCmd returns the oracle commands that do not need a connection to a node.
+func GetOfflineCmd(cdc *codec.Codec) *cobra.Command {
+	oracleCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Offline oracle subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	oracleCmd.AddCommand(
+		GetCmdSimulate(cdc),
+	)
+	return oracleCmd
+}
+
+// GetCmdSimulate implements the simulate command, which runs a local oracle script the way the
+// chain would run it for a request.
+func GetCmdSimulate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate [wasm-file] [ask-count] [min-count] (-c [calldata] | -j [calldata-json]) (--schema [schema]) (--reports [reports-file])",
+		Short: "Simulate a request to a local oracle script without a node",
+		Args:  cobra.ExactArgs(3),
+		Long: strings.TrimSpace(`Run the prepare function of a local Wasm oracle script and print the raw requests it asks
+for, together with the gas used. If a reports file is given, also run the execute function with
+those reports and print the result and the gas used.
+
+The reports file is a JSON list of the reports of simulated validators. Each validator is the
+index of one of the ask-count requested validators, and raw report data is given as text, as
+data sources print it. The voting power of a validator is 1 unless given:
+
+[
+  {"validator": 0, "power": 100, "raw_reports": [{"external_id": 1, "exit_code": 0, "data": "9320.5"}]},
+  {"validator": 2, "raw_reports": [{"external_id": 1, "exit_code": 0, "data": "9321.0"}]}
+]
+
+If the schema of the oracle script is given, JSON calldata is encoded and the result is decoded
+using it.
+
+Example:
+$ bandcli oracle simulate crypto_price.wasm 4 3 -c 0000000342544300000000000003e8
+$ bandcli oracle simulate crypto_price.wasm 4 3 -j '{"symbol":"BTC","multiplier":"1000"}' --schema '{symbol:string,multiplier:u64}/{px:u64}' --reports reports.json
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wasm, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			askCount, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			minCount, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+			schema, err := cmd.Flags().GetString(flagSchema)
+			if err != nil {
+				return err
+			}
+			var input, output *obi.Type
+			if schema != "" {
+				in, out, err := obi.ParseOracleScriptSchema(schema)
+				if err != nil {
+					return err
+				}
+				input, output = &in, &out
+			}
+			calldata, err := cmd.Flags().GetBytesHex(flagCalldata)
+			if err != nil {
+				return err
+			}
+			calldataJSON, err := cmd.Flags().GetString(flagCalldataJSON)
+			if err != nil {
+				return err
+			}
+			if calldataJSON != "" {
+				if len(calldata) != 0 {
+					return fmt.Errorf("only one of --%s and --%s can be set", flagCalldata, flagCalldataJSON)
+				}
+				if input == nil {
+					return fmt.Errorf("--%s requires --%s", flagCalldataJSON, flagSchema)
+				}
+				calldata, err = obi.EncodeJSON([]byte(calldataJSON), *input)
+				if err != nil {
+					return err
+				}
+			}
+			var reports []clientcmn.SimulateReport
+			reportsFile, err := cmd.Flags().GetString(flagReports)
+			if err != nil {
+				return err
+			}
+			if reportsFile != "" {
+				bz, err := ioutil.ReadFile(reportsFile)
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal(bz, &reports); err != nil {
+					return fmt.Errorf("failed to parse reports file: %w", err)
+				}
+			}
+			maxRawRequests, err := cmd.Flags().GetUint64(flagMaxRawRequests)
+			if err != nil {
+				return err
+			}
+
+			vm, err := owasm.NewVm(0) // A single run doesn't need a cache
+			if err != nil {
+				return err
+			}
+			simulator, err := clientcmn.NewSimulator(vm, wasm, int64(maxRawRequests))
+			if err != nil {
+				return err
+			}
+			req, err := clientcmn.NewSimulatedRequest(calldata, askCount, minCount)
+			if err != nil {
+				return err
+			}
+			var result clientcmn.SimulateResult
+			req, result.Prepare, err = simulator.Prepare(req)
+			if err != nil {
+				return err
+			}
+			if reportsFile != "" {
+				execute, err := simulator.Execute(req, reports, output)
+				if err != nil {
+					return err
+				}
+				result.Execute = &execute
+			}
+			bz, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+	cmd.Flags().BytesHexP(flagCalldata, "c", nil, "Calldata used in calling the oracle script")
+	cmd.Flags().StringP(flagCalldataJSON, "j", "", "Calldata as JSON, encoded using the schema")
+	cmd.Flags().String(flagSchema, "", "Schema of the oracle script, used to encode JSON calldata and decode the result")
+	cmd.Flags().String(flagReports, "", "Path to a JSON file of validator reports, to simulate execution")
+	cmd.Flags().Uint64(flagMaxRawRequests, types.DefaultMaxRawRequestCount, "Maximum number of raw requests an oracle script can ask for")
+	return cmd
+}
@@ -2,11 +2,18 @@ package cli
 
 import (
 	"bufio"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"os"
 	"strconv"
 	"strings"
 
+	"github.com/bandprotocol/bandchain/chain/pkg/gzip"
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
+	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -25,9 +32,16 @@ const (
 	flagScript        = "script"
 	flagOwner         = "owner"
 	flagCalldata      = "calldata"
+	flagCalldataJSON  = "calldata-json"
+	flagDecode        = "decode"
 	flagClientID      = "client-id"
 	flagSchema        = "schema"
 	flagSourceCodeURL = "url"
+	flagStrictSchema  = "strict-schema"
+	flagCompress      = "compress"
+	flagAllowedVals   = "allowed-validators"
+	flagDeniedVals    = "denied-validators"
+	flagValidator     = "validator"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -44,19 +58,21 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdEditDataSource(cdc),
 		GetCmdCreateOracleScript(cdc),
 		GetCmdEditOracleScript(cdc),
-		GetCmdRequest(cdc),
+		GetCmdRequest(storeKey, cdc),
 		GetCmdActivate(cdc),
 		GetCmdAddReporters(cdc),
 		GetCmdRemoveReporter(cdc),
+		GetCmdSetVRFKey(cdc),
+		GetCmdSubmitVRF(storeKey, cdc),
 	)...)
 
 	return oracleCmd
 }
 
 // GetCmdRequest implements the request command handler.
-func GetCmdRequest(cdc *codec.Codec) *cobra.Command {
+func GetCmdRequest(route string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "request [oracle-script-id] [ask-count] [min-count] (-c [calldata]) (-m [client-id])",
+		Use:   "request [oracle-script-id] [ask-count] [min-count] (-c [calldata] | -j [calldata-json]) (-m [client-id])",
 		Short: "Make a new data request via an existing oracle script",
 		Args:  cobra.ExactArgs(3),
 		Long: strings.TrimSpace(
@@ -64,8 +80,14 @@ func GetCmdRequest(cdc *codec.Codec) *cobra.Command {
 Example:
 $ %s tx oracle request 1 4 3 -c 1234abcdef -x 20 -m client-id --from mykey
 $ %s tx oracle request 1 4 3 --calldata 1234abcdef --client-id cliend-id --from mykey
+$ %s tx oracle request 1 4 3 -j '{"symbols":["BTC","ETH"],"multiplier":"1000000"}' --from mykey
+$ %s tx oracle request 1 2 2 -c 1234abcdef --denied-validators bandvaloper1...,bandvaloper1... --from mykey
+
+JSON calldata is encoded using the input schema of the oracle script. Integers can be numbers or
+decimal strings, and bytes are hex strings. If --allowed-validators is set, only the given
+validators may be chosen for the request. Validators in --denied-validators are never chosen.
 `,
-				version.ClientName, version.ClientName,
+				version.ClientName, version.ClientName, version.ClientName, version.ClientName,
 			),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -94,11 +116,35 @@ $ %s tx oracle request 1 4 3 --calldata 1234abcdef --client-id cliend-id --from
 				return err
 			}
 
+			calldataJSON, err := cmd.Flags().GetString(flagCalldataJSON)
+			if err != nil {
+				return err
+			}
+			if calldataJSON != "" {
+				if len(calldata) != 0 {
+					return fmt.Errorf("only one of --%s and --%s can be set", flagCalldata, flagCalldataJSON)
+				}
+				calldata, err = clientcmn.EncodeCalldataJSON(route, cliCtx, oracleScriptID, []byte(calldataJSON))
+				if err != nil {
+					return err
+				}
+			}
+
 			clientID, err := cmd.Flags().GetString(flagClientID)
 			if err != nil {
 				return err
 			}
 
+			allowedVals, err := getValAddressesFlag(cmd, flagAllowedVals)
+			if err != nil {
+				return err
+			}
+
+			deniedVals, err := getValAddressesFlag(cmd, flagDeniedVals)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgRequestData(
 				oracleScriptID,
 				calldata,
@@ -106,6 +152,8 @@ $ %s tx oracle request 1 4 3 --calldata 1234abcdef --client-id cliend-id --from
 				minCount,
 				clientID,
 				cliCtx.GetFromAddress(),
+				allowedVals,
+				deniedVals,
 			)
 
 			err = msg.ValidateBasic()
@@ -118,11 +166,52 @@ $ %s tx oracle request 1 4 3 --calldata 1234abcdef --client-id cliend-id --from
 	}
 
 	cmd.Flags().BytesHexP(flagCalldata, "c", nil, "Calldata used in calling the oracle script")
+	cmd.Flags().StringP(flagCalldataJSON, "j", "", "Calldata as JSON, encoded using the oracle script schema")
 	cmd.Flags().StringP(flagClientID, "m", "", "Requester can match up the request with response by clientID")
+	cmd.Flags().StringSlice(flagAllowedVals, nil, "Comma-separated validator addresses that may be chosen for the request")
+	cmd.Flags().StringSlice(flagDeniedVals, nil, "Comma-separated validator addresses that must not be chosen for the request")
 
 	return cmd
 }
 
+// getValAddressesFlag parses the validator addresses given to the string slice flag.
+func getValAddressesFlag(cmd *cobra.Command, flag string) ([]sdk.ValAddress, error) {
+	bech32s, err := cmd.Flags().GetStringSlice(flag)
+	if err != nil {
+		return nil, err
+	}
+	var vals []sdk.ValAddress
+	for _, bech32 := range bech32s {
+		val, err := sdk.ValAddressFromBech32(bech32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in --%s: %s", flag, err.Error())
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+// readScriptFile reads the script file at the given path, compressing it in the format given
+// by the compress flag if set.
+func readScriptFile(cmd *cobra.Command, path string) ([]byte, error) {
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	compress, err := cmd.Flags().GetString(flagCompress)
+	if err != nil {
+		return nil, err
+	}
+	if compress == "" {
+		return file, nil
+	}
+	format, err := gzip.ParseFormat(compress)
+	if err != nil {
+		return nil, err
+	}
+	return gzip.Compress(file, format)
+}
+
 // GetCmdCreateDataSource implements the create data source command handler.
 func GetCmdCreateDataSource(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -156,7 +245,7 @@ $ %s tx oracle create-data-source --name coingecko-price --description "The scri
 			if err != nil {
 				return err
 			}
-			execBytes, err := ioutil.ReadFile(scriptPath)
+			execBytes, err := readScriptFile(cmd, scriptPath)
 			if err != nil {
 				return err
 			}
@@ -189,6 +278,7 @@ $ %s tx oracle create-data-source --name coingecko-price --description "The scri
 	cmd.Flags().String(flagName, "", "Name of this data source")
 	cmd.Flags().String(flagDescription, "", "Description of this data source")
 	cmd.Flags().String(flagScript, "", "Path to this data source script")
+	cmd.Flags().String(flagCompress, "", "Compress the script before upload, one of gzip, zstd and brotli")
 	cmd.Flags().String(flagOwner, "", "Owner of this data source")
 
 	return cmd
@@ -234,7 +324,7 @@ $ %s tx oracle edit-data-source 1 --name coingecko-price --description The scrip
 			}
 			execBytes := types.DoNotModifyBytes
 			if scriptPath != types.DoNotModify {
-				execBytes, err = ioutil.ReadFile(scriptPath)
+				execBytes, err = readScriptFile(cmd, scriptPath)
 				if err != nil {
 					return err
 				}
@@ -269,6 +359,7 @@ $ %s tx oracle edit-data-source 1 --name coingecko-price --description The scrip
 	cmd.Flags().String(flagName, types.DoNotModify, "Name of this data source")
 	cmd.Flags().String(flagDescription, types.DoNotModify, "Description of this data source")
 	cmd.Flags().String(flagScript, types.DoNotModify, "Path to this data source script")
+	cmd.Flags().String(flagCompress, "", "Compress the script before upload, one of gzip, zstd and brotli")
 	cmd.Flags().String(flagOwner, "", "Owner of this data source")
 
 	return cmd
@@ -277,7 +368,7 @@ $ %s tx oracle edit-data-source 1 --name coingecko-price --description The scrip
 // GetCmdCreateOracleScript implements the create oracle script command handler.
 func GetCmdCreateOracleScript(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create-oracle-script (--name [name]) (--description [description]) (--script [path-to-script]) (--owner [owner]) (--schema [schema]) (--url [source-code-url])",
+		Use:   "create-oracle-script (--name [name]) (--description [description]) (--script [path-to-script]) (--owner [owner]) (--schema [schema]) (--url [source-code-url]) (--strict-schema)",
 		Short: "Create a new oracle script that will be used by data requests.",
 		Args:  cobra.NoArgs,
 		Long: strings.TrimSpace(
@@ -306,7 +397,7 @@ $ %s tx oracle create-oracle-script --name eth-price --description "Oracle scrip
 			if err != nil {
 				return err
 			}
-			scriptCode, err := ioutil.ReadFile(scriptPath)
+			scriptCode, err := readScriptFile(cmd, scriptPath)
 			if err != nil {
 				return err
 			}
@@ -330,6 +421,11 @@ $ %s tx oracle create-oracle-script --name eth-price --description "Oracle scrip
 				return err
 			}
 
+			strictSchema, err := cmd.Flags().GetBool(flagStrictSchema)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgCreateOracleScript(
 				owner,
 				name,
@@ -338,6 +434,7 @@ $ %s tx oracle create-oracle-script --name eth-price --description "Oracle scrip
 				schema,
 				sourceCodeURL,
 				cliCtx.GetFromAddress(),
+				strictSchema,
 			)
 
 			err = msg.ValidateBasic()
@@ -351,9 +448,11 @@ $ %s tx oracle create-oracle-script --name eth-price --description "Oracle scrip
 	cmd.Flags().String(flagName, "", "Name of this oracle script")
 	cmd.Flags().String(flagDescription, "", "Description of this oracle script")
 	cmd.Flags().String(flagScript, "", "Path to this oracle script")
+	cmd.Flags().String(flagCompress, "", "Compress the script before upload, one of gzip, zstd and brotli")
 	cmd.Flags().String(flagOwner, "", "Owner of this oracle script")
 	cmd.Flags().String(flagSchema, "", "Schema of this oracle script")
 	cmd.Flags().String(flagSourceCodeURL, "", "URL for the source code of this oracle script")
+	cmd.Flags().Bool(flagStrictSchema, false, "Reject calldata and fail results that do not match the schema")
 
 	return cmd
 }
@@ -361,7 +460,7 @@ $ %s tx oracle create-oracle-script --name eth-price --description "Oracle scrip
 // GetCmdEditOracleScript implements the editing of oracle script command handler.
 func GetCmdEditOracleScript(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "edit-oracle-script [id] (--name [name]) (--description [description]) (--script [path-to-script]) (--owner [owner]) (--schema [schema]) (--url [source-code-url])",
+		Use:   "edit-oracle-script [id] (--name [name]) (--description [description]) (--script [path-to-script]) (--owner [owner]) (--schema [schema]) (--url [source-code-url]) (--strict-schema [true|false])",
 		Short: "Edit an existing oracle script that will be used by data requests.",
 		Args:  cobra.ExactArgs(1),
 		Long: strings.TrimSpace(
@@ -398,7 +497,7 @@ $ %s tx oracle edit-oracle-script 1 --name eth-price --description "Oracle scrip
 			}
 			scriptCode := types.DoNotModifyBytes
 			if scriptPath != types.DoNotModify {
-				scriptCode, err = ioutil.ReadFile(scriptPath)
+				scriptCode, err = readScriptFile(cmd, scriptPath)
 				if err != nil {
 					return err
 				}
@@ -423,6 +522,22 @@ $ %s tx oracle edit-oracle-script 1 --name eth-price --description "Oracle scrip
 				return err
 			}
 
+			strictSchemaFlag, err := cmd.Flags().GetString(flagStrictSchema)
+			if err != nil {
+				return err
+			}
+			strictSchema := types.StrictSchemaEdit_Keep
+			if strictSchemaFlag != types.DoNotModify {
+				strict, err := strconv.ParseBool(strictSchemaFlag)
+				if err != nil {
+					return err
+				}
+				strictSchema = types.StrictSchemaEdit_Disable
+				if strict {
+					strictSchema = types.StrictSchemaEdit_Enable
+				}
+			}
+
 			msg := types.NewMsgEditOracleScript(
 				oracleScriptID,
 				owner,
@@ -432,6 +547,7 @@ $ %s tx oracle edit-oracle-script 1 --name eth-price --description "Oracle scrip
 				schema,
 				sourceCodeURL,
 				cliCtx.GetFromAddress(),
+				strictSchema,
 			)
 
 			err = msg.ValidateBasic()
@@ -445,9 +561,11 @@ $ %s tx oracle edit-oracle-script 1 --name eth-price --description "Oracle scrip
 	cmd.Flags().String(flagName, types.DoNotModify, "Name of this oracle script")
 	cmd.Flags().String(flagDescription, types.DoNotModify, "Description of this oracle script")
 	cmd.Flags().String(flagScript, types.DoNotModify, "Path to this oracle script")
+	cmd.Flags().String(flagCompress, "", "Compress the script before upload, one of gzip, zstd and brotli")
 	cmd.Flags().String(flagOwner, "", "Owner of this oracle script")
 	cmd.Flags().String(flagSchema, types.DoNotModify, "Schema of this oracle script")
 	cmd.Flags().String(flagSourceCodeURL, types.DoNotModify, "URL for the source code of this oracle script")
+	cmd.Flags().String(flagStrictSchema, types.DoNotModify, "Whether to reject calldata and fail results that do not match the schema, true or false")
 
 	return cmd
 }
@@ -562,3 +680,125 @@ $ %s tx oracle remove-reporter band1p40yh3zkmhcv0ecqp3mcazy83sa57rgjp07dun --fro
 
 	return cmd
 }
+
+// readVRFKeyFile returns the hex-encoded VRF private key in the given file. If the file does not
+// exist and generate is true, a new key is generated and saved to the file.
+func readVRFKeyFile(path string, generate bool) (vrf.PrivateKey, error) {
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && generate {
+		key, err := vrf.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(key)), 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimSpace(string(raw)))
+}
+
+// GetCmdSetVRFKey implements the set VRF key command handler.
+func GetCmdSetVRFKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-vrf-key [key-file]",
+		Short: "Set the VRF key used to contribute to the randomness beacon.",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Set the VRF key used to contribute to the randomness beacon, from the hex-encoded
+private key in the given file. A new key is generated and saved to the file if it does not exist.
+Example:
+$ %s tx oracle set-vrf-key ~/.vrf_key --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			key, err := readVRFKeyFile(args[0], true)
+			if err != nil {
+				return err
+			}
+			pubKey, err := key.PublicKey()
+			if err != nil {
+				return err
+			}
+			validator := sdk.ValAddress(cliCtx.GetFromAddress())
+			msg := types.NewMsgSetVRFKey(validator, pubKey)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdSubmitVRF implements the submit VRF command handler.
+func GetCmdSubmitVRF(route string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-vrf [key-file]",
+		Short: "Contribute a VRF output to the randomness beacon of the next block.",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Contribute a VRF output to the randomness beacon of the next block, using the
+hex-encoded private key in the given file. The contribution is only accepted if the transaction
+is included in the next block.
+Example:
+$ %s tx oracle submit-vrf ~/.vrf_key --validator bandvaloper1p40yh3zkmhcv0ecqp3mcazy83sa57rgjdfxd6a --from myreporter
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			key, err := readVRFKeyFile(args[0], false)
+			if err != nil {
+				return err
+			}
+			validator := sdk.ValAddress(cliCtx.GetFromAddress())
+			if valStr, _ := cmd.Flags().GetString(flagValidator); valStr != "" {
+				validator, err = sdk.ValAddressFromBech32(valStr)
+				if err != nil {
+					return err
+				}
+			}
+			bz, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", route, types.QueryRandomness))
+			if err != nil {
+				return err
+			}
+			var result types.QueryResult
+			if err := json.Unmarshal(bz, &result); err != nil {
+				return err
+			}
+			if result.Status != http.StatusOK {
+				return fmt.Errorf("failed to query randomness: %s", result.Result)
+			}
+			var latest types.QueryRandomnessResult
+			cdc.MustUnmarshalJSON(result.Result, &latest)
+			height := latest.Height + 1
+			output, proof, err := key.Prove(types.VRFInput(cliCtx.ChainID, height, latest.RandomValue))
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgSubmitVRF(height, output, proof, validator, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagValidator, "", "Validator to contribute for, if not the sender")
+
+	return cmd
+}
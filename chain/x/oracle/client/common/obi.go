@@ -0,0 +1,119 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// QueryOracleScript returns the oracle script with the given ID from the chain.
+func QueryOracleScript(route string, cliCtx context.CLIContext, id types.OracleScriptID) (types.OracleScript, error) {
+	bz, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s/%d", route, types.QueryOracleScripts, id))
+	if err != nil {
+		return types.OracleScript{}, err
+	}
+	var result types.QueryResult
+	if err := json.Unmarshal(bz, &result); err != nil {
+		return types.OracleScript{}, err
+	}
+	if result.Status != http.StatusOK {
+		return types.OracleScript{}, fmt.Errorf("oracle script %d not found", id)
+	}
+	var oracleScript types.OracleScript
+	if err := cliCtx.Codec.UnmarshalJSON(result.Result, &oracleScript); err != nil {
+		return types.OracleScript{}, err
+	}
+	return oracleScript, nil
+}
+
+// QueryOracleScriptSchema returns the parsed input and output types of the given oracle script.
+func QueryOracleScriptSchema(route string, cliCtx context.CLIContext, id types.OracleScriptID) (obi.Type, obi.Type, error) {
+	oracleScript, err := QueryOracleScript(route, cliCtx, id)
+	if err != nil {
+		return obi.Type{}, obi.Type{}, err
+	}
+	return obi.ParseOracleScriptSchema(oracleScript.Schema)
+}
+
+// EncodeCalldataJSON encodes the given JSON calldata using the input schema of the oracle script.
+func EncodeCalldataJSON(route string, cliCtx context.CLIContext, id types.OracleScriptID, calldata []byte) ([]byte, error) {
+	input, _, err := QueryOracleScriptSchema(route, cliCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	return obi.EncodeJSON(calldata, input)
+}
+
+// DecodeRequestResponse adds the JSON decoded calldata and result, as "decoded_calldata" and
+// "decoded_result", to the given successful QueryRequestResult response, using the oracle script
+// schema at the height the request was made. The raw fields are kept as they are. If the schema
+// is unavailable or does not match, the affected fields are null and "decode_error" tells why.
+// Other responses are returned unchanged.
+func DecodeRequestResponse(route string, cliCtx context.CLIContext, bz []byte) ([]byte, error) {
+	var result types.QueryResult
+	if err := json.Unmarshal(bz, &result); err != nil {
+		return nil, err
+	}
+	if result.Status != http.StatusOK {
+		return bz, nil
+	}
+	var reqResult types.QueryRequestResult
+	if err := cliCtx.Codec.UnmarshalJSON(result.Result, &reqResult); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(result.Result, &fields); err != nil {
+		return nil, err
+	}
+	fields["decoded_calldata"] = json.RawMessage("null")
+	fields["decoded_result"] = json.RawMessage("null")
+	if decodeErr := decodeRequestFields(route, cliCtx, reqResult, fields); decodeErr != nil {
+		errBz, err := json.Marshal(decodeErr.Error())
+		if err != nil {
+			return nil, err
+		}
+		fields["decode_error"] = errBz
+	}
+	var err error
+	result.Result, err = json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// decodeRequestFields sets the decoded calldata and result of the given request in fields,
+// leaving the ones that fail to decode as they are.
+func decodeRequestFields(
+	route string, cliCtx context.CLIContext, reqResult types.QueryRequestResult, fields map[string]json.RawMessage,
+) error {
+	input, output, err := QueryOracleScriptSchema(
+		route, cliCtx.WithHeight(reqResult.Request.RequestHeight), reqResult.Request.OracleScriptID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get schema: %w", err)
+	}
+	calldata, err := obi.DecodeJSON(reqResult.Request.Calldata, input)
+	if err != nil {
+		err = fmt.Errorf("failed to decode calldata: %w", err)
+	} else {
+		fields["decoded_calldata"] = calldata
+	}
+	if reqResult.Result == nil || reqResult.Result.ResponsePacketData.ResolveStatus != types.ResolveStatus_Success {
+		return err
+	}
+	res, resErr := obi.DecodeJSON(reqResult.Result.ResponsePacketData.Result, output)
+	if resErr != nil {
+		if err != nil {
+			return fmt.Errorf("%v; failed to decode result: %w", err, resErr)
+		}
+		return fmt.Errorf("failed to decode result: %w", resErr)
+	}
+	fields["decoded_result"] = res
+	return err
+}
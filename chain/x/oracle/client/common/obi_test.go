@@ -0,0 +1,135 @@
+package common_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/mock"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	bandapp "github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// schemaApp serves oracle script 1 with the schema it had at each height.
+type schemaApp struct {
+	abci.BaseApplication
+	schemas map[int64]string
+}
+
+func (app schemaApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if req.Path != fmt.Sprintf("custom/oracle/%s/1", types.QueryOracleScripts) {
+		return abci.ResponseQuery{Code: 1, Log: "unknown query"}
+	}
+	schema, ok := app.schemas[req.Height]
+	if !ok {
+		return abci.ResponseQuery{Code: 1, Log: "height not available"}
+	}
+	bz, err := types.QueryOK(types.NewOracleScript(testapp.Owner.Address, "script", "", "", schema, "", false))
+	if err != nil {
+		panic(err)
+	}
+	return abci.ResponseQuery{Value: bz}
+}
+
+// schemaClient answers ABCI queries from the given app. Other calls are not supported.
+type schemaClient struct {
+	rpcclient.Client
+	app mock.ABCIApp
+}
+
+func (c schemaClient) ABCIQueryWithOptions(
+	path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return c.app.ABCIQueryWithOptions(path, data, opts)
+}
+
+func newSchemaCLIContext(schemas map[int64]string) context.CLIContext {
+	return context.CLIContext{
+		Client:    schemaClient{app: mock.ABCIApp{App: schemaApp{schemas: schemas}}},
+		Codec:     bandapp.MakeCodec(),
+		TrustNode: true,
+	}
+}
+
+func mustRequestResponse(t *testing.T, calldata []byte, result []byte) []byte {
+	reqResult := types.QueryRequestResult{
+		Request: types.NewRequest(1, calldata, nil, 1, 10, testapp.ParseTime(1581589790), "client", nil),
+	}
+	if result != nil {
+		res := types.NewResult(
+			types.NewOracleRequestPacketData("client", 1, calldata, 1, 1),
+			types.NewOracleResponsePacketData("client", 1, 1, 1581589790, 1581589800, types.ResolveStatus_Success, result),
+		)
+		reqResult.Result = &res
+	}
+	bz, err := types.QueryOK(reqResult)
+	require.NoError(t, err)
+	return bz
+}
+
+func mustDecodeRequestFields(t *testing.T, cliCtx context.CLIContext, bz []byte) map[string]json.RawMessage {
+	bz, err := clientcmn.DecodeRequestResponse("oracle", cliCtx, bz)
+	require.NoError(t, err)
+	var result types.QueryResult
+	require.NoError(t, json.Unmarshal(bz, &result))
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(result.Result, &fields))
+	return fields
+}
+
+func TestDecodeRequestResponse(t *testing.T) {
+	// The schema is edited after the request, so it must be read at the request height.
+	cliCtx := newSchemaCLIContext(map[int64]string{10: "{symbol:string}/{px:u64}", 0: "{x:u8}/{y:u8}"})
+	calldata := obi.MustEncode(struct{ Symbol string }{"BTC"})
+	fields := mustDecodeRequestFields(t, cliCtx, mustRequestResponse(t, calldata, obi.MustEncode(struct{ Px uint64 }{9000})))
+	require.JSONEq(t, `{"symbol":"BTC"}`, string(fields["decoded_calldata"]))
+	require.JSONEq(t, `{"px":"9000"}`, string(fields["decoded_result"]))
+	require.NotContains(t, fields, "decode_error")
+	// Unresolved requests have no decoded result.
+	fields = mustDecodeRequestFields(t, cliCtx, mustRequestResponse(t, calldata, nil))
+	require.JSONEq(t, `{"symbol":"BTC"}`, string(fields["decoded_calldata"]))
+	require.Equal(t, "null", string(fields["decoded_result"]))
+	require.NotContains(t, fields, "decode_error")
+}
+
+func TestDecodeRequestResponseSchemaMismatch(t *testing.T) {
+	cliCtx := newSchemaCLIContext(map[int64]string{10: "{symbol:string}/{px:u64}"})
+	calldata := obi.MustEncode(struct{ Symbol string }{"BTC"})
+	// The result does not match the schema, but the calldata is still decoded.
+	fields := mustDecodeRequestFields(t, cliCtx, mustRequestResponse(t, calldata, []byte("beeb")))
+	require.JSONEq(t, `{"symbol":"BTC"}`, string(fields["decoded_calldata"]))
+	require.Equal(t, "null", string(fields["decoded_result"]))
+	require.Contains(t, string(fields["decode_error"]), "failed to decode result")
+	// Neither matches.
+	fields = mustDecodeRequestFields(t, cliCtx, mustRequestResponse(t, []byte("beeb"), []byte("beeb")))
+	require.Equal(t, "null", string(fields["decoded_calldata"]))
+	require.Equal(t, "null", string(fields["decoded_result"]))
+	require.Contains(t, string(fields["decode_error"]), "failed to decode calldata")
+	require.Contains(t, string(fields["decode_error"]), "failed to decode result")
+	// The raw fields are kept.
+	require.Contains(t, fields, "request")
+	require.Contains(t, fields, "result")
+}
+
+func TestDecodeRequestResponseUnavailableSchema(t *testing.T) {
+	for _, cliCtx := range []context.CLIContext{
+		newSchemaCLIContext(map[int64]string{}),
+		newSchemaCLIContext(map[int64]string{10: "not a schema"}),
+	} {
+		calldata := obi.MustEncode(struct{ Symbol string }{"BTC"})
+		fields := mustDecodeRequestFields(t, cliCtx, mustRequestResponse(t, calldata, nil))
+		require.Equal(t, "null", string(fields["decoded_calldata"]))
+		require.Equal(t, "null", string(fields["decoded_result"]))
+		require.Contains(t, string(fields["decode_error"]), "failed to get schema")
+	}
+}
@@ -236,3 +236,166 @@ var verifyFormat = []byte(`
   }
 ]
 `)
+
+var batchVerifyFormat = []byte(`
+[
+  {
+    "internalType": "uint256",
+    "name": "_blockHeight",
+    "type": "uint256"
+  },
+  {
+    "components": [
+      {
+        "components": [
+          {
+            "internalType": "string",
+            "name": "clientId",
+            "type": "string"
+          },
+          {
+            "internalType": "uint64",
+            "name": "oracleScriptId",
+            "type": "uint64"
+          },
+          {
+            "internalType": "bytes",
+            "name": "params",
+            "type": "bytes"
+          },
+          {
+            "internalType": "uint64",
+            "name": "askCount",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint64",
+            "name": "minCount",
+            "type": "uint64"
+          }
+        ],
+        "internalType": "struct IBridge.RequestPacket",
+        "name": "requestPacket",
+        "type": "tuple"
+      },
+      {
+        "components": [
+          {
+            "internalType": "string",
+            "name": "clientId",
+            "type": "string"
+          },
+          {
+            "internalType": "uint64",
+            "name": "requestId",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint64",
+            "name": "ansCount",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint64",
+            "name": "requestTime",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint64",
+            "name": "resolveTime",
+            "type": "uint64"
+          },
+          {
+            "internalType": "uint8",
+            "name": "resolveStatus",
+            "type": "uint8"
+          },
+          {
+            "internalType": "bytes",
+            "name": "result",
+            "type": "bytes"
+          }
+        ],
+        "internalType": "struct IBridge.ResponsePacket",
+        "name": "responsePacket",
+        "type": "tuple"
+      },
+      {
+        "internalType": "uint256",
+        "name": "version",
+        "type": "uint256"
+      }
+    ],
+    "internalType": "struct IAVLMerkleMultiPath.Leaf[]",
+    "name": "_leaves",
+    "type": "tuple[]"
+  },
+  {
+    "components": [
+      {
+        "internalType": "uint256",
+        "name": "leafCount",
+        "type": "uint256"
+      },
+      {
+        "internalType": "uint8",
+        "name": "subtreeHeight",
+        "type": "uint8"
+      },
+      {
+        "internalType": "uint256",
+        "name": "subtreeSize",
+        "type": "uint256"
+      },
+      {
+        "internalType": "uint256",
+        "name": "subtreeVersion",
+        "type": "uint256"
+      },
+      {
+        "internalType": "bytes32",
+        "name": "leftHash",
+        "type": "bytes32"
+      },
+      {
+        "internalType": "bytes32",
+        "name": "rightHash",
+        "type": "bytes32"
+      }
+    ],
+    "internalType": "struct IAVLMerkleMultiPath.Node[]",
+    "name": "_innerNodes",
+    "type": "tuple[]"
+  }
+]
+`)
+
+var relayAndVerifyFormat = []byte(`
+[
+  {
+    "internalType": "bytes",
+    "name": "relayData",
+    "type": "bytes"
+  },
+  {
+    "internalType": "bytes",
+    "name": "verifyData",
+    "type": "bytes"
+  }
+]
+`)
+
+var relayAndMultiVerifyFormat = []byte(`
+[
+  {
+    "internalType": "bytes",
+    "name": "relayData",
+    "type": "bytes"
+  },
+  {
+    "internalType": "bytes[]",
+    "name": "manyVerifyData",
+    "type": "bytes[]"
+  }
+]
+`)
@@ -0,0 +1,405 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+const (
+	// MaxBatchProofSize is the maximum number of results that can be proven in one batch proof.
+	MaxBatchProofSize = 256
+)
+
+var batchVerifyArguments abi.Arguments
+
+func init() {
+	err := json.Unmarshal(batchVerifyFormat, &batchVerifyArguments)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// OracleDataLeaf is a request result stored as a leaf of the oracle IAVL tree.
+type OracleDataLeaf struct {
+	RequestPacket  types.OracleRequestPacketData  `json:"requestPacket"`
+	ResponsePacket types.OracleResponsePacketData `json:"responsePacket"`
+	Version        uint64                         `json:"version"`
+}
+
+// OracleDataLeafEthereum is an Ethereum version of OracleDataLeaf for solidity ABI-encoding.
+type OracleDataLeafEthereum struct {
+	RequestPacket  RequestPacketEthereum
+	ResponsePacket ResponsePacketEthereum
+	Version        *big.Int
+}
+
+// IAVLMerkleNode is an inner node of the oracle IAVL tree that is needed to prove a batch of
+// results. A child hash is empty when the child is computed from the batch itself.
+type IAVLMerkleNode struct {
+	LeafCount      uint64           `json:"leafCount"` // Number of leaves to take right before this node
+	SubtreeHeight  uint8            `json:"subtreeHeight"`
+	SubtreeSize    uint64           `json:"subtreeSize"`
+	SubtreeVersion uint64           `json:"subtreeVersion"`
+	LeftHash       tmbytes.HexBytes `json:"leftHash"`
+	RightHash      tmbytes.HexBytes `json:"rightHash"`
+}
+
+// IAVLMerkleNodeEthereum is an Ethereum version of IAVLMerkleNode for solidity ABI-encoding.
+// Child hashes computed from the batch are encoded as zero hashes.
+type IAVLMerkleNodeEthereum struct {
+	LeafCount      *big.Int
+	SubtreeHeight  uint8
+	SubtreeSize    *big.Int
+	SubtreeVersion *big.Int
+	LeftHash       common.Hash
+	RightHash      common.Hash
+}
+
+func (node *IAVLMerkleNode) encodeToEthFormat() IAVLMerkleNodeEthereum {
+	return IAVLMerkleNodeEthereum{
+		big.NewInt(int64(node.LeafCount)),
+		node.SubtreeHeight,
+		big.NewInt(int64(node.SubtreeSize)),
+		big.NewInt(int64(node.SubtreeVersion)),
+		common.BytesToHash(node.LeftHash),
+		common.BytesToHash(node.RightHash),
+	}
+}
+
+// getHash returns the hash of this node given the hashes of its children.
+func (node *IAVLMerkleNode) getHash(left, right []byte) []byte {
+	raw := []byte{node.SubtreeHeight << 1} // Tendermint signed-int8 encoding requires multiplying by 2
+	raw = append(raw, encodeVarint(int64(node.SubtreeSize))...)
+	raw = append(raw, encodeVarint(int64(node.SubtreeVersion))...)
+	raw = append(raw, uint8(len(left)))
+	raw = append(raw, left...)
+	raw = append(raw, uint8(len(right)))
+	raw = append(raw, right...)
+	return tmhash.Sum(raw)
+}
+
+// OracleDataBatchProof proves a set of results against one oracle IAVL root. Leaves are sorted by
+// request ID and inner nodes are listed in post-order, so the root is computed with one stack:
+// for each node, push its LeafCount next leaves, then pop the computed right and left children.
+type OracleDataBatchProof struct {
+	Leaves     []OracleDataLeaf `json:"leaves"`
+	InnerNodes []IAVLMerkleNode `json:"innerNodes"`
+}
+
+func (b *OracleDataBatchProof) encodeToEthData(blockHeight uint64) ([]byte, error) {
+	parseLeaves := make([]OracleDataLeafEthereum, len(b.Leaves))
+	for i, leaf := range b.Leaves {
+		parseLeaves[i] = OracleDataLeafEthereum{
+			transformRequestPacket(leaf.RequestPacket),
+			transformResponsePacket(leaf.ResponsePacket),
+			big.NewInt(int64(leaf.Version)),
+		}
+	}
+	parseNodes := make([]IAVLMerkleNodeEthereum, len(b.InnerNodes))
+	for i, node := range b.InnerNodes {
+		parseNodes[i] = node.encodeToEthFormat()
+	}
+	return batchVerifyArguments.Pack(big.NewInt(int64(blockHeight)), parseLeaves, parseNodes)
+}
+
+// GetOracleStateHash returns the root hash of the oracle IAVL tree computed from the leaves and
+// the inner nodes of this batch proof. Returns an error if the proof is not well-formed.
+func (b *OracleDataBatchProof) GetOracleStateHash() ([]byte, error) {
+	if len(b.Leaves) == 0 {
+		return nil, errors.New("batch proof has no leaves")
+	}
+	stack := make([][]byte, 0)
+	nextLeaf := 0
+	pushLeaves := func(count uint64) error {
+		if count > uint64(len(b.Leaves)-nextLeaf) {
+			return errors.New("batch proof leaves exhausted")
+		}
+		for ; count > 0; count-- {
+			leaf := b.Leaves[nextLeaf]
+			stack = append(stack, getResultLeafHash(leaf.RequestPacket, leaf.ResponsePacket, leaf.Version))
+			nextLeaf++
+		}
+		return nil
+	}
+	popChild := func(hash []byte) ([]byte, error) {
+		if len(hash) != 0 {
+			if len(hash) != 32 {
+				return nil, fmt.Errorf("invalid child hash length %d", len(hash))
+			}
+			return hash, nil
+		}
+		if len(stack) == 0 {
+			return nil, errors.New("batch proof stack underflow")
+		}
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return top, nil
+	}
+	for idx, node := range b.InnerNodes {
+		if err := pushLeaves(node.LeafCount); err != nil {
+			return nil, fmt.Errorf("inner node #%d: %w", idx, err)
+		}
+		right, err := popChild(node.RightHash)
+		if err != nil {
+			return nil, fmt.Errorf("inner node #%d: %w", idx, err)
+		}
+		left, err := popChild(node.LeftHash)
+		if err != nil {
+			return nil, fmt.Errorf("inner node #%d: %w", idx, err)
+		}
+		stack = append(stack, node.getHash(left, right))
+	}
+	if err := pushLeaves(uint64(len(b.Leaves) - nextLeaf)); err != nil {
+		return nil, err
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("batch proof does not reduce to one root, got %d hashes", len(stack))
+	}
+	return stack[0], nil
+}
+
+// batchTreeNode is an inner node of the partial IAVL tree assembled from single result proofs.
+type batchTreeNode struct {
+	path     IAVLMerklePath
+	children [2][]byte // Sibling hashes of the left and right children, nil if computed
+}
+
+// NewOracleDataBatchProof merges single result proofs taken from the same oracle IAVL root into
+// one batch proof, sharing the inner nodes common to several results.
+func NewOracleDataBatchProof(proofs []OracleDataProof) (OracleDataBatchProof, error) {
+	if len(proofs) == 0 {
+		return OracleDataBatchProof{}, errors.New("no proofs to batch")
+	}
+	// Nodes are identified by their position from the root, written as a string of 'L' and 'R'.
+	nodes := make(map[string]*batchTreeNode)
+	leaves := make(map[string]int)
+	for idx, p := range proofs {
+		position := ""
+		for i := len(p.MerklePaths) - 1; i >= 0; i-- {
+			path := p.MerklePaths[i]
+			side, direction := 0, "L"
+			if path.IsDataOnRight {
+				side, direction = 1, "R"
+			}
+			node, ok := nodes[position]
+			if !ok {
+				node = &batchTreeNode{path: path}
+				node.children[1-side] = path.SiblingHash
+				nodes[position] = node
+			} else if node.path.SubtreeHeight != path.SubtreeHeight ||
+				node.path.SubtreeSize != path.SubtreeSize ||
+				node.path.SubtreeVersion != path.SubtreeVersion {
+				return OracleDataBatchProof{}, fmt.Errorf(
+					"request #%d: proof is not from the same tree", p.ResponsePacket.RequestID)
+			}
+			node.children[side] = nil
+			if _, ok := leaves[position]; ok {
+				return OracleDataBatchProof{}, fmt.Errorf(
+					"request #%d: proof is not from the same tree", p.ResponsePacket.RequestID)
+			}
+			position += direction
+		}
+		if _, ok := nodes[position]; ok {
+			return OracleDataBatchProof{}, fmt.Errorf(
+				"request #%d: proof is not from the same tree", p.ResponsePacket.RequestID)
+		}
+		if _, ok := leaves[position]; ok {
+			return OracleDataBatchProof{}, fmt.Errorf(
+				"request #%d: duplicate result in batch", p.ResponsePacket.RequestID)
+		}
+		leaves[position] = idx
+	}
+	batch := OracleDataBatchProof{}
+	leafCount := uint64(0)
+	var visit func(position string)
+	visit = func(position string) {
+		if idx, ok := leaves[position]; ok {
+			p := proofs[idx]
+			batch.Leaves = append(batch.Leaves, OracleDataLeaf{p.RequestPacket, p.ResponsePacket, p.Version})
+			leafCount++
+			return
+		}
+		node := nodes[position]
+		if node.children[0] == nil {
+			visit(position + "L")
+		}
+		if node.children[1] == nil {
+			visit(position + "R")
+		}
+		batch.InnerNodes = append(batch.InnerNodes, IAVLMerkleNode{
+			LeafCount:      leafCount,
+			SubtreeHeight:  node.path.SubtreeHeight,
+			SubtreeSize:    node.path.SubtreeSize,
+			SubtreeVersion: node.path.SubtreeVersion,
+			LeftHash:       node.children[0],
+			RightHash:      node.children[1],
+		})
+		leafCount = 0
+	}
+	visit("")
+	return batch, nil
+}
+
+type JsonBatchProof struct {
+	BlockHeight          uint64               `json:"blockHeight"`
+	OracleDataBatchProof OracleDataBatchProof `json:"oracleDataBatchProof"`
+	BlockRelayProof      BlockRelayProof      `json:"blockRelayProof"`
+}
+
+type BatchProof struct {
+	JsonProof     JsonBatchProof   `json:"jsonProof"`
+	EVMProofBytes tmbytes.HexBytes `json:"evmProofBytes"`
+}
+
+// parseBatchRequestIDs returns the sorted request IDs to prove, given either as a contiguous
+// range with "from" and "to" or as a list of "id" query parameters.
+func parseBatchRequestIDs(r *http.Request) ([]types.RequestID, error) {
+	query := r.URL.Query()
+	from, to, ids := query.Get("from"), query.Get("to"), query["id"]
+	requestIDs := make([]types.RequestID, 0)
+	if from != "" || to != "" {
+		if len(ids) != 0 {
+			return nil, errors.New("cannot use id together with from and to")
+		}
+		start, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		end, err := strconv.ParseUint(to, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		if start > end || end-start >= MaxBatchProofSize {
+			return nil, fmt.Errorf("range must contain 1 to %d requests", MaxBatchProofSize)
+		}
+		for id := start; id <= end; id++ {
+			requestIDs = append(requestIDs, types.RequestID(id))
+		}
+		return requestIDs, nil
+	}
+	if len(ids) == 0 || len(ids) > MaxBatchProofSize {
+		return nil, fmt.Errorf("must specify 1 to %d request ids", MaxBatchProofSize)
+	}
+	seen := make(map[types.RequestID]bool)
+	for _, id := range ids {
+		intRequestID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		requestID := types.RequestID(intRequestID)
+		if seen[requestID] {
+			return nil, fmt.Errorf("duplicate request id %d", requestID)
+		}
+		seen[requestID] = true
+		requestIDs = append(requestIDs, requestID)
+	}
+	sort.Slice(requestIDs, func(i, j int) bool { return requestIDs[i] < requestIDs[j] })
+	return requestIDs, nil
+}
+
+// GetBatchProofHandlerFn returns one proof for a batch of results, given either as a contiguous
+// range with "from" and "to" or as a list of "id" query parameters.
+func GetBatchProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		height := &ctx.Height
+		if ctx.Height == 0 {
+			height = nil
+		}
+		requestIDs, err := parseBatchRequestIDs(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		commit, err := ctx.Client.Commit(height)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		signatures, err := GetSignaturesAndPrefix(&commit.SignedHeader)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		blockRelay := BlockRelayProof{
+			BlockHeaderMerkleParts: GetBlockHeaderMerkleParts(ctx.Codec, commit.Header),
+			Signatures:             signatures,
+		}
+
+		oracleDataList := make([]OracleDataProof, len(requestIDs))
+		for idx, requestID := range requestIDs {
+			if !checkResultResolved(w, ctx, route, requestID) {
+				return
+			}
+			oracleData, multiStoreProof, status, err := queryOracleDataProof(ctx, requestID, commit.Height-1)
+			if err != nil {
+				rest.WriteErrorResponse(w, status, err.Error())
+				return
+			}
+			if idx == 0 {
+				// All results share the same multistore proof, so only take it from the first one.
+				blockRelay.MultiStoreProof = GetMultiStoreProof(multiStoreProof)
+			}
+			oracleDataList[idx] = oracleData
+		}
+		batch, err := NewOracleDataBatchProof(oracleDataList)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		oracleStateHash, err := batch.GetOracleStateHash()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !bytes.Equal(oracleStateHash, blockRelay.MultiStoreProof.OracleIAVLStateHash) {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Batch proof does not match oracle state hash")
+			return
+		}
+
+		blockRelayBytes, err := blockRelay.EncodeToEthData()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		batchBytes, err := batch.encodeToEthData(uint64(commit.Height))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		evmProofBytes, err := relayAndVerifyArguments.Pack(blockRelayBytes, batchBytes)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, ctx, BatchProof{
+			JsonProof: JsonBatchProof{
+				BlockHeight:          uint64(commit.Height),
+				OracleDataBatchProof: batch,
+				BlockRelayProof:      blockRelay,
+			},
+			EVMProofBytes: evmProofBytes,
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package proof
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func mockResult(id types.RequestID) types.Result {
+	return types.NewResult(
+		types.NewOracleRequestPacketData(fmt.Sprintf("client%d", id), 1, []byte("calldata"), 4, 3),
+		types.NewOracleResponsePacketData(
+			fmt.Sprintf("client%d", id), id, 3, 1596632713, 1596632719, types.ResolveStatus_Success, []byte("result"),
+		),
+	)
+}
+
+// mockOracleTree returns an IAVL tree with results of requests 1 to 100 saved over many versions,
+// mixed with other oracle store keys.
+func mockOracleTree(t *testing.T) *iavl.MutableTree {
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 0)
+	require.NoError(t, err)
+	r := rand.New(rand.NewSource(42))
+	for id := types.RequestID(1); id <= 100; id++ {
+		tree.Set(types.RequestStoreKey(id), []byte(fmt.Sprintf("request%d", id)))
+		tree.Set(types.ResultStoreKey(id), obi.MustEncode(mockResult(id)))
+		if r.Intn(3) == 0 {
+			_, _, err := tree.SaveVersion()
+			require.NoError(t, err)
+		}
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	return tree
+}
+
+func mockOracleDataProof(t *testing.T, tree *iavl.MutableTree, id types.RequestID) OracleDataProof {
+	_, rp, err := tree.GetVersionedWithProof(types.ResultStoreKey(id), tree.Version())
+	require.NoError(t, err)
+	valueOp := iavl.NewValueOp(types.ResultStoreKey(id), rp)
+	result := mockResult(id)
+	return OracleDataProof{
+		RequestPacket:  result.RequestPacketData,
+		ResponsePacket: result.ResponsePacketData,
+		Version:        uint64(rp.Leaves[0].Version),
+		MerklePaths:    GetIAVLMerklePaths(&valueOp),
+	}
+}
+
+func TestSingleOracleDataProof(t *testing.T) {
+	tree := mockOracleTree(t)
+	p := mockOracleDataProof(t, tree, 42)
+	require.Equal(t, tree.Hash(), p.GetOracleStateHash())
+}
+
+func TestOracleDataBatchProof(t *testing.T) {
+	tree := mockOracleTree(t)
+	testCases := [][]types.RequestID{
+		{1},
+		{100},
+		{1, 2},
+		{7, 3, 5},
+		{1, 50, 100},
+		{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+	}
+	all := make([]types.RequestID, 100)
+	for idx := range all {
+		all[idx] = types.RequestID(idx + 1)
+	}
+	testCases = append(testCases, all)
+	for _, ids := range testCases {
+		proofs := make([]OracleDataProof, len(ids))
+		singlePathCount := 0
+		for idx, id := range ids {
+			proofs[idx] = mockOracleDataProof(t, tree, id)
+			singlePathCount += len(proofs[idx].MerklePaths)
+		}
+		batch, err := NewOracleDataBatchProof(proofs)
+		require.NoError(t, err)
+		require.Len(t, batch.Leaves, len(ids))
+		for idx := 1; idx < len(batch.Leaves); idx++ {
+			require.True(t, batch.Leaves[idx-1].ResponsePacket.RequestID < batch.Leaves[idx].ResponsePacket.RequestID)
+		}
+		require.LessOrEqual(t, len(batch.InnerNodes), singlePathCount)
+		hash, err := batch.GetOracleStateHash()
+		require.NoError(t, err)
+		require.Equal(t, tree.Hash(), hash, "ids: %v", ids)
+		_, err = batch.encodeToEthData(100)
+		require.NoError(t, err)
+	}
+}
+
+func TestOracleDataBatchProofSharesInnerNodes(t *testing.T) {
+	tree := mockOracleTree(t)
+	proofs := make([]OracleDataProof, 100)
+	singlePathCount := 0
+	for idx := range proofs {
+		proofs[idx] = mockOracleDataProof(t, tree, types.RequestID(idx+1))
+		singlePathCount += len(proofs[idx].MerklePaths)
+	}
+	batch, err := NewOracleDataBatchProof(proofs)
+	require.NoError(t, err)
+	// A batch over N leaves needs at most N-1 computed nodes plus the paths to the rest of the tree.
+	require.Less(t, len(batch.InnerNodes)*4, singlePathCount)
+}
+
+func TestNewOracleDataBatchProofFail(t *testing.T) {
+	tree := mockOracleTree(t)
+	_, err := NewOracleDataBatchProof(nil)
+	require.EqualError(t, err, "no proofs to batch")
+	p := mockOracleDataProof(t, tree, 5)
+	_, err = NewOracleDataBatchProof([]OracleDataProof{p, p})
+	require.EqualError(t, err, "request #5: duplicate result in batch")
+	// Proofs from different versions of the tree cannot be merged.
+	other := mockOracleDataProof(t, tree, 6)
+	tree.Set(types.ResultStoreKey(101), obi.MustEncode(mockResult(101)))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	newer := mockOracleDataProof(t, tree, 7)
+	_, err = NewOracleDataBatchProof([]OracleDataProof{other, newer})
+	require.EqualError(t, err, "request #7: proof is not from the same tree")
+}
+
+func TestOracleDataBatchProofGetOracleStateHashFail(t *testing.T) {
+	tree := mockOracleTree(t)
+	batch, err := NewOracleDataBatchProof([]OracleDataProof{
+		mockOracleDataProof(t, tree, 3), mockOracleDataProof(t, tree, 4), mockOracleDataProof(t, tree, 90),
+	})
+	require.NoError(t, err)
+
+	empty := OracleDataBatchProof{InnerNodes: batch.InnerNodes}
+	_, err = empty.GetOracleStateHash()
+	require.EqualError(t, err, "batch proof has no leaves")
+
+	extraLeaf := OracleDataBatchProof{Leaves: append(batch.Leaves, batch.Leaves[0]), InnerNodes: batch.InnerNodes}
+	_, err = extraLeaf.GetOracleStateHash()
+	require.EqualError(t, err, "batch proof does not reduce to one root, got 2 hashes")
+
+	missingLeaf := OracleDataBatchProof{Leaves: batch.Leaves[:2], InnerNodes: batch.InnerNodes}
+	_, err = missingLeaf.GetOracleStateHash()
+	require.Error(t, err)
+
+	badHash := OracleDataBatchProof{Leaves: batch.Leaves, InnerNodes: append([]IAVLMerkleNode{}, batch.InnerNodes...)}
+	for idx, node := range badHash.InnerNodes {
+		if len(node.LeftHash) != 0 {
+			badHash.InnerNodes[idx].LeftHash = node.LeftHash[:31]
+			break
+		}
+	}
+	_, err = badHash.GetOracleStateHash()
+	require.Contains(t, err.Error(), "invalid child hash length 31")
+
+	tampered := OracleDataBatchProof{Leaves: append([]OracleDataLeaf{}, batch.Leaves...), InnerNodes: batch.InnerNodes}
+	tampered.Leaves[1].ResponsePacket.Result = []byte("tampered")
+	hash, err := tampered.GetOracleStateHash()
+	require.NoError(t, err)
+	require.NotEqual(t, tree.Hash(), hash)
+}
@@ -1,6 +1,8 @@
 package proof
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/tendermint/tendermint/crypto/merkle"
@@ -70,6 +72,33 @@ func (bp *BlockHeaderMerkleParts) encodeToEthFormat() BlockHeaderMerklePartsEthe
 	}
 }
 
+// GetBlockHeader returns the block header hash after combining the Merkle parts with the given
+// application state hash.
+func (bp *BlockHeaderMerkleParts) GetBlockHeader(appHash []byte) []byte {
+	return merkleInnerHash( // [BlockHeader]
+		merkleInnerHash( // [3A]
+			merkleInnerHash( // [2A]
+				bp.VersionAndChainIdHash, // [1A]
+				merkleInnerHash( // [1B]
+					merkleLeafHash(encodeUvarint(bp.Height)),                                              // [2]
+					merkleLeafHash(encodeTime(time.Unix(int64(bp.TimeSecond), int64(bp.TimeNanoSecond)))), // [3]
+				),
+			),
+			bp.LastBlockIDAndOther, // [2B]
+		),
+		merkleInnerHash( // [3B]
+			merkleInnerHash( // [2C]
+				bp.NextValidatorHashAndConsensusHash, // [1E]
+				merkleInnerHash( // [1F]
+					merkleLeafHash(append([]byte{32}, appHash...)), // [A]
+					bp.LastResultsHash, // [B]
+				),
+			),
+			bp.EvidenceAndProposerHash, // [2D]
+		),
+	)
+}
+
 // GetBlockHeaderMerkleParts converts Tendermint block header struct into BlockHeaderMerkleParts for gas-optimized proof verification.
 func GetBlockHeaderMerkleParts(codec *codec.Codec, block *types.Header) BlockHeaderMerkleParts {
 	return BlockHeaderMerkleParts{
@@ -0,0 +1,118 @@
+package proof
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	FormatTag = "format"
+
+	// FormatEVM is the Solidity ABI encoding accepted by the Ethereum bridge contracts.
+	FormatEVM = "evm"
+	// FormatICON is the OBI encoding accepted by the ICON bridge contracts under bridges/icon.
+	FormatICON = "icon"
+	// FormatRLP is the RLP encoding of the same structures used by the EVM format.
+	FormatRLP = "rlp"
+	// FormatProto is the canonical protobuf encoding of the JSON proof.
+	FormatProto = "proto"
+)
+
+var (
+	relayAndVerifyArguments      abi.Arguments
+	relayAndMultiVerifyArguments abi.Arguments
+)
+
+func init() {
+	err := json.Unmarshal(relayAndVerifyFormat, &relayAndVerifyArguments)
+	if err != nil {
+		panic(err)
+	}
+	err = json.Unmarshal(relayAndMultiVerifyFormat, &relayAndMultiVerifyArguments)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ProofEncoder serializes proofs into the byte format understood by the bridge contracts of
+// a target chain.
+type ProofEncoder interface {
+	// EncodeProof encodes the proof of a single request result.
+	EncodeProof(proof JsonProof) ([]byte, error)
+	// EncodeMultiProof encodes the proof of multiple request results sharing one block relay.
+	EncodeMultiProof(proof JsonMultiProof) ([]byte, error)
+}
+
+var proofEncoders = map[string]ProofEncoder{
+	FormatEVM:   evmEncoder{},
+	FormatICON:  iconEncoder{},
+	FormatRLP:   rlpEncoder{},
+	FormatProto: protoEncoder{},
+}
+
+// RegisterProofEncoder makes the given encoder available under the given format name. Panics
+// if the format is already registered.
+func RegisterProofEncoder(format string, encoder ProofEncoder) {
+	if _, ok := proofEncoders[format]; ok {
+		panic(fmt.Sprintf("proof encoder for format %s already registered", format))
+	}
+	proofEncoders[format] = encoder
+}
+
+// GetProofEncoder returns the encoder registered under the given format name. An empty format
+// name selects the EVM encoder.
+func GetProofEncoder(format string) (ProofEncoder, error) {
+	if format == "" {
+		format = FormatEVM
+	}
+	encoder, ok := proofEncoders[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown proof format %s, expect one of: %s", format, strings.Join(getProofFormats(), ", "))
+	}
+	return encoder, nil
+}
+
+// getProofFormats returns the sorted names of all registered proof formats.
+func getProofFormats() []string {
+	formats := make([]string, 0, len(proofEncoders))
+	for format := range proofEncoders {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// evmEncoder encodes proofs as the calldata of relayAndVerify and relayAndMultiVerify of the
+// Ethereum bridge.
+type evmEncoder struct{}
+
+func (evmEncoder) EncodeProof(proof JsonProof) ([]byte, error) {
+	blockRelayBytes, err := proof.BlockRelayProof.EncodeToEthData()
+	if err != nil {
+		return nil, err
+	}
+	oracleDataBytes, err := proof.OracleDataProof.encodeToEthData(proof.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	return relayAndVerifyArguments.Pack(blockRelayBytes, oracleDataBytes)
+}
+
+func (evmEncoder) EncodeMultiProof(proof JsonMultiProof) ([]byte, error) {
+	blockRelayBytes, err := proof.BlockRelayProof.EncodeToEthData()
+	if err != nil {
+		return nil, err
+	}
+	oracleDataBytesList := make([][]byte, len(proof.OracleDataMultiProof))
+	for idx, oracleData := range proof.OracleDataMultiProof {
+		oracleDataBytesList[idx], err = oracleData.encodeToEthData(proof.BlockHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return relayAndMultiVerifyArguments.Pack(blockRelayBytes, oracleDataBytesList)
+}
@@ -0,0 +1,211 @@
+package proof
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func mockJsonMultiProof(t *testing.T, ids ...types.RequestID) JsonMultiProof {
+	tree := mockOracleTree(t)
+	oracleData := make([]OracleDataProof, len(ids))
+	for i, id := range ids {
+		oracleData[i] = mockOracleDataProof(t, tree, id)
+	}
+	return JsonMultiProof{
+		BlockHeight:          uint64(tree.Version()) + 1,
+		OracleDataMultiProof: oracleData,
+		BlockRelayProof: BlockRelayProof{
+			MultiStoreProof: MultiStoreProof{
+				AccToGovStoresMerkleHash:          tmhash.Sum([]byte("accToGov")),
+				MainAndMintStoresMerkleHash:       tmhash.Sum([]byte("mainAndMint")),
+				OracleIAVLStateHash:               tree.Hash(),
+				ParamsStoresMerkleHash:            tmhash.Sum([]byte("params")),
+				SlashingToUpgradeStoresMerkleHash: tmhash.Sum([]byte("slashingToUpgrade")),
+			},
+			BlockHeaderMerkleParts: BlockHeaderMerkleParts{
+				VersionAndChainIdHash:             tmhash.Sum([]byte("versionAndChainId")),
+				Height:                            uint64(tree.Version()) + 1,
+				TimeSecond:                        1596632719,
+				TimeNanoSecond:                    123456789,
+				LastBlockIDAndOther:               tmhash.Sum([]byte("lastBlockIDAndOther")),
+				NextValidatorHashAndConsensusHash: tmhash.Sum([]byte("nextValidatorHashAndConsensus")),
+				LastResultsHash:                   tmhash.Sum([]byte("lastResults")),
+				EvidenceAndProposerHash:           tmhash.Sum([]byte("evidenceAndProposer")),
+			},
+			Signatures: []TMSignature{
+				{
+					R:                tmhash.Sum([]byte("r1")),
+					S:                tmhash.Sum([]byte("s1")),
+					V:                27,
+					SignedDataPrefix: []byte("prefix1"),
+					SignedDataSuffix: []byte("suffix1"),
+				},
+				{
+					R:                tmhash.Sum([]byte("r2")),
+					S:                tmhash.Sum([]byte("s2")),
+					V:                28,
+					SignedDataPrefix: []byte("prefix2"),
+					SignedDataSuffix: []byte("suffix2"),
+				},
+			},
+		},
+	}
+}
+
+func mockJsonProof(t *testing.T, id types.RequestID) JsonProof {
+	multi := mockJsonMultiProof(t, id)
+	return JsonProof{
+		BlockHeight:     multi.BlockHeight,
+		OracleDataProof: multi.OracleDataMultiProof[0],
+		BlockRelayProof: multi.BlockRelayProof,
+	}
+}
+
+func TestGetProofEncoder(t *testing.T) {
+	encoder, err := GetProofEncoder("")
+	require.NoError(t, err)
+	require.Equal(t, evmEncoder{}, encoder)
+	for _, format := range []string{FormatEVM, FormatICON, FormatRLP, FormatProto} {
+		_, err := GetProofEncoder(format)
+		require.NoError(t, err)
+	}
+	_, err = GetProofEncoder("solana")
+	require.EqualError(t, err, "unknown proof format solana, expect one of: evm, icon, proto, rlp")
+	require.Panics(t, func() { RegisterProofEncoder(FormatEVM, rlpEncoder{}) })
+}
+
+func TestEVMEncoder(t *testing.T) {
+	proof := mockJsonProof(t, 42)
+	bz, err := evmEncoder{}.EncodeProof(proof)
+	require.NoError(t, err)
+	values, err := relayAndVerifyArguments.UnpackValues(bz)
+	require.NoError(t, err)
+	relayBytes, err := proof.BlockRelayProof.EncodeToEthData()
+	require.NoError(t, err)
+	verifyBytes, err := proof.OracleDataProof.encodeToEthData(proof.BlockHeight)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{relayBytes, verifyBytes}, values)
+
+	multi := mockJsonMultiProof(t, 1, 42, 100)
+	bz, err = evmEncoder{}.EncodeMultiProof(multi)
+	require.NoError(t, err)
+	values, err = relayAndMultiVerifyArguments.UnpackValues(bz)
+	require.NoError(t, err)
+	require.Equal(t, relayBytes, values[0])
+	require.Len(t, values[1], 3)
+}
+
+func TestICONEncoder(t *testing.T) {
+	proof := mockJsonProof(t, 42)
+	bz, err := iconEncoder{}.EncodeProof(proof)
+	require.NoError(t, err)
+	var icon ICONProof
+	require.NoError(t, obi.Decode(bz, &icon))
+	require.Equal(t, proof.BlockHeight, icon.BlockHeight)
+	require.Equal(t, proof.OracleDataProof.Version, icon.Version)
+
+	// Recompute the block hash the same way as the ICON bridge contract.
+	require.Len(t, icon.MultiStore, 160)
+	require.Len(t, icon.MerkleParts, 192)
+	multiStore := MultiStoreProof{
+		AccToGovStoresMerkleHash:          icon.MultiStore[0:32],
+		MainAndMintStoresMerkleHash:       icon.MultiStore[32:64],
+		OracleIAVLStateHash:               icon.MultiStore[64:96],
+		ParamsStoresMerkleHash:            icon.MultiStore[96:128],
+		SlashingToUpgradeStoresMerkleHash: icon.MultiStore[128:160],
+	}
+	appHash := multiStore.GetAppHash()
+	parts := icon.MerkleParts
+	blockHash := merkleInnerHash(
+		merkleInnerHash(
+			merkleInnerHash(parts[0:32], merkleInnerHash(merkleLeafHash(encodeUvarint(icon.BlockHeight)), parts[32:64])),
+			parts[64:96],
+		),
+		merkleInnerHash(
+			merkleInnerHash(parts[96:128], merkleInnerHash(merkleLeafHash(append([]byte{32}, appHash...)), parts[128:160])),
+			parts[160:192],
+		),
+	)
+	require.Equal(t, proof.BlockRelayProof.BlockHeaderMerkleParts.GetBlockHeader(appHash), blockHash)
+
+	var signatures []TMSignature
+	require.NoError(t, obi.Decode(icon.Signatures, &signatures))
+	require.Equal(t, proof.BlockRelayProof.Signatures, signatures)
+
+	// Recompute the oracle state hash from the encoded packet and Merkle paths.
+	var result types.Result
+	require.NoError(t, obi.Decode(icon.EncodedPacket, &result))
+	require.Equal(t, types.NewResult(proof.OracleDataProof.RequestPacket, proof.OracleDataProof.ResponsePacket), result)
+	var paths []IAVLMerklePath
+	require.NoError(t, obi.Decode(icon.IAVLMerklePaths, &paths))
+	hash := getLeafHash(types.ResultStoreKey(result.ResponsePacketData.RequestID), tmhash.Sum(icon.EncodedPacket), icon.Version)
+	for _, path := range paths {
+		hash = path.GetParentHash(hash)
+	}
+	require.Equal(t, []byte(multiStore.OracleIAVLStateHash), hash)
+}
+
+func TestICONEncoderMultiProof(t *testing.T) {
+	multi := mockJsonMultiProof(t, 1, 42, 100)
+	bz, err := iconEncoder{}.EncodeMultiProof(multi)
+	require.NoError(t, err)
+	var proofs [][]byte
+	require.NoError(t, obi.Decode(bz, &proofs))
+	require.Len(t, proofs, 3)
+	for i, oracleData := range multi.OracleDataMultiProof {
+		expected, err := iconEncoder{}.EncodeProof(JsonProof{
+			BlockHeight:     multi.BlockHeight,
+			OracleDataProof: oracleData,
+			BlockRelayProof: multi.BlockRelayProof,
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected, proofs[i])
+	}
+}
+
+func TestRLPEncoder(t *testing.T) {
+	proof := mockJsonProof(t, 42)
+	bz, err := rlpEncoder{}.EncodeProof(proof)
+	require.NoError(t, err)
+	var decoded RLPProof
+	require.NoError(t, rlp.DecodeBytes(bz, &decoded))
+	require.Equal(t, proof.BlockHeight, decoded.BlockHeight)
+	require.Equal(t, proof.BlockRelayProof.encodeToRLPFormat(), decoded.BlockRelay)
+	require.Equal(t, proof.OracleDataProof.encodeToRLPFormat(), decoded.OracleData)
+
+	multi := mockJsonMultiProof(t, 1, 42, 100)
+	bz, err = rlpEncoder{}.EncodeMultiProof(multi)
+	require.NoError(t, err)
+	var decodedMulti RLPMultiProof
+	require.NoError(t, rlp.DecodeBytes(bz, &decodedMulti))
+	require.Len(t, decodedMulti.OracleData, 3)
+	require.Equal(t, multi.OracleDataMultiProof[2].encodeToRLPFormat(), decodedMulti.OracleData[2])
+}
+
+func TestProtoEncoder(t *testing.T) {
+	proof := mockJsonProof(t, 42)
+	bz, err := protoEncoder{}.EncodeProof(proof)
+	require.NoError(t, err)
+	var decoded ProtoProof
+	require.NoError(t, proto.Unmarshal(bz, &decoded))
+	require.Equal(t, proof.BlockHeight, decoded.BlockHeight)
+	require.Equal(t, proof.OracleDataProof.RequestPacket, *decoded.OracleDataProof.RequestPacket)
+	require.Equal(t, proof.OracleDataProof.ResponsePacket, *decoded.OracleDataProof.ResponsePacket)
+	require.Equal(t, proof.OracleDataProof.encodeToProtoFormat(), decoded.OracleDataProof)
+	require.Equal(t, proof.BlockRelayProof.encodeToProtoFormat(), decoded.BlockRelayProof)
+
+	multi := mockJsonMultiProof(t, 1, 42, 100)
+	bz, err = protoEncoder{}.EncodeMultiProof(multi)
+	require.NoError(t, err)
+	var decodedMulti ProtoMultiProof
+	require.NoError(t, proto.Unmarshal(bz, &decodedMulti))
+	require.Len(t, decodedMulti.OracleDataMultiProof, 3)
+	require.Equal(t, multi.OracleDataMultiProof[1].encodeToProtoFormat(), decodedMulti.OracleDataMultiProof[1])
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 )
 
@@ -36,6 +37,22 @@ func (merklePath *IAVLMerklePath) encodeToEthFormat() IAVLMerklePathEthereum {
 	}
 }
 
+// GetParentHash returns the hash of the parent node of the given subtree hash in this Merkle step.
+func (merklePath *IAVLMerklePath) GetParentHash(subtreeHash []byte) []byte {
+	left, right := subtreeHash, []byte(merklePath.SiblingHash)
+	if merklePath.IsDataOnRight {
+		left, right = right, left
+	}
+	raw := []byte{merklePath.SubtreeHeight << 1} // Tendermint signed-int8 encoding requires multiplying by 2
+	raw = append(raw, encodeVarint(int64(merklePath.SubtreeSize))...)
+	raw = append(raw, encodeVarint(int64(merklePath.SubtreeVersion))...)
+	raw = append(raw, uint8(len(left)))
+	raw = append(raw, left...)
+	raw = append(raw, uint8(len(right)))
+	raw = append(raw, right...)
+	return tmhash.Sum(raw)
+}
+
 // GetIAVLMerklePaths returns the list of IAVLMerklePath elements from the given iAVL proof.
 func GetIAVLMerklePaths(proof *iavl.ValueOp) []IAVLMerklePath {
 	paths := make([]IAVLMerklePath, 0)
@@ -0,0 +1,101 @@
+package proof
+
+import (
+	"time"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// ICONProof is the OBI structure accepted by relay_and_verify of the ICON bridge contract.
+type ICONProof struct {
+	BlockHeight     uint64 `obi:"block_height"`
+	MultiStore      []byte `obi:"multi_store"`
+	MerkleParts     []byte `obi:"merkle_parts"`
+	Signatures      []byte `obi:"signatures"`
+	EncodedPacket   []byte `obi:"encoded_packet"`
+	Version         uint64 `obi:"version"`
+	IAVLMerklePaths []byte `obi:"iavl_merkle_paths"`
+}
+
+// encodeToICONData returns the multi store, merkle parts, and signatures bytes in the layout
+// expected by relay_oracle_state of the ICON bridge contract.
+func (blockRelay *BlockRelayProof) encodeToICONData() ([]byte, []byte, []byte, error) {
+	m := blockRelay.MultiStoreProof
+	multiStore := make([]byte, 0, 160)
+	multiStore = append(multiStore, m.AccToGovStoresMerkleHash...)          // [I5]
+	multiStore = append(multiStore, m.MainAndMintStoresMerkleHash...)       // [I3]
+	multiStore = append(multiStore, m.OracleIAVLStateHash...)               // [6]
+	multiStore = append(multiStore, m.ParamsStoresMerkleHash...)            // [7]
+	multiStore = append(multiStore, m.SlashingToUpgradeStoresMerkleHash...) // [I10]
+
+	// The ICON contract takes the hash of the time leaf instead of the time itself.
+	bp := blockRelay.BlockHeaderMerkleParts
+	timeHash := merkleLeafHash(encodeTime(time.Unix(int64(bp.TimeSecond), int64(bp.TimeNanoSecond))))
+	merkleParts := make([]byte, 0, 192)
+	merkleParts = append(merkleParts, bp.VersionAndChainIdHash...)             // [1A]
+	merkleParts = append(merkleParts, timeHash...)                             // [3]
+	merkleParts = append(merkleParts, bp.LastBlockIDAndOther...)               // [2B]
+	merkleParts = append(merkleParts, bp.NextValidatorHashAndConsensusHash...) // [1E]
+	merkleParts = append(merkleParts, bp.LastResultsHash...)                   // [B]
+	merkleParts = append(merkleParts, bp.EvidenceAndProposerHash...)           // [2D]
+
+	signatures, err := obi.Encode(blockRelay.Signatures)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return multiStore, merkleParts, signatures, nil
+}
+
+func (o *OracleDataProof) encodeToICONData() ([]byte, []byte, error) {
+	packet, err := obi.Encode(types.NewResult(o.RequestPacket, o.ResponsePacket))
+	if err != nil {
+		return nil, nil, err
+	}
+	paths, err := obi.Encode(o.MerklePaths)
+	if err != nil {
+		return nil, nil, err
+	}
+	return packet, paths, nil
+}
+
+// iconEncoder encodes proofs as the OBI input of relay_and_verify of the ICON bridge. The ICON
+// bridge has no multi-verify entry point, so a multi proof is the OBI list of the proofs of
+// each request, each of which can be submitted to relay_and_verify on its own.
+type iconEncoder struct{}
+
+func (iconEncoder) EncodeProof(proof JsonProof) ([]byte, error) {
+	multiStore, merkleParts, signatures, err := proof.BlockRelayProof.encodeToICONData()
+	if err != nil {
+		return nil, err
+	}
+	packet, paths, err := proof.OracleDataProof.encodeToICONData()
+	if err != nil {
+		return nil, err
+	}
+	return obi.Encode(ICONProof{
+		BlockHeight:     proof.BlockHeight,
+		MultiStore:      multiStore,
+		MerkleParts:     merkleParts,
+		Signatures:      signatures,
+		EncodedPacket:   packet,
+		Version:         proof.OracleDataProof.Version,
+		IAVLMerklePaths: paths,
+	})
+}
+
+func (e iconEncoder) EncodeMultiProof(proof JsonMultiProof) ([]byte, error) {
+	proofs := make([][]byte, len(proof.OracleDataMultiProof))
+	for idx, oracleData := range proof.OracleDataMultiProof {
+		var err error
+		proofs[idx], err = e.EncodeProof(JsonProof{
+			BlockHeight:     proof.BlockHeight,
+			OracleDataProof: oracleData,
+			BlockRelayProof: proof.BlockRelayProof,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return obi.Encode(proofs)
+}
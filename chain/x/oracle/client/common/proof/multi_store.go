@@ -64,6 +64,23 @@ func (m *MultiStoreProof) encodeToEthFormat() MultiStoreProofEthereum {
 	}
 }
 
+// GetAppHash returns Tendermint's application state hash computed from the multistore proof.
+func (m *MultiStoreProof) GetAppHash() []byte {
+	return merkleInnerHash( // [AppHash]
+		merkleInnerHash( // [I9]
+			m.AccToGovStoresMerkleHash, // [I5]
+			merkleInnerHash( // [I6]
+				m.MainAndMintStoresMerkleHash, // [I3]
+				merkleInnerHash( // [I4]
+					merkleLeafHash(encodeStoreMerkleHash(types.StoreKey, m.OracleIAVLStateHash)), // [6]
+					m.ParamsStoresMerkleHash, // [7]
+				),
+			),
+		),
+		m.SlashingToUpgradeStoresMerkleHash, // [I10]
+	)
+}
+
 // GetMultiStoreProof compacts Multi store proof from Tendermint to MultiStoreProof version.
 func GetMultiStoreProof(proof rootmulti.MultiStoreProofOp) MultiStoreProof {
 	m := make(map[string][]byte, len(proof.Proof.StoreInfos))
@@ -12,9 +12,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/gorilla/mux"
-	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
-	rpcclient "github.com/tendermint/tendermint/rpc/client"
 
 	"github.com/bandprotocol/bandchain/chain/pkg/obi"
 	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
@@ -47,7 +46,8 @@ type BlockRelayProof struct {
 	Signatures             []TMSignature          `json:"signatures"`
 }
 
-func (blockRelay *BlockRelayProof) encodeToEthData() ([]byte, error) {
+// EncodeToEthData returns the ABI-encoded arguments of relayOracleState of the EVM bridge.
+func (blockRelay *BlockRelayProof) EncodeToEthData() ([]byte, error) {
 	parseSignatures := make([]TMSignatureEthereum, len(blockRelay.Signatures))
 	for i, sig := range blockRelay.Signatures {
 		parseSignatures[i] = sig.encodeToEthFormat()
@@ -59,6 +59,29 @@ func (blockRelay *BlockRelayProof) encodeToEthData() ([]byte, error) {
 	)
 }
 
+// GetBlockRelayProof returns the proof that the block at the given height was committed by its
+// validators, together with the multistore proof of the application state hash in that block.
+func GetBlockRelayProof(ctx context.CLIContext, height int64) (BlockRelayProof, error) {
+	commit, err := ctx.Client.Commit(&height)
+	if err != nil {
+		return BlockRelayProof{}, err
+	}
+	// The application state hash in a block header is the state after the previous block.
+	_, multiStoreProof, _, err := queryStoreProof(ctx, types.RequestCountStoreKey, commit.Height-1)
+	if err != nil {
+		return BlockRelayProof{}, err
+	}
+	signatures, err := GetSignaturesAndPrefix(&commit.SignedHeader)
+	if err != nil {
+		return BlockRelayProof{}, err
+	}
+	return BlockRelayProof{
+		MultiStoreProof:        GetMultiStoreProof(multiStoreProof),
+		BlockHeaderMerkleParts: GetBlockHeaderMerkleParts(ctx.Codec, commit.Header),
+		Signatures:             signatures,
+	}, nil
+}
+
 type OracleDataProof struct {
 	RequestPacket  types.OracleRequestPacketData  `json:"requestPacket"`
 	ResponsePacket types.OracleResponsePacketData `json:"responsePacket"`
@@ -80,6 +103,37 @@ func (o *OracleDataProof) encodeToEthData(blockHeight uint64) ([]byte, error) {
 	)
 }
 
+// GetOracleStateHash returns the root hash of the oracle IAVL tree computed from the result
+// packets and the Merkle paths of this proof.
+func (o *OracleDataProof) GetOracleStateHash() []byte {
+	hash := getResultLeafHash(o.RequestPacket, o.ResponsePacket, o.Version)
+	for _, path := range o.MerklePaths {
+		hash = path.GetParentHash(hash)
+	}
+	return hash
+}
+
+// getResultLeafHash returns the hash of the IAVL leaf node that stores the result of the given
+// request and response packets at the given version.
+func getResultLeafHash(
+	req types.OracleRequestPacketData, res types.OracleResponsePacketData, version uint64,
+) []byte {
+	value := obi.MustEncode(types.NewResult(req, res))
+	return getLeafHash(types.ResultStoreKey(res.RequestID), tmhash.Sum(value), version)
+}
+
+// getLeafHash returns the hash of the IAVL leaf node with the given key, value hash, and version.
+func getLeafHash(key []byte, valueHash []byte, version uint64) []byte {
+	leaf := []byte{0}                                       // Height of tree (only leaf node) is 0 (signed-varint encode)
+	leaf = append(leaf, 2)                                  // Size of subtree is 1 (signed-varint encode)
+	leaf = append(leaf, encodeVarint(int64(version))...)    // Version of the leaf node
+	leaf = append(leaf, encodeUvarint(uint64(len(key)))...) // Size of data key
+	leaf = append(leaf, key...)                             // Data key
+	leaf = append(leaf, uint8(len(valueHash)))              // Size of data hash
+	leaf = append(leaf, valueHash...)                       // Hash of the data value
+	return tmhash.Sum(leaf)
+}
+
 type JsonProof struct {
 	BlockHeight     uint64          `json:"blockHeight"`
 	OracleDataProof OracleDataProof `json:"oracleDataProof"`
@@ -92,14 +146,91 @@ type JsonMultiProof struct {
 	BlockRelayProof      BlockRelayProof   `json:"blockRelayProof"`
 }
 
+// Proof is the response of the proof endpoint. EVMProofBytes always holds the proof in the EVM
+// format, while Format and ProofBytes are only set if a format is requested explicitly.
 type Proof struct {
 	JsonProof     JsonProof        `json:"jsonProof"`
 	EVMProofBytes tmbytes.HexBytes `json:"evmProofBytes"`
+	Format        string           `json:"format,omitempty"`
+	ProofBytes    tmbytes.HexBytes `json:"proofBytes,omitempty"`
 }
 
+// MultiProof is the response of the multi proof endpoint, see Proof.
 type MultiProof struct {
 	JsonProof     JsonMultiProof   `json:"jsonProof"`
 	EVMProofBytes tmbytes.HexBytes `json:"evmProofBytes"`
+	Format        string           `json:"format,omitempty"`
+	ProofBytes    tmbytes.HexBytes `json:"proofBytes,omitempty"`
+}
+
+// parseProofFormat returns the proof format requested in the query string of the given request
+// together with its encoder, or writes a bad request response and returns false. The format is
+// empty and the encoder nil if no format is requested.
+func parseProofFormat(w http.ResponseWriter, r *http.Request) (string, ProofEncoder, bool) {
+	format := r.URL.Query().Get(FormatTag)
+	if format == "" {
+		return "", nil, true
+	}
+	encoder, err := GetProofEncoder(format)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return "", nil, false
+	}
+	return format, encoder, true
+}
+
+// checkResultResolved writes an error response and returns false if the given request does not
+// exist or has not been resolved yet.
+func checkResultResolved(w http.ResponseWriter, ctx context.CLIContext, route string, requestID types.RequestID) bool {
+	bz, _, err := ctx.Query(fmt.Sprintf("custom/%s/%s/%d", route, types.QueryRequests, requestID))
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	var qResult types.QueryResult
+	if err := json.Unmarshal(bz, &qResult); err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	if qResult.Status != http.StatusOK {
+		clientcmn.PostProcessQueryResponse(w, ctx, bz)
+		return false
+	}
+	var request types.QueryRequestResult
+	if err := ctx.Codec.UnmarshalJSON(qResult.Result, &request); err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	if request.Result == nil {
+		rest.WriteErrorResponse(w, http.StatusNotFound, "Result has not been resolved")
+		return false
+	}
+	return true
+}
+
+// queryOracleDataProof returns the proof of the result of the given request at the given store
+// height, together with the multistore proof of the oracle store. On error, also returns the
+// HTTP status code to respond with.
+func queryOracleDataProof(
+	ctx context.CLIContext, requestID types.RequestID, height int64,
+) (OracleDataProof, rootmulti.MultiStoreProofOp, int, error) {
+	storeProof, multiStoreProof, status, err := queryStoreProof(ctx, types.ResultStoreKey(requestID), height)
+	if err != nil {
+		return OracleDataProof{}, rootmulti.MultiStoreProofOp{}, status, err
+	}
+	if !storeProof.Exists() {
+		return OracleDataProof{}, rootmulti.MultiStoreProofOp{}, http.StatusBadRequest, fmt.Errorf(
+			"Proof of #%d is unavailable please wait on the next block", requestID,
+		)
+	}
+	var rs types.Result
+	obi.MustDecode(storeProof.Value, &rs)
+	return OracleDataProof{
+		RequestPacket:  rs.RequestPacketData,
+		ResponsePacket: rs.ResponsePacketData,
+		Version:        storeProof.Existence.Version,
+		MerklePaths:    storeProof.Existence.MerklePaths,
+	}, multiStoreProof, http.StatusOK, nil
 }
 
 func GetProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc {
@@ -113,6 +244,11 @@ func GetProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc
 			height = nil
 		}
 
+		format, encoder, ok := parseProofFormat(w, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		intRequestID, err := strconv.ParseUint(vars[RequestIDTag], 10, 64)
 		if err != nil {
@@ -120,27 +256,7 @@ func GetProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc
 			return
 		}
 		requestID := types.RequestID(intRequestID)
-		bz, _, err := ctx.Query(fmt.Sprintf("custom/%s/%s/%d", route, types.QueryRequests, requestID))
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		var qResult types.QueryResult
-		if err := json.Unmarshal(bz, &qResult); err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		if qResult.Status != http.StatusOK {
-			clientcmn.PostProcessQueryResponse(w, ctx, bz)
-			return
-		}
-		var request types.QueryRequestResult
-		if err := ctx.Codec.UnmarshalJSON(qResult.Result, &request); err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		if request.Result == nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, "Result has not been resolved")
+		if !checkResultResolved(w, ctx, route, requestID) {
 			return
 		}
 
@@ -150,56 +266,11 @@ func GetProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc
 			return
 		}
 
-		resp, err := ctx.Client.ABCIQueryWithOptions(
-			"/store/oracle/key",
-			types.ResultStoreKey(requestID),
-			rpcclient.ABCIQueryOptions{Height: commit.Height - 1, Prove: true},
-		)
+		oracleData, multiStoreProof, status, err := queryOracleDataProof(ctx, requestID, commit.Height-1)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		proof := resp.Response.GetProof()
-		if proof == nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Proof not found")
-			return
-		}
-
-		ops := proof.GetOps()
-		if ops == nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, "proof ops not found")
+			rest.WriteErrorResponse(w, status, err.Error())
 			return
 		}
-
-		var iavlProof iavl.ValueOp
-		var multiStoreProof rootmulti.MultiStoreProofOp
-		for _, op := range ops {
-			opType := op.GetType()
-			if opType == "iavl:v" {
-				err := ctx.Codec.UnmarshalBinaryLengthPrefixed(op.GetData(), &iavlProof)
-				if err != nil {
-					rest.WriteErrorResponse(w, http.StatusInternalServerError,
-						fmt.Sprintf("iavl: %s", err.Error()),
-					)
-					return
-				}
-			} else if opType == "multistore" {
-				mp, err := rootmulti.MultiStoreProofOpDecoder(op)
-				multiStoreProof = mp.(rootmulti.MultiStoreProofOp)
-				if err != nil {
-					rest.WriteErrorResponse(w, http.StatusInternalServerError,
-						fmt.Sprintf("multiStore: %s", err.Error()),
-					)
-					return
-				}
-			}
-		}
-		if iavlProof.Proof == nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, "Proof has not been ready.")
-			return
-		}
-		eventHeight := iavlProof.Proof.Leaves[0].Version
 		signatures, err := GetSignaturesAndPrefix(&commit.SignedHeader)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
@@ -210,56 +281,28 @@ func GetProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc
 			BlockHeaderMerkleParts: GetBlockHeaderMerkleParts(ctx.Codec, commit.Header),
 			Signatures:             signatures,
 		}
-		resValue := resp.Response.GetValue()
 
-		type result struct {
-			Req types.OracleRequestPacketData
-			Res types.OracleResponsePacketData
-		}
-		var rs result
-		obi.MustDecode(resValue, &rs)
-
-		oracleData := OracleDataProof{
-			RequestPacket:  rs.Req,
-			ResponsePacket: rs.Res,
-			Version:        uint64(eventHeight),
-			MerklePaths:    GetIAVLMerklePaths(&iavlProof),
+		jsonProof := JsonProof{
+			BlockHeight:     uint64(commit.Height),
+			OracleDataProof: oracleData,
+			BlockRelayProof: blockRelay,
 		}
-
-		// Calculate byte for proofbytes
-		var relayAndVerifyArguments abi.Arguments
-		format := `[{"type":"bytes"},{"type":"bytes"}]`
-		err = json.Unmarshal([]byte(format), &relayAndVerifyArguments)
-		if err != nil {
-			panic(err)
-		}
-
-		blockRelayBytes, err := blockRelay.encodeToEthData()
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		oracleDataBytes, err := oracleData.encodeToEthData(uint64(commit.Height))
+		evmProofBytes, err := evmEncoder{}.EncodeProof(jsonProof)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		evmProofBytes, err := relayAndVerifyArguments.Pack(blockRelayBytes, oracleDataBytes)
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+		proof := Proof{JsonProof: jsonProof, EVMProofBytes: evmProofBytes}
+		if encoder != nil {
+			proof.Format = format
+			proof.ProofBytes, err = encoder.EncodeProof(jsonProof)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
 		}
-
-		rest.PostProcessResponse(w, ctx, Proof{
-			JsonProof: JsonProof{
-				BlockHeight:     uint64(commit.Height),
-				OracleDataProof: oracleData,
-				BlockRelayProof: blockRelay,
-			},
-			EVMProofBytes: evmProofBytes,
-		})
+		rest.PostProcessResponse(w, ctx, proof)
 	}
 }
 
@@ -270,6 +313,10 @@ func GetMutiProofHandlerFn(cliCtx context.CLIContext, route string) http.Handler
 		if !ok {
 			return
 		}
+		format, encoder, ok := parseProofFormat(w, r)
+		if !ok {
+			return
+		}
 		height := &ctx.Height
 		if ctx.Height == 0 {
 			height = nil
@@ -291,10 +338,8 @@ func GetMutiProofHandlerFn(cliCtx context.CLIContext, route string) http.Handler
 			Signatures:             signatures,
 		}
 
-		oracleDataBytesList := make([][]byte, len(requestIDs))
 		oracleDataList := make([]OracleDataProof, len(requestIDs))
 
-		isFirstRequest := true
 		for idx, requestID := range requestIDs {
 			intRequestID, err := strconv.ParseUint(requestID, 10, 64)
 			if err != nil {
@@ -302,148 +347,42 @@ func GetMutiProofHandlerFn(cliCtx context.CLIContext, route string) http.Handler
 				return
 			}
 			requestID := types.RequestID(intRequestID)
-			bz, _, err := ctx.Query(fmt.Sprintf("custom/%s/%s/%d", route, types.QueryRequests, requestID))
-			if err != nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-			var qResult types.QueryResult
-			if err := json.Unmarshal(bz, &qResult); err != nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-			if qResult.Status != http.StatusOK {
-				clientcmn.PostProcessQueryResponse(w, ctx, bz)
-				return
-			}
-			var request types.QueryRequestResult
-			if err := ctx.Codec.UnmarshalJSON(qResult.Result, &request); err != nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-			if request.Result == nil {
-				rest.WriteErrorResponse(w, http.StatusNotFound, "Result has not been resolved")
+			if !checkResultResolved(w, ctx, route, requestID) {
 				return
 			}
 
-			resp, err := ctx.Client.ABCIQueryWithOptions(
-				"/store/oracle/key",
-				types.ResultStoreKey(requestID),
-				rpcclient.ABCIQueryOptions{Height: commit.Height - 1, Prove: true},
-			)
+			oracleData, multiStoreProof, status, err := queryOracleDataProof(ctx, requestID, commit.Height-1)
 			if err != nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				rest.WriteErrorResponse(w, status, err.Error())
 				return
 			}
-
-			proof := resp.Response.GetProof()
-			if proof == nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, "Proof not found")
-				return
-			}
-
-			ops := proof.GetOps()
-			if ops == nil {
-				rest.WriteErrorResponse(w, http.StatusInternalServerError, "proof ops not found")
-				return
+			if idx == 0 {
+				// Only create multi store proof in the first request.
+				blockRelay.MultiStoreProof = GetMultiStoreProof(multiStoreProof)
 			}
-
-			var iavlProof iavl.ValueOp
-			var multiStoreProof rootmulti.MultiStoreProofOp
-			for _, op := range ops {
-				switch op.GetType() {
-				case "iavl:v":
-					err := ctx.Codec.UnmarshalBinaryLengthPrefixed(op.GetData(), &iavlProof)
-					if err != nil {
-						rest.WriteErrorResponse(w, http.StatusInternalServerError,
-							fmt.Sprintf("iavl: %s", err.Error()),
-						)
-						return
-					}
-					if iavlProof.Proof == nil {
-						rest.WriteErrorResponse(w, http.StatusNotFound, "Proof has not been ready.")
-						return
-					}
-
-					eventHeight := iavlProof.Proof.Leaves[0].Version
-					resValue := resp.Response.GetValue()
-
-					type result struct {
-						Req types.OracleRequestPacketData
-						Res types.OracleResponsePacketData
-					}
-					var rs result
-					obi.MustDecode(resValue, &rs)
-
-					oracleData := OracleDataProof{
-						RequestPacket:  rs.Req,
-						ResponsePacket: rs.Res,
-						Version:        uint64(eventHeight),
-						MerklePaths:    GetIAVLMerklePaths(&iavlProof),
-					}
-					oracleDataBytes, err := oracleData.encodeToEthData(uint64(commit.Height))
-					if err != nil {
-						rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-						return
-					}
-					// Append oracle data proof to list
-					oracleDataBytesList[idx] = oracleDataBytes
-					oracleDataList[idx] = oracleData
-				case "multistore":
-					if isFirstRequest {
-						// Only create multi store proof in the first request.
-						isFirstRequest = false
-						mp, err := rootmulti.MultiStoreProofOpDecoder(op)
-						multiStoreProof = mp.(rootmulti.MultiStoreProofOp)
-						if err != nil {
-							rest.WriteErrorResponse(w, http.StatusInternalServerError,
-								fmt.Sprintf("multiStore: %s", err.Error()),
-							)
-							return
-						}
-						blockRelay.MultiStoreProof = GetMultiStoreProof(multiStoreProof)
-					}
-				case "iavl:a":
-					rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf(
-						"Proof of #%d is unavailable please wait on the next block", requestID,
-					))
-					return
-				default:
-					rest.WriteErrorResponse(w, http.StatusInternalServerError,
-						fmt.Sprintf("Unknown proof type %s", op.GetType()),
-					)
-					return
-				}
-			}
-		}
-
-		blockRelayBytes, err := blockRelay.encodeToEthData()
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+			oracleDataList[idx] = oracleData
 		}
 
-		// Calculate byte for MultiProofbytes
-		var relayAndVerifyArguments abi.Arguments
-		format := `[{"type":"bytes"},{"type":"bytes[]"}]`
-		err = json.Unmarshal([]byte(format), &relayAndVerifyArguments)
-		if err != nil {
-			panic(err)
+		jsonProof := JsonMultiProof{
+			BlockHeight:          uint64(commit.Height),
+			OracleDataMultiProof: oracleDataList,
+			BlockRelayProof:      blockRelay,
 		}
-
-		evmProofBytes, err := relayAndVerifyArguments.Pack(blockRelayBytes, oracleDataBytesList)
+		evmProofBytes, err := evmEncoder{}.EncodeMultiProof(jsonProof)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		rest.PostProcessResponse(w, ctx, MultiProof{
-			JsonProof: JsonMultiProof{
-				BlockHeight:          uint64(commit.Height),
-				OracleDataMultiProof: oracleDataList,
-				BlockRelayProof:      blockRelay,
-			},
-			EVMProofBytes: evmProofBytes,
-		})
+		proof := MultiProof{JsonProof: jsonProof, EVMProofBytes: evmProofBytes}
+		if encoder != nil {
+			proof.Format = format
+			proof.ProofBytes, err = encoder.EncodeMultiProof(jsonProof)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		rest.PostProcessResponse(w, ctx, proof)
 	}
 }
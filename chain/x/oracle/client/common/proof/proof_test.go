@@ -2,8 +2,10 @@ package proof
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 
@@ -25,7 +27,7 @@ func leafHash(item []byte) []byte {
 
 func branchHash(left, right []byte) []byte {
 	// branch prefix is 1
-	return tmhash.Sum(append([]byte{1}, append(left, right...)...))
+	return tmhash.Sum(append(append([]byte{1}, left...), right...))
 }
 
 func TestEncodeRelay(t *testing.T) {
@@ -99,7 +101,7 @@ func TestEncodeRelay(t *testing.T) {
 			},
 		},
 	}
-	result, err := block.encodeToEthData()
+	result, err := block.EncodeToEthData()
 	require.Nil(t, err)
 	expect := hexToBytes("0d4af3f5ffa02a56b1deed7bc8c16732aeb8fd003c67eef26048b314c351fae868bf06d17dbf1f5870d3092e1433a99fdaf6e263efd5f8c82c533691d87592b74f900b8b425cf85ab2a1ed2907d4830bf674703c64954847daaee9b81a09ba312b6a7e0f44ed9c179a47a40f93d5824189a5426d6c3f77692de28e50e20a33ddf5e26e9e91f18051f41453b5a5fc82279c364351155cea5564b9d61bc12be58a3561783e9c3bdf932a16580fc0c9ceffec4c509073fff65a42871bfab64408ae00000000000000000000000000000000000000000000000000000000002e1ace000000000000000000000000000000000000000000000000000000005fb55d5e00000000000000000000000000000000000000000000000000000000241077d221114e3076a55c6853b4730fb8678b5bf2314c1df6dce169acee9aece893c60fea01cd62e714b603323a21a4a7382f8ab04788c867a0c99ade687d00e7d5fe62aa3c7cbeff135291e6415eca2528fc98d263b120c67bcecd8d8ccd3253efecc168d9ef5eb2afaf2e36940299c8cda2f43acb015fc2d6cafd2c577ca48f1b2c2600000000000000000000000000000000000000000000000000000000000001c0000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000024000000000000000000000000000000000000000000000000000000000000003a00000000000000000000000000000000000000000000000000000000000000500000000000000000000000000000000000000000000000000000000000000066000000000000000000000000000000000000000000000000000000000000007c00000000000000000000000000000000000000000000000000000000000000920d090ef654a5c8b59eb97346eb46e601a6d57dda9174c1e535c40485fd5a8d41447a7b3e582103c2b28b8d3d3c8d9b7d715d852e449711c7b91bdf9edcb3f7ada000000000000000000000000000000000000000000000000000000000000001c00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd0510dad8c69b02320f62616e642d6775616e79752d706f6100000000000000000000000000000000000000000000000000000026ed90ee89d4f6b5d172904ddb82a18419e6833bfc74522416800e3a7d2e3ae00c2df3f307106861195aa41643cf6da1f5be195b11c74b9c90329f99b1e24ca7000000000000000000000000000000000000000000000000000000000000001b00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd0510a9f1dcc402320f62616e642d6775616e79752d706f610000000000000000000000000000000000000000000000000000005ca760bda037610b623b83cbf8e0f55feca37a9a621ebad17360e5725f8e34253df55e237cb9d1eae87243cd4d3f5f1091b9ff2e2f16a2b083928f2b0f09c7bb000000000000000000000000000000000000000000000000000000000000001c00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd0510a196b3c502320f62616e642d6775616e79752d706f6100000000000000000000000000000000000000000000000000000037466d99bb8ed9ea462b6a7e988189224a628ffa973a05a29bb401c4c4fc9b2d7fd076b0e28b7e6a35cc80ad6fa688958e9f22093ce00c22d4fe67124c633b04000000000000000000000000000000000000000000000000000000000000001c00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd05108bff92c502320f62616e642d6775616e79752d706f6100000000000000000000000000000000000000000000000000000099cfb8e6848f039863d98c48ac985f4ad6a27fe0b602a8735d44654df1b06de275c7162f5abada35fb48460f2196e5348334e98ba05de1236d59db329ec2e4aa000000000000000000000000000000000000000000000000000000000000001b00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd05108e8badc302320f62616e642d6775616e79752d706f61000000000000000000000000000000000000000000000000000000780733f9013d10f88a2fa936bc2789a61146749b779fb847c0f46279ca31543e28eb691288b02c06d29cecc3ddea3387cb10f69aec9f6c0a51ed39784d6675d0000000000000000000000000000000000000000000000000000000000000001b00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd0510f0cdc5d402320f62616e642d6775616e79752d706f610000000000000000000000000000000000000000000000000000002914567728cae2abe2b707933ae63660c7c79786c7b897cb867b68f67aac07a804f683b20f6b043a0ca79c39dd68167869262bf262cef1b1ce8e30b4a06b0d5d000000000000000000000000000000000000000000000000000000000000001b00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000000e0000000000000000000000000000000000000000000000000000000000000001074080211ce1a2e000000000022480a2000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004512240a202dc401225b681224cb8f597d157a5de78ef4f04fe1c884595f2b18d941ebca2010012a0c08e1bad5fd0510a7cec19a02320f62616e642d6775616e79752d706f61000000000000000000000000000000000000000000000000000000")
 
@@ -183,3 +185,25 @@ func TestEncodeVerify(t *testing.T) {
 	expect := hexToBytes("00000000000000000000000000000000000000000000000000000000000000bf00000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000001c000000000000000000000000000000000000000000000000000000000000000b4000000000000000000000000000000000000000000000000000000000000032000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000e00000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000047465737400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000f000000034254430000000000000064000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000005ef1b4cd000000000000000000000000000000000000000000000000000000005ef1b4d50000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000012000000000000000000000000000000000000000000000000000000000000000047465737400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000800000000000eb9e6000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000700000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000b4108873ce6589add98e40996e7ea55d6592cfee6ebf140cfbe7f0c7eaf554b8bd00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000000000000000000000000000000000b4796aeb2094f52e848a9c9acc57c380f92010fa19956ccf9e2c6e8e7d1e490f0c00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000000500000000000000000000000000000000000000000000000000000000000000b4423f98be29bd72613a6815e696ecf229e2b489198e9385d3bf1f743b99ca157300000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000b4386e60db569e57eaf6f2dbfa0e87ac5dafb849ebf1db7c6b29c0231d644ad92e00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000001500000000000000000000000000000000000000000000000000000000000000b4465252af4fe160ad23c446ce54dfc3eecad65b96bda8c68871a244033424616a00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000006000000000000000000000000000000000000000000000000000000000000002900000000000000000000000000000000000000000000000000000000000000b4203800627751ee71822b76cf8c24f806d2528dc82247f5a49e42af9df04adc6500000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000007000000000000000000000000000000000000000000000000000000000000004d00000000000000000000000000000000000000000000000000000000000000be68b4e7b02288dce3c92c590cf09c96b64d18ff48c92d57d1f14d877c6a2720f9")
 	require.Equal(t, expect, result)
 }
+
+func TestProofJSONFields(t *testing.T) {
+	cdc := codec.New()
+	getFields := func(proof interface{}) []string {
+		bz, err := cdc.MarshalJSON(proof)
+		require.NoError(t, err)
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(bz, &fields))
+		names := []string{}
+		for name := range fields {
+			names = append(names, name)
+		}
+		return names
+	}
+	// Without an explicit format, the response keeps its original fields.
+	require.ElementsMatch(t, []string{"jsonProof", "evmProofBytes"}, getFields(Proof{}))
+	require.ElementsMatch(t, []string{"jsonProof", "evmProofBytes"}, getFields(MultiProof{}))
+	require.ElementsMatch(t, []string{"jsonProof", "evmProofBytes", "format", "proofBytes"},
+		getFields(Proof{Format: FormatRLP, ProofBytes: []byte{1}}))
+	require.ElementsMatch(t, []string{"jsonProof", "evmProofBytes", "format", "proofBytes"},
+		getFields(MultiProof{Format: FormatRLP, ProofBytes: []byte{1}}))
+}
@@ -0,0 +1,235 @@
+package proof
+
+import (
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// The messages below define the canonical protobuf encoding of proofs. Field numbers follow the
+// JSON proof structures and must never be reused. Request and response packets are embedded as
+// bandchain.chain.x.oracle.v1.OracleRequestPacketData and OracleResponsePacketData.
+//
+//   message MultiStoreProof {
+//     bytes acc_to_gov_stores_merkle_hash = 1;
+//     bytes main_and_mint_stores_merkle_hash = 2;
+//     bytes oracle_iavl_state_hash = 3;
+//     bytes params_stores_merkle_hash = 4;
+//     bytes slashing_to_upgrade_stores_merkle_hash = 5;
+//   }
+//   message BlockHeaderMerkleParts {
+//     bytes version_and_chain_id_hash = 1;
+//     uint64 height = 2;
+//     uint64 time_second = 3;
+//     uint32 time_nano_second = 4;
+//     bytes last_block_id_and_other = 5;
+//     bytes next_validator_hash_and_consensus_hash = 6;
+//     bytes last_results_hash = 7;
+//     bytes evidence_and_proposer_hash = 8;
+//   }
+//   message TMSignature {
+//     bytes r = 1;
+//     bytes s = 2;
+//     uint32 v = 3;
+//     bytes signed_data_prefix = 4;
+//     bytes signed_data_suffix = 5;
+//   }
+//   message BlockRelayProof {
+//     MultiStoreProof multi_store_proof = 1;
+//     BlockHeaderMerkleParts block_header_merkle_parts = 2;
+//     repeated TMSignature signatures = 3;
+//   }
+//   message IAVLMerklePath {
+//     bool is_data_on_right = 1;
+//     uint32 subtree_height = 2;
+//     uint64 subtree_size = 3;
+//     uint64 subtree_version = 4;
+//     bytes sibling_hash = 5;
+//   }
+//   message OracleDataProof {
+//     OracleRequestPacketData request_packet = 1;
+//     OracleResponsePacketData response_packet = 2;
+//     uint64 version = 3;
+//     repeated IAVLMerklePath merkle_paths = 4;
+//   }
+//   message Proof {
+//     uint64 block_height = 1;
+//     OracleDataProof oracle_data_proof = 2;
+//     BlockRelayProof block_relay_proof = 3;
+//   }
+//   message MultiProof {
+//     uint64 block_height = 1;
+//     repeated OracleDataProof oracle_data_multi_proof = 2;
+//     BlockRelayProof block_relay_proof = 3;
+//   }
+
+type ProtoMultiStoreProof struct {
+	AccToGovStoresMerkleHash          []byte `protobuf:"bytes,1,opt,name=acc_to_gov_stores_merkle_hash,json=accToGovStoresMerkleHash,proto3"`
+	MainAndMintStoresMerkleHash       []byte `protobuf:"bytes,2,opt,name=main_and_mint_stores_merkle_hash,json=mainAndMintStoresMerkleHash,proto3"`
+	OracleIAVLStateHash               []byte `protobuf:"bytes,3,opt,name=oracle_iavl_state_hash,json=oracleIavlStateHash,proto3"`
+	ParamsStoresMerkleHash            []byte `protobuf:"bytes,4,opt,name=params_stores_merkle_hash,json=paramsStoresMerkleHash,proto3"`
+	SlashingToUpgradeStoresMerkleHash []byte `protobuf:"bytes,5,opt,name=slashing_to_upgrade_stores_merkle_hash,json=slashingToUpgradeStoresMerkleHash,proto3"`
+}
+
+func (m *ProtoMultiStoreProof) Reset()         { *m = ProtoMultiStoreProof{} }
+func (m *ProtoMultiStoreProof) String() string { return proto.CompactTextString(m) }
+func (*ProtoMultiStoreProof) ProtoMessage()    {}
+
+type ProtoBlockHeaderMerkleParts struct {
+	VersionAndChainIdHash             []byte `protobuf:"bytes,1,opt,name=version_and_chain_id_hash,json=versionAndChainIdHash,proto3"`
+	Height                            uint64 `protobuf:"varint,2,opt,name=height,proto3"`
+	TimeSecond                        uint64 `protobuf:"varint,3,opt,name=time_second,json=timeSecond,proto3"`
+	TimeNanoSecond                    uint32 `protobuf:"varint,4,opt,name=time_nano_second,json=timeNanoSecond,proto3"`
+	LastBlockIDAndOther               []byte `protobuf:"bytes,5,opt,name=last_block_id_and_other,json=lastBlockIdAndOther,proto3"`
+	NextValidatorHashAndConsensusHash []byte `protobuf:"bytes,6,opt,name=next_validator_hash_and_consensus_hash,json=nextValidatorHashAndConsensusHash,proto3"`
+	LastResultsHash                   []byte `protobuf:"bytes,7,opt,name=last_results_hash,json=lastResultsHash,proto3"`
+	EvidenceAndProposerHash           []byte `protobuf:"bytes,8,opt,name=evidence_and_proposer_hash,json=evidenceAndProposerHash,proto3"`
+}
+
+func (m *ProtoBlockHeaderMerkleParts) Reset()         { *m = ProtoBlockHeaderMerkleParts{} }
+func (m *ProtoBlockHeaderMerkleParts) String() string { return proto.CompactTextString(m) }
+func (*ProtoBlockHeaderMerkleParts) ProtoMessage()    {}
+
+type ProtoTMSignature struct {
+	R                []byte `protobuf:"bytes,1,opt,name=r,proto3"`
+	S                []byte `protobuf:"bytes,2,opt,name=s,proto3"`
+	V                uint32 `protobuf:"varint,3,opt,name=v,proto3"`
+	SignedDataPrefix []byte `protobuf:"bytes,4,opt,name=signed_data_prefix,json=signedDataPrefix,proto3"`
+	SignedDataSuffix []byte `protobuf:"bytes,5,opt,name=signed_data_suffix,json=signedDataSuffix,proto3"`
+}
+
+func (m *ProtoTMSignature) Reset()         { *m = ProtoTMSignature{} }
+func (m *ProtoTMSignature) String() string { return proto.CompactTextString(m) }
+func (*ProtoTMSignature) ProtoMessage()    {}
+
+type ProtoBlockRelayProof struct {
+	MultiStoreProof        *ProtoMultiStoreProof        `protobuf:"bytes,1,opt,name=multi_store_proof,json=multiStoreProof,proto3"`
+	BlockHeaderMerkleParts *ProtoBlockHeaderMerkleParts `protobuf:"bytes,2,opt,name=block_header_merkle_parts,json=blockHeaderMerkleParts,proto3"`
+	Signatures             []*ProtoTMSignature          `protobuf:"bytes,3,rep,name=signatures,proto3"`
+}
+
+func (m *ProtoBlockRelayProof) Reset()         { *m = ProtoBlockRelayProof{} }
+func (m *ProtoBlockRelayProof) String() string { return proto.CompactTextString(m) }
+func (*ProtoBlockRelayProof) ProtoMessage()    {}
+
+type ProtoIAVLMerklePath struct {
+	IsDataOnRight  bool   `protobuf:"varint,1,opt,name=is_data_on_right,json=isDataOnRight,proto3"`
+	SubtreeHeight  uint32 `protobuf:"varint,2,opt,name=subtree_height,json=subtreeHeight,proto3"`
+	SubtreeSize    uint64 `protobuf:"varint,3,opt,name=subtree_size,json=subtreeSize,proto3"`
+	SubtreeVersion uint64 `protobuf:"varint,4,opt,name=subtree_version,json=subtreeVersion,proto3"`
+	SiblingHash    []byte `protobuf:"bytes,5,opt,name=sibling_hash,json=siblingHash,proto3"`
+}
+
+func (m *ProtoIAVLMerklePath) Reset()         { *m = ProtoIAVLMerklePath{} }
+func (m *ProtoIAVLMerklePath) String() string { return proto.CompactTextString(m) }
+func (*ProtoIAVLMerklePath) ProtoMessage()    {}
+
+type ProtoOracleDataProof struct {
+	RequestPacket  *types.OracleRequestPacketData  `protobuf:"bytes,1,opt,name=request_packet,json=requestPacket,proto3"`
+	ResponsePacket *types.OracleResponsePacketData `protobuf:"bytes,2,opt,name=response_packet,json=responsePacket,proto3"`
+	Version        uint64                          `protobuf:"varint,3,opt,name=version,proto3"`
+	MerklePaths    []*ProtoIAVLMerklePath          `protobuf:"bytes,4,rep,name=merkle_paths,json=merklePaths,proto3"`
+}
+
+func (m *ProtoOracleDataProof) Reset()         { *m = ProtoOracleDataProof{} }
+func (m *ProtoOracleDataProof) String() string { return proto.CompactTextString(m) }
+func (*ProtoOracleDataProof) ProtoMessage()    {}
+
+type ProtoProof struct {
+	BlockHeight     uint64                `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3"`
+	OracleDataProof *ProtoOracleDataProof `protobuf:"bytes,2,opt,name=oracle_data_proof,json=oracleDataProof,proto3"`
+	BlockRelayProof *ProtoBlockRelayProof `protobuf:"bytes,3,opt,name=block_relay_proof,json=blockRelayProof,proto3"`
+}
+
+func (m *ProtoProof) Reset()         { *m = ProtoProof{} }
+func (m *ProtoProof) String() string { return proto.CompactTextString(m) }
+func (*ProtoProof) ProtoMessage()    {}
+
+type ProtoMultiProof struct {
+	BlockHeight          uint64                  `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3"`
+	OracleDataMultiProof []*ProtoOracleDataProof `protobuf:"bytes,2,rep,name=oracle_data_multi_proof,json=oracleDataMultiProof,proto3"`
+	BlockRelayProof      *ProtoBlockRelayProof   `protobuf:"bytes,3,opt,name=block_relay_proof,json=blockRelayProof,proto3"`
+}
+
+func (m *ProtoMultiProof) Reset()         { *m = ProtoMultiProof{} }
+func (m *ProtoMultiProof) String() string { return proto.CompactTextString(m) }
+func (*ProtoMultiProof) ProtoMessage()    {}
+
+func (blockRelay *BlockRelayProof) encodeToProtoFormat() *ProtoBlockRelayProof {
+	m := blockRelay.MultiStoreProof
+	bp := blockRelay.BlockHeaderMerkleParts
+	signatures := make([]*ProtoTMSignature, len(blockRelay.Signatures))
+	for i, sig := range blockRelay.Signatures {
+		signatures[i] = &ProtoTMSignature{
+			R:                sig.R,
+			S:                sig.S,
+			V:                uint32(sig.V),
+			SignedDataPrefix: sig.SignedDataPrefix,
+			SignedDataSuffix: sig.SignedDataSuffix,
+		}
+	}
+	return &ProtoBlockRelayProof{
+		MultiStoreProof: &ProtoMultiStoreProof{
+			AccToGovStoresMerkleHash:          m.AccToGovStoresMerkleHash,
+			MainAndMintStoresMerkleHash:       m.MainAndMintStoresMerkleHash,
+			OracleIAVLStateHash:               m.OracleIAVLStateHash,
+			ParamsStoresMerkleHash:            m.ParamsStoresMerkleHash,
+			SlashingToUpgradeStoresMerkleHash: m.SlashingToUpgradeStoresMerkleHash,
+		},
+		BlockHeaderMerkleParts: &ProtoBlockHeaderMerkleParts{
+			VersionAndChainIdHash:             bp.VersionAndChainIdHash,
+			Height:                            bp.Height,
+			TimeSecond:                        bp.TimeSecond,
+			TimeNanoSecond:                    bp.TimeNanoSecond,
+			LastBlockIDAndOther:               bp.LastBlockIDAndOther,
+			NextValidatorHashAndConsensusHash: bp.NextValidatorHashAndConsensusHash,
+			LastResultsHash:                   bp.LastResultsHash,
+			EvidenceAndProposerHash:           bp.EvidenceAndProposerHash,
+		},
+		Signatures: signatures,
+	}
+}
+
+func (o *OracleDataProof) encodeToProtoFormat() *ProtoOracleDataProof {
+	paths := make([]*ProtoIAVLMerklePath, len(o.MerklePaths))
+	for i, path := range o.MerklePaths {
+		paths[i] = &ProtoIAVLMerklePath{
+			IsDataOnRight:  path.IsDataOnRight,
+			SubtreeHeight:  uint32(path.SubtreeHeight),
+			SubtreeSize:    path.SubtreeSize,
+			SubtreeVersion: path.SubtreeVersion,
+			SiblingHash:    path.SiblingHash,
+		}
+	}
+	req := o.RequestPacket
+	res := o.ResponsePacket
+	return &ProtoOracleDataProof{
+		RequestPacket:  &req,
+		ResponsePacket: &res,
+		Version:        o.Version,
+		MerklePaths:    paths,
+	}
+}
+
+// protoEncoder encodes proofs with the canonical protobuf messages above.
+type protoEncoder struct{}
+
+func (protoEncoder) EncodeProof(proof JsonProof) ([]byte, error) {
+	return proto.Marshal(&ProtoProof{
+		BlockHeight:     proof.BlockHeight,
+		OracleDataProof: proof.OracleDataProof.encodeToProtoFormat(),
+		BlockRelayProof: proof.BlockRelayProof.encodeToProtoFormat(),
+	})
+}
+
+func (protoEncoder) EncodeMultiProof(proof JsonMultiProof) ([]byte, error) {
+	oracleData := make([]*ProtoOracleDataProof, len(proof.OracleDataMultiProof))
+	for i, data := range proof.OracleDataMultiProof {
+		oracleData[i] = data.encodeToProtoFormat()
+	}
+	return proto.Marshal(&ProtoMultiProof{
+		BlockHeight:          proof.BlockHeight,
+		OracleDataMultiProof: oracleData,
+		BlockRelayProof:      proof.BlockRelayProof.encodeToProtoFormat(),
+	})
+}
@@ -0,0 +1,83 @@
+package proof
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// RLPBlockRelayProof is an RLP version of BlockRelayProof, sharing its layout with the EVM format.
+type RLPBlockRelayProof struct {
+	MultiStore  MultiStoreProofEthereum
+	MerkleParts BlockHeaderMerklePartsEthereum
+	Signatures  []TMSignatureEthereum
+}
+
+func (blockRelay *BlockRelayProof) encodeToRLPFormat() RLPBlockRelayProof {
+	signatures := make([]TMSignatureEthereum, len(blockRelay.Signatures))
+	for i, sig := range blockRelay.Signatures {
+		signatures[i] = sig.encodeToEthFormat()
+	}
+	return RLPBlockRelayProof{
+		MultiStore:  blockRelay.MultiStoreProof.encodeToEthFormat(),
+		MerkleParts: blockRelay.BlockHeaderMerkleParts.encodeToEthFormat(),
+		Signatures:  signatures,
+	}
+}
+
+// RLPOracleDataProof is an RLP version of OracleDataProof, sharing its layout with the EVM format.
+type RLPOracleDataProof struct {
+	RequestPacket  RequestPacketEthereum
+	ResponsePacket ResponsePacketEthereum
+	Version        uint64
+	MerklePaths    []IAVLMerklePathEthereum
+}
+
+func (o *OracleDataProof) encodeToRLPFormat() RLPOracleDataProof {
+	paths := make([]IAVLMerklePathEthereum, len(o.MerklePaths))
+	for i, path := range o.MerklePaths {
+		paths[i] = path.encodeToEthFormat()
+	}
+	return RLPOracleDataProof{
+		RequestPacket:  transformRequestPacket(o.RequestPacket),
+		ResponsePacket: transformResponsePacket(o.ResponsePacket),
+		Version:        o.Version,
+		MerklePaths:    paths,
+	}
+}
+
+// RLPProof is the RLP structure of JsonProof.
+type RLPProof struct {
+	BlockHeight uint64
+	BlockRelay  RLPBlockRelayProof
+	OracleData  RLPOracleDataProof
+}
+
+// RLPMultiProof is the RLP structure of JsonMultiProof.
+type RLPMultiProof struct {
+	BlockHeight uint64
+	BlockRelay  RLPBlockRelayProof
+	OracleData  []RLPOracleDataProof
+}
+
+// rlpEncoder encodes proofs with the recursive length prefix encoding, for chains whose
+// contracts come with an RLP decoder but no ABI decoder.
+type rlpEncoder struct{}
+
+func (rlpEncoder) EncodeProof(proof JsonProof) ([]byte, error) {
+	return rlp.EncodeToBytes(RLPProof{
+		BlockHeight: proof.BlockHeight,
+		BlockRelay:  proof.BlockRelayProof.encodeToRLPFormat(),
+		OracleData:  proof.OracleDataProof.encodeToRLPFormat(),
+	})
+}
+
+func (rlpEncoder) EncodeMultiProof(proof JsonMultiProof) ([]byte, error) {
+	oracleData := make([]RLPOracleDataProof, len(proof.OracleDataMultiProof))
+	for i, data := range proof.OracleDataMultiProof {
+		oracleData[i] = data.encodeToRLPFormat()
+	}
+	return rlp.EncodeToBytes(RLPMultiProof{
+		BlockHeight: proof.BlockHeight,
+		BlockRelay:  proof.BlockRelayProof.encodeToRLPFormat(),
+		OracleData:  oracleData,
+	})
+}
@@ -46,6 +46,20 @@ func (signature *TMSignature) encodeToEthFormat() TMSignatureEthereum {
 	}
 }
 
+// RecoverSigner returns the EVM address of the validator who signed the given block hash.
+func (signature *TMSignature) RecoverSigner(blockHash []byte) (common.Address, error) {
+	if len(signature.R) != 32 || len(signature.S) != 32 || (signature.V != 27 && signature.V != 28) {
+		return common.Address{}, fmt.Errorf("Invalid signature format")
+	}
+	msg := append(append(append([]byte{}, signature.SignedDataPrefix...), blockHash...), signature.SignedDataSuffix...)
+	sig := append(append(append([]byte{}, signature.R...), signature.S...), signature.V-27)
+	pub, err := crypto.SigToPub(tmhash.Sum(msg), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
 func recoverETHAddress(msg, sig, signer []byte) ([]byte, uint8, error) {
 	for i := uint8(0); i < 2; i++ {
 		pubuc, err := crypto.SigToPub(tmhash.Sum(msg), append(sig, byte(i)))
@@ -0,0 +1,450 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+const (
+	KeyTypeTag = "keyType"
+	KeyTag     = "key"
+)
+
+// Oracle store key types that can be proven with GetStoreProofHandlerFn.
+const (
+	KeyTypeResult            = "result"
+	KeyTypeRequest           = "request"
+	KeyTypeDataSource        = "data_source"
+	KeyTypeOracleScript      = "oracle_script"
+	KeyTypeValidatorStatus   = "validator_status"
+	KeyTypeRequestCount      = "request_count"
+	KeyTypeDataSourceCount   = "data_source_count"
+	KeyTypeOracleScriptCount = "oracle_script_count"
+	KeyTypeRollingSeed       = "rolling_seed"
+	KeyTypeRaw               = "raw"
+)
+
+// GetStoreKey returns the oracle store key of the given key type. The key argument is an ID for
+// results, requests, data sources and oracle scripts, a validator address for validator statuses,
+// a hex string for raw keys, and must be empty for global state variables.
+func GetStoreKey(keyType string, key string) ([]byte, error) {
+	switch keyType {
+	case KeyTypeResult, KeyTypeRequest, KeyTypeDataSource, KeyTypeOracleScript:
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s id: %w", keyType, err)
+		}
+		switch keyType {
+		case KeyTypeResult:
+			return types.ResultStoreKey(types.RequestID(id)), nil
+		case KeyTypeRequest:
+			return types.RequestStoreKey(types.RequestID(id)), nil
+		case KeyTypeDataSource:
+			return types.DataSourceStoreKey(types.DataSourceID(id)), nil
+		default:
+			return types.OracleScriptStoreKey(types.OracleScriptID(id)), nil
+		}
+	case KeyTypeValidatorStatus:
+		val, err := sdk.ValAddressFromBech32(key)
+		if err != nil {
+			return nil, err
+		}
+		return types.ValidatorStatusStoreKey(val), nil
+	case KeyTypeRaw:
+		raw, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) == 0 {
+			return nil, errors.New("raw key must not be empty")
+		}
+		return raw, nil
+	case KeyTypeRequestCount, KeyTypeDataSourceCount, KeyTypeOracleScriptCount, KeyTypeRollingSeed:
+		if key != "" {
+			return nil, fmt.Errorf("%s does not take a key", keyType)
+		}
+		switch keyType {
+		case KeyTypeRequestCount:
+			return types.RequestCountStoreKey, nil
+		case KeyTypeDataSourceCount:
+			return types.DataSourceCountStoreKey, nil
+		case KeyTypeOracleScriptCount:
+			return types.OracleScriptCountStoreKey, nil
+		default:
+			return types.RollingSeedStoreKey, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown key type: %s", keyType)
+	}
+}
+
+// IAVLLeafProof proves that a leaf with the given key and value hash is in an IAVL tree.
+type IAVLLeafProof struct {
+	Key         tmbytes.HexBytes `json:"key"`
+	ValueHash   tmbytes.HexBytes `json:"valueHash"`
+	Version     uint64           `json:"version"`
+	MerklePaths []IAVLMerklePath `json:"merklePaths"`
+}
+
+// GetRootHash returns the root hash of the IAVL tree computed from this leaf proof.
+func (l *IAVLLeafProof) GetRootHash() []byte {
+	hash := getLeafHash(l.Key, l.ValueHash, l.Version)
+	for _, path := range l.MerklePaths {
+		hash = path.GetParentHash(hash)
+	}
+	return hash
+}
+
+// isEdge returns whether every step of this proof goes to the right child, or to the left child if
+// right is false, starting from the given number of steps below the root.
+func (l *IAVLLeafProof) isEdge(right bool, depth int) bool {
+	for i := len(l.MerklePaths) - 1 - depth; i >= 0; i-- {
+		if l.MerklePaths[i].IsDataOnRight != right {
+			return false
+		}
+	}
+	return true
+}
+
+// StoreProof proves either the value of a key in the oracle store, or that the key is absent.
+// Absence is proven with the leaves right before and after the key, either of which is nil when
+// the key is before the first or after the last leaf of the tree.
+type StoreProof struct {
+	Key           tmbytes.HexBytes `json:"key"`
+	Value         tmbytes.HexBytes `json:"value"`
+	Existence     *IAVLLeafProof   `json:"existence"`
+	LeftNeighbor  *IAVLLeafProof   `json:"leftNeighbor"`
+	RightNeighbor *IAVLLeafProof   `json:"rightNeighbor"`
+}
+
+// Exists returns whether this is a proof of existence.
+func (s *StoreProof) Exists() bool {
+	return s.Existence != nil
+}
+
+// GetOracleStateHash returns the root hash of the oracle IAVL tree computed from this proof.
+// Returns an error if the proof does not prove the existence or the absence of its key.
+func (s *StoreProof) GetOracleStateHash() ([]byte, error) {
+	if s.Existence != nil {
+		if s.LeftNeighbor != nil || s.RightNeighbor != nil {
+			return nil, errors.New("existence proof must not have neighbors")
+		}
+		if !bytes.Equal(s.Existence.Key, s.Key) {
+			return nil, errors.New("existence proof is for a different key")
+		}
+		if !bytes.Equal(s.Existence.ValueHash, tmhash.Sum(s.Value)) {
+			return nil, errors.New("existence proof is for a different value")
+		}
+		return s.Existence.GetRootHash(), nil
+	}
+	if len(s.Value) != 0 {
+		return nil, errors.New("absence proof must not have value")
+	}
+	left, right := s.LeftNeighbor, s.RightNeighbor
+	switch {
+	case left == nil && right == nil:
+		return nil, errors.New("absence proof must have at least one neighbor")
+	case right == nil:
+		if bytes.Compare(left.Key, s.Key) >= 0 || !left.isEdge(true, 0) {
+			return nil, errors.New("left neighbor is not the last leaf before the key")
+		}
+		return left.GetRootHash(), nil
+	case left == nil:
+		if bytes.Compare(right.Key, s.Key) <= 0 || !right.isEdge(false, 0) {
+			return nil, errors.New("right neighbor is not the first leaf after the key")
+		}
+		return right.GetRootHash(), nil
+	}
+	if bytes.Compare(left.Key, s.Key) >= 0 || bytes.Compare(right.Key, s.Key) <= 0 {
+		return nil, errors.New("neighbors do not surround the key")
+	}
+	root := left.GetRootHash()
+	if !bytes.Equal(root, right.GetRootHash()) {
+		return nil, errors.New("neighbors are not from the same tree")
+	}
+	// The neighbors must split at a common node, below which the left neighbor is the rightmost
+	// leaf of the left subtree and the right neighbor is the leftmost leaf of the right subtree.
+	leftDepth, rightDepth := len(left.MerklePaths), len(right.MerklePaths)
+	depth := 0
+	for depth < leftDepth && depth < rightDepth &&
+		left.MerklePaths[leftDepth-1-depth].IsDataOnRight == right.MerklePaths[rightDepth-1-depth].IsDataOnRight {
+		depth++
+	}
+	if depth == leftDepth || depth == rightDepth ||
+		left.MerklePaths[leftDepth-1-depth].IsDataOnRight || !left.isEdge(true, depth+1) ||
+		!right.MerklePaths[rightDepth-1-depth].IsDataOnRight || !right.isEdge(false, depth+1) {
+		return nil, errors.New("neighbors are not adjacent")
+	}
+	return root, nil
+}
+
+type JsonStoreProof struct {
+	BlockHeight     uint64          `json:"blockHeight"`
+	StoreProof      StoreProof      `json:"storeProof"`
+	BlockRelayProof BlockRelayProof `json:"blockRelayProof"`
+}
+
+// errProofNotReady is returned when the store has no proof for the queried height yet.
+var errProofNotReady = errors.New("Proof has not been ready.")
+
+// storeQuerier reads the oracle store at a fixed height.
+type storeQuerier interface {
+	// QueryWithProof returns the value of the key, or nil if absent, and its IAVL range proof.
+	QueryWithProof(key []byte) ([]byte, *iavl.RangeProof, error)
+	// QueryKeys returns all keys that start with the given prefix.
+	QueryKeys(prefix []byte) ([][]byte, error)
+}
+
+// abciStoreQuerier is a storeQuerier that reads the oracle store through ABCI queries. It also
+// keeps the multistore proof of the last query.
+type abciStoreQuerier struct {
+	ctx             context.CLIContext
+	height          int64
+	multiStoreProof rootmulti.MultiStoreProofOp
+}
+
+func (q *abciStoreQuerier) QueryWithProof(key []byte) ([]byte, *iavl.RangeProof, error) {
+	resp, err := q.ctx.Client.ABCIQueryWithOptions(
+		"/store/oracle/key", key, rpcclient.ABCIQueryOptions{Height: q.height, Prove: true},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := resp.Response.GetProof()
+	if proof == nil || proof.GetOps() == nil {
+		return nil, nil, errors.New("Proof not found")
+	}
+	var rangeProof *iavl.RangeProof
+	for _, op := range proof.GetOps() {
+		switch op.GetType() {
+		case iavl.ProofOpIAVLValue:
+			var iavlProof iavl.ValueOp
+			err := q.ctx.Codec.UnmarshalBinaryLengthPrefixed(op.GetData(), &iavlProof)
+			if err != nil {
+				return nil, nil, fmt.Errorf("iavl: %w", err)
+			}
+			rangeProof = iavlProof.Proof
+		case iavl.ProofOpIAVLAbsence:
+			var absenceProof iavl.AbsenceOp
+			err := q.ctx.Codec.UnmarshalBinaryLengthPrefixed(op.GetData(), &absenceProof)
+			if err != nil {
+				return nil, nil, fmt.Errorf("iavl: %w", err)
+			}
+			rangeProof = absenceProof.Proof
+		case "multistore":
+			mp, err := rootmulti.MultiStoreProofOpDecoder(op)
+			if err != nil {
+				return nil, nil, fmt.Errorf("multiStore: %w", err)
+			}
+			q.multiStoreProof = mp.(rootmulti.MultiStoreProofOp)
+		default:
+			return nil, nil, fmt.Errorf("Unknown proof type %s", op.GetType())
+		}
+	}
+	if rangeProof == nil {
+		return nil, nil, errProofNotReady
+	}
+	return resp.Response.GetValue(), rangeProof, nil
+}
+
+func (q *abciStoreQuerier) QueryKeys(prefix []byte) ([][]byte, error) {
+	kvs, _, err := q.ctx.WithHeight(q.height).QuerySubspace(prefix, types.StoreKey)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, len(kvs))
+	for idx, kv := range kvs {
+		keys[idx] = kv.Key
+	}
+	return keys, nil
+}
+
+// queryStoreProof returns the proof of existence or absence of the given key in the oracle store
+// at the given store height, together with the multistore proof of the oracle store. On error,
+// also returns the HTTP status code to respond with.
+func queryStoreProof(
+	ctx context.CLIContext, key []byte, height int64,
+) (StoreProof, rootmulti.MultiStoreProofOp, int, error) {
+	q := &abciStoreQuerier{ctx: ctx, height: height}
+	storeProof, err := getStoreProof(q, key)
+	if errors.Is(err, errProofNotReady) {
+		return StoreProof{}, rootmulti.MultiStoreProofOp{}, http.StatusNotFound, err
+	} else if err != nil {
+		return StoreProof{}, rootmulti.MultiStoreProofOp{}, http.StatusInternalServerError, err
+	}
+	return storeProof, q.multiStoreProof, http.StatusOK, nil
+}
+
+// getStoreProof returns the proof of existence or absence of the given key using the given querier.
+func getStoreProof(q storeQuerier, key []byte) (StoreProof, error) {
+	value, rangeProof, err := q.QueryWithProof(key)
+	if err != nil {
+		return StoreProof{}, err
+	}
+	storeProof := StoreProof{Key: key}
+	if value != nil {
+		storeProof.Value = value
+		valueOp := iavl.NewValueOp(key, rangeProof)
+		storeProof.Existence = &IAVLLeafProof{
+			Key:         key,
+			ValueHash:   tmbytes.HexBytes(rangeProof.Leaves[0].ValueHash),
+			Version:     uint64(rangeProof.Leaves[0].Version),
+			MerklePaths: GetIAVLMerklePaths(&valueOp),
+		}
+		return storeProof, nil
+	}
+	// The absence proof covers the leaves around the key. Prove each neighbor on its own.
+	for _, leaf := range rangeProof.Leaves {
+		neighbor, err := getExistenceProof(q, leaf.Key)
+		if err != nil {
+			return StoreProof{}, err
+		}
+		if bytes.Compare(leaf.Key, key) < 0 {
+			storeProof.LeftNeighbor = neighbor
+		} else if storeProof.RightNeighbor == nil {
+			storeProof.RightNeighbor = neighbor
+		}
+	}
+	if storeProof.LeftNeighbor != nil && storeProof.RightNeighbor == nil && !storeProof.LeftNeighbor.isEdge(true, 0) {
+		// The IAVL store leaves out the right neighbor when the key extends the left neighbor's key.
+		// The right neighbor is then either the next key that extends the left neighbor's key, or
+		// the first key after all of those.
+		right, err := getRightNeighborProof(q, key, storeProof.LeftNeighbor.Key)
+		if err != nil {
+			return StoreProof{}, err
+		}
+		storeProof.RightNeighbor = right
+	}
+	return storeProof, nil
+}
+
+// getExistenceProof returns the proof of the leaf of the given key, which must exist.
+func getExistenceProof(q storeQuerier, key []byte) (*IAVLLeafProof, error) {
+	leaf, err := getStoreProof(q, key)
+	if err != nil {
+		return nil, err
+	}
+	if !leaf.Exists() {
+		return nil, fmt.Errorf("leaf %X not found", key)
+	}
+	return leaf.Existence, nil
+}
+
+// getRightNeighborProof returns the proof of the first leaf after the given key, which is known to
+// be after all keys that do not start with the key of its left neighbor.
+func getRightNeighborProof(q storeQuerier, key []byte, leftKey []byte) (*IAVLLeafProof, error) {
+	keys, err := q.QueryKeys(leftKey)
+	if err != nil {
+		return nil, err
+	}
+	var next []byte
+	for _, k := range keys {
+		if bytes.Compare(k, key) > 0 && (next == nil || bytes.Compare(k, next) < 0) {
+			next = k
+		}
+	}
+	if next != nil {
+		return getExistenceProof(q, next)
+	}
+	next = incrementPrefix(leftKey)
+	if next == nil {
+		return nil, fmt.Errorf("no leaf after %X", key)
+	}
+	storeProof, err := getStoreProof(q, next)
+	if err != nil {
+		return nil, err
+	}
+	if storeProof.Exists() {
+		return storeProof.Existence, nil
+	}
+	if storeProof.RightNeighbor == nil {
+		return nil, fmt.Errorf("no leaf after %X", key)
+	}
+	return storeProof.RightNeighbor, nil
+}
+
+// incrementPrefix returns the smallest key that is greater than all keys starting with the given
+// prefix, or nil if there is none.
+func incrementPrefix(prefix []byte) []byte {
+	next := append([]byte{}, prefix...)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i] < 0xff {
+			next[i]++
+			return next[:i+1]
+		}
+	}
+	return nil
+}
+
+// GetStoreProofHandlerFn returns the proof of existence or absence of an oracle store key, given
+// by its key type and key as documented in GetStoreKey.
+func GetStoreProofHandlerFn(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		height := &ctx.Height
+		if ctx.Height == 0 {
+			height = nil
+		}
+		vars := mux.Vars(r)
+		key, err := GetStoreKey(vars[KeyTypeTag], vars[KeyTag])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		commit, err := ctx.Client.Commit(height)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		storeProof, multiStoreProof, status, err := queryStoreProof(ctx, key, commit.Height-1)
+		if err != nil {
+			rest.WriteErrorResponse(w, status, err.Error())
+			return
+		}
+		signatures, err := GetSignaturesAndPrefix(&commit.SignedHeader)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		blockRelay := BlockRelayProof{
+			MultiStoreProof:        GetMultiStoreProof(multiStoreProof),
+			BlockHeaderMerkleParts: GetBlockHeaderMerkleParts(ctx.Codec, commit.Header),
+			Signatures:             signatures,
+		}
+		oracleStateHash, err := storeProof.GetOracleStateHash()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !bytes.Equal(oracleStateHash, blockRelay.MultiStoreProof.OracleIAVLStateHash) {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Store proof does not match oracle state hash")
+			return
+		}
+
+		rest.PostProcessResponse(w, ctx, JsonStoreProof{
+			BlockHeight:     uint64(commit.Height),
+			StoreProof:      storeProof,
+			BlockRelayProof: blockRelay,
+		})
+	}
+}
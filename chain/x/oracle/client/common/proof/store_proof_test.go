@@ -0,0 +1,195 @@
+package proof
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func TestGetStoreKey(t *testing.T) {
+	val := sdk.ValAddress([]byte("validator1__________"))
+	testCases := []struct {
+		keyType  string
+		key      string
+		expected []byte
+	}{
+		{KeyTypeResult, "5", types.ResultStoreKey(5)},
+		{KeyTypeRequest, "5", types.RequestStoreKey(5)},
+		{KeyTypeDataSource, "3", types.DataSourceStoreKey(3)},
+		{KeyTypeOracleScript, "3", types.OracleScriptStoreKey(3)},
+		{KeyTypeValidatorStatus, val.String(), types.ValidatorStatusStoreKey(val)},
+		{KeyTypeRequestCount, "", types.RequestCountStoreKey},
+		{KeyTypeDataSourceCount, "", types.DataSourceCountStoreKey},
+		{KeyTypeOracleScriptCount, "", types.OracleScriptCountStoreKey},
+		{KeyTypeRollingSeed, "", types.RollingSeedStoreKey},
+		{KeyTypeRaw, "ff0000000000000005", types.ResultStoreKey(5)},
+	}
+	for _, tc := range testCases {
+		key, err := GetStoreKey(tc.keyType, tc.key)
+		require.NoError(t, err, tc.keyType)
+		require.Equal(t, tc.expected, key, tc.keyType)
+	}
+	_, err := GetStoreKey(KeyTypeResult, "abc")
+	require.Error(t, err)
+	_, err = GetStoreKey(KeyTypeValidatorStatus, "band1abc")
+	require.Error(t, err)
+	_, err = GetStoreKey(KeyTypeRaw, "")
+	require.EqualError(t, err, "raw key must not be empty")
+	_, err = GetStoreKey(KeyTypeRequestCount, "1")
+	require.EqualError(t, err, "request_count does not take a key")
+	_, err = GetStoreKey("report", "1")
+	require.EqualError(t, err, "unknown key type: report")
+}
+
+// treeStoreQuerier is a storeQuerier that reads the latest version of an IAVL tree.
+type treeStoreQuerier struct {
+	tree *iavl.MutableTree
+}
+
+func (q treeStoreQuerier) QueryWithProof(key []byte) ([]byte, *iavl.RangeProof, error) {
+	return q.tree.GetVersionedWithProof(key, q.tree.Version())
+}
+
+func (q treeStoreQuerier) QueryKeys(prefix []byte) ([][]byte, error) {
+	keys := [][]byte{}
+	q.tree.IterateRange(prefix, incrementPrefix(prefix), true, func(key []byte, value []byte) bool {
+		keys = append(keys, key)
+		return false
+	})
+	return keys, nil
+}
+
+func mockStoreProof(t *testing.T, tree *iavl.MutableTree, key []byte) StoreProof {
+	p, err := getStoreProof(treeStoreQuerier{tree}, key)
+	require.NoError(t, err)
+	return p
+}
+
+func TestStoreProofExistence(t *testing.T) {
+	tree := mockOracleTree(t)
+	for _, key := range [][]byte{types.ResultStoreKey(1), types.ResultStoreKey(100), types.RequestStoreKey(42)} {
+		p := mockStoreProof(t, tree, key)
+		require.True(t, p.Exists())
+		hash, err := p.GetOracleStateHash()
+		require.NoError(t, err)
+		require.Equal(t, tree.Hash(), hash)
+	}
+	p := mockStoreProof(t, tree, types.ResultStoreKey(7))
+	p.Value = []byte("tampered")
+	_, err := p.GetOracleStateHash()
+	require.EqualError(t, err, "existence proof is for a different value")
+	p = mockStoreProof(t, tree, types.ResultStoreKey(7))
+	p.Key = types.ResultStoreKey(8)
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "existence proof is for a different key")
+}
+
+func TestStoreProofAbsence(t *testing.T) {
+	tree := mockOracleTree(t)
+	testCases := []struct {
+		key         []byte
+		hasLeft     bool
+		hasRight    bool
+		description string
+	}{
+		{types.ResultStoreKey(101), true, false, "after the last leaf"},
+		{types.RollingSeedStoreKey, false, true, "before the first leaf"},
+		{types.DataSourceStoreKey(1), true, true, "between requests and results"},
+		{append(types.RequestStoreKey(50), 0x00), true, true, "between two requests"},
+	}
+	for _, tc := range testCases {
+		p := mockStoreProof(t, tree, tc.key)
+		require.False(t, p.Exists(), tc.description)
+		require.Equal(t, tc.hasLeft, p.LeftNeighbor != nil, tc.description)
+		require.Equal(t, tc.hasRight, p.RightNeighbor != nil, tc.description)
+		hash, err := p.GetOracleStateHash()
+		require.NoError(t, err, tc.description)
+		require.Equal(t, tree.Hash(), hash, tc.description)
+	}
+}
+
+func TestStoreProofAbsenceOfUnresolvedResult(t *testing.T) {
+	tree := mockOracleTree(t)
+	tree.Remove(types.ResultStoreKey(50))
+	// Keys that extend the left neighbor's key, before and after the absent key.
+	tree.Set(append(types.RequestStoreKey(50), 0x00, 0x01), []byte("extended"))
+	tree.Set(append(types.RequestStoreKey(50), 0x01), []byte("extended"))
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	testCases := []struct {
+		key   []byte
+		left  []byte
+		right []byte
+	}{
+		{types.ResultStoreKey(50), types.ResultStoreKey(49), types.ResultStoreKey(51)},
+		{append(types.RequestStoreKey(50), 0x00), types.RequestStoreKey(50), append(types.RequestStoreKey(50), 0x00, 0x01)},
+		{append(types.RequestStoreKey(50), 0x00, 0x01, 0x00), append(types.RequestStoreKey(50), 0x00, 0x01), append(types.RequestStoreKey(50), 0x01)},
+		{append(types.RequestStoreKey(50), 0x01, 0x00), append(types.RequestStoreKey(50), 0x01), types.RequestStoreKey(51)},
+	}
+	for _, tc := range testCases {
+		p := mockStoreProof(t, tree, tc.key)
+		require.False(t, p.Exists())
+		require.Equal(t, tc.left, []byte(p.LeftNeighbor.Key))
+		require.Equal(t, tc.right, []byte(p.RightNeighbor.Key))
+		hash, err := p.GetOracleStateHash()
+		require.NoError(t, err)
+		require.Equal(t, tree.Hash(), hash)
+	}
+}
+
+func TestIncrementPrefix(t *testing.T) {
+	require.Equal(t, []byte{0x01, 0x03}, incrementPrefix([]byte{0x01, 0x02}))
+	require.Equal(t, []byte{0x02}, incrementPrefix([]byte{0x01, 0xff, 0xff}))
+	require.Nil(t, incrementPrefix([]byte{0xff, 0xff}))
+}
+
+func TestStoreProofAbsenceFail(t *testing.T) {
+	tree := mockOracleTree(t)
+	key := append(types.RequestStoreKey(50), 0x00)
+	// Neighbors that are not adjacent cannot prove absence, even though both are in the tree.
+	p := mockStoreProof(t, tree, key)
+	p.LeftNeighbor = mockStoreProof(t, tree, types.RequestStoreKey(49)).Existence
+	_, err := p.GetOracleStateHash()
+	require.EqualError(t, err, "neighbors are not adjacent")
+	// Dropping a neighbor in the middle of the tree is also rejected.
+	p = mockStoreProof(t, tree, key)
+	p.RightNeighbor = nil
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "left neighbor is not the last leaf before the key")
+	p = mockStoreProof(t, tree, key)
+	p.LeftNeighbor = nil
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "right neighbor is not the first leaf after the key")
+	// An existing key cannot be proven absent by its own neighbors.
+	p = mockStoreProof(t, tree, key)
+	p.Key = types.RequestStoreKey(50)
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "neighbors do not surround the key")
+	p = mockStoreProof(t, tree, key)
+	p.RightNeighbor = mockStoreProof(t, tree, types.ResultStoreKey(3)).Existence
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "neighbors are not adjacent")
+	// Neighbors must be from the same tree.
+	other, err := iavl.NewMutableTree(dbm.NewMemDB(), 0)
+	require.NoError(t, err)
+	other.Set(types.RequestStoreKey(51), []byte("other"))
+	other.Set(types.RequestStoreKey(50), []byte("other"))
+	_, _, err = other.SaveVersion()
+	require.NoError(t, err)
+	p = mockStoreProof(t, tree, key)
+	p.RightNeighbor = mockStoreProof(t, other, types.RequestStoreKey(51)).Existence
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "neighbors are not from the same tree")
+	p = StoreProof{Key: key}
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "absence proof must have at least one neighbor")
+	p = mockStoreProof(t, tree, key)
+	p.Value = []byte("value")
+	_, err = p.GetOracleStateHash()
+	require.EqualError(t, err, "absence proof must not have value")
+}
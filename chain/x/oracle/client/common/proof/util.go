@@ -53,6 +53,19 @@ func base64ToBytes(s string) []byte {
 	return decodedString
 }
 
+// merkleLeafHash returns the hash of a Merkle leaf node.
+func merkleLeafHash(value []byte) []byte {
+	return tmhash.Sum(append([]byte{0}, value...))
+}
+
+// merkleInnerHash returns the hash of an internal Merkle node, calculated from child nodes.
+func merkleInnerHash(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, 1)
+	data = append(data, left...)
+	return tmhash.Sum(append(data, right...))
+}
+
 func encodeStoreMerkleHash(key string, value []byte) []byte {
 	bytesKey := []byte(key)
 	keyBytes := append([]byte{uint8(len(bytesKey))}, bytesKey...)
@@ -9,3 +9,13 @@ import (
 func TestEncodeTime(t *testing.T) {
 	require.Equal(t, hexToBytes("08d78dd9fd0510c4a1aae301"), encodeTime(parseTime("2020-11-19T10:20:07.476745924Z")))
 }
+
+func TestMerkleInnerHashDoesNotAlias(t *testing.T) {
+	// A left child with spare capacity must not have the right child written into its backing array.
+	backing := make([]byte, 64)
+	left := backing[:32]
+	right := append(make([]byte, 0, 32), hexToBytes("0D4AF3F5FFA02A56B1DEED7BC8C16732AEB8FD003C67EEF26048B314C351FAE8")...)
+	hash := merkleInnerHash(left, right)
+	require.Equal(t, make([]byte, 64), backing)
+	require.Equal(t, hash, merkleInnerHash(make([]byte, 32), right))
+}
@@ -0,0 +1,19 @@
+package verifier
+
+import "errors"
+
+// Errors returned by the verifier. Every failure is wrapped around one of these values, so
+// callers can distinguish failure modes with errors.Is.
+var (
+	ErrInvalidProof            = errors.New("verifier: malformed proof")
+	ErrEmptyValidatorSet       = errors.New("verifier: empty validator set")
+	ErrDuplicateValidator      = errors.New("verifier: duplicate validator")
+	ErrBlockHeightMismatch     = errors.New("verifier: block height mismatch")
+	ErrInvalidSignature        = errors.New("verifier: invalid signature")
+	ErrUnorderedSigners        = errors.New("verifier: signers not in ascending order")
+	ErrInsufficientVotingPower = errors.New("verifier: insufficient voting power")
+	ErrInvalidMerklePath       = errors.New("verifier: invalid merkle path")
+	ErrInvalidVersion          = errors.New("verifier: tree node version not before block height")
+	ErrInvalidStoreProof       = errors.New("verifier: invalid store proof")
+	ErrOracleStateMismatch     = errors.New("verifier: oracle state hash mismatch")
+)
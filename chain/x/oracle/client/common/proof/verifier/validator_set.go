@@ -0,0 +1,85 @@
+package verifier
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Validator is a trusted block validator identified by its EVM address, as kept by the Bridge.
+type Validator struct {
+	Address common.Address `json:"address"`
+	Power   uint64         `json:"power"`
+}
+
+// ValidatorSet is the set of trusted validators that block relay proofs are checked against.
+type ValidatorSet struct {
+	powers     map[common.Address]uint64
+	totalPower uint64
+}
+
+// NewValidatorSet creates a validator set from the given validators. Validators with zero power
+// are ignored. Returns an error if the set is empty or contains the same address twice.
+func NewValidatorSet(vals []Validator) (ValidatorSet, error) {
+	powers := make(map[common.Address]uint64)
+	totalPower := uint64(0)
+	for _, val := range vals {
+		if val.Power == 0 {
+			continue
+		}
+		if _, ok := powers[val.Address]; ok {
+			return ValidatorSet{}, fmt.Errorf("%w: %s", ErrDuplicateValidator, val.Address.Hex())
+		}
+		powers[val.Address] = val.Power
+		totalPower += val.Power
+	}
+	if totalPower == 0 {
+		return ValidatorSet{}, ErrEmptyValidatorSet
+	}
+	return ValidatorSet{powers: powers, totalPower: totalPower}, nil
+}
+
+// NewValidatorSetFromTendermint creates a validator set from Tendermint validators, which must
+// use secp256k1 public keys in order to be verifiable by EVM address.
+func NewValidatorSetFromTendermint(vals []*tmtypes.Validator) (ValidatorSet, error) {
+	converted := make([]Validator, len(vals))
+	for idx, val := range vals {
+		pubKey, ok := val.PubKey.(secp256k1.PubKeySecp256k1)
+		if !ok {
+			return ValidatorSet{}, fmt.Errorf("validator %s does not use secp256k1 key", val.Address)
+		}
+		ecdsaPubKey, err := crypto.DecompressPubkey(pubKey[:])
+		if err != nil {
+			return ValidatorSet{}, err
+		}
+		converted[idx] = Validator{Address: crypto.PubkeyToAddress(*ecdsaPubKey), Power: uint64(val.VotingPower)}
+	}
+	return NewValidatorSet(converted)
+}
+
+// Power returns the voting power of the given address, or zero if it is not in the set.
+func (vs ValidatorSet) Power(addr common.Address) uint64 {
+	return vs.powers[addr]
+}
+
+// TotalPower returns the sum of voting power of all validators in the set.
+func (vs ValidatorSet) TotalPower() uint64 {
+	return vs.totalPower
+}
+
+// Validators returns all validators in the set, sorted by address.
+func (vs ValidatorSet) Validators() []Validator {
+	vals := make([]Validator, 0, len(vs.powers))
+	for addr, power := range vs.powers {
+		vals = append(vals, Validator{Address: addr, Power: power})
+	}
+	sort.Slice(vals, func(i, j int) bool {
+		return bytes.Compare(vals[i].Address.Bytes(), vals[j].Address.Bytes()) < 0
+	})
+	return vals
+}
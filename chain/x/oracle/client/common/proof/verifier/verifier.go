@@ -0,0 +1,275 @@
+// Package verifier checks oracle result proofs produced by the BandChain proof REST endpoints
+// against a trusted validator set, following the same steps as the Bridge contract on EVM.
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof"
+)
+
+// VerifyBlockRelay checks that the block at the given height was signed by more than two-thirds
+// of the voting power of the trusted validator set. Returns the verified oracle state hash.
+func VerifyBlockRelay(blockHeight uint64, relay proof.BlockRelayProof, vals ValidatorSet) ([]byte, error) {
+	if vals.TotalPower() == 0 {
+		return nil, ErrEmptyValidatorSet
+	}
+	if relay.BlockHeaderMerkleParts.Height != blockHeight {
+		return nil, fmt.Errorf("%w: proof for %d, header for %d",
+			ErrBlockHeightMismatch, blockHeight, relay.BlockHeaderMerkleParts.Height)
+	}
+	if err := checkMultiStoreProof(relay.MultiStoreProof); err != nil {
+		return nil, err
+	}
+	if err := checkBlockHeaderMerkleParts(relay.BlockHeaderMerkleParts); err != nil {
+		return nil, err
+	}
+	appHash := relay.MultiStoreProof.GetAppHash()
+	blockHash := relay.BlockHeaderMerkleParts.GetBlockHeader(appHash)
+	signedPower := uint64(0)
+	lastSigner := common.Address{}
+	for idx, sig := range relay.Signatures {
+		signer, err := sig.RecoverSigner(blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("%w: signature #%d: %s", ErrInvalidSignature, idx, err.Error())
+		}
+		if bytes.Compare(signer.Bytes(), lastSigner.Bytes()) <= 0 {
+			return nil, fmt.Errorf("%w: signature #%d from %s", ErrUnorderedSigners, idx, signer.Hex())
+		}
+		signedPower += vals.Power(signer)
+		lastSigner = signer
+	}
+	if signedPower*3 <= vals.TotalPower()*2 {
+		return nil, fmt.Errorf("%w: got %d of %d", ErrInsufficientVotingPower, signedPower, vals.TotalPower())
+	}
+	return relay.MultiStoreProof.OracleIAVLStateHash, nil
+}
+
+// VerifyOracleData checks that the result in the given oracle data proof is part of the oracle
+// IAVL tree whose root hash is the given oracle state hash, as of the given block height.
+func VerifyOracleData(blockHeight uint64, oracleStateHash []byte, data proof.OracleDataProof) error {
+	if err := checkVersions(blockHeight, data.Version, data.MerklePaths); err != nil {
+		return err
+	}
+	lastHeight := uint8(0)
+	for idx, path := range data.MerklePaths {
+		if path.SubtreeHeight <= lastHeight {
+			return fmt.Errorf("%w: step #%d has subtree height %d", ErrInvalidMerklePath, idx, path.SubtreeHeight)
+		}
+		if len(path.SiblingHash) != 32 {
+			return fmt.Errorf("%w: step #%d has sibling hash of %d bytes", ErrInvalidMerklePath, idx, len(path.SiblingHash))
+		}
+		lastHeight = path.SubtreeHeight
+	}
+	if got := data.GetOracleStateHash(); !bytes.Equal(got, oracleStateHash) {
+		return fmt.Errorf("%w: expect %X, got %X", ErrOracleStateMismatch, oracleStateHash, got)
+	}
+	return nil
+}
+
+// VerifyProof checks a single oracle result proof against the trusted validator set.
+func VerifyProof(p proof.JsonProof, vals ValidatorSet) error {
+	oracleStateHash, err := VerifyBlockRelay(p.BlockHeight, p.BlockRelayProof, vals)
+	if err != nil {
+		return err
+	}
+	return VerifyOracleData(p.BlockHeight, oracleStateHash, p.OracleDataProof)
+}
+
+// VerifyMultiProof checks every oracle result in a multi-result proof against the trusted
+// validator set.
+func VerifyMultiProof(p proof.JsonMultiProof, vals ValidatorSet) error {
+	oracleStateHash, err := VerifyBlockRelay(p.BlockHeight, p.BlockRelayProof, vals)
+	if err != nil {
+		return err
+	}
+	for _, data := range p.OracleDataMultiProof {
+		if err := VerifyOracleData(p.BlockHeight, oracleStateHash, data); err != nil {
+			return fmt.Errorf("request #%d: %w", data.ResponsePacket.RequestID, err)
+		}
+	}
+	return nil
+}
+
+// VerifyBatchProof checks every oracle result in a batch proof against the trusted validator set.
+func VerifyBatchProof(p proof.JsonBatchProof, vals ValidatorSet) error {
+	oracleStateHash, err := VerifyBlockRelay(p.BlockHeight, p.BlockRelayProof, vals)
+	if err != nil {
+		return err
+	}
+	for idx, leaf := range p.OracleDataBatchProof.Leaves {
+		if err := checkVersion(fmt.Sprintf("leaf #%d", idx), leaf.Version, p.BlockHeight); err != nil {
+			return err
+		}
+	}
+	for idx, node := range p.OracleDataBatchProof.InnerNodes {
+		if err := checkVersion(fmt.Sprintf("inner node #%d", idx), node.SubtreeVersion, p.BlockHeight); err != nil {
+			return err
+		}
+	}
+	got, err := p.OracleDataBatchProof.GetOracleStateHash()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMerklePath, err.Error())
+	}
+	if !bytes.Equal(got, oracleStateHash) {
+		return fmt.Errorf("%w: expect %X, got %X", ErrOracleStateMismatch, oracleStateHash, got)
+	}
+	return nil
+}
+
+// VerifyStoreProof checks a proof of existence or absence of an oracle store key against the
+// trusted validator set.
+func VerifyStoreProof(p proof.JsonStoreProof, vals ValidatorSet) error {
+	oracleStateHash, err := VerifyBlockRelay(p.BlockHeight, p.BlockRelayProof, vals)
+	if err != nil {
+		return err
+	}
+	for _, leaf := range []*proof.IAVLLeafProof{
+		p.StoreProof.Existence, p.StoreProof.LeftNeighbor, p.StoreProof.RightNeighbor,
+	} {
+		if leaf == nil {
+			continue
+		}
+		if err := checkVersions(p.BlockHeight, leaf.Version, leaf.MerklePaths); err != nil {
+			return err
+		}
+	}
+	got, err := p.StoreProof.GetOracleStateHash()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidStoreProof, err.Error())
+	}
+	if !bytes.Equal(got, oracleStateHash) {
+		return fmt.Errorf("%w: expect %X, got %X", ErrOracleStateMismatch, oracleStateHash, got)
+	}
+	return nil
+}
+
+// ParseProof decodes a proof from its JSON form. It accepts the raw proof REST response, the
+// Proof object in its result, or the bare JsonProof.
+func ParseProof(bz []byte) (proof.JsonProof, error) {
+	var p proof.JsonProof
+	if err := codec.New().UnmarshalJSON(unwrapJSON(bz, "result", "jsonProof"), &p); err != nil {
+		return proof.JsonProof{}, fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+	return p, nil
+}
+
+// ParseMultiProof decodes a multi-result proof from its JSON form. It accepts the raw multi proof
+// REST response, the MultiProof object in its result, or the bare JsonMultiProof.
+func ParseMultiProof(bz []byte) (proof.JsonMultiProof, error) {
+	var p proof.JsonMultiProof
+	if err := codec.New().UnmarshalJSON(unwrapJSON(bz, "result", "jsonProof"), &p); err != nil {
+		return proof.JsonMultiProof{}, fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+	return p, nil
+}
+
+// ParseBatchProof decodes a batch proof from its JSON form. It accepts the raw batch proof REST
+// response, the BatchProof object in its result, or the bare JsonBatchProof.
+func ParseBatchProof(bz []byte) (proof.JsonBatchProof, error) {
+	var p proof.JsonBatchProof
+	if err := codec.New().UnmarshalJSON(unwrapJSON(bz, "result", "jsonProof"), &p); err != nil {
+		return proof.JsonBatchProof{}, fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+	return p, nil
+}
+
+// ParseStoreProof decodes a store proof from its JSON form. It accepts the raw store proof REST
+// response or the bare JsonStoreProof.
+func ParseStoreProof(bz []byte) (proof.JsonStoreProof, error) {
+	var p proof.JsonStoreProof
+	if err := codec.New().UnmarshalJSON(unwrapJSON(bz, "result"), &p); err != nil {
+		return proof.JsonStoreProof{}, fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+	return p, nil
+}
+
+// VerifyProofJSON decodes the given proof JSON and verifies it against the trusted validator set.
+// Returns the decoded proof on success.
+func VerifyProofJSON(bz []byte, vals ValidatorSet) (proof.JsonProof, error) {
+	p, err := ParseProof(bz)
+	if err != nil {
+		return proof.JsonProof{}, err
+	}
+	if err := VerifyProof(p, vals); err != nil {
+		return proof.JsonProof{}, err
+	}
+	return p, nil
+}
+
+// unwrapJSON descends into the given object fields in order, whenever they are present.
+func unwrapJSON(bz []byte, fields ...string) []byte {
+	for _, field := range fields {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(bz, &obj); err != nil {
+			return bz
+		}
+		if inner, ok := obj[field]; ok {
+			bz = inner
+		}
+	}
+	return bz
+}
+
+// checkVersion returns an error unless a tree node of the given version is in the state proven by
+// the block at the given height, which is the state committed at the block before it.
+func checkVersion(name string, version uint64, blockHeight uint64) error {
+	if version == 0 || version >= blockHeight {
+		return fmt.Errorf("%w: %s has version %d at block %d", ErrInvalidVersion, name, version, blockHeight)
+	}
+	return nil
+}
+
+// checkVersions checks the versions of a leaf and of the nodes on its path to the root, each of
+// which must be at least as new as its child.
+func checkVersions(blockHeight uint64, leafVersion uint64, paths []proof.IAVLMerklePath) error {
+	if err := checkVersion("leaf", leafVersion, blockHeight); err != nil {
+		return err
+	}
+	lastVersion := leafVersion
+	for idx, path := range paths {
+		if err := checkVersion(fmt.Sprintf("step #%d", idx), path.SubtreeVersion, blockHeight); err != nil {
+			return err
+		}
+		if path.SubtreeVersion < lastVersion {
+			return fmt.Errorf("%w: step #%d has version %d below its child version %d",
+				ErrInvalidVersion, idx, path.SubtreeVersion, lastVersion)
+		}
+		lastVersion = path.SubtreeVersion
+	}
+	return nil
+}
+
+func checkMultiStoreProof(m proof.MultiStoreProof) error {
+	return checkHashes("multi store proof", map[string][]byte{
+		"accToGovStoresMerkleHash":          m.AccToGovStoresMerkleHash,
+		"mainAndMintStoresMerkleHash":       m.MainAndMintStoresMerkleHash,
+		"oracleIAVLStateHash":               m.OracleIAVLStateHash,
+		"paramsStoresMerkleHash":            m.ParamsStoresMerkleHash,
+		"slashingToUpgradeStoresMerkleHash": m.SlashingToUpgradeStoresMerkleHash,
+	})
+}
+
+func checkBlockHeaderMerkleParts(bp proof.BlockHeaderMerkleParts) error {
+	return checkHashes("block header merkle parts", map[string][]byte{
+		"versionAndChainIdHash":             bp.VersionAndChainIdHash,
+		"lastBlockIDAndOther":               bp.LastBlockIDAndOther,
+		"nextValidatorHashAndConsensusHash": bp.NextValidatorHashAndConsensusHash,
+		"lastResultsHash":                   bp.LastResultsHash,
+		"evidenceAndProposerHash":           bp.EvidenceAndProposerHash,
+	})
+}
+
+func checkHashes(name string, hashes map[string][]byte) error {
+	for field, hash := range hashes {
+		if len(hash) != 32 {
+			return fmt.Errorf("%w: %s %s has %d bytes", ErrInvalidProof, name, field, len(hash))
+		}
+	}
+	return nil
+}
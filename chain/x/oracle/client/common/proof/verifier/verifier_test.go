@@ -0,0 +1,283 @@
+package verifier
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/client/common/proof"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// Validators that signed block 3021518 of band-guanyu-poa, sorted by address.
+var guanyuSigners = []common.Address{
+	common.HexToAddress("0x03899178B4994a3E8EA88c69b0014FDf71c656C6"),
+	common.HexToAddress("0x1c18c1Ed4ec5d1Dd39b15c26b3346Fc0b4ADb138"),
+	common.HexToAddress("0x4705e5935aCb7c6eb23A49b17767e01fB7D8Ab73"),
+	common.HexToAddress("0x53256a45877e8Cc3A868D53Dc4a4493256a01701"),
+	common.HexToAddress("0x975E60E8544bbb2945d6630553C269c5c8E72980"),
+	common.HexToAddress("0xa704058b50414C47cb2DFf32929D0830bbcb7f39"),
+	common.HexToAddress("0xeb666C2755AaA9C7e69D6e29f57d8416938c7ee6"),
+}
+
+func mustLoadProof(t *testing.T) proof.JsonProof {
+	bz, err := ioutil.ReadFile("testdata/proof.json")
+	require.NoError(t, err)
+	p, err := ParseProof(bz)
+	require.NoError(t, err)
+	return p
+}
+
+func mustGuanyuValidatorSet(t *testing.T, extra ...Validator) ValidatorSet {
+	vals := extra
+	for _, addr := range guanyuSigners {
+		vals = append(vals, Validator{Address: addr, Power: 100})
+	}
+	vs, err := NewValidatorSet(vals)
+	require.NoError(t, err)
+	return vs
+}
+
+func TestNewValidatorSet(t *testing.T) {
+	_, err := NewValidatorSet(nil)
+	require.True(t, errors.Is(err, ErrEmptyValidatorSet))
+	_, err = NewValidatorSet([]Validator{{Address: guanyuSigners[0], Power: 0}})
+	require.True(t, errors.Is(err, ErrEmptyValidatorSet))
+	_, err = NewValidatorSet([]Validator{{Address: guanyuSigners[0], Power: 1}, {Address: guanyuSigners[0], Power: 2}})
+	require.True(t, errors.Is(err, ErrDuplicateValidator))
+	vs, err := NewValidatorSet([]Validator{{Address: guanyuSigners[0], Power: 1}, {Address: guanyuSigners[1], Power: 2}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), vs.TotalPower())
+	require.Equal(t, uint64(2), vs.Power(guanyuSigners[1]))
+	require.Equal(t, uint64(0), vs.Power(guanyuSigners[2]))
+	vs, err = NewValidatorSet([]Validator{{Address: guanyuSigners[1], Power: 2}, {Address: guanyuSigners[0], Power: 1}})
+	require.NoError(t, err)
+	require.Equal(t, []Validator{{Address: guanyuSigners[0], Power: 1}, {Address: guanyuSigners[1], Power: 2}}, vs.Validators())
+}
+
+func TestParseProof(t *testing.T) {
+	bz, err := ioutil.ReadFile("testdata/proof.json")
+	require.NoError(t, err)
+	expected := mustLoadProof(t)
+	// Bare JsonProof and REST response should decode to the same proof.
+	bare := unwrapJSON(bz, "jsonProof")
+	p, err := ParseProof(bare)
+	require.NoError(t, err)
+	require.Equal(t, expected, p)
+	p, err = ParseProof([]byte(`{"height":"3021519","result":` + string(bz) + `}`))
+	require.NoError(t, err)
+	require.Equal(t, expected, p)
+	_, err = ParseProof([]byte(`{"jsonProof":{"blockHeight":3}}`))
+	require.True(t, errors.Is(err, ErrInvalidProof))
+}
+
+func TestVerifyProofSuccess(t *testing.T) {
+	bz, err := ioutil.ReadFile("testdata/proof.json")
+	require.NoError(t, err)
+	p, err := VerifyProofJSON(bz, mustGuanyuValidatorSet(t))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3021518), p.BlockHeight)
+	require.Equal(t, uint64(1), uint64(p.OracleDataProof.ResponsePacket.RequestID))
+	// 7 of 10 equal-power validators is still more than two-thirds.
+	p = mustLoadProof(t)
+	require.NoError(t, VerifyProof(p, mustGuanyuValidatorSet(t,
+		Validator{Address: common.HexToAddress("0x01"), Power: 100},
+		Validator{Address: common.HexToAddress("0x02"), Power: 100},
+		Validator{Address: common.HexToAddress("0x03"), Power: 100},
+	)))
+}
+
+func TestVerifyProofInsufficientVotingPower(t *testing.T) {
+	p := mustLoadProof(t)
+	err := VerifyProof(p, mustGuanyuValidatorSet(t, Validator{Address: common.HexToAddress("0x01"), Power: 350}))
+	require.True(t, errors.Is(err, ErrInsufficientVotingPower))
+	p.BlockRelayProof.Signatures = p.BlockRelayProof.Signatures[:4]
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInsufficientVotingPower))
+}
+
+func TestVerifyProofBlockHeightMismatch(t *testing.T) {
+	p := mustLoadProof(t)
+	p.BlockHeight++
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrBlockHeightMismatch))
+}
+
+func TestVerifyProofInvalidSignature(t *testing.T) {
+	p := mustLoadProof(t)
+	p.BlockRelayProof.Signatures[0].V = 30
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidSignature))
+}
+
+func TestVerifyProofUnorderedSigners(t *testing.T) {
+	p := mustLoadProof(t)
+	sigs := p.BlockRelayProof.Signatures
+	sigs[0], sigs[1] = sigs[1], sigs[0]
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrUnorderedSigners))
+	p = mustLoadProof(t)
+	p.BlockRelayProof.Signatures = append(p.BlockRelayProof.Signatures, p.BlockRelayProof.Signatures[6])
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrUnorderedSigners))
+}
+
+func TestVerifyProofTamperedBlock(t *testing.T) {
+	// Any change to the block data changes the block hash, so signatures recover to unknown signers.
+	p := mustLoadProof(t)
+	p.BlockRelayProof.MultiStoreProof.ParamsStoresMerkleHash[0] ^= 1
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrOracleStateMismatch))
+	p = mustLoadProof(t)
+	p.BlockRelayProof.BlockHeaderMerkleParts.TimeSecond++
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.Error(t, err)
+	p = mustLoadProof(t)
+	p.BlockRelayProof.MultiStoreProof.OracleIAVLStateHash = p.BlockRelayProof.MultiStoreProof.OracleIAVLStateHash[:31]
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidProof))
+}
+
+func TestVerifyProofOracleStateMismatch(t *testing.T) {
+	p := mustLoadProof(t)
+	p.OracleDataProof.ResponsePacket.Result = []byte("tampered")
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+	p = mustLoadProof(t)
+	p.OracleDataProof.Version++
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+	p = mustLoadProof(t)
+	p.OracleDataProof.MerklePaths[1].IsDataOnRight = !p.OracleDataProof.MerklePaths[1].IsDataOnRight
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+}
+
+func TestVerifyProofInvalidMerklePath(t *testing.T) {
+	p := mustLoadProof(t)
+	paths := p.OracleDataProof.MerklePaths
+	paths[0], paths[1] = paths[1], paths[0]
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidMerklePath))
+	p = mustLoadProof(t)
+	p.OracleDataProof.MerklePaths[2].SiblingHash = nil
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidMerklePath))
+}
+
+func TestVerifyProofInvalidVersion(t *testing.T) {
+	// The proven state is the one committed at the block before the relayed block.
+	p := mustLoadProof(t)
+	p.OracleDataProof.Version = p.BlockHeight
+	err := VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	p = mustLoadProof(t)
+	p.OracleDataProof.Version = 0
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	p = mustLoadProof(t)
+	paths := p.OracleDataProof.MerklePaths
+	paths[len(paths)-1].SubtreeVersion = p.BlockHeight
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	// Inner nodes cannot be older than their children.
+	p = mustLoadProof(t)
+	p.OracleDataProof.MerklePaths[0].SubtreeVersion = p.OracleDataProof.Version - 1
+	err = VerifyProof(p, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+}
+
+func TestVerifyMultiProof(t *testing.T) {
+	p := mustLoadProof(t)
+	multi := proof.JsonMultiProof{
+		BlockHeight:          p.BlockHeight,
+		OracleDataMultiProof: []proof.OracleDataProof{p.OracleDataProof, p.OracleDataProof},
+		BlockRelayProof:      p.BlockRelayProof,
+	}
+	require.NoError(t, VerifyMultiProof(multi, mustGuanyuValidatorSet(t)))
+	bad := mustLoadProof(t).OracleDataProof
+	bad.ResponsePacket.AnsCount = 6
+	multi.OracleDataMultiProof = append(multi.OracleDataMultiProof, bad)
+	err := VerifyMultiProof(multi, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+}
+
+func TestVerifyBatchProof(t *testing.T) {
+	p := mustLoadProof(t)
+	batch, err := proof.NewOracleDataBatchProof([]proof.OracleDataProof{p.OracleDataProof})
+	require.NoError(t, err)
+	bp := proof.JsonBatchProof{
+		BlockHeight:          p.BlockHeight,
+		OracleDataBatchProof: batch,
+		BlockRelayProof:      p.BlockRelayProof,
+	}
+	require.NoError(t, VerifyBatchProof(bp, mustGuanyuValidatorSet(t)))
+	bz, err := codec.New().MarshalJSON(proof.BatchProof{JsonProof: bp})
+	require.NoError(t, err)
+	parsed, err := ParseBatchProof(bz)
+	require.NoError(t, err)
+	require.NoError(t, VerifyBatchProof(parsed, mustGuanyuValidatorSet(t)))
+
+	bp.OracleDataBatchProof.Leaves[0].Version = bp.BlockHeight
+	err = VerifyBatchProof(bp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	bp.OracleDataBatchProof.Leaves[0].Version = p.OracleDataProof.Version
+	subtreeVersion := bp.OracleDataBatchProof.InnerNodes[0].SubtreeVersion
+	bp.OracleDataBatchProof.InnerNodes[0].SubtreeVersion = bp.BlockHeight + 1
+	err = VerifyBatchProof(bp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	bp.OracleDataBatchProof.InnerNodes[0].SubtreeVersion = subtreeVersion
+
+	bp.OracleDataBatchProof.Leaves[0].ResponsePacket.AnsCount = 6
+	err = VerifyBatchProof(bp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+	bp.OracleDataBatchProof.InnerNodes[0].LeftHash = nil
+	bp.OracleDataBatchProof.InnerNodes[0].RightHash = nil
+	err = VerifyBatchProof(bp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidMerklePath))
+}
+
+func TestVerifyStoreProof(t *testing.T) {
+	p := mustLoadProof(t)
+	data := p.OracleDataProof
+	value := obi.MustEncode(types.NewResult(data.RequestPacket, data.ResponsePacket))
+	key := types.ResultStoreKey(data.ResponsePacket.RequestID)
+	sp := proof.JsonStoreProof{
+		BlockHeight: p.BlockHeight,
+		StoreProof: proof.StoreProof{
+			Key:   key,
+			Value: value,
+			Existence: &proof.IAVLLeafProof{
+				Key:         key,
+				ValueHash:   tmhash.Sum(value),
+				Version:     data.Version,
+				MerklePaths: data.MerklePaths,
+			},
+		},
+		BlockRelayProof: p.BlockRelayProof,
+	}
+	require.NoError(t, VerifyStoreProof(sp, mustGuanyuValidatorSet(t)))
+	bz, err := codec.New().MarshalJSON(sp)
+	require.NoError(t, err)
+	parsed, err := ParseStoreProof([]byte(`{"height":"3021519","result":` + string(bz) + `}`))
+	require.NoError(t, err)
+	require.NoError(t, VerifyStoreProof(parsed, mustGuanyuValidatorSet(t)))
+
+	sp.StoreProof.Existence.Version++
+	err = VerifyStoreProof(sp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrOracleStateMismatch))
+	sp.StoreProof.Existence.Version = sp.BlockHeight
+	err = VerifyStoreProof(sp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidVersion))
+	sp.StoreProof.Existence.Version = data.Version
+	sp.StoreProof.Value = []byte("tampered")
+	err = VerifyStoreProof(sp, mustGuanyuValidatorSet(t))
+	require.True(t, errors.Is(err, ErrInvalidStoreProof))
+}
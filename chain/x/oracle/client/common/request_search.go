@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -136,3 +137,38 @@ func QueryMultiSearchLatestRequest(
 	bz, err := types.QueryOK(queryRequestResults)
 	return bz, h, err
 }
+
+// RequestSearchParams mirrors the search parameters accepted by the request search hook.
+type RequestSearchParams struct {
+	Requester     string               `json:"requester,omitempty"`
+	ClientID      string               `json:"client_id,omitempty"`
+	ResolveStatus *types.ResolveStatus `json:"resolve_status,omitempty"`
+	FromTime      int64                `json:"from_time,omitempty"`
+	ToTime        int64                `json:"to_time,omitempty"`
+	Offset        int64                `json:"offset"`
+	Limit         int64                `json:"limit"`
+}
+
+// QuerySearchRequests searches indexed requests matching the given parameters.
+func QuerySearchRequests(cliCtx context.CLIContext, params RequestSearchParams) ([]byte, int64, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, 0, err
+	}
+	bz, height, err := cliCtx.QueryWithData("band/search_requests", data)
+	if err != nil {
+		return nil, 0, err
+	}
+	bz, err = json.MarshalIndent(types.QueryResult{Status: http.StatusOK, Result: bz}, "", "  ")
+	return bz, height, err
+}
+
+// QueryRequestReports returns the indexed reports of the given request.
+func QueryRequestReports(cliCtx context.CLIContext, rid string) ([]byte, int64, error) {
+	bz, height, err := cliCtx.Query(fmt.Sprintf("band/request_reports/%s", rid))
+	if err != nil {
+		return nil, 0, err
+	}
+	bz, err = json.MarshalIndent(types.QueryResult{Status: http.StatusOK, Result: bz}, "", "  ")
+	return bz, height, err
+}
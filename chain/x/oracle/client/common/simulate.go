@@ -0,0 +1,209 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	owasm "github.com/bandprotocol/go-owasm/api"
+)
+
+// SimulateRawReport is a raw report in a simulation reports file. Data is given as the text a
+// data source prints to stdout.
+type SimulateRawReport struct {
+	ExternalID types.ExternalID `json:"external_id"`
+	ExitCode   uint32           `json:"exit_code"`
+	Data       string           `json:"data"`
+}
+
+// SimulateReport is the report of one simulated validator, identified by its index in the list
+// of requested validators. Power is the voting power of the validator, 1 if not given.
+type SimulateReport struct {
+	Validator  int64               `json:"validator"`
+	Power      *int64              `json:"power,omitempty"`
+	RawReports []SimulateRawReport `json:"raw_reports"`
+}
+
+// SimulateRawRequest is a raw request asked for by an oracle script during prepare.
+type SimulateRawRequest struct {
+	ExternalID   types.ExternalID   `json:"external_id"`
+	DataSourceID types.DataSourceID `json:"data_source_id"`
+	Calldata     string             `json:"calldata"`
+}
+
+// SimulatePrepareResult is the outcome of running the prepare function of an oracle script.
+type SimulatePrepareResult struct {
+	GasUsed     uint32               `json:"gas_used"`
+	RawRequests []SimulateRawRequest `json:"raw_requests"`
+}
+
+// SimulateExecuteResult is the outcome of running the execute function of an oracle script.
+// DecodedResult is only set if the output type of the oracle script is known.
+type SimulateExecuteResult struct {
+	GasUsed       uint32           `json:"gas_used"`
+	Result        tmbytes.HexBytes `json:"result"`
+	DecodedResult json.RawMessage  `json:"decoded_result,omitempty"`
+}
+
+// SimulateResult is the outcome of simulating a request. Execute is nil if execution was not
+// simulated.
+type SimulateResult struct {
+	Prepare SimulatePrepareResult  `json:"prepare"`
+	Execute *SimulateExecuteResult `json:"execute,omitempty"`
+}
+
+// Simulator runs the prepare and execute functions of an oracle script outside of the chain,
+// the same way PrepareRequest and ResolveRequest of the oracle keeper do.
+type Simulator struct {
+	vm             *owasm.Vm
+	code           []byte
+	maxRawRequests int64
+}
+
+// NewSimulator compiles the given Wasm code and returns a simulator of it. Raw requests beyond
+// maxRawRequests fail the prepare function.
+func NewSimulator(vm *owasm.Vm, wasm []byte, maxRawRequests int64) (Simulator, error) {
+	code, err := vm.Compile(wasm, types.MaxCompiledWasmCodeSize)
+	if err != nil {
+		return Simulator{}, sdkerrors.Wrapf(types.ErrOwasmCompilation, "with error: %s", err.Error())
+	}
+	return Simulator{vm: vm, code: code, maxRawRequests: maxRawRequests}, nil
+}
+
+// simulatedRequestID is the request ID given to oracle scripts in a simulation.
+const simulatedRequestID = types.RequestID(1)
+
+// simulatedRandomValue is the random value given to oracle scripts in a simulation.
+var simulatedRandomValue = tmhash.Sum([]byte("simulated-random-value"))
+
+// getSimulatedValidators returns the addresses of the given number of simulated validators.
+func getSimulatedValidators(askCount uint64) []sdk.ValAddress {
+	vals := make([]sdk.ValAddress, askCount)
+	for idx := range vals {
+		vals[idx] = tmhash.SumTruncated([]byte(fmt.Sprintf("simulated-validator-%d", idx)))
+	}
+	return vals
+}
+
+// NewSimulatedRequest returns a request of the given calldata asked to the given number of
+// simulated validators.
+func NewSimulatedRequest(calldata []byte, askCount, minCount uint64) (types.Request, error) {
+	if len(calldata) > types.MaxDataSize {
+		return types.Request{}, types.WrapMaxError(types.ErrTooLargeCalldata, len(calldata), types.MaxDataSize)
+	}
+	if minCount <= 0 {
+		return types.Request{}, sdkerrors.Wrapf(types.ErrInvalidMinCount, "got: %d", minCount)
+	}
+	if askCount < minCount {
+		return types.Request{}, sdkerrors.Wrapf(types.ErrInvalidAskCount, "got: %d, min count: %d", askCount, minCount)
+	}
+	return types.NewRequest(0, calldata, getSimulatedValidators(askCount), minCount, 0, time.Now(), "", nil), nil
+}
+
+// Prepare runs the prepare function of the oracle script and returns the request with the raw
+// requests it asks for.
+func (s Simulator) Prepare(req types.Request) (types.Request, SimulatePrepareResult, error) {
+	env := types.NewPrepareEnv(simulatedRequestID, req, s.maxRawRequests, simulatedRandomValue)
+	output, err := s.vm.Prepare(s.code, types.WasmPrepareGas, types.MaxDataSize, env)
+	if err != nil {
+		return types.Request{}, SimulatePrepareResult{}, sdkerrors.Wrapf(types.ErrBadWasmExecution, err.Error())
+	}
+	req.RawRequests = env.GetRawRequests()
+	if len(req.RawRequests) == 0 {
+		return types.Request{}, SimulatePrepareResult{}, types.ErrEmptyRawRequests
+	}
+	result := SimulatePrepareResult{GasUsed: output.GasUsed}
+	for _, raw := range req.RawRequests {
+		result.RawRequests = append(result.RawRequests, SimulateRawRequest{
+			ExternalID:   raw.ExternalID,
+			DataSourceID: raw.DataSourceID,
+			Calldata:     string(raw.Calldata),
+		})
+	}
+	return req, result, nil
+}
+
+// getReports converts the simulated reports to reports of the given prepared request and the
+// voting powers of the requested validators in validator index order, applying the same checks
+// as the oracle keeper does on report submission.
+func getReports(req types.Request, simReports []SimulateReport) ([]types.Report, []int64, error) {
+	reports := make([]types.Report, 0, len(simReports))
+	powers := make([]int64, len(req.RequestedValidators))
+	reported := make(map[int64]bool)
+	for _, simReport := range simReports {
+		if simReport.Validator < 0 || simReport.Validator >= int64(len(req.RequestedValidators)) {
+			return nil, nil, sdkerrors.Wrapf(types.ErrValidatorNotRequested, "validator index: %d", simReport.Validator)
+		}
+		if reported[simReport.Validator] {
+			return nil, nil, sdkerrors.Wrapf(types.ErrValidatorAlreadyReported, "validator index: %d", simReport.Validator)
+		}
+		reported[simReport.Validator] = true
+		if simReport.Power != nil && *simReport.Power < 0 {
+			return nil, nil, fmt.Errorf("validator index: %d, negative power: %d", simReport.Validator, *simReport.Power)
+		}
+		if len(simReport.RawReports) != len(req.RawRequests) {
+			return nil, nil, sdkerrors.Wrapf(types.ErrInvalidReportSize, "validator index: %d", simReport.Validator)
+		}
+		rawReports := make([]types.RawReport, 0, len(simReport.RawReports))
+		seen := make(map[types.ExternalID]bool)
+		for _, raw := range simReport.RawReports {
+			if seen[raw.ExternalID] {
+				return nil, nil, sdkerrors.Wrapf(types.ErrDuplicateExternalID, "validator index: %d, extID: %d", simReport.Validator, raw.ExternalID)
+			}
+			seen[raw.ExternalID] = true
+			found := false
+			for _, rawReq := range req.RawRequests {
+				if rawReq.ExternalID == raw.ExternalID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, nil, sdkerrors.Wrapf(types.ErrRawRequestNotFound, "validator index: %d, extID: %d", simReport.Validator, raw.ExternalID)
+			}
+			rawReports = append(rawReports, types.NewRawReport(raw.ExternalID, raw.ExitCode, []byte(raw.Data)))
+		}
+		val := req.RequestedValidators[simReport.Validator]
+		reports = append(reports, types.NewReport(val, true, rawReports))
+		powers[simReport.Validator] = 1
+		if simReport.Power != nil {
+			powers[simReport.Validator] = *simReport.Power
+		}
+	}
+	if uint64(len(reports)) < req.MinCount {
+		return nil, nil, fmt.Errorf("got %d reports, fewer than min count %d", len(reports), req.MinCount)
+	}
+	return reports, powers, nil
+}
+
+// Execute runs the execute function of the oracle script on the given prepared request and
+// simulated reports. If output is not nil, the result is also decoded as JSON using it.
+func (s Simulator) Execute(req types.Request, simReports []SimulateReport, output *obi.Type) (SimulateExecuteResult, error) {
+	reports, powers, err := getReports(req, simReports)
+	if err != nil {
+		return SimulateExecuteResult{}, err
+	}
+	env := types.NewExecuteEnv(simulatedRequestID, req, reports, powers, simulatedRandomValue)
+	out, err := s.vm.Execute(s.code, types.WasmExecuteGas, types.MaxDataSize, env)
+	if err != nil {
+		return SimulateExecuteResult{}, sdkerrors.Wrapf(types.ErrBadWasmExecution, err.Error())
+	}
+	if env.Retdata == nil {
+		return SimulateExecuteResult{}, sdkerrors.Wrapf(types.ErrBadWasmExecution, "no return data")
+	}
+	result := SimulateExecuteResult{GasUsed: out.GasUsed, Result: env.Retdata}
+	if output != nil {
+		result.DecodedResult, err = obi.DecodeJSON(env.Retdata, *output)
+		if err != nil {
+			return SimulateExecuteResult{}, sdkerrors.Wrapf(types.ErrOBIDecode, "schema %s: %s", *output, err.Error())
+		}
+	}
+	return result, nil
+}
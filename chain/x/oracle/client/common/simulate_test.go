@@ -0,0 +1,103 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	clientcmn "github.com/bandprotocol/bandchain/chain/x/oracle/client/common"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func newWasm4Simulator(t *testing.T) clientcmn.Simulator {
+	simulator, err := clientcmn.NewSimulator(testapp.OwasmVM, testapp.Wasm4, int64(types.DefaultMaxRawRequestCount))
+	require.NoError(t, err)
+	return simulator
+}
+
+func TestSimulate(t *testing.T) {
+	simulator := newWasm4Simulator(t)
+	calldata := obi.MustEncode(testapp.Wasm4Input{IDs: []int64{1, 2}, Calldata: "beeb"})
+	req, err := clientcmn.NewSimulatedRequest(calldata, 3, 2)
+	require.NoError(t, err)
+	require.Len(t, req.RequestedValidators, 3)
+
+	req, prepare, err := simulator.Prepare(req)
+	require.NoError(t, err)
+	require.Equal(t, []clientcmn.SimulateRawRequest{
+		{ExternalID: 0, DataSourceID: 1, Calldata: "beeb"},
+		{ExternalID: 1, DataSourceID: 2, Calldata: "beeb"},
+	}, prepare.RawRequests)
+	require.NotZero(t, prepare.GasUsed)
+
+	output := obi.MustParseSchema("{ret:string}")
+	execute, err := simulator.Execute(req, []clientcmn.SimulateReport{
+		{Validator: 2, RawReports: []clientcmn.SimulateRawReport{{ExternalID: 1, Data: "d"}, {ExternalID: 0, Data: "c"}}},
+		{Validator: 0, RawReports: []clientcmn.SimulateRawReport{{ExternalID: 0, Data: "a"}, {ExternalID: 1, Data: "b"}}},
+	}, &output)
+	require.NoError(t, err)
+	require.Equal(t, obi.MustEncode(testapp.Wasm4Output{Ret: "acbd"}), []byte(execute.Result))
+	require.JSONEq(t, `{"ret":"acbd"}`, string(execute.DecodedResult))
+	require.NotZero(t, execute.GasUsed)
+
+	execute, err = simulator.Execute(req, []clientcmn.SimulateReport{
+		{Validator: 1, RawReports: []clientcmn.SimulateRawReport{{ExternalID: 0, Data: "a"}, {ExternalID: 1, Data: "b"}}},
+		{Validator: 2, RawReports: []clientcmn.SimulateRawReport{{ExternalID: 0, Data: "c"}, {ExternalID: 1, Data: "d"}}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, obi.MustEncode(testapp.Wasm4Output{Ret: "acbd"}), []byte(execute.Result))
+	require.Nil(t, execute.DecodedResult)
+}
+
+func TestSimulateBadRequest(t *testing.T) {
+	_, err := clientcmn.NewSimulatedRequest([]byte("beeb"), 3, 0)
+	require.Error(t, err)
+	_, err = clientcmn.NewSimulatedRequest([]byte("beeb"), 1, 2)
+	require.Error(t, err)
+
+	simulator := newWasm4Simulator(t)
+	req, err := clientcmn.NewSimulatedRequest(obi.MustEncode(testapp.Wasm4Input{IDs: []int64{}, Calldata: "beeb"}), 3, 2)
+	require.NoError(t, err)
+	_, _, err = simulator.Prepare(req)
+	require.Error(t, err)
+
+	_, err = clientcmn.NewSimulator(testapp.OwasmVM, []byte("beeb"), int64(types.DefaultMaxRawRequestCount))
+	require.Error(t, err)
+}
+
+func TestSimulateBadReports(t *testing.T) {
+	simulator := newWasm4Simulator(t)
+	req, err := clientcmn.NewSimulatedRequest(obi.MustEncode(testapp.Wasm4Input{IDs: []int64{1, 2}, Calldata: "beeb"}), 3, 2)
+	require.NoError(t, err)
+	req, _, err = simulator.Prepare(req)
+	require.NoError(t, err)
+
+	report := func(val int64, eids ...types.ExternalID) clientcmn.SimulateReport {
+		rep := clientcmn.SimulateReport{Validator: val}
+		for _, eid := range eids {
+			rep.RawReports = append(rep.RawReports, clientcmn.SimulateRawReport{ExternalID: eid, Data: "data"})
+		}
+		return rep
+	}
+	negative := report(1, 0, 1)
+	negative.Power = new(int64)
+	*negative.Power = -1
+	testCases := []struct {
+		name    string
+		reports []clientcmn.SimulateReport
+	}{
+		{"validator out of range", []clientcmn.SimulateReport{report(0, 0, 1), report(3, 0, 1)}},
+		{"duplicate validator", []clientcmn.SimulateReport{report(0, 0, 1), report(0, 0, 1)}},
+		{"missing raw report", []clientcmn.SimulateReport{report(0, 0, 1), report(1, 0)}},
+		{"duplicate external id", []clientcmn.SimulateReport{report(0, 0, 1), report(1, 0, 0)}},
+		{"unknown external id", []clientcmn.SimulateReport{report(0, 0, 1), report(1, 0, 2)}},
+		{"fewer than min count", []clientcmn.SimulateReport{report(0, 0, 1)}},
+		{"negative power", []clientcmn.SimulateReport{report(0, 0, 1), negative}},
+	}
+	for _, tc := range testCases {
+		_, err := simulator.Execute(req, tc.reports, nil)
+		require.Error(t, err, tc.name)
+	}
+}
@@ -114,6 +114,13 @@ func getRequestByIDHandler(cliCtx context.CLIContext, route string) http.Handler
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		if r.FormValue("decode") == "true" {
+			bz, err = clientcmn.DecodeRequestResponse(route, cliCtx, bz)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
 		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
 	}
 }
@@ -132,6 +139,13 @@ func getRequestSearchHandler(cliCtx context.CLIContext, route string) http.Handl
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		if r.FormValue("decode") == "true" {
+			bz, err = clientcmn.DecodeRequestResponse(route, cliCtx, bz)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
 		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
 	}
 }
@@ -198,6 +212,150 @@ func getRequestsPriceSymbolsHandler(cliCtx context.CLIContext, route string) htt
 	}
 }
 
+func getSearchRequestsHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		params := clientcmn.RequestSearchParams{
+			Requester: r.FormValue("requester"),
+			ClientID:  r.FormValue("client_id"),
+			Limit:     10,
+		}
+		for name, dst := range map[string]*int64{
+			"from_time": &params.FromTime, "to_time": &params.ToTime, "offset": &params.Offset, "limit": &params.Limit,
+		} {
+			if raw := r.FormValue(name); raw != "" {
+				val, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				*dst = val
+			}
+		}
+		if raw := r.FormValue("resolve_status"); raw != "" {
+			val, err := strconv.ParseInt(raw, 10, 32)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			status := types.ResolveStatus(val)
+			params.ResolveStatus = &status
+		}
+		bz, height, err := clientcmn.QuerySearchRequests(cliCtx, params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
+func getRequestReportsHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		vars := mux.Vars(r)
+		if _, err := strconv.ParseInt(vars[idTag], 10, 64); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid request id: %s", vars[idTag]))
+			return
+		}
+		bz, height, err := clientcmn.QueryRequestReports(cliCtx, vars[idTag])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
+func getPriceAtHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		bz, height, err := cliCtx.Query(fmt.Sprintf("band/price_at/%s/%s/%s/%s",
+			r.FormValue("symbol"), r.FormValue("ask_count"), r.FormValue("min_count"), r.FormValue("timestamp"),
+		))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var px price.Price
+		if err := cliCtx.Codec.UnmarshalBinaryBare(bz, &px); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		bz, err = types.QueryOK(px)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
+func getPriceOHLCHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		bz, height, err := cliCtx.Query(fmt.Sprintf("band/price_ohlc/%s/%s/%s/%s/%s/%s",
+			r.FormValue("symbol"), r.FormValue("ask_count"), r.FormValue("min_count"),
+			r.FormValue("from"), r.FormValue("to"), r.FormValue("interval"),
+		))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var buckets []price.OHLC
+		if err := cliCtx.Codec.UnmarshalBinaryBare(bz, &buckets); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		bz, err = types.QueryOK(buckets)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
+func getPriceTWAPHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		bz, height, err := cliCtx.Query(fmt.Sprintf("band/price_twap/%s/%s/%s/%s/%s",
+			r.FormValue("symbol"), r.FormValue("ask_count"), r.FormValue("min_count"),
+			r.FormValue("from"), r.FormValue("to"),
+		))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var twap price.TWAP
+		if err := cliCtx.Codec.UnmarshalBinaryBare(bz, &twap); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		bz, err = types.QueryOK(twap)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
 func getMultiRequestSearchHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
@@ -271,6 +429,25 @@ func getActiveValidatorsHandler(cliCtx context.CLIContext, route string) http.Ha
 	}
 }
 
+func getRandomnessHandler(cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		path := fmt.Sprintf("custom/%s/%s", route, types.QueryRandomness)
+		if height, ok := mux.Vars(r)[heightTag]; ok {
+			path += "/" + height
+		}
+		bz, height, err := cliCtx.Query(path)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		clientcmn.PostProcessQueryResponse(w, cliCtx.WithHeight(height), bz)
+	}
+}
+
 type requestDetail struct {
 	ChainID    string           `json:"chain_id"`
 	Validator  sdk.ValAddress   `json:"validator"`
@@ -12,19 +12,21 @@ import (
 
 // GenesisState is the oracle state that must be provided at genesis.
 type GenesisState struct {
-	Params        types.Params                  `json:"params" yaml:"params"`
-	DataSources   []types.DataSource            `json:"data_sources"  yaml:"data_sources"`
-	OracleScripts []types.OracleScript          `json:"oracle_scripts"  yaml:"oracle_scripts"`
-	Reporters     []types.ReportersPerValidator `json:"reporters" yaml:"reporters"`
+	Params             types.Params                  `json:"params" yaml:"params"`
+	DataSources        []types.DataSource            `json:"data_sources"  yaml:"data_sources"`
+	OracleScripts      []types.OracleScript          `json:"oracle_scripts"  yaml:"oracle_scripts"`
+	Reporters          []types.ReportersPerValidator `json:"reporters" yaml:"reporters"`
+	ReportSuccessRates []types.ReportSuccessRate     `json:"report_success_rates" yaml:"report_success_rates"`
 }
 
 // DefaultGenesisState returns the default oracle genesis state.
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Params:        types.DefaultParams(),
-		DataSources:   []types.DataSource{},
-		OracleScripts: []types.OracleScript{},
-		Reporters:     []types.ReportersPerValidator{},
+		Params:             types.DefaultParams(),
+		DataSources:        []types.DataSource{},
+		OracleScripts:      []types.OracleScript{},
+		Reporters:          []types.ReportersPerValidator{},
+		ReportSuccessRates: []types.ReportSuccessRate{},
 	}
 }
 
@@ -38,6 +40,8 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorU
 	k.SetParam(ctx, types.KeySamplingTryCount, data.Params.SamplingTryCount)
 	k.SetParam(ctx, types.KeyOracleRewardPercentage, data.Params.OracleRewardPercentage)
 	k.SetParam(ctx, types.KeyInactivePenaltyDuration, data.Params.InactivePenaltyDuration)
+	k.SetParam(ctx, types.KeySamplingMethod, data.Params.SamplingMethod)
+	k.SetParam(ctx, types.KeyRandomnessFallback, data.Params.RandomnessFallback)
 	k.SetDataSourceCount(ctx, 0)
 	k.SetOracleScriptCount(ctx, 0)
 	k.SetRequestCount(ctx, 0)
@@ -54,6 +58,9 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorU
 			k.AddReporter(ctx, reportersPerValidator.Validator, reporter)
 		}
 	}
+	for _, rate := range data.ReportSuccessRates {
+		k.SetReportSuccessRate(ctx, rate.Validator, rate.Rate)
+	}
 
 	return []abci.ValidatorUpdate{}
 }
@@ -61,10 +68,11 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorU
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	return GenesisState{
-		Params:        k.GetParams(ctx),
-		DataSources:   k.GetAllDataSources(ctx),
-		OracleScripts: k.GetAllOracleScripts(ctx),
-		Reporters:     k.GetAllReporters(ctx),
+		Params:             k.GetParams(ctx),
+		DataSources:        k.GetAllDataSources(ctx),
+		OracleScripts:      k.GetAllOracleScripts(ctx),
+		Reporters:          k.GetAllReporters(ctx),
+		ReportSuccessRates: k.GetAllReportSuccessRates(ctx),
 	}
 }
 
@@ -0,0 +1,29 @@
+package oracle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+func TestExportImportReportSuccessRates(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetReportSuccessRate(ctx, testapp.Validator1.ValAddress, 950000)
+	k.SetReportSuccessRate(ctx, testapp.Validator3.ValAddress, 0)
+	genesis := oracle.ExportGenesis(ctx, k)
+	require.ElementsMatch(t, []types.ReportSuccessRate{
+		types.NewReportSuccessRate(testapp.Validator1.ValAddress, 950000),
+		types.NewReportSuccessRate(testapp.Validator3.ValAddress, 0),
+	}, genesis.ReportSuccessRates)
+	// Importing the exported state into a fresh chain restores the same rates.
+	_, ctx, k = testapp.CreateTestInput(true)
+	oracle.InitGenesis(ctx, k, genesis)
+	require.Equal(t, uint64(950000), k.GetReportSuccessRate(ctx, testapp.Validator1.ValAddress))
+	require.Equal(t, types.ReportSuccessRatePrecision, k.GetReportSuccessRate(ctx, testapp.Validator2.ValAddress))
+	require.Equal(t, uint64(0), k.GetReportSuccessRate(ctx, testapp.Validator3.ValAddress))
+	require.Equal(t, genesis.ReportSuccessRates, oracle.ExportGenesis(ctx, k).ReportSuccessRates)
+}
@@ -32,6 +32,10 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgAddReporter(ctx, k, msg)
 		case MsgRemoveReporter:
 			return handleMsgRemoveReporter(ctx, k, msg)
+		case MsgSetVRFKey:
+			return handleMsgSetVRFKey(ctx, k, msg)
+		case MsgSubmitVRF:
+			return handleMsgSubmitVRF(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -39,9 +43,9 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgCreateDataSource(ctx sdk.Context, k Keeper, m MsgCreateDataSource) (*sdk.Result, error) {
-	if gzip.IsGzipped(m.Executable) {
+	if gzip.IsCompressed(m.Executable) {
 		var err error
-		m.Executable, err = gzip.Uncompress(m.Executable, types.MaxExecutableSize)
+		m.Executable, err = gzip.Uncompress(m.Executable, types.MaxExecutableSize, types.MaxExecutableSize)
 		if err != nil {
 			return nil, sdkerrors.Wrapf(types.ErrUncompressionFailed, err.Error())
 		}
@@ -64,8 +68,8 @@ func handleMsgEditDataSource(ctx sdk.Context, k Keeper, m MsgEditDataSource) (*s
 	if !dataSource.Owner.Equals(m.Sender) {
 		return nil, types.ErrEditorNotAuthorized
 	}
-	if gzip.IsGzipped(m.Executable) {
-		m.Executable, err = gzip.Uncompress(m.Executable, types.MaxExecutableSize)
+	if gzip.IsCompressed(m.Executable) {
+		m.Executable, err = gzip.Uncompress(m.Executable, types.MaxExecutableSize, types.MaxExecutableSize)
 		if err != nil {
 			return nil, sdkerrors.Wrapf(types.ErrUncompressionFailed, err.Error())
 		}
@@ -82,9 +86,9 @@ func handleMsgEditDataSource(ctx sdk.Context, k Keeper, m MsgEditDataSource) (*s
 }
 
 func handleMsgCreateOracleScript(ctx sdk.Context, k Keeper, m MsgCreateOracleScript) (*sdk.Result, error) {
-	if gzip.IsGzipped(m.Code) {
+	if gzip.IsCompressed(m.Code) {
 		var err error
-		m.Code, err = gzip.Uncompress(m.Code, types.MaxWasmCodeSize)
+		m.Code, err = gzip.Uncompress(m.Code, types.MaxWasmCodeSize, types.MaxWasmCodeSize)
 		if err != nil {
 			return nil, sdkerrors.Wrapf(types.ErrUncompressionFailed, err.Error())
 		}
@@ -94,7 +98,7 @@ func handleMsgCreateOracleScript(ctx sdk.Context, k Keeper, m MsgCreateOracleScr
 		return nil, err
 	}
 	id := k.AddOracleScript(ctx, types.NewOracleScript(
-		m.Owner, m.Name, m.Description, filename, m.Schema, m.SourceCodeURL,
+		m.Owner, m.Name, m.Description, filename, m.Schema, m.SourceCodeURL, m.StrictSchema,
 	))
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeCreateOracleScript,
@@ -111,8 +115,19 @@ func handleMsgEditOracleScript(ctx sdk.Context, k Keeper, m MsgEditOracleScript)
 	if !oracleScript.Owner.Equals(m.Sender) {
 		return nil, types.ErrEditorNotAuthorized
 	}
-	if gzip.IsGzipped(m.Code) {
-		m.Code, err = gzip.Uncompress(m.Code, types.MaxWasmCodeSize)
+	// The schema and strictness may be kept as is, so strictness can only be checked against the
+	// final schema. Non-strict oracle scripts may keep legacy schemas that do not parse.
+	if m.StrictSchema.Apply(oracleScript.StrictSchema) {
+		schema := m.Schema
+		if schema == types.DoNotModify {
+			schema = oracleScript.Schema
+		}
+		if err := types.ValidateSchema(schema, true); err != nil {
+			return nil, err
+		}
+	}
+	if gzip.IsCompressed(m.Code) {
+		m.Code, err = gzip.Uncompress(m.Code, types.MaxWasmCodeSize, types.MaxWasmCodeSize)
 		if err != nil {
 			return nil, sdkerrors.Wrapf(types.ErrUncompressionFailed, err.Error())
 		}
@@ -122,8 +137,8 @@ func handleMsgEditOracleScript(ctx sdk.Context, k Keeper, m MsgEditOracleScript)
 		return nil, err
 	}
 	k.MustEditOracleScript(ctx, m.OracleScriptID, types.NewOracleScript(
-		m.Owner, m.Name, m.Description, filename, m.Schema, m.SourceCodeURL,
-	))
+		m.Owner, m.Name, m.Description, filename, m.Schema, m.SourceCodeURL, false,
+	), m.StrictSchema)
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeEditOracleScript,
 		sdk.NewAttribute(types.AttributeKeyID, fmt.Sprintf("%d", m.OracleScriptID)),
@@ -201,3 +216,28 @@ func handleMsgRemoveReporter(ctx sdk.Context, k Keeper, m MsgRemoveReporter) (*s
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgSetVRFKey(ctx sdk.Context, k Keeper, m MsgSetVRFKey) (*sdk.Result, error) {
+	k.SetVRFKey(ctx, m.Validator, m.PublicKey)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetVRFKey,
+		sdk.NewAttribute(types.AttributeKeyValidator, m.Validator.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSubmitVRF(ctx sdk.Context, k Keeper, m MsgSubmitVRF) (*sdk.Result, error) {
+	if !k.IsReporter(ctx, m.Validator, m.Reporter) {
+		return nil, types.ErrReporterNotAuthorized
+	}
+	err := k.AddVRFContribution(ctx, m.Validator, m.Height, m.Output, m.Proof)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSubmitVRF,
+		sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", m.Height)),
+		sdk.NewAttribute(types.AttributeKeyValidator, m.Validator.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -133,12 +133,12 @@ func TestCreateOracleScriptSuccess(t *testing.T) {
 	code := testapp.WasmExtra1
 	schema := "schema"
 	url := "url"
-	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, code, schema, url, testapp.Alice.Address)
+	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, code, schema, url, testapp.Alice.Address, false)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.NoError(t, err)
 	os, err := k.GetOracleScript(ctx, types.OracleScriptID(osCount+1))
 	require.NoError(t, err)
-	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, name, description, testapp.WasmExtra1FileName, schema, url), os)
+	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, name, description, testapp.WasmExtra1FileName, schema, url, false), os)
 	require.Equal(t, sdk.Events{sdk.NewEvent(
 		types.EventTypeCreateOracleScript,
 		sdk.NewAttribute(types.AttributeKeyID, fmt.Sprintf("%d", osCount+1)),
@@ -156,12 +156,12 @@ func TestCreateGzippedOracleScriptSuccess(t *testing.T) {
 	zw := gz.NewWriter(&buf)
 	zw.Write(testapp.WasmExtra1)
 	zw.Close()
-	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, buf.Bytes(), schema, url, testapp.Alice.Address)
+	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, buf.Bytes(), schema, url, testapp.Alice.Address, false)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.NoError(t, err)
 	os, err := k.GetOracleScript(ctx, types.OracleScriptID(osCount+1))
 	require.NoError(t, err)
-	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, name, description, testapp.WasmExtra1FileName, schema, url), os)
+	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, name, description, testapp.WasmExtra1FileName, schema, url, false), os)
 	require.Equal(t, sdk.Events{sdk.NewEvent(
 		types.EventTypeCreateOracleScript,
 		sdk.NewAttribute(types.AttributeKeyID, fmt.Sprintf("%d", osCount+1)),
@@ -175,7 +175,7 @@ func TestCreateOracleScriptFail(t *testing.T) {
 	schema := "schema"
 	url := "url"
 	// Bad Owasm code
-	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, []byte("BAD"), schema, url, testapp.Alice.Address)
+	msg := types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, []byte("BAD"), schema, url, testapp.Alice.Address, false)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "owasm compilation failed: with error: wasm code does not pass basic validation")
 	require.Nil(t, res)
@@ -184,7 +184,7 @@ func TestCreateOracleScriptFail(t *testing.T) {
 	zw := gz.NewWriter(&buf)
 	zw.Write(testapp.WasmExtra1)
 	zw.Close()
-	msg = types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, buf.Bytes()[:5], schema, url, testapp.Alice.Address)
+	msg = types.NewMsgCreateOracleScript(testapp.Owner.Address, name, description, buf.Bytes()[:5], schema, url, testapp.Alice.Address, false)
 	res, err = oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "uncompression failed: unexpected EOF")
 	require.Nil(t, res)
@@ -197,12 +197,12 @@ func TestEditOracleScriptSuccess(t *testing.T) {
 	newCode := testapp.WasmExtra2
 	newSchema := "new_schema"
 	newURL := "new_url"
-	msg := types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Owner.Address)
+	msg := types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Owner.Address, types.StrictSchemaEdit_Keep)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.NoError(t, err)
 	os, err := k.GetOracleScript(ctx, 1)
 	require.NoError(t, err)
-	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, newName, newDescription, testapp.WasmExtra2FileName, newSchema, newURL), os)
+	require.Equal(t, types.NewOracleScript(testapp.Owner.Address, newName, newDescription, testapp.WasmExtra2FileName, newSchema, newURL, false), os)
 	require.Equal(t, sdk.Events{sdk.NewEvent(
 		types.EventTypeEditOracleScript,
 		sdk.NewAttribute(types.AttributeKeyID, "1"),
@@ -217,17 +217,17 @@ func TestEditOracleScriptFail(t *testing.T) {
 	newSchema := "new_schema"
 	newURL := "new_url"
 	// Bad ID
-	msg := types.NewMsgEditOracleScript(999, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Owner.Address)
+	msg := types.NewMsgEditOracleScript(999, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Owner.Address, types.StrictSchemaEdit_Keep)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "oracle script not found: id: 999")
 	require.Nil(t, res)
 	// Not owner
-	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Bob.Address)
+	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, newCode, newSchema, newURL, testapp.Bob.Address, types.StrictSchemaEdit_Keep)
 	res, err = oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "editor not authorized")
 	require.Nil(t, res)
 	// Bad Owasm code
-	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, []byte("BAD_CODE"), newSchema, newURL, testapp.Owner.Address)
+	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, []byte("BAD_CODE"), newSchema, newURL, testapp.Owner.Address, types.StrictSchemaEdit_Keep)
 	res, err = oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "owasm compilation failed: with error: wasm code does not pass basic validation")
 	require.Nil(t, res)
@@ -236,16 +236,35 @@ func TestEditOracleScriptFail(t *testing.T) {
 	zw := gz.NewWriter(&buf)
 	zw.Write(testapp.WasmExtra2)
 	zw.Close()
-	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, buf.Bytes()[:5], newSchema, newURL, testapp.Owner.Address)
+	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, buf.Bytes()[:5], newSchema, newURL, testapp.Owner.Address, types.StrictSchemaEdit_Keep)
 	res, err = oracle.NewHandler(k)(ctx, msg)
 	require.EqualError(t, err, "uncompression failed: unexpected EOF")
 	require.Nil(t, res)
+	// Strict schema while keeping the existing schema, which is not a valid OBI schema
+	msg = types.NewMsgEditOracleScript(1, testapp.Owner.Address, newName, newDescription, newCode, types.DoNotModify, newURL, testapp.Owner.Address, types.StrictSchemaEdit_Enable)
+	res, err = oracle.NewHandler(k)(ctx, msg)
+	require.EqualError(t, err, "obi: oracle script schema must be in input/output format: invalid schema")
+	require.Nil(t, res)
+}
+
+func TestEditOracleScriptKeepLegacySchema(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(false)
+	// The existing schema is not a valid OBI schema, which non-strict oracle scripts may keep.
+	oldOS, err := k.GetOracleScript(ctx, 1)
+	require.NoError(t, err)
+	msg := types.NewMsgEditOracleScript(1, testapp.Owner.Address, types.DoNotModify, types.DoNotModify, testapp.WasmExtra2, types.DoNotModify, types.DoNotModify, testapp.Owner.Address, types.StrictSchemaEdit_Keep)
+	_, err = oracle.NewHandler(k)(ctx, msg)
+	require.NoError(t, err)
+	os, err := k.GetOracleScript(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, oldOS.Schema, os.Schema)
+	require.False(t, os.StrictSchema)
 }
 
 func TestRequestDataSuccess(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	ctx = ctx.WithBlockHeight(124).WithBlockTime(testapp.ParseTime(1581589790))
-	msg := types.NewMsgRequestData(1, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address)
+	msg := types.NewMsgRequestData(1, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address, nil, nil)
 	res, err := oracle.NewHandler(k)(ctx, msg)
 	require.NoError(t, err)
 	require.Equal(t, types.NewRequest(
@@ -297,17 +316,17 @@ func TestRequestDataSuccess(t *testing.T) {
 func TestRequestDataFail(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(false)
 	// No active oracle validators
-	res, err := oracle.NewHandler(k)(ctx, types.NewMsgRequestData(1, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address))
+	res, err := oracle.NewHandler(k)(ctx, types.NewMsgRequestData(1, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address, nil, nil))
 	require.EqualError(t, err, "insufficent available validators: 0 < 2")
 	require.Nil(t, res)
 	k.Activate(ctx, testapp.Validator1.ValAddress)
 	k.Activate(ctx, testapp.Validator2.ValAddress)
 	// Too high ask count
-	res, err = oracle.NewHandler(k)(ctx, types.NewMsgRequestData(1, []byte("beeb"), 3, 2, "CID", testapp.Alice.Address))
+	res, err = oracle.NewHandler(k)(ctx, types.NewMsgRequestData(1, []byte("beeb"), 3, 2, "CID", testapp.Alice.Address, nil, nil))
 	require.EqualError(t, err, "insufficent available validators: 2 < 3")
 	require.Nil(t, res)
 	// Bad oracle script ID
-	res, err = oracle.NewHandler(k)(ctx, types.NewMsgRequestData(999, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address))
+	res, err = oracle.NewHandler(k)(ctx, types.NewMsgRequestData(999, []byte("beeb"), 2, 2, "CID", testapp.Alice.Address, nil, nil))
 	require.EqualError(t, err, "oracle script not found: id: 999")
 	require.Nil(t, res)
 }
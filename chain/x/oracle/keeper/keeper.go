@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/bandprotocol/bandchain/chain/pkg/filecache"
+	"github.com/bandprotocol/bandchain/chain/pkg/owasmcache"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 	owasm "github.com/bandprotocol/go-owasm/api"
 )
@@ -27,6 +28,7 @@ type Keeper struct {
 	stakingKeeper    types.StakingKeeper
 	distrKeeper      types.DistrKeeper
 	owasmVM          *owasm.Vm
+	compiledCache    *owasmcache.Cache
 }
 
 // NewKeeper creates a new oracle Keeper instance.
@@ -34,7 +36,7 @@ func NewKeeper(
 	cdc *codec.Codec, key sdk.StoreKey, fileDir string, feeCollectorName string,
 	paramSpace params.Subspace, supplyKeeper types.SupplyKeeper,
 	stakingKeeper types.StakingKeeper, distrKeeper types.DistrKeeper,
-	owasmVM *owasm.Vm,
+	owasmVM *owasm.Vm, compiledCache *owasmcache.Cache,
 ) Keeper {
 	if !paramSpace.HasKeyTable() {
 		paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
@@ -49,6 +51,7 @@ func NewKeeper(
 		stakingKeeper:    stakingKeeper,
 		distrKeeper:      distrKeeper,
 		owasmVM:          owasmVM,
+		compiledCache:    compiledCache,
 	}
 }
 
@@ -82,7 +82,9 @@ func TestGetSetParams(t *testing.T) {
 	k.SetParam(ctx, types.KeySamplingTryCount, 3)
 	k.SetParam(ctx, types.KeyOracleRewardPercentage, 50)
 	k.SetParam(ctx, types.KeyInactivePenaltyDuration, 1000)
-	require.Equal(t, types.NewParams(1, 10, 30, 50000, 3000, 3, 50, 1000), k.GetParams(ctx))
+	k.SetParam(ctx, types.KeySamplingMethod, 1)
+	k.SetParam(ctx, types.KeyRandomnessFallback, 0)
+	require.Equal(t, types.NewParams(1, 10, 30, 50000, 3000, 3, 50, 1000, 1, 0), k.GetParams(ctx))
 	k.SetParam(ctx, types.KeyMaxRawRequestCount, 2)
 	k.SetParam(ctx, types.KeyMaxAskCount, 20)
 	k.SetParam(ctx, types.KeyExpirationBlockCount, 40)
@@ -91,5 +93,7 @@ func TestGetSetParams(t *testing.T) {
 	k.SetParam(ctx, types.KeySamplingTryCount, 5)
 	k.SetParam(ctx, types.KeyOracleRewardPercentage, 80)
 	k.SetParam(ctx, types.KeyInactivePenaltyDuration, 10000)
-	require.Equal(t, types.NewParams(2, 20, 40, 150000, 30000, 5, 80, 10000), k.GetParams(ctx))
+	k.SetParam(ctx, types.KeySamplingMethod, 2)
+	k.SetParam(ctx, types.KeyRandomnessFallback, 1)
+	require.Equal(t, types.NewParams(2, 20, 40, 150000, 30000, 5, 80, 10000, 2, 1), k.GetParams(ctx))
 }
@@ -48,7 +48,10 @@ func (k Keeper) AddOracleScript(ctx sdk.Context, oracleScript types.OracleScript
 }
 
 // MustEditOracleScript edits the given oracle script by id and flushes it to the storage. Panic if not exists.
-func (k Keeper) MustEditOracleScript(ctx sdk.Context, id types.OracleScriptID, new types.OracleScript) {
+// The strict schema flag is changed by the given edit rather than taken from the new oracle script.
+func (k Keeper) MustEditOracleScript(
+	ctx sdk.Context, id types.OracleScriptID, new types.OracleScript, strictSchema types.StrictSchemaEdit,
+) {
 	oracleScript := k.MustGetOracleScript(ctx, id)
 	oracleScript.Owner = new.Owner
 	oracleScript.Name = modify(oracleScript.Name, new.Name)
@@ -56,6 +59,7 @@ func (k Keeper) MustEditOracleScript(ctx sdk.Context, id types.OracleScriptID, n
 	oracleScript.Filename = modify(oracleScript.Filename, new.Filename)
 	oracleScript.Schema = modify(oracleScript.Schema, new.Schema)
 	oracleScript.SourceCodeURL = modify(oracleScript.SourceCodeURL, new.SourceCodeURL)
+	oracleScript.StrictSchema = strictSchema.Apply(oracleScript.StrictSchema)
 	k.SetOracleScript(ctx, id, oracleScript)
 }
 
@@ -74,11 +78,21 @@ func (k Keeper) GetAllOracleScripts(ctx sdk.Context) (oracleScripts []types.Orac
 
 // AddOracleScriptFile compiles Wasm code (see go-owasm), adds the compiled file to filecache,
 // and returns its sha256 reference name. Returns do-not-modify symbol if input is do-not-modify.
+// Compilation is skipped if the compiled code cache already has the code.
 func (k Keeper) AddOracleScriptFile(file []byte) (string, error) {
 	if bytes.Equal(file, types.DoNotModifyBytes) {
 		return types.DoNotModify, nil
 	}
-	compiledFile, err := k.owasmVM.Compile(file, types.MaxCompiledWasmCodeSize)
+	compile := func(code []byte) ([]byte, error) {
+		return k.owasmVM.Compile(code, types.MaxCompiledWasmCodeSize)
+	}
+	var compiledFile []byte
+	var err error
+	if k.compiledCache != nil {
+		compiledFile, err = k.compiledCache.GetOrCompile(file, compile)
+	} else {
+		compiledFile, err = compile(file)
+	}
 	if err != nil {
 		return "", sdkerrors.Wrapf(types.ErrOwasmCompilation, "with error: %s", err.Error())
 	}
@@ -15,7 +15,7 @@ func TestHasOracleScript(t *testing.T) {
 	require.False(t, k.HasOracleScript(ctx, 42))
 	// After we set it, we should be able to find it.
 	k.SetOracleScript(ctx, 42, types.NewOracleScript(
-		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL,
+		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL, false,
 	))
 	require.True(t, k.HasOracleScript(ctx, 42))
 }
@@ -28,10 +28,10 @@ func TestSetterGetterOracleScript(t *testing.T) {
 	require.Panics(t, func() { _ = k.MustGetOracleScript(ctx, 42) })
 	// Creates some basic oracle scripts.
 	oracleScript1 := types.NewOracleScript(
-		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL,
+		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL, false,
 	)
 	oracleScript2 := types.NewOracleScript(
-		testapp.Bob.Address, "NAME2", "DESCRIPTION2", "FILENAME2", BasicSchema, BasicSourceCodeURL,
+		testapp.Bob.Address, "NAME2", "DESCRIPTION2", "FILENAME2", BasicSchema, BasicSourceCodeURL, false,
 	)
 	// Sets id 42 with oracle script 1 and id 42 with oracle script 2.
 	k.SetOracleScript(ctx, 42, oracleScript1)
@@ -55,10 +55,10 @@ func TestAddEditOracleScriptBasic(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	// Creates some basic oracle scripts.
 	oracleScript1 := types.NewOracleScript(
-		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL,
+		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL, false,
 	)
 	oracleScript2 := types.NewOracleScript(
-		testapp.Bob.Address, "NAME2", "DESCRIPTION2", "FILENAME2", BasicSchema, BasicSourceCodeURL,
+		testapp.Bob.Address, "NAME2", "DESCRIPTION2", "FILENAME2", BasicSchema, BasicSourceCodeURL, false,
 	)
 	// Adds a new oracle script to the store. We should be able to retreive it back.
 	id := k.AddOracleScript(ctx, oracleScript1)
@@ -68,8 +68,8 @@ func TestAddEditOracleScriptBasic(t *testing.T) {
 	require.NotPanics(t, func() {
 		k.MustEditOracleScript(ctx, id, types.NewOracleScript(
 			oracleScript2.Owner, oracleScript2.Name, oracleScript2.Description, oracleScript2.Filename,
-			oracleScript2.Schema, oracleScript2.SourceCodeURL,
-		))
+			oracleScript2.Schema, oracleScript2.SourceCodeURL, false,
+		), types.StrictSchemaEdit_Disable)
 	})
 	require.NotEqual(t, oracleScript1, k.MustGetOracleScript(ctx, id))
 	require.Equal(t, oracleScript2, k.MustGetOracleScript(ctx, id))
@@ -79,18 +79,18 @@ func TestAddEditOracleScriptDoNotModify(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	// Creates some basic oracle scripts.
 	oracleScript1 := types.NewOracleScript(
-		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL,
+		testapp.Alice.Address, "NAME1", "DESCRIPTION1", "FILENAME1", BasicSchema, BasicSourceCodeURL, true,
 	)
 	oracleScript2 := types.NewOracleScript(
 		testapp.Bob.Address, types.DoNotModify, types.DoNotModify, "FILENAME2",
-		types.DoNotModify, types.DoNotModify,
+		types.DoNotModify, types.DoNotModify, false,
 	)
 	// Adds a new oracle script to the store. We should be able to retreive it back.
 	id := k.AddOracleScript(ctx, oracleScript1)
 	require.Equal(t, oracleScript1, k.MustGetOracleScript(ctx, id))
 	require.NotEqual(t, oracleScript2, k.MustGetOracleScript(ctx, id))
 	// Edits the oracle script. We should get the updated oracle script.
-	require.NotPanics(t, func() { k.MustEditOracleScript(ctx, id, oracleScript2) })
+	require.NotPanics(t, func() { k.MustEditOracleScript(ctx, id, oracleScript2, types.StrictSchemaEdit_Keep) })
 	oracleScriptRes := k.MustGetOracleScript(ctx, id)
 	require.NotEqual(t, oracleScriptRes, oracleScript1)
 	require.NotEqual(t, oracleScriptRes, oracleScript2)
@@ -100,6 +100,7 @@ func TestAddEditOracleScriptDoNotModify(t *testing.T) {
 	require.Equal(t, oracleScriptRes.Filename, oracleScript2.Filename)
 	require.Equal(t, oracleScriptRes.Schema, oracleScript1.Schema)
 	require.Equal(t, oracleScriptRes.SourceCodeURL, oracleScript1.SourceCodeURL)
+	require.True(t, oracleScriptRes.StrictSchema)
 }
 
 func TestAddOracleScriptMustReturnCorrectID(t *testing.T) {
@@ -109,12 +110,12 @@ func TestAddOracleScriptMustReturnCorrectID(t *testing.T) {
 	require.Equal(t, genesisCount, k.GetOracleScriptCount(ctx))
 	// Every new oracle script we add should return a new ID.
 	id1 := k.AddOracleScript(ctx, types.NewOracleScript(
-		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL,
+		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL, false,
 	))
 	require.Equal(t, types.OracleScriptID(genesisCount+1), id1)
 	// Adds another oracle script so now ID should increase by 2.
 	id2 := k.AddOracleScript(ctx, types.NewOracleScript(
-		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL,
+		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL, false,
 	))
 	require.Equal(t, types.OracleScriptID(genesisCount+2), id2)
 	// Finally we expect the oracle script to increase as well.
@@ -126,8 +127,8 @@ func TestEditNonExistentOracleScript(t *testing.T) {
 	// Editing a non-existent oracle script should return error.
 	require.Panics(t, func() {
 		k.MustEditOracleScript(ctx, 42, types.NewOracleScript(
-			testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL,
-		))
+			testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL, false,
+		), types.StrictSchemaEdit_Keep)
 	})
 }
 
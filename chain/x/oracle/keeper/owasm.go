@@ -9,39 +9,91 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking/exported"
 
 	"github.com/bandprotocol/bandchain/chain/pkg/bandrng"
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 )
 
-// GetRandomValidators returns a pseudorandom subset of active validators. Each validator has
-// chance of getting selected directly proportional to the amount of voting power it has.
-func (k Keeper) GetRandomValidators(ctx sdk.Context, size int, id int64) ([]sdk.ValAddress, error) {
-	valOperators := []sdk.ValAddress{}
-	valPowers := []uint64{}
+// GetRandomValidators returns a pseudorandom subset of active validators, sampled with the method
+// set by SamplingMethod parameter. See validatorSamplers for the supported methods. If allowed is
+// not empty, only the validators in it are sampled. Validators in denied are never sampled.
+func (k Keeper) GetRandomValidators(
+	ctx sdk.Context, size int, id int64, allowed []sdk.ValAddress, denied []sdk.ValAddress,
+) ([]sdk.ValAddress, error) {
+	vals := []exported.ValidatorI{}
 	k.stakingKeeper.IterateBondedValidatorsByPower(ctx,
 		func(idx int64, val exported.ValidatorI) (stop bool) {
-			if k.GetValidatorStatus(ctx, val.GetOperator()).IsActive {
-				valOperators = append(valOperators, val.GetOperator())
-				valPowers = append(valPowers, val.GetTokens().Uint64())
+			operator := val.GetOperator()
+			if len(allowed) > 0 && !ContainsVal(allowed, operator) {
+				return false
+			}
+			if ContainsVal(denied, operator) {
+				return false
+			}
+			if k.GetValidatorStatus(ctx, operator).IsActive {
+				vals = append(vals, val)
 			}
 			return false
 		})
-	if len(valOperators) < size {
+	if len(vals) < size {
+		if len(allowed) > 0 || len(denied) > 0 {
+			return nil, sdkerrors.Wrapf(types.ErrInsufficientValidators,
+				"%d active validators permitted by allowed and denied lists < %d", len(vals), size)
+		}
 		return nil, sdkerrors.Wrapf(
-			types.ErrInsufficientValidators, "%d < %d", len(valOperators), size)
+			types.ErrInsufficientValidators, "%d < %d", len(vals), size)
+	}
+	method := types.SamplingMethod(k.GetParam(ctx, types.KeySamplingMethod))
+	sample, ok := validatorSamplers[method]
+	if !ok {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidSamplingMethod, "%d", method)
 	}
 	rng, err := bandrng.NewRng(k.GetRollingSeed(ctx), sdk.Uint64ToBigEndian(uint64(id)), []byte(ctx.ChainID()))
 	if err != nil {
 		return nil, sdkerrors.Wrapf(types.ErrBadDrbgInitialization, err.Error())
 	}
-	tryCount := int(k.GetParam(ctx, types.KeySamplingTryCount))
-	chosenValIndexes := bandrng.ChooseSomeMaxWeight(rng, valPowers, size, tryCount)
+	chosenValIndexes := sample(ctx, k, rng, vals, size)
 	validators := make([]sdk.ValAddress, size)
 	for i, idx := range chosenValIndexes {
-		validators[i] = valOperators[idx]
+		validators[i] = vals[idx].GetOperator()
 	}
 	return validators, nil
 }
 
+// getValidatorPowers returns the bonded tokens of each requested validator of the given request,
+// in validator index order. Powers are taken when the request is resolved rather than when it is
+// submitted, so they reflect the stake backing the reports at aggregation time. Validators that
+// are no longer bonded have zero power.
+func (k Keeper) getValidatorPowers(ctx sdk.Context, req types.Request) []int64 {
+	powers := make([]int64, len(req.RequestedValidators))
+	for idx, valAddr := range req.RequestedValidators {
+		val := k.stakingKeeper.Validator(ctx, valAddr)
+		if val != nil {
+			powers[idx] = val.GetBondedTokens().Int64()
+		}
+	}
+	return powers
+}
+
+// checkStrictSchema checks that the given data decodes cleanly against the input or output type
+// of the oracle script's schema. Oracle scripts without strict schema accept any data.
+func checkStrictSchema(script types.OracleScript, data []byte, isOutput bool) error {
+	if !script.StrictSchema {
+		return nil
+	}
+	input, output, err := obi.ParseOracleScriptSchema(script.Schema)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidSchema, err.Error())
+	}
+	t := input
+	if isOutput {
+		t = output
+	}
+	if _, err := obi.DecodeValue(data, t); err != nil {
+		return sdkerrors.Wrapf(types.ErrOBIDecode, "schema %s: %s", t, err.Error())
+	}
+	return nil
+}
+
 // PrepareRequest takes an request specification object, performs the prepare call, and saves
 // the request object to store. Also emits events related to the request.
 func (k Keeper) PrepareRequest(ctx sdk.Context, r types.RequestSpec) error {
@@ -53,7 +105,11 @@ func (k Keeper) PrepareRequest(ctx sdk.Context, r types.RequestSpec) error {
 	ctx.GasMeter().ConsumeGas(k.GetParam(ctx, types.KeyBaseRequestGas), "BASE_REQUEST_FEE")
 	ctx.GasMeter().ConsumeGas(askCount*k.GetParam(ctx, types.KeyPerValidatorRequestGas), "PER_VALIDATOR_REQUEST_FEE")
 	// Get a random validator set to perform this request.
-	validators, err := k.GetRandomValidators(ctx, int(askCount), k.GetRequestCount(ctx)+1)
+	// The request will be stored under the next request ID once preparation succeeds.
+	nextID := types.RequestID(k.GetRequestCount(ctx) + 1)
+	validators, err := k.GetRandomValidators(
+		ctx, int(askCount), int64(nextID), r.GetAllowedValidators(), r.GetDeniedValidators(),
+	)
 	if err != nil {
 		return err
 	}
@@ -63,11 +119,16 @@ func (k Keeper) PrepareRequest(ctx sdk.Context, r types.RequestSpec) error {
 		ctx.BlockHeight(), ctx.BlockTime(), r.GetClientID(), nil,
 	)
 	// Create an execution environment and call Owasm prepare function.
-	env := types.NewPrepareEnv(req, int64(k.GetParam(ctx, types.KeyMaxRawRequestCount)))
+	env := types.NewPrepareEnv(
+		nextID, req, int64(k.GetParam(ctx, types.KeyMaxRawRequestCount)), k.GetRollingSeed(ctx),
+	)
 	script, err := k.GetOracleScript(ctx, req.OracleScriptID)
 	if err != nil {
 		return err
 	}
+	if err := checkStrictSchema(script, req.Calldata, false); err != nil {
+		return err
+	}
 	code := k.GetFile(script.Filename)
 	output, err := k.owasmVM.Prepare(code, types.WasmPrepareGas, types.MaxDataSize, env)
 	if err != nil {
@@ -116,7 +177,12 @@ func (k Keeper) PrepareRequest(ctx sdk.Context, r types.RequestSpec) error {
 // assumes that the given request is in a resolvable state with sufficient reporters.
 func (k Keeper) ResolveRequest(ctx sdk.Context, reqID types.RequestID) {
 	req := k.MustGetRequest(ctx, reqID)
-	env := types.NewExecuteEnv(req, k.GetReports(ctx, reqID))
+	reports := k.GetReports(ctx, reqID)
+	// An unavailable random value only fails oracle scripts that read it.
+	randomValue, _ := k.GetRequestRandomValue(ctx, req)
+	env := types.NewExecuteEnv(
+		reqID, req, reports, k.getValidatorPowers(ctx, req), randomValue,
+	)
 	script := k.MustGetOracleScript(ctx, req.OracleScriptID)
 	code := k.GetFile(script.Filename)
 	output, err := k.owasmVM.Execute(code, types.WasmExecuteGas, types.MaxDataSize, env)
@@ -124,6 +190,8 @@ func (k Keeper) ResolveRequest(ctx sdk.Context, reqID types.RequestID) {
 		k.ResolveFailure(ctx, reqID, err.Error())
 	} else if env.Retdata == nil {
 		k.ResolveFailure(ctx, reqID, "no return data")
+	} else if err := checkStrictSchema(script, env.Retdata, true); err != nil {
+		k.ResolveFailure(ctx, reqID, err.Error())
 	} else {
 		k.ResolveSuccess(ctx, reqID, env.Retdata, output.GasUsed)
 	}
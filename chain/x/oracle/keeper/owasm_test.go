@@ -1,7 +1,9 @@
 package keeper_test
 
 import (
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
 
@@ -17,37 +19,37 @@ func TestGetRandomValidatorsSuccessActivateAll(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	// Getting 3 validators using ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY
 	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY"))
-	vals, err := k.GetRandomValidators(ctx, 3, 1)
+	vals, err := k.GetRandomValidators(ctx, 3, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator3.ValAddress, testapp.Validator1.ValAddress, testapp.Validator2.ValAddress}, vals)
 	// Getting 3 validators using ROLLING_SEED_A
 	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_A_WITH_LONG_ENOUGH_ENTROPY"))
-	vals, err = k.GetRandomValidators(ctx, 3, 1)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator1.ValAddress, testapp.Validator3.ValAddress, testapp.Validator2.ValAddress}, vals)
 	// Getting 3 validators using ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY again should return the same result as the first one.
 	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY"))
-	vals, err = k.GetRandomValidators(ctx, 3, 1)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator3.ValAddress, testapp.Validator1.ValAddress, testapp.Validator2.ValAddress}, vals)
 	// Getting 3 validators using ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY but for a different request ID.
 	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY"))
-	vals, err = k.GetRandomValidators(ctx, 3, 42)
+	vals, err = k.GetRandomValidators(ctx, 3, 42, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator1.ValAddress, testapp.Validator3.ValAddress, testapp.Validator2.ValAddress}, vals)
 }
 
 func TestGetRandomValidatorsTooBigSize(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	_, err := k.GetRandomValidators(ctx, 1, 1)
+	_, err := k.GetRandomValidators(ctx, 1, 1, nil, nil)
 	require.NoError(t, err)
-	_, err = k.GetRandomValidators(ctx, 2, 1)
+	_, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
 	require.NoError(t, err)
-	_, err = k.GetRandomValidators(ctx, 3, 1)
+	_, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
 	require.NoError(t, err)
-	_, err = k.GetRandomValidators(ctx, 4, 1)
+	_, err = k.GetRandomValidators(ctx, 4, 1, nil, nil)
 	require.Error(t, err)
-	_, err = k.GetRandomValidators(ctx, 9999, 1)
+	_, err = k.GetRandomValidators(ctx, 9999, 1, nil, nil)
 	require.Error(t, err)
 }
 
@@ -55,33 +57,59 @@ func TestGetRandomValidatorsWithActivate(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(false)
 	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_WITH_LONG_ENOUGH_ENTROPY"))
 	// If no validators are active, you must not be able to get random validators
-	_, err := k.GetRandomValidators(ctx, 1, 1)
+	_, err := k.GetRandomValidators(ctx, 1, 1, nil, nil)
 	require.Error(t, err)
 	// If we activate 2 validators, we should be able to get at most 2 from the function.
 	k.Activate(ctx, testapp.Validator1.ValAddress)
 	k.Activate(ctx, testapp.Validator2.ValAddress)
-	vals, err := k.GetRandomValidators(ctx, 1, 1)
+	vals, err := k.GetRandomValidators(ctx, 1, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator1.ValAddress}, vals)
-	vals, err = k.GetRandomValidators(ctx, 2, 1)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator1.ValAddress, testapp.Validator2.ValAddress}, vals)
-	_, err = k.GetRandomValidators(ctx, 3, 1)
+	_, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
 	require.Error(t, err)
 	// After we deactivate 1 validator due to missing a report, we can only get at most 1 validator.
 	k.MissReport(ctx, testapp.Validator1.ValAddress, time.Now())
-	vals, err = k.GetRandomValidators(ctx, 1, 1)
+	vals, err = k.GetRandomValidators(ctx, 1, 1, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, []sdk.ValAddress{testapp.Validator2.ValAddress}, vals)
-	_, err = k.GetRandomValidators(ctx, 2, 1)
+	_, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
 	require.Error(t, err)
 }
 
+func TestGetRandomValidatorsWithAllowedAndDeniedLists(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetRollingSeed(ctx, []byte("ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY"))
+	v1, v2, v3 := testapp.Validator1.ValAddress, testapp.Validator2.ValAddress, testapp.Validator3.ValAddress
+	// Only allowed validators are sampled.
+	vals, err := k.GetRandomValidators(ctx, 2, 1, []sdk.ValAddress{v1, v2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v1, v2}, vals)
+	_, err = k.GetRandomValidators(ctx, 3, 1, []sdk.ValAddress{v1, v2}, nil)
+	require.True(t, errors.Is(err, types.ErrInsufficientValidators))
+	// Denied validators are never sampled.
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, []sdk.ValAddress{v1})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v2}, vals)
+	_, err = k.GetRandomValidators(ctx, 3, 1, nil, []sdk.ValAddress{v1})
+	require.True(t, errors.Is(err, types.ErrInsufficientValidators))
+	// Both lists apply together.
+	vals, err = k.GetRandomValidators(ctx, 1, 1, []sdk.ValAddress{v1, v2}, []sdk.ValAddress{v1})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v2}, vals)
+	// Allowed validators that are not active are not sampled either.
+	k.MissReport(ctx, v2, time.Now())
+	_, err = k.GetRandomValidators(ctx, 1, 1, []sdk.ValAddress{v2}, nil)
+	require.True(t, errors.Is(err, types.ErrInsufficientValidators))
+}
+
 func TestPrepareRequestSuccessBasic(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589790)).WithBlockHeight(42)
 	// OracleScript#1: Prepare asks for DS#1,2,3 with ExtID#1,2,3 and calldata "beeb"
-	m := types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
 	require.Equal(t, types.NewRequest(
@@ -126,13 +154,13 @@ func TestPrepareRequestSuccessBasic(t *testing.T) {
 func TestPrepareRequestInvalidAskCountFail(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	k.SetParam(ctx, types.KeyMaxAskCount, 5)
-	m := types.NewMsgRequestData(1, BasicCalldata, 10, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(1, BasicCalldata, 10, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "invalid ask count: got: 10, max: 5")
-	m = types.NewMsgRequestData(1, BasicCalldata, 4, 1, BasicClientID, testapp.Alice.Address)
+	m = types.NewMsgRequestData(1, BasicCalldata, 4, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err = k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "insufficent available validators: 3 < 4")
-	m = types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m = types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err = k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
 }
@@ -141,7 +169,7 @@ func TestPrepareRequestBaseRequestFeePanic(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	k.SetParam(ctx, types.KeyBaseRequestGas, 100000) // Set BaseRequestGas to 100000
 	k.SetParam(ctx, types.KeyPerValidatorRequestGas, 0)
-	m := types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	ctx = ctx.WithGasMeter(sdk.NewGasMeter(90000))
 	require.PanicsWithValue(t, sdk.ErrorOutOfGas{Descriptor: "BASE_REQUEST_FEE"}, func() { k.PrepareRequest(ctx, &m) })
 	ctx = ctx.WithGasMeter(sdk.NewGasMeter(200000))
@@ -153,10 +181,10 @@ func TestPrepareRequestPerValidatorRequestFeePanic(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	k.SetParam(ctx, types.KeyBaseRequestGas, 100000)
 	k.SetParam(ctx, types.KeyPerValidatorRequestGas, 50000) // Set erValidatorRequestGas to 50000
-	m := types.NewMsgRequestData(1, BasicCalldata, 2, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(1, BasicCalldata, 2, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	ctx = ctx.WithGasMeter(sdk.NewGasMeter(190000))
 	require.PanicsWithValue(t, sdk.ErrorOutOfGas{Descriptor: "PER_VALIDATOR_REQUEST_FEE"}, func() { k.PrepareRequest(ctx, &m) })
-	m = types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m = types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	ctx = ctx.WithGasMeter(sdk.NewGasMeter(190000))
 	err := k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
@@ -164,28 +192,28 @@ func TestPrepareRequestPerValidatorRequestFeePanic(t *testing.T) {
 
 func TestPrepareRequestEmptyCalldata(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true) // Send nil while oracle script expects calldata
-	m := types.NewMsgRequestData(4, nil, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(4, nil, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "bad wasm execution: runtime error while executing the Wasm script")
 }
 
 func TestPrepareRequestOracleScriptNotFound(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	m := types.NewMsgRequestData(999, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(999, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "oracle script not found: id: 999")
 }
 
 func TestPrepareRequestBadWasmExecutionFail(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	m := types.NewMsgRequestData(2, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(2, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "bad wasm execution: OEI action to invoke is not available")
 }
 
 func TestPrepareRequestWithEmptyRawRequest(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	m := types.NewMsgRequestData(3, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(3, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "empty raw requests")
 }
@@ -195,7 +223,7 @@ func TestPrepareRequestUnknownDataSource(t *testing.T) {
 	m := types.NewMsgRequestData(4, obi.MustEncode(testapp.Wasm4Input{
 		IDs:      []int64{1, 2, 99},
 		Calldata: "beeb",
-	}), 1, 1, BasicClientID, testapp.Alice.Address)
+	}), 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "data source not found: id: 99")
 }
@@ -206,37 +234,73 @@ func TestPrepareRequestInvalidDataSourceCount(t *testing.T) {
 	m := types.NewMsgRequestData(4, obi.MustEncode(testapp.Wasm4Input{
 		IDs:      []int64{1, 2, 3, 4},
 		Calldata: "beeb",
-	}), 1, 1, BasicClientID, testapp.Alice.Address)
+	}), 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "bad wasm execution: too many external data requests")
 	m = types.NewMsgRequestData(4, obi.MustEncode(testapp.Wasm4Input{
 		IDs:      []int64{1, 2, 3},
 		Calldata: "beeb",
-	}), 1, 1, BasicClientID, testapp.Alice.Address)
+	}), 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err = k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
 }
 
 func TestPrepareRequestTooMuchWasmGas(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	m := types.NewMsgRequestData(5, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(5, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
-	m = types.NewMsgRequestData(6, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m = types.NewMsgRequestData(6, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err = k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "bad wasm execution: out-of-gas while executing the wasm script")
 }
 
 func TestPrepareRequestTooLargeCalldata(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
-	m := types.NewMsgRequestData(7, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m := types.NewMsgRequestData(7, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err := k.PrepareRequest(ctx, &m)
 	require.NoError(t, err)
-	m = types.NewMsgRequestData(8, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address)
+	m = types.NewMsgRequestData(8, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
 	err = k.PrepareRequest(ctx, &m)
 	require.EqualError(t, err, "bad wasm execution: span to write is too small")
 }
 
+func TestPrepareRequestStrictSchema(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	script := k.MustGetOracleScript(ctx, 1)
+	script.Schema = "{symbol:string}/{px:u64}"
+	script.StrictSchema = true
+	k.SetOracleScript(ctx, 1, script)
+	// BASIC_CALLDATA does not decode as {symbol:string}, so the request is rejected.
+	m := types.NewMsgRequestData(1, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
+	err := k.PrepareRequest(ctx, &m)
+	require.EqualError(t, err, "schema {symbol:string}: obi: out of range: obi decode failed")
+	require.Equal(t, int64(0), k.GetRequestCount(ctx))
+	m = types.NewMsgRequestData(1, obi.MustEncode(struct {
+		Symbol string `obi:"symbol"`
+	}{"BTC"}), 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
+	err = k.PrepareRequest(ctx, &m)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), k.GetRequestCount(ctx))
+}
+
+func TestPrepareRequestHostFunctions(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589790)).WithBlockHeight(42)
+	// OracleScript#10: Prepare asks DS#1 with the request metadata read from the host functions.
+	m := types.NewMsgRequestData(10, BasicCalldata, 1, 1, BasicClientID, testapp.Alice.Address, nil, nil)
+	err := k.PrepareRequest(ctx, &m)
+	require.NoError(t, err)
+	calldata := make([]byte, 24)
+	binary.LittleEndian.PutUint64(calldata[0:8], 1)
+	binary.LittleEndian.PutUint64(calldata[8:16], 42)
+	binary.LittleEndian.PutUint64(calldata[16:24], 1581589790)
+	calldata = append(calldata, []byte(BasicClientID)...)
+	require.Equal(t, []types.RawRequest{
+		types.NewRawRequest(1, 1, calldata),
+	}, k.MustGetRequest(ctx, 1).RawRequests)
+}
+
 func TestResolveRequestSuccess(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589890))
@@ -394,6 +458,40 @@ func TestResolveRequestNoReturnData(t *testing.T) {
 	)}, ctx.EventManager().Events())
 }
 
+func TestResolveRequestStrictSchemaFailure(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589890))
+	// 1st Wasm returns "beeb", which is too short for a u64 output.
+	script := k.MustGetOracleScript(ctx, 1)
+	script.Schema = "{symbol:string}/{px:u64}"
+	script.StrictSchema = true
+	k.SetOracleScript(ctx, 1, script)
+	k.SetRequest(ctx, 42, types.NewRequest(
+		1, BasicCalldata, []sdk.ValAddress{testapp.Validator1.ValAddress, testapp.Validator2.ValAddress}, 1,
+		42, testapp.ParseTime(1581589790), BasicClientID, []types.RawRequest{
+			types.NewRawRequest(1, 1, []byte("beeb")),
+		},
+	))
+	k.SetReport(ctx, 42, types.NewReport(
+		testapp.Validator1.ValAddress, true, []types.RawReport{
+			types.NewRawReport(1, 0, []byte("beeb")),
+		},
+	))
+	k.ResolveRequest(ctx, 42)
+	reqPacket := types.NewOracleRequestPacketData(BasicClientID, 1, BasicCalldata, 2, 1)
+	resPacket := types.NewOracleResponsePacketData(
+		BasicClientID, 42, 1, testapp.ParseTime(1581589790).Unix(),
+		testapp.ParseTime(1581589890).Unix(), types.ResolveStatus_Failure, []byte{},
+	)
+	require.Equal(t, types.NewResult(reqPacket, resPacket), k.MustGetResult(ctx, 42))
+	require.Equal(t, sdk.Events{sdk.NewEvent(
+		types.EventTypeResolve,
+		sdk.NewAttribute(types.AttributeKeyID, "42"),
+		sdk.NewAttribute(types.AttributeKeyResolveStatus, "2"),
+		sdk.NewAttribute(types.AttributeKeyReason, "schema {px:u64}: obi: out of range: obi decode failed"),
+	)}, ctx.EventManager().Events())
+}
+
 func TestResolveRequestWasmFailure(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589890))
@@ -448,3 +546,51 @@ func TestResolveRequestCallReturnDataSeveralTimes(t *testing.T) {
 		sdk.NewAttribute(types.AttributeKeyReason, "set return data is called more than once"),
 	)}, ctx.EventManager().Events())
 }
+
+func TestResolveRequestHostFunctions(t *testing.T) {
+	app, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589890))
+	k.SetRequest(ctx, 42, types.NewRequest(
+		// 10th Wasm - return the power and address of the first requested validator and the random value
+		10, BasicCalldata, []sdk.ValAddress{testapp.Validator1.ValAddress, testapp.Validator2.ValAddress}, 1,
+		42, testapp.ParseTime(1581589790), BasicClientID, []types.RawRequest{
+			types.NewRawRequest(1, 1, []byte("beeb")),
+		},
+	))
+	k.SetReport(ctx, 42, types.NewReport(
+		testapp.Validator1.ValAddress, true, []types.RawReport{
+			types.NewRawReport(1, 0, []byte("beeb")),
+		},
+	))
+	// Powers are the bonded tokens at resolve time, not at request time.
+	val := app.StakingKeeper.Validator(ctx, testapp.Validator1.ValAddress)
+	k.SetRandomness(ctx, 42, []byte("VRF_RANDOM_VALUE"))
+	k.ResolveRequest(ctx, 42)
+	expected := make([]byte, 8)
+	binary.LittleEndian.PutUint64(expected, uint64(val.GetBondedTokens().Int64()))
+	expected = append(expected, []byte(testapp.Validator1.ValAddress.String())...)
+	expected = append(expected, []byte("VRF_RANDOM_VALUE")...)
+	result := k.MustGetResult(ctx, 42)
+	require.Equal(t, types.ResolveStatus_Success, result.ResponsePacketData.ResolveStatus)
+	require.Equal(t, expected, result.ResponsePacketData.Result)
+}
+
+func TestResolveRequestUnavailableRandomValue(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockTime(testapp.ParseTime(1581589890))
+	k.SetRequest(ctx, 42, types.NewRequest(
+		// 10th Wasm - reads the random value, which block 42 does not have
+		10, BasicCalldata, []sdk.ValAddress{testapp.Validator1.ValAddress}, 1,
+		42, testapp.ParseTime(1581589790), BasicClientID, []types.RawRequest{
+			types.NewRawRequest(1, 1, []byte("beeb")),
+		},
+	))
+	k.SetReport(ctx, 42, types.NewReport(
+		testapp.Validator1.ValAddress, true, []types.RawReport{
+			types.NewRawReport(1, 0, []byte("beeb")),
+		},
+	))
+	k.ResolveRequest(ctx, 42)
+	result := k.MustGetResult(ctx, 42)
+	require.Equal(t, types.ResolveStatus_Failure, result.ResponsePacketData.ResolveStatus)
+}
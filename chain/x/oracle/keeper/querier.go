@@ -35,6 +35,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryActiveValidators(ctx, keeper)
 		case types.QueryPendingRequests:
 			return queryPendingRequests(ctx, path[1:], keeper)
+		case types.QueryRandomness:
+			return queryRandomness(ctx, path[1:], keeper)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown oracle query endpoint")
 		}
@@ -220,3 +222,26 @@ func queryPendingRequests(ctx sdk.Context, path []string, k Keeper) ([]byte, err
 
 	return types.QueryOK(pendingIDs)
 }
+
+func queryRandomness(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) > 1 {
+		return types.QueryBadRequest("too many arguments")
+	}
+	// Without a height, returns the random value of the latest block.
+	height := ctx.BlockHeight()
+	if len(path) == 1 {
+		var err error
+		height, err = strconv.ParseInt(path[0], 10, 64)
+		if err != nil {
+			return types.QueryBadRequest(err.Error())
+		}
+	}
+	value, err := k.GetRandomness(ctx, height)
+	if err != nil {
+		return types.QueryNotFound(err.Error())
+	}
+	return types.QueryOK(types.QueryRandomnessResult{
+		Height:      height,
+		RandomValue: value,
+	})
+}
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -70,3 +71,59 @@ func TestQueryPendingRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRandomness(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockHeight(20)
+	k.SetRandomness(ctx, 10, []byte("RANDOM_VALUE_10"))
+	k.SetRandomness(ctx, 20, []byte("RANDOM_VALUE_20"))
+	q := keeper.NewQuerier(k)
+
+	tests := []struct {
+		name     string
+		args     []string
+		status   int
+		expected types.QueryRandomnessResult
+	}{
+		{
+			name:     "Get randomness of the latest block",
+			args:     []string{},
+			status:   http.StatusOK,
+			expected: types.QueryRandomnessResult{Height: 20, RandomValue: []byte("RANDOM_VALUE_20")},
+		},
+		{
+			name:     "Get randomness at height 10",
+			args:     []string{"10"},
+			status:   http.StatusOK,
+			expected: types.QueryRandomnessResult{Height: 10, RandomValue: []byte("RANDOM_VALUE_10")},
+		},
+		{
+			name:   "Get randomness at height without random value",
+			args:   []string{"15"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Get randomness at invalid height",
+			args:   []string{"abc"},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := q(ctx, append([]string{types.QueryRandomness}, tt.args...), abci.RequestQuery{})
+			require.NoError(t, err)
+
+			var queryRequest types.QueryResult
+			require.NoError(t, json.Unmarshal(raw, &queryRequest))
+			require.Equal(t, tt.status, queryRequest.Status)
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var result types.QueryRandomnessResult
+			types.ModuleCdc.MustUnmarshalJSON(queryRequest.Result, &result)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
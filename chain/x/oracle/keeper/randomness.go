@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// GetVRFKey returns the VRF key of the given validator.
+func (k Keeper) GetVRFKey(ctx sdk.Context, val sdk.ValAddress) (types.VRFKey, error) {
+	bz := ctx.KVStore(k.storeKey).Get(types.VRFKeyStoreKey(val))
+	if bz == nil {
+		return types.VRFKey{}, sdkerrors.Wrapf(types.ErrVRFKeyNotFound, "val: %s", val.String())
+	}
+	var key types.VRFKey
+	k.cdc.MustUnmarshalBinaryBare(bz, &key)
+	return key, nil
+}
+
+// SetVRFKey sets the VRF public key of the given validator, replacing its existing key. The key
+// can be used to contribute to the randomness beacon after VRFKeyActivationDelay blocks.
+func (k Keeper) SetVRFKey(ctx sdk.Context, val sdk.ValAddress, pubKey []byte) {
+	key := types.NewVRFKey(pubKey, ctx.BlockHeight())
+	ctx.KVStore(k.storeKey).Set(types.VRFKeyStoreKey(val), k.cdc.MustMarshalBinaryBare(key))
+}
+
+// HasVRFContribution checks if the validator has contributed to the randomness beacon of the
+// current block.
+func (k Keeper) HasVRFContribution(ctx sdk.Context, val sdk.ValAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.VRFContributionStoreKey(val))
+}
+
+// AddVRFContribution verifies the VRF output and proof of the validator on the beacon input of
+// the current block, and saves the output to be aggregated at the end of the block. Only bonded
+// validators can contribute, at most once per block.
+func (k Keeper) AddVRFContribution(
+	ctx sdk.Context, val sdk.ValAddress, height int64, output []byte, proof []byte,
+) error {
+	if height != ctx.BlockHeight() {
+		return sdkerrors.Wrapf(types.ErrInvalidVRFHeight, "got: %d, block height: %d", height, ctx.BlockHeight())
+	}
+	validator := k.stakingKeeper.Validator(ctx, val)
+	if validator == nil || !validator.IsBonded() {
+		return sdkerrors.Wrapf(types.ErrValidatorNotBonded, "val: %s", val.String())
+	}
+	key, err := k.GetVRFKey(ctx, val)
+	if err != nil {
+		return err
+	}
+	if height < key.Height+types.VRFKeyActivationDelay {
+		return sdkerrors.Wrapf(types.ErrVRFKeyNotActive,
+			"val: %s, active from height: %d", val.String(), key.Height+types.VRFKeyActivationDelay)
+	}
+	if k.HasVRFContribution(ctx, val) {
+		return sdkerrors.Wrapf(types.ErrVRFAlreadySubmitted, "val: %s", val.String())
+	}
+	input := types.VRFInput(ctx.ChainID(), height, k.GetRollingSeed(ctx))
+	if err := vrf.Verify(key.PublicKey, input, output, proof); err != nil {
+		return sdkerrors.Wrapf(types.ErrInvalidVRF, err.Error())
+	}
+	ctx.KVStore(k.storeKey).Set(types.VRFContributionStoreKey(val), output)
+	return nil
+}
+
+// GetVRFContributions returns the VRF outputs contributed in the current block, ordered by
+// validator address.
+func (k Keeper) GetVRFContributions(ctx sdk.Context) (outputs [][]byte) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.VRFContributionStoreKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		outputs = append(outputs, iterator.Value())
+	}
+	return outputs
+}
+
+// deleteVRFContributions removes all VRF outputs contributed in the current block.
+func (k Keeper) deleteVRFContributions(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.VRFContributionStoreKeyPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetRandomness returns the random value of the randomness beacon at the given block height.
+func (k Keeper) GetRandomness(ctx sdk.Context, height int64) ([]byte, error) {
+	bz := ctx.KVStore(k.storeKey).Get(types.RandomnessStoreKey(height))
+	if bz == nil {
+		return nil, sdkerrors.Wrapf(types.ErrRandomnessNotFound, "height: %d", height)
+	}
+	return bz, nil
+}
+
+// SetRandomness sets the random value of the randomness beacon at the given block height.
+func (k Keeper) SetRandomness(ctx sdk.Context, height int64, value []byte) {
+	ctx.KVStore(k.storeKey).Set(types.RandomnessStoreKey(height), value)
+}
+
+// GetRequestRandomValue returns the random value that the given request reads during execution:
+// the random value of the block at which the request was submitted. If that block has no VRF
+// contribution, the RandomnessFallback param decides whether to use the current rolling seed,
+// which block proposers can predict, or to return ErrRandomnessNotFound.
+func (k Keeper) GetRequestRandomValue(ctx sdk.Context, req types.Request) ([]byte, error) {
+	value, err := k.GetRandomness(ctx, req.RequestHeight)
+	if err == nil {
+		return value, nil
+	}
+	if types.RandomnessFallback(k.GetParam(ctx, types.KeyRandomnessFallback)) == types.RandomnessFallbackRollingSeed {
+		return k.GetRollingSeed(ctx), nil
+	}
+	return nil, err
+}
+
+// FinalizeRandomness computes the random value of the current block by hashing the random value
+// of the previous block, kept as the rolling seed, together with the VRF outputs contributed in
+// this block. The value becomes the new rolling seed. A block without contributions falls back
+// to the previous block hash, which its proposer could influence, so its value is not saved as
+// the randomness of the block.
+func (k Keeper) FinalizeRandomness(ctx sdk.Context) []byte {
+	h := sha256.New()
+	h.Write(k.GetRollingSeed(ctx))
+	outputs := k.GetVRFContributions(ctx)
+	for _, output := range outputs {
+		h.Write(vrf.Hash(output))
+	}
+	if len(outputs) == 0 {
+		h.Write(ctx.BlockHeader().LastBlockId.Hash)
+	}
+	value := h.Sum(nil)
+	k.deleteVRFContributions(ctx)
+	if len(outputs) > 0 {
+		k.SetRandomness(ctx, ctx.BlockHeight(), value)
+	}
+	k.SetRollingSeed(ctx, value)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRandomness,
+		sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", ctx.BlockHeight())),
+		sdk.NewAttribute(types.AttributeKeyRandomValue, hex.EncodeToString(value)),
+		sdk.NewAttribute(types.AttributeKeyVRFCount, fmt.Sprintf("%d", len(outputs))),
+	))
+	return value
+}
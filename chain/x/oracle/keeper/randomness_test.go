@@ -0,0 +1,162 @@
+package keeper_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+var prevRandomValue = []byte("PREVIOUS_RANDOM_VALUE_OF_32BYTES")
+
+func mustGenerateVRFKey(t *testing.T) (vrf.PrivateKey, []byte) {
+	key, err := vrf.GenerateKey()
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	return key, pubKey
+}
+
+func mustProve(t *testing.T, key vrf.PrivateKey, height int64) ([]byte, []byte) {
+	output, proof, err := key.Prove(types.VRFInput("bandchain", height, prevRandomValue))
+	require.NoError(t, err)
+	return output, proof
+}
+
+func TestGetSetVRFKey(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	_, err := k.GetVRFKey(ctx, testapp.Validator1.ValAddress)
+	require.Error(t, err)
+	_, pubKey := mustGenerateVRFKey(t)
+	k.SetVRFKey(ctx.WithBlockHeight(5), testapp.Validator1.ValAddress, pubKey)
+	key, err := k.GetVRFKey(ctx, testapp.Validator1.ValAddress)
+	require.NoError(t, err)
+	require.Equal(t, types.NewVRFKey(pubKey, 5), key)
+}
+
+func TestAddVRFContribution(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithChainID("bandchain").WithBlockHeight(10)
+	k.SetRollingSeed(ctx, prevRandomValue)
+	key, pubKey := mustGenerateVRFKey(t)
+	k.SetVRFKey(ctx.WithBlockHeight(8), testapp.Validator1.ValAddress, pubKey)
+
+	output, proof := mustProve(t, key, 10)
+	err := k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, output, proof)
+	require.NoError(t, err)
+	require.True(t, k.HasVRFContribution(ctx, testapp.Validator1.ValAddress))
+	require.Equal(t, [][]byte{output}, k.GetVRFContributions(ctx))
+	// Only one contribution per validator per block.
+	err = k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, output, proof)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, types.ErrVRFAlreadySubmitted))
+}
+
+func TestAddVRFContributionFail(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithChainID("bandchain").WithBlockHeight(10)
+	k.SetRollingSeed(ctx, prevRandomValue)
+	key, pubKey := mustGenerateVRFKey(t)
+	output, proof := mustProve(t, key, 10)
+	// Validator without a VRF key.
+	err := k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, output, proof)
+	require.True(t, errors.Is(err, types.ErrVRFKeyNotFound))
+	// Key set too recently.
+	k.SetVRFKey(ctx.WithBlockHeight(9), testapp.Validator1.ValAddress, pubKey)
+	err = k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, output, proof)
+	require.True(t, errors.Is(err, types.ErrVRFKeyNotActive))
+	k.SetVRFKey(ctx.WithBlockHeight(8), testapp.Validator1.ValAddress, pubKey)
+	// Contribution to a block other than the current one.
+	otherOutput, otherProof := mustProve(t, key, 11)
+	err = k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 11, otherOutput, otherProof)
+	require.True(t, errors.Is(err, types.ErrInvalidVRFHeight))
+	// Output proven on an input other than the current one.
+	err = k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, otherOutput, otherProof)
+	require.True(t, errors.Is(err, types.ErrInvalidVRF))
+	// Output proven with another validator's key.
+	k.SetVRFKey(ctx.WithBlockHeight(8), testapp.Validator2.ValAddress, pubKey)
+	otherKey, _ := mustGenerateVRFKey(t)
+	otherOutput, otherProof = mustProve(t, otherKey, 10)
+	err = k.AddVRFContribution(ctx, testapp.Validator2.ValAddress, 10, otherOutput, otherProof)
+	require.True(t, errors.Is(err, types.ErrInvalidVRF))
+	// Not a validator.
+	k.SetVRFKey(ctx.WithBlockHeight(8), testapp.Alice.ValAddress, pubKey)
+	err = k.AddVRFContribution(ctx, testapp.Alice.ValAddress, 10, output, proof)
+	require.True(t, errors.Is(err, types.ErrValidatorNotBonded))
+	require.Empty(t, k.GetVRFContributions(ctx))
+}
+
+func TestFinalizeRandomness(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithChainID("bandchain").WithBlockHeight(10)
+	k.SetRollingSeed(ctx, prevRandomValue)
+	key1, pubKey1 := mustGenerateVRFKey(t)
+	key2, pubKey2 := mustGenerateVRFKey(t)
+	k.SetVRFKey(ctx.WithBlockHeight(1), testapp.Validator1.ValAddress, pubKey1)
+	k.SetVRFKey(ctx.WithBlockHeight(1), testapp.Validator2.ValAddress, pubKey2)
+	output1, proof1 := mustProve(t, key1, 10)
+	output2, proof2 := mustProve(t, key2, 10)
+	require.NoError(t, k.AddVRFContribution(ctx, testapp.Validator2.ValAddress, 10, output2, proof2))
+	require.NoError(t, k.AddVRFContribution(ctx, testapp.Validator1.ValAddress, 10, output1, proof1))
+
+	// Outputs are aggregated in validator address order, regardless of the order of submission.
+	first, second := output1, output2
+	if string(testapp.Validator2.ValAddress) < string(testapp.Validator1.ValAddress) {
+		first, second = output2, output1
+	}
+	expected := sha256.Sum256(append(append(append([]byte{}, prevRandomValue...), vrf.Hash(first)...), vrf.Hash(second)...))
+	value := k.FinalizeRandomness(ctx)
+	require.Equal(t, expected[:], value)
+	require.Equal(t, value, k.GetRollingSeed(ctx))
+	stored, err := k.GetRandomness(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, value, stored)
+	require.Empty(t, k.GetVRFContributions(ctx))
+	_, err = k.GetRandomness(ctx, 11)
+	require.True(t, errors.Is(err, types.ErrRandomnessNotFound))
+}
+
+func TestFinalizeRandomnessWithoutContributions(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	ctx = ctx.WithBlockHeader(abci.Header{
+		Height:      10,
+		LastBlockId: abci.BlockID{Hash: []byte("LAST_BLOCK_HASH")},
+	})
+	k.SetRollingSeed(ctx, prevRandomValue)
+	expected := sha256.Sum256(append(append([]byte{}, prevRandomValue...), []byte("LAST_BLOCK_HASH")...))
+	require.Equal(t, expected[:], k.FinalizeRandomness(ctx))
+	require.Equal(t, expected[:], k.GetRollingSeed(ctx))
+	_, err := k.GetRandomness(ctx, 10)
+	require.True(t, errors.Is(err, types.ErrRandomnessNotFound))
+}
+
+func TestGetRequestRandomValue(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetRollingSeed(ctx, prevRandomValue)
+	req := types.NewRequest(1, []byte("CALLDATA"), []sdk.ValAddress{testapp.Validator1.ValAddress}, 1, 10, testapp.ParseTime(1581589790), "", nil)
+	k.SetRandomness(ctx, 10, []byte("VRF_RANDOM_VALUE"))
+	value, err := k.GetRequestRandomValue(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []byte("VRF_RANDOM_VALUE"), value)
+}
+
+func TestGetRequestRandomValueWithoutVRFOutput(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetRollingSeed(ctx, prevRandomValue)
+	req := types.NewRequest(1, []byte("CALLDATA"), []sdk.ValAddress{testapp.Validator1.ValAddress}, 1, 10, testapp.ParseTime(1581589790), "", nil)
+	// By default, the request cannot get a random value.
+	_, err := k.GetRequestRandomValue(ctx, req)
+	require.True(t, errors.Is(err, types.ErrRandomnessNotFound))
+	// With the fallback enabled, the request gets the rolling seed instead.
+	k.SetParam(ctx, types.KeyRandomnessFallback, uint64(types.RandomnessFallbackRollingSeed))
+	value, err := k.GetRequestRandomValue(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, prevRandomValue, value)
+}
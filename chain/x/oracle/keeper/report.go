@@ -45,6 +45,7 @@ func (k Keeper) AddReport(ctx sdk.Context, rid types.RequestID, rep types.Report
 		}
 	}
 	k.SetReport(ctx, rid, rep)
+	k.RecordReportOutcome(ctx, rep.Validator, true)
 	return nil
 }
 
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// GetReportSuccessRate returns the recent report success rate of the given validator, out of
+// ReportSuccessRatePrecision. Validators with no report history start with a perfect rate.
+func (k Keeper) GetReportSuccessRate(ctx sdk.Context, val sdk.ValAddress) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.ReportSuccessRateStoreKey(val))
+	if bz == nil {
+		return types.ReportSuccessRatePrecision
+	}
+	var rate uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &rate)
+	return rate
+}
+
+// SetReportSuccessRate sets the recent report success rate of the given validator.
+func (k Keeper) SetReportSuccessRate(ctx sdk.Context, val sdk.ValAddress, rate uint64) {
+	ctx.KVStore(k.storeKey).Set(types.ReportSuccessRateStoreKey(val), k.cdc.MustMarshalBinaryLengthPrefixed(rate))
+}
+
+// GetAllReportSuccessRates returns the list of all stored report success rates, ordered by
+// validator address.
+func (k Keeper) GetAllReportSuccessRates(ctx sdk.Context) (rates []types.ReportSuccessRate) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ReportSuccessRateKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var rate uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &rate)
+		val := sdk.ValAddress(iterator.Key()[len(types.ReportSuccessRateKeyPrefix):])
+		rates = append(rates, types.NewReportSuccessRate(val, rate))
+	}
+	return rates
+}
+
+// RecordReportOutcome updates the recent report success rate of the given validator after it
+// reported or missed reporting to a request. The rate is an exponential moving average, so the
+// outcome of the most recent ReportSuccessRateWindow requests dominates. Rates are only used by
+// reputation-weighted sampling, so nothing is recorded while another sampling method is in use.
+func (k Keeper) RecordReportOutcome(ctx sdk.Context, val sdk.ValAddress, success bool) {
+	if types.SamplingMethod(k.GetParam(ctx, types.KeySamplingMethod)) != types.SamplingReputationWeighted {
+		return
+	}
+	rate := k.GetReportSuccessRate(ctx, val)
+	if success {
+		rate += (types.ReportSuccessRatePrecision - rate) / types.ReportSuccessRateWindow
+	} else {
+		rate -= rate / types.ReportSuccessRateWindow
+	}
+	k.SetReportSuccessRate(ctx, val, rate)
+}
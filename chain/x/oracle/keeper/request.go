@@ -68,9 +68,10 @@ func (k Keeper) ProcessExpiredRequests(ctx sdk.Context) {
 		if !k.HasResult(ctx, currentReqID) {
 			k.ResolveExpired(ctx, currentReqID)
 		}
-		// Deactivate all validators that do not report to this request.
+		// Record a miss for and deactivate all validators that do not report to this request.
 		for _, val := range req.RequestedValidators {
 			if !k.HasReport(ctx, currentReqID, val) {
+				k.RecordReportOutcome(ctx, val, false)
 				k.MissReport(ctx, val, req.RequestTime)
 			}
 		}
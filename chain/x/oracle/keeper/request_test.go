@@ -78,7 +78,7 @@ func TestAddDataSourceBasic(t *testing.T) {
 	_, ctx, k := testapp.CreateTestInput(true)
 	// We start by setting an oracle request available at ID 42.
 	k.SetOracleScript(ctx, 42, types.NewOracleScript(
-		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL,
+		testapp.Owner.Address, BasicName, BasicDesc, BasicFilename, BasicSchema, BasicSourceCodeURL, false,
 	))
 	// Adding the first request should return ID 1.
 	id := k.AddRequest(ctx, types.NewRequest(42, BasicCalldata, nil, 1, 1, testapp.ParseTime(0), "", nil))
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/staking/exported"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/bandrng"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+// validatorSampler picks size distinct indexes of the given candidate validators. It must only
+// use the given rng as its source of randomness so that all nodes pick the same validators.
+type validatorSampler func(ctx sdk.Context, k Keeper, rng *bandrng.Rng, vals []exported.ValidatorI, size int) []int
+
+// validatorSamplers maps each supported sampling method to its implementation.
+var validatorSamplers = map[types.SamplingMethod]validatorSampler{
+	types.SamplingStakeWeighted:      sampleStakeWeighted,
+	types.SamplingUniform:            sampleUniform,
+	types.SamplingReputationWeighted: sampleReputationWeighted,
+}
+
+// sampleStakeWeighted picks validators with probability proportional to their bonded tokens,
+// returning the subset with the highest total tokens among SamplingTryCount tries.
+func sampleStakeWeighted(ctx sdk.Context, k Keeper, rng *bandrng.Rng, vals []exported.ValidatorI, size int) []int {
+	weights := make([]uint64, len(vals))
+	for idx, val := range vals {
+		weights[idx] = val.GetTokens().Uint64()
+	}
+	tryCount := int(k.GetParam(ctx, types.KeySamplingTryCount))
+	return bandrng.ChooseSomeMaxWeight(rng, weights, size, tryCount)
+}
+
+// sampleUniform picks validators with equal probability.
+func sampleUniform(ctx sdk.Context, k Keeper, rng *bandrng.Rng, vals []exported.ValidatorI, size int) []int {
+	weights := make([]uint64, len(vals))
+	for idx := range vals {
+		weights[idx] = 1
+	}
+	return bandrng.ChooseSome(rng, weights, size)
+}
+
+// sampleReputationWeighted picks validators like sampleStakeWeighted, but with each validator's
+// bonded tokens scaled by its recent report success rate. Every validator keeps a weight of at
+// least one, so that validators with a poor record can still be picked to redeem themselves.
+func sampleReputationWeighted(ctx sdk.Context, k Keeper, rng *bandrng.Rng, vals []exported.ValidatorI, size int) []int {
+	weights := make([]uint64, len(vals))
+	for idx, val := range vals {
+		rate := k.GetReportSuccessRate(ctx, val.GetOperator())
+		weight := val.GetTokens().Mul(sdk.NewIntFromUint64(rate)).Quo(sdk.NewIntFromUint64(types.ReportSuccessRatePrecision))
+		weights[idx] = sdk.MaxInt(weight, sdk.OneInt()).Uint64()
+	}
+	tryCount := int(k.GetParam(ctx, types.KeySamplingTryCount))
+	return bandrng.ChooseSomeMaxWeight(rng, weights, size, tryCount)
+}
@@ -0,0 +1,136 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/testapp"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+)
+
+var (
+	rollingSeed1 = []byte("ROLLING_SEED_1_WITH_LONG_ENOUGH_ENTROPY")
+	rollingSeedA = []byte("ROLLING_SEED_A_WITH_LONG_ENOUGH_ENTROPY")
+	rollingSeedB = []byte("ROLLING_SEED_B_WITH_LONG_ENOUGH_ENTROPY")
+)
+
+func TestGetRandomValidatorsStakeWeighted(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetParam(ctx, types.KeySamplingMethod, uint64(types.SamplingStakeWeighted))
+	v1, v2, v3 := testapp.Validator1.ValAddress, testapp.Validator2.ValAddress, testapp.Validator3.ValAddress
+	// Validator2 has 1% of the stake of the others, so it is never picked before them.
+	k.SetRollingSeed(ctx, rollingSeed1)
+	vals, err := k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v1}, vals)
+	k.SetRollingSeed(ctx, rollingSeedA)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v1, v3}, vals)
+	k.SetRollingSeed(ctx, rollingSeedB)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v1, v2}, vals)
+}
+
+func TestGetRandomValidatorsUniform(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetParam(ctx, types.KeySamplingMethod, uint64(types.SamplingUniform))
+	v1, v2, v3 := testapp.Validator1.ValAddress, testapp.Validator2.ValAddress, testapp.Validator3.ValAddress
+	// Stake does not matter, so Validator2 gets picked first as often as the others.
+	k.SetRollingSeed(ctx, rollingSeed1)
+	vals, err := k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v1, v2}, vals)
+	k.SetRollingSeed(ctx, rollingSeedA)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v2, v1}, vals)
+	k.SetRollingSeed(ctx, rollingSeedB)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v2, v1}, vals)
+	// Sampling again with the same seed must return the same result.
+	k.SetRollingSeed(ctx, rollingSeedA)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v2, v1}, vals)
+}
+
+func TestGetRandomValidatorsReputationWeighted(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetParam(ctx, types.KeySamplingMethod, uint64(types.SamplingReputationWeighted))
+	v1, v2, v3 := testapp.Validator1.ValAddress, testapp.Validator2.ValAddress, testapp.Validator3.ValAddress
+	// With perfect success rates, reputation-weighted sampling is the same as stake-weighted.
+	k.SetRollingSeed(ctx, rollingSeedA)
+	vals, err := k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v1, v3}, vals)
+	// Validator1 reports 1% of the time, so it now weighs as much as Validator2.
+	k.SetReportSuccessRate(ctx, v1, types.ReportSuccessRatePrecision/100)
+	k.SetRollingSeed(ctx, rollingSeed1)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v1}, vals)
+	k.SetRollingSeed(ctx, rollingSeedA)
+	vals, err = k.GetRandomValidators(ctx, 2, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v2}, vals)
+	k.SetRollingSeed(ctx, rollingSeedB)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ValAddress{v3, v1, v2}, vals)
+	// Validators with zero success rate can still be picked.
+	k.SetReportSuccessRate(ctx, v1, 0)
+	k.SetReportSuccessRate(ctx, v2, 0)
+	vals, err = k.GetRandomValidators(ctx, 3, 1, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, vals, 3)
+}
+
+func TestGetRandomValidatorsInvalidSamplingMethod(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetParam(ctx, types.KeySamplingMethod, 3)
+	_, err := k.GetRandomValidators(ctx, 1, 1, nil, nil)
+	require.Error(t, err)
+}
+
+func TestRecordReportOutcome(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	k.SetParam(ctx, types.KeySamplingMethod, uint64(types.SamplingReputationWeighted))
+	val := testapp.Validator1.ValAddress
+	require.Equal(t, types.ReportSuccessRatePrecision, k.GetReportSuccessRate(ctx, val))
+	k.RecordReportOutcome(ctx, val, false)
+	require.Equal(t, uint64(950000), k.GetReportSuccessRate(ctx, val))
+	k.RecordReportOutcome(ctx, val, false)
+	require.Equal(t, uint64(902500), k.GetReportSuccessRate(ctx, val))
+	k.RecordReportOutcome(ctx, val, true)
+	require.Equal(t, uint64(907375), k.GetReportSuccessRate(ctx, val))
+	// Other validators are not affected.
+	require.Equal(t, types.ReportSuccessRatePrecision, k.GetReportSuccessRate(ctx, testapp.Validator2.ValAddress))
+}
+
+func TestRecordReportOutcomeNotReputationWeighted(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	val := testapp.Validator1.ValAddress
+	// Other sampling methods do not use success rates, so no state is written.
+	for _, method := range []types.SamplingMethod{types.SamplingStakeWeighted, types.SamplingUniform} {
+		k.SetParam(ctx, types.KeySamplingMethod, uint64(method))
+		k.RecordReportOutcome(ctx, val, false)
+		require.Equal(t, types.ReportSuccessRatePrecision, k.GetReportSuccessRate(ctx, val))
+		require.Empty(t, k.GetAllReportSuccessRates(ctx))
+	}
+}
+
+func TestGetAllReportSuccessRates(t *testing.T) {
+	_, ctx, k := testapp.CreateTestInput(true)
+	require.Empty(t, k.GetAllReportSuccessRates(ctx))
+	k.SetReportSuccessRate(ctx, testapp.Validator1.ValAddress, 950000)
+	k.SetReportSuccessRate(ctx, testapp.Validator2.ValAddress, 0)
+	require.ElementsMatch(t, []types.ReportSuccessRate{
+		types.NewReportSuccessRate(testapp.Validator1.ValAddress, 950000),
+		types.NewReportSuccessRate(testapp.Validator2.ValAddress, 0),
+	}, k.GetAllReportSuccessRates(ctx))
+}
@@ -108,12 +108,13 @@ func getGenesisOracleScripts() []types.OracleScript {
 	OracleScripts = []types.OracleScript{{}} // 0th index should be ignored
 	wasms := [][]byte{
 		Wasm1, Wasm2, Wasm3, Wasm4, Wasm56(10), Wasm56(10000000), Wasm78(10), Wasm78(2000), Wasm9,
+		Wasm10,
 	}
 	for idx := 0; idx < len(wasms); idx++ {
 		idxStr := fmt.Sprintf("%d", idx+1)
 		hash := fc.AddFile(compile(wasms[idx]))
 		OracleScripts = append(OracleScripts, types.NewOracleScript(
-			Owner.Address, "name"+idxStr, "desc"+idxStr, hash, "schema"+idxStr, "url"+idxStr,
+			Owner.Address, "name"+idxStr, "desc"+idxStr, hash, "schema"+idxStr, "url"+idxStr, false,
 		))
 	}
 	return OracleScripts[1:]
@@ -154,7 +155,7 @@ func NewSimApp(chainID string, logger log.Logger) *bandapp.BandApp {
 	}
 	viper.Set(cli.HomeFlag, dir)
 	db := dbm.NewMemDB()
-	app := bandapp.NewBandApp(logger, db, nil, true, 0, map[int64]bool{}, "", false, 0)
+	app := bandapp.NewBandApp(logger, db, nil, true, 0, map[int64]bool{}, "", false, 0, 0)
 	genesis := bandapp.NewDefaultGenesisState()
 	// Fund seed accounts and validators with 1000000uband and 100000000uband initially.
 	authGenesis := auth.NewGenesisState(auth.DefaultParams(), []authexported.GenesisAccount{
@@ -0,0 +1,71 @@
+package testapp
+
+// An oracle script that reads the request metadata through the Owasm host functions.
+//   PREPARE:
+//     CALL ask_external_data with EID 1 DID 1 CALLDATA of the request ID, request height and
+//     request time as little-endian i64, followed by the client ID
+//   EXECUTE:
+//     CALL set_return_data with the power of the validator at index 0 as little-endian i64,
+//     followed by its address and the random value of the request
+var Wasm10 []byte = wat2wasm([]byte(`
+(module
+	(type $t0 (func))
+	(type $t1 (func (param i64 i64 i64 i64)))
+	(type $t2 (func (param i64 i64)))
+	(type $t3 (func (result i64)))
+	(type $t4 (func (param i64) (result i64)))
+	(type $t5 (func (param i64 i64) (result i64)))
+	(import "env" "ask_external_data" (func $ask_external_data (type $t1)))
+	(import "env" "set_return_data" (func $set_return_data (type $t2)))
+	(import "env" "get_request_id" (func $get_request_id (type $t3)))
+	(import "env" "get_request_height" (func $get_request_height (type $t3)))
+	(import "env" "get_request_time" (func $get_request_time (type $t3)))
+	(import "env" "read_client_id" (func $read_client_id (type $t4)))
+	(import "env" "read_validator_address" (func $read_validator_address (type $t5)))
+	(import "env" "get_validator_power" (func $get_validator_power (type $t4)))
+	(import "env" "read_random_value" (func $read_random_value (type $t4)))
+	(func $prepare (export "prepare") (type $t0)
+	  (local $l0 i64)
+	  i32.const 0
+	  call $get_request_id
+	  i64.store
+	  i32.const 8
+	  call $get_request_height
+	  i64.store
+	  i32.const 16
+	  call $get_request_time
+	  i64.store
+	  i64.const 24
+	  call $read_client_id
+	  set_local $l0
+	  i64.const 1
+	  i64.const 1
+	  i64.const 0
+	  get_local $l0
+	  i64.const 24
+	  i64.add
+	  call $ask_external_data)
+	(func $execute (export "execute") (type $t0)
+	  (local $l0 i64) (local $l1 i64)
+	  i32.const 0
+	  i64.const 0
+	  call $get_validator_power
+	  i64.store
+	  i64.const 0
+	  i64.const 8
+	  call $read_validator_address
+	  set_local $l0
+	  get_local $l0
+	  i64.const 8
+	  i64.add
+	  call $read_random_value
+	  set_local $l1
+	  i64.const 0
+	  get_local $l0
+	  get_local $l1
+	  i64.add
+	  i64.const 8
+	  i64.add
+	  call $set_return_data)
+	(memory $memory (export "memory") 17))
+`))
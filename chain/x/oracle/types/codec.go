@@ -22,6 +22,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgActivate{}, "oracle/Activate", nil)
 	cdc.RegisterConcrete(MsgAddReporter{}, "oracle/AddReporter", nil)
 	cdc.RegisterConcrete(MsgRemoveReporter{}, "oracle/RemoveReporter", nil)
+	cdc.RegisterConcrete(MsgSetVRFKey{}, "oracle/SetVRFKey", nil)
+	cdc.RegisterConcrete(MsgSubmitVRF{}, "oracle/SubmitVRF", nil)
 	cdc.RegisterConcrete(OracleRequestPacketData{}, "oracle/OracleRequestPacketData", nil)
 	cdc.RegisterConcrete(OracleResponsePacketData{}, "oracle/OracleResponsePacketData", nil)
 }
@@ -10,11 +10,16 @@ const (
 	MaxSchemaLength      = 512
 	MaxURLLength         = 128
 
+	MaxValidatorListLength = 100
+
 	MaxExecutableSize       = 8 * 1024        // 8kB
 	MaxWasmCodeSize         = 512 * 1024      // 512kB
 	MaxCompiledWasmCodeSize = 1 * 1024 * 1024 // 1MB
 	MaxDataSize             = 256             // 256B
 
+	// Fixed-length arrays in schemas can be no longer than data, as every element takes a byte.
+	MaxSchemaArrayLength = MaxDataSize
+
 	WasmPrepareGas = 1000000
 	WasmExecuteGas = 5000000
 )
@@ -11,14 +11,18 @@ func NewMsgRequestData(
 	MinCount uint64,
 	ClientID string,
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress,
+	AllowedValidators []github_com_cosmos_cosmos_sdk_types.ValAddress,
+	DeniedValidators []github_com_cosmos_cosmos_sdk_types.ValAddress,
 ) MsgRequestData {
 	return MsgRequestData{
-		OracleScriptID: OracleScriptID,
-		Calldata:       Calldata,
-		AskCount:       AskCount,
-		MinCount:       MinCount,
-		ClientID:       ClientID,
-		Sender:         Sender,
+		OracleScriptID:    OracleScriptID,
+		Calldata:          Calldata,
+		AskCount:          AskCount,
+		MinCount:          MinCount,
+		ClientID:          ClientID,
+		Sender:            Sender,
+		AllowedValidators: AllowedValidators,
+		DeniedValidators:  DeniedValidators,
 	}
 }
 
@@ -78,6 +82,7 @@ func NewMsgCreateOracleScript(
 	Schema string,
 	SourceCodeURL string,
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress,
+	StrictSchema bool,
 ) MsgCreateOracleScript {
 	return MsgCreateOracleScript{
 		Owner:         Owner,
@@ -87,6 +92,7 @@ func NewMsgCreateOracleScript(
 		Schema:        Schema,
 		SourceCodeURL: SourceCodeURL,
 		Sender:        Sender,
+		StrictSchema:  StrictSchema,
 	}
 }
 
@@ -99,6 +105,7 @@ func NewMsgEditOracleScript(
 	Schema string,
 	SourceCodeURL string,
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress,
+	StrictSchema StrictSchemaEdit,
 ) MsgEditOracleScript {
 	return MsgEditOracleScript{
 		OracleScriptID: OracleScriptID,
@@ -109,6 +116,7 @@ func NewMsgEditOracleScript(
 		Schema:         Schema,
 		SourceCodeURL:  SourceCodeURL,
 		Sender:         Sender,
+		StrictSchema:   StrictSchema,
 	}
 }
 
@@ -140,6 +148,32 @@ func NewMsgRemoveReporter(
 	}
 }
 
+func NewMsgSetVRFKey(
+	Validator github_com_cosmos_cosmos_sdk_types.ValAddress,
+	PublicKey []byte,
+) MsgSetVRFKey {
+	return MsgSetVRFKey{
+		Validator: Validator,
+		PublicKey: PublicKey,
+	}
+}
+
+func NewMsgSubmitVRF(
+	Height int64,
+	Output []byte,
+	Proof []byte,
+	Validator github_com_cosmos_cosmos_sdk_types.ValAddress,
+	Reporter github_com_cosmos_cosmos_sdk_types.AccAddress,
+) MsgSubmitVRF {
+	return MsgSubmitVRF{
+		Height:    Height,
+		Output:    Output,
+		Proof:     Proof,
+		Validator: Validator,
+		Reporter:  Reporter,
+	}
+}
+
 func NewDataSource(
 	Owner github_com_cosmos_cosmos_sdk_types.AccAddress,
 	Name string,
@@ -161,6 +195,7 @@ func NewOracleScript(
 	Filename string,
 	Schema string,
 	SourceCodeURL string,
+	StrictSchema bool,
 ) OracleScript {
 	return OracleScript{
 		Owner:         Owner,
@@ -169,6 +204,7 @@ func NewOracleScript(
 		Filename:      Filename,
 		Schema:        Schema,
 		SourceCodeURL: SourceCodeURL,
+		StrictSchema:  StrictSchema,
 	}
 }
 
@@ -276,6 +312,16 @@ func NewValidatorStatus(
 	}
 }
 
+func NewVRFKey(
+	PublicKey []byte,
+	Height int64,
+) VRFKey {
+	return VRFKey{
+		PublicKey: PublicKey,
+		Height:    Height,
+	}
+}
+
 func NewParams(
 	MaxRawRequestCount uint64,
 	MaxAskCount uint64,
@@ -285,6 +331,8 @@ func NewParams(
 	SamplingTryCount uint64,
 	OracleRewardPercentage uint64,
 	InactivePenaltyDuration uint64,
+	SamplingMethod uint64,
+	RandomnessFallback uint64,
 ) Params {
 	return Params{
 		MaxRawRequestCount:      MaxRawRequestCount,
@@ -295,5 +343,7 @@ func NewParams(
 		SamplingTryCount:        SamplingTryCount,
 		OracleRewardPercentage:  OracleRewardPercentage,
 		InactivePenaltyDuration: InactivePenaltyDuration,
+		SamplingMethod:          SamplingMethod,
+		RandomnessFallback:      RandomnessFallback,
 	}
 }
@@ -44,6 +44,19 @@ var (
 	ErrUncompressionFailed      = sdkerrors.Register(ModuleName, 38, "uncompression failed")
 	ErrRequestAlreadyExpired    = sdkerrors.Register(ModuleName, 39, "request already expired")
 	ErrBadDrbgInitialization    = sdkerrors.Register(ModuleName, 40, "bad drbg initialization")
+	ErrInvalidSchema            = sdkerrors.Register(ModuleName, 41, "invalid schema")
+	ErrInvalidSamplingMethod    = sdkerrors.Register(ModuleName, 42, "invalid sampling method")
+	ErrTooLongValidatorList     = sdkerrors.Register(ModuleName, 43, "too long validator list")
+	ErrInvalidValidatorList     = sdkerrors.Register(ModuleName, 44, "invalid validator list")
+	ErrInvalidVRFKey            = sdkerrors.Register(ModuleName, 45, "invalid vrf key")
+	ErrVRFKeyNotFound           = sdkerrors.Register(ModuleName, 46, "vrf key not found")
+	ErrVRFKeyNotActive          = sdkerrors.Register(ModuleName, 47, "vrf key not active")
+	ErrInvalidVRF               = sdkerrors.Register(ModuleName, 48, "invalid vrf")
+	ErrInvalidVRFHeight         = sdkerrors.Register(ModuleName, 49, "invalid vrf height")
+	ErrVRFAlreadySubmitted      = sdkerrors.Register(ModuleName, 50, "vrf already submitted")
+	ErrValidatorNotBonded       = sdkerrors.Register(ModuleName, 51, "validator not bonded")
+	ErrRandomnessNotFound       = sdkerrors.Register(ModuleName, 52, "randomness not found")
+	ErrInvalidStrictSchemaEdit  = sdkerrors.Register(ModuleName, 53, "invalid strict schema edit")
 )
 
 // WrapMaxError wraps an error message with additional info of the current and max values.
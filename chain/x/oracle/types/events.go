@@ -14,6 +14,9 @@ const (
 	EventTypeAddReporter        = "add_reporter"
 	EventTypeRemoveReporter     = "remove_reporter"
 	EventTypeResolve            = "resolve"
+	EventTypeSetVRFKey          = "set_vrf_key"
+	EventTypeSubmitVRF          = "submit_vrf"
+	EventTypeRandomness         = "randomness"
 
 	AttributeKeyID             = "id"
 	AttributeKeyDataSourceID   = "data_source_id"
@@ -30,4 +33,7 @@ const (
 	AttributeKeyGasUsed        = "gas_used"
 	AttributeKeyResult         = "result"
 	AttributeKeyReason         = "reason"
+	AttributeKeyHeight         = "height"
+	AttributeKeyRandomValue    = "random_value"
+	AttributeKeyVRFCount       = "vrf_count"
 )
@@ -6,7 +6,9 @@ import (
 
 // BaseEnv combines shared functions used in prepare and execution Owasm program,
 type BaseEnv struct {
-	request Request
+	requestID   RequestID
+	request     Request
+	randomValue []byte
 }
 
 // GetCalldata implements Owasm ExecEnv interface.
@@ -49,6 +51,49 @@ func (env *BaseEnv) GetExternalData(eid int64, vid int64) ([]byte, error) {
 	return nil, api.ErrWrongPeriodAction
 }
 
+// GetRequestID implements Owasm ExecEnv interface.
+func (env *BaseEnv) GetRequestID() int64 {
+	return int64(env.requestID)
+}
+
+// GetRequestHeight implements Owasm ExecEnv interface.
+func (env *BaseEnv) GetRequestHeight() int64 {
+	return env.request.RequestHeight
+}
+
+// GetRequestTime implements Owasm ExecEnv interface. The time is returned as a Unix timestamp
+// in seconds.
+func (env *BaseEnv) GetRequestTime() int64 {
+	return env.request.RequestTime.Unix()
+}
+
+// GetClientID implements Owasm ExecEnv interface.
+func (env *BaseEnv) GetClientID() []byte {
+	return []byte(env.request.ClientID)
+}
+
+// GetValidatorAddress implements Owasm ExecEnv interface. The address is returned as the
+// Bech32 operator address of the requested validator at the given index.
+func (env *BaseEnv) GetValidatorAddress(vid int64) ([]byte, error) {
+	if vid < 0 || vid >= int64(len(env.request.RequestedValidators)) {
+		return nil, api.ErrBadValidatorIndex
+	}
+	return []byte(env.request.RequestedValidators[vid].String()), nil
+}
+
+// GetRandomValue implements Owasm ExecEnv interface.
+func (env *BaseEnv) GetRandomValue() ([]byte, error) {
+	if env.randomValue == nil {
+		return nil, api.ErrUnavailableRandomValue
+	}
+	return env.randomValue, nil
+}
+
+// GetValidatorPower implements Owasm ExecEnv interface.
+func (env *BaseEnv) GetValidatorPower(vid int64) (int64, error) {
+	return 0, api.ErrWrongPeriodAction
+}
+
 // PrepareEnv implements ExecEnv interface only expected function and panic on non-prepare functions.
 type PrepareEnv struct {
 	BaseEnv
@@ -56,11 +101,14 @@ type PrepareEnv struct {
 	rawRequests    []RawRequest
 }
 
-// NewPrepareEnv creates a new environment instance for prepare period.
-func NewPrepareEnv(req Request, maxRawRequests int64) *PrepareEnv {
+// NewPrepareEnv creates a new environment instance for prepare period. The random value is the
+// value of the randomness beacon at the latest finalized block.
+func NewPrepareEnv(id RequestID, req Request, maxRawRequests int64, randomValue []byte) *PrepareEnv {
 	return &PrepareEnv{
 		BaseEnv: BaseEnv{
-			request: req,
+			requestID:   id,
+			request:     req,
+			randomValue: randomValue,
 		},
 		maxRawRequests: maxRawRequests,
 	}
@@ -94,24 +142,42 @@ func (env *PrepareEnv) GetRawRequests() []RawRequest {
 type ExecuteEnv struct {
 	BaseEnv
 	reports map[string]map[ExternalID]RawReport
+	powers  []int64
 	Retdata []byte
 }
 
-// NewExecuteEnv creates a new environment instance for execution period.
-func NewExecuteEnv(req Request, reports []Report) *ExecuteEnv {
+// NewExecuteEnv creates a new environment instance for execution period. The powers slice holds
+// the bonded tokens of each requested validator, in validator index order, as of the time the
+// request is resolved. Validators that did not report get zero power. The random value is the
+// value of the randomness beacon at the block of the request, which was unknown at the time the
+// request was submitted, or nil if that value is not available.
+func NewExecuteEnv(
+	id RequestID, req Request, reports []Report, powers []int64, randomValue []byte,
+) *ExecuteEnv {
+	reported := make(map[string]bool)
 	envReports := make(map[string]map[ExternalID]RawReport)
 	for _, report := range reports {
+		reported[string(report.Validator)] = true
 		valReports := make(map[ExternalID]RawReport)
 		for _, each := range report.RawReports {
 			valReports[each.ExternalID] = each
 		}
 		envReports[report.Validator.String()] = valReports
 	}
+	envPowers := make([]int64, len(req.RequestedValidators))
+	for idx, val := range req.RequestedValidators {
+		if idx < len(powers) && reported[string(val)] {
+			envPowers[idx] = powers[idx]
+		}
+	}
 	return &ExecuteEnv{
 		BaseEnv: BaseEnv{
-			request: req,
+			requestID:   id,
+			request:     req,
+			randomValue: randomValue,
 		},
 		reports: envReports,
+		powers:  envPowers,
 	}
 }
 
@@ -156,3 +222,12 @@ func (env *ExecuteEnv) GetExternalData(eid int64, vid int64) ([]byte, error) {
 	data, _, err := env.getExternalDataFull(eid, vid)
 	return data, err
 }
+
+// GetValidatorPower implements Owasm ExecEnv interface. Validators that did not report have
+// zero power.
+func (env *ExecuteEnv) GetValidatorPower(vid int64) (int64, error) {
+	if vid < 0 || vid >= int64(len(env.powers)) {
+		return 0, api.ErrBadValidatorIndex
+	}
+	return env.powers[vid], nil
+}
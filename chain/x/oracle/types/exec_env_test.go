@@ -37,7 +37,7 @@ func mockExecEnv() *ExecuteEnv {
 	rawReport3 := NewRawReport(3, 0, []byte("DATA3"))
 	report1 := NewReport(validatorAddress1, true, []RawReport{rawReport1, rawReport2})
 	report2 := NewReport(validatorAddress2, true, []RawReport{rawReport3})
-	env := NewExecuteEnv(request, []Report{report1, report2})
+	env := NewExecuteEnv(42, request, []Report{report1, report2}, []int64{100, 25, 10}, []byte("EXECUTE_RANDOM_VALUE"))
 	return env
 }
 
@@ -50,7 +50,7 @@ func mockFreshPrepareEnv() *PrepareEnv {
 	requestTime := time.Unix(1581589700, 0)
 	clientID := "beeb"
 	request := NewRequest(oracleScriptID, calldata, valAddresses, minCount, requestHeight, requestTime, clientID, nil)
-	env := NewPrepareEnv(request, 3)
+	env := NewPrepareEnv(42, request, 3, []byte("PREPARE_RANDOM_VALUE"))
 	return env
 }
 
@@ -228,3 +228,71 @@ func TestGetRawRequests(t *testing.T) {
 	}
 	require.Equal(t, expect, env.GetRawRequests())
 }
+
+func TestGetRequestMetadata(t *testing.T) {
+	penv := mockFreshPrepareEnv()
+	eenv := mockExecEnv()
+	for _, env := range []*BaseEnv{&penv.BaseEnv, &eenv.BaseEnv} {
+		require.Equal(t, int64(42), env.GetRequestID())
+		require.Equal(t, int64(999), env.GetRequestHeight())
+		require.Equal(t, int64(1581589700), env.GetRequestTime())
+		require.Equal(t, []byte("beeb"), env.GetClientID())
+	}
+}
+
+func TestGetValidatorAddress(t *testing.T) {
+	penv := mockFreshPrepareEnv()
+	addr, err := penv.GetValidatorAddress(0)
+	require.NoError(t, err)
+	require.Equal(t, []byte(validatorAddress1.String()), addr)
+
+	eenv := mockExecEnv()
+	addr, err = eenv.GetValidatorAddress(2)
+	require.NoError(t, err)
+	require.Equal(t, []byte(validatorAddress3.String()), addr)
+
+	_, err = eenv.GetValidatorAddress(-1)
+	require.Equal(t, api.ErrBadValidatorIndex, err)
+	_, err = eenv.GetValidatorAddress(3)
+	require.Equal(t, api.ErrBadValidatorIndex, err)
+}
+
+func TestGetValidatorPower(t *testing.T) {
+	penv := mockFreshPrepareEnv()
+	_, err := penv.GetValidatorPower(0)
+	require.Equal(t, api.ErrWrongPeriodAction, err)
+
+	eenv := mockExecEnv()
+	power, err := eenv.GetValidatorPower(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), power)
+	power, err = eenv.GetValidatorPower(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(25), power)
+	// Validator 3 did not report, so it has no say in aggregation.
+	power, err = eenv.GetValidatorPower(2)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), power)
+
+	_, err = eenv.GetValidatorPower(-1)
+	require.Equal(t, api.ErrBadValidatorIndex, err)
+	_, err = eenv.GetValidatorPower(3)
+	require.Equal(t, api.ErrBadValidatorIndex, err)
+}
+
+func TestGetRandomValue(t *testing.T) {
+	penv := mockFreshPrepareEnv()
+	value, err := penv.GetRandomValue()
+	require.NoError(t, err)
+	require.Equal(t, []byte("PREPARE_RANDOM_VALUE"), value)
+
+	eenv := mockExecEnv()
+	value, err = eenv.GetRandomValue()
+	require.NoError(t, err)
+	require.Equal(t, []byte("EXECUTE_RANDOM_VALUE"), value)
+
+	// The request block has no random value, so reading it fails.
+	eenv = NewExecuteEnv(42, eenv.request, []Report{}, []int64{}, nil)
+	_, err = eenv.GetRandomValue()
+	require.Equal(t, api.ErrUnavailableRandomValue, err)
+}
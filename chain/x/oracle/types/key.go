@@ -18,7 +18,7 @@ var (
 	RollingSeedSizeInBytes = 32
 	// GlobalStoreKeyPrefix is the prefix for global primitive state variables.
 	GlobalStoreKeyPrefix = []byte{0x00}
-	// RollingSeedStoreKey is the key that keeps the seed based on the first 8-bit of the most recent 32 block hashes.
+	// RollingSeedStoreKey is the key that keeps the random value of the latest finalized block, used as the seed for validator sampling.
 	RollingSeedStoreKey = append(GlobalStoreKeyPrefix, []byte("RollingSeed")...)
 	// RequestCountStoreKey is the key that keeps the total request count.
 	RequestCountStoreKey = append(GlobalStoreKeyPrefix, []byte("RequestCount")...)
@@ -43,6 +43,14 @@ var (
 	ReporterStoreKeyPrefix = []byte{0x05}
 	// ValidatorStatusKeyPrefix is the prefix for validator status store.
 	ValidatorStatusKeyPrefix = []byte{0x06}
+	// ReportSuccessRateKeyPrefix is the prefix for validator report success rate store.
+	ReportSuccessRateKeyPrefix = []byte{0x07}
+	// VRFKeyStoreKeyPrefix is the prefix for validator VRF public key store.
+	VRFKeyStoreKeyPrefix = []byte{0x08}
+	// VRFContributionStoreKeyPrefix is the prefix for the VRF outputs contributed in the current block.
+	VRFContributionStoreKeyPrefix = []byte{0x09}
+	// RandomnessStoreKeyPrefix is the prefix for the random value of each block.
+	RandomnessStoreKeyPrefix = []byte{0x0a}
 	// ResultStoreKeyPrefix is the prefix for request result store.
 	ResultStoreKeyPrefix = []byte{0xff}
 )
@@ -79,6 +87,26 @@ func ValidatorStatusStoreKey(v sdk.ValAddress) []byte {
 	return append(ValidatorStatusKeyPrefix, v.Bytes()...)
 }
 
+// ReportSuccessRateStoreKey returns the key to a validator's recent report success rate.
+func ReportSuccessRateStoreKey(v sdk.ValAddress) []byte {
+	return append(ReportSuccessRateKeyPrefix, v.Bytes()...)
+}
+
+// VRFKeyStoreKey returns the key to a validator's VRF public key.
+func VRFKeyStoreKey(v sdk.ValAddress) []byte {
+	return append(VRFKeyStoreKeyPrefix, v.Bytes()...)
+}
+
+// VRFContributionStoreKey returns the key to a validator's VRF output in the current block.
+func VRFContributionStoreKey(v sdk.ValAddress) []byte {
+	return append(VRFContributionStoreKeyPrefix, v.Bytes()...)
+}
+
+// RandomnessStoreKey returns the key to the random value of the block at the given height.
+func RandomnessStoreKey(height int64) []byte {
+	return append(RandomnessStoreKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
 // ResultStoreKey returns the key to a request result in the store.
 func ResultStoreKey(requestID RequestID) []byte {
 	return append(ResultStoreKeyPrefix, sdk.Uint64ToBigEndian(uint64(requestID))...)
@@ -41,6 +41,23 @@ func TestValidatorStatusStoreKey(t *testing.T) {
 	require.Equal(t, expect, ValidatorStatusStoreKey(val))
 }
 
+func TestVRFKeyStoreKey(t *testing.T) {
+	val, _ := sdk.ValAddressFromHex("b80f2a5df7d5710b15622d1a9f1e3830ded5bda8")
+	expect, _ := hex.DecodeString("08b80f2a5df7d5710b15622d1a9f1e3830ded5bda8")
+	require.Equal(t, expect, VRFKeyStoreKey(val))
+}
+
+func TestVRFContributionStoreKey(t *testing.T) {
+	val, _ := sdk.ValAddressFromHex("b80f2a5df7d5710b15622d1a9f1e3830ded5bda8")
+	expect, _ := hex.DecodeString("09b80f2a5df7d5710b15622d1a9f1e3830ded5bda8")
+	require.Equal(t, expect, VRFContributionStoreKey(val))
+}
+
+func TestRandomnessStoreKey(t *testing.T) {
+	expect, _ := hex.DecodeString("0a0000000000000014")
+	require.Equal(t, expect, RandomnessStoreKey(20))
+}
+
 func TestResultStoreKey(t *testing.T) {
 	expect, _ := hex.DecodeString("ff0000000000000014")
 	require.Equal(t, expect, ResultStoreKey(20))
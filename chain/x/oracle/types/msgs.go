@@ -5,11 +5,70 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/obi"
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
 )
 
 // RouterKey is the name of the oracle module
 const RouterKey = ModuleName
 
+// ValidateSchema checks that the given oracle script schema is either empty or a valid OBI
+// "input/output" schema. A strict oracle script must have a schema to validate against.
+func ValidateSchema(schema string, strict bool) error {
+	if schema == "" {
+		if strict {
+			return sdkerrors.Wrap(ErrInvalidSchema, "strict schema requires a non-empty schema")
+		}
+		return nil
+	}
+	input, output, err := obi.ParseOracleScriptSchema(schema)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidSchema, err.Error())
+	}
+	if err := validateSchemaType(input); err != nil {
+		return err
+	}
+	return validateSchemaType(output)
+}
+
+// validateSchemaType checks that data decoded against the given type is bounded by its size.
+// Fixed-length arrays must not exceed MaxSchemaArrayLength, and the elements of vectors and
+// arrays must encode to at least one byte.
+func validateSchemaType(t obi.Type) error {
+	switch t.Kind {
+	case obi.KindVector, obi.KindArray:
+		if t.Kind == obi.KindArray && t.Len > MaxSchemaArrayLength {
+			return sdkerrors.Wrapf(ErrInvalidSchema, "array %s is longer than %d", t, MaxSchemaArrayLength)
+		}
+		if obi.MinSize(*t.Elem) == 0 {
+			return sdkerrors.Wrapf(ErrInvalidSchema, "elements of %s encode to zero bytes", t)
+		}
+		return validateSchemaType(*t.Elem)
+	case obi.KindOption:
+		return validateSchemaType(*t.Elem)
+	case obi.KindStruct:
+		for _, field := range t.Fields {
+			if err := validateSchemaType(field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Apply returns the strict schema flag of an oracle script after the edit, given its current flag.
+func (e StrictSchemaEdit) Apply(strict bool) bool {
+	switch e {
+	case StrictSchemaEdit_Enable:
+		return true
+	case StrictSchemaEdit_Disable:
+		return false
+	default:
+		return strict
+	}
+}
+
 // Route returns the route of MsgRequestData - "oracle" (sdk.Msg interface).
 func (msg MsgRequestData) Route() string { return RouterKey }
 
@@ -33,6 +92,38 @@ func (msg MsgRequestData) ValidateBasic() error {
 	if len(msg.ClientID) > MaxClientIDLength {
 		return WrapMaxError(ErrTooLongClientID, len(msg.ClientID), MaxClientIDLength)
 	}
+	if len(msg.AllowedValidators) > MaxValidatorListLength {
+		return WrapMaxError(ErrTooLongValidatorList, len(msg.AllowedValidators), MaxValidatorListLength)
+	}
+	if len(msg.DeniedValidators) > MaxValidatorListLength {
+		return WrapMaxError(ErrTooLongValidatorList, len(msg.DeniedValidators), MaxValidatorListLength)
+	}
+	if len(msg.AllowedValidators) > 0 && uint64(len(msg.AllowedValidators)) < msg.AskCount {
+		return sdkerrors.Wrapf(ErrInvalidAskCount, "got: %d, allowed validators: %d", msg.AskCount, len(msg.AllowedValidators))
+	}
+	allowed := make(map[string]bool)
+	for _, val := range msg.AllowedValidators {
+		if err := sdk.VerifyAddressFormat(val); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "allowed validator: %s", val)
+		}
+		if allowed[val.String()] {
+			return sdkerrors.Wrapf(ErrInvalidValidatorList, "duplicate allowed validator: %s", val)
+		}
+		allowed[val.String()] = true
+	}
+	denied := make(map[string]bool)
+	for _, val := range msg.DeniedValidators {
+		if err := sdk.VerifyAddressFormat(val); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "denied validator: %s", val)
+		}
+		if denied[val.String()] {
+			return sdkerrors.Wrapf(ErrInvalidValidatorList, "duplicate denied validator: %s", val)
+		}
+		if allowed[val.String()] {
+			return sdkerrors.Wrapf(ErrInvalidValidatorList, "validator both allowed and denied: %s", val)
+		}
+		denied[val.String()] = true
+	}
 	return nil
 }
 
@@ -190,6 +281,9 @@ func (msg MsgCreateOracleScript) ValidateBasic() error {
 	if len(msg.Schema) > MaxSchemaLength {
 		return WrapMaxError(ErrTooLongSchema, len(msg.Schema), MaxSchemaLength)
 	}
+	if err := ValidateSchema(msg.Schema, msg.StrictSchema); err != nil {
+		return err
+	}
 	if len(msg.SourceCodeURL) > MaxURLLength {
 		return WrapMaxError(ErrTooLongURL, len(msg.SourceCodeURL), MaxURLLength)
 	}
@@ -238,6 +332,14 @@ func (msg MsgEditOracleScript) ValidateBasic() error {
 	if len(msg.Schema) > MaxSchemaLength {
 		return WrapMaxError(ErrTooLongSchema, len(msg.Schema), MaxSchemaLength)
 	}
+	if _, ok := StrictSchemaEdit_name[int32(msg.StrictSchema)]; !ok {
+		return sdkerrors.Wrapf(ErrInvalidStrictSchemaEdit, "got: %d", msg.StrictSchema)
+	}
+	if msg.Schema != DoNotModify {
+		if err := ValidateSchema(msg.Schema, msg.StrictSchema == StrictSchemaEdit_Enable); err != nil {
+			return err
+		}
+	}
 	if len(msg.SourceCodeURL) > MaxURLLength {
 		return WrapMaxError(ErrTooLongURL, len(msg.SourceCodeURL), MaxURLLength)
 	}
@@ -343,3 +445,66 @@ func (msg MsgRemoveReporter) GetSigners() []sdk.AccAddress {
 func (msg MsgRemoveReporter) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
 }
+
+// Route returns the route of MsgSetVRFKey - "oracle" (sdk.Msg interface).
+func (msg MsgSetVRFKey) Route() string { return RouterKey }
+
+// Type returns the message type of MsgSetVRFKey (sdk.Msg interface).
+func (msg MsgSetVRFKey) Type() string { return "set_vrf_key" }
+
+// ValidateBasic checks whether the given MsgSetVRFKey instance (sdk.Msg interface).
+func (msg MsgSetVRFKey) ValidateBasic() error {
+	if err := sdk.VerifyAddressFormat(msg.Validator); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "validator: %s", msg.Validator)
+	}
+	if err := vrf.ValidatePublicKey(msg.PublicKey); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidVRFKey, err.Error())
+	}
+	return nil
+}
+
+// GetSigners returns the required signers for the given MsgSetVRFKey (sdk.Msg interface).
+func (msg MsgSetVRFKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Validator)}
+}
+
+// GetSignBytes returns raw JSON bytes to be signed by the signers (sdk.Msg interface).
+func (msg MsgSetVRFKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// Route returns the route of MsgSubmitVRF - "oracle" (sdk.Msg interface).
+func (msg MsgSubmitVRF) Route() string { return RouterKey }
+
+// Type returns the message type of MsgSubmitVRF (sdk.Msg interface).
+func (msg MsgSubmitVRF) Type() string { return "submit_vrf" }
+
+// ValidateBasic checks whether the given MsgSubmitVRF instance (sdk.Msg interface).
+func (msg MsgSubmitVRF) ValidateBasic() error {
+	if err := sdk.VerifyAddressFormat(msg.Validator); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "validator: %s", msg.Validator)
+	}
+	if err := sdk.VerifyAddressFormat(msg.Reporter); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "reporter: %s", msg.Reporter)
+	}
+	if msg.Height <= 0 {
+		return sdkerrors.Wrapf(ErrInvalidVRFHeight, "height: %d", msg.Height)
+	}
+	if len(msg.Output) != vrf.OutputSize {
+		return sdkerrors.Wrapf(ErrInvalidVRF, "output size: %d", len(msg.Output))
+	}
+	if len(msg.Proof) != vrf.ProofSize {
+		return sdkerrors.Wrapf(ErrInvalidVRF, "proof size: %d", len(msg.Proof))
+	}
+	return nil
+}
+
+// GetSigners returns the required signers for the given MsgSubmitVRF (sdk.Msg interface).
+func (msg MsgSubmitVRF) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Reporter}
+}
+
+// GetSignBytes returns raw JSON bytes to be signed by the signers (sdk.Msg interface).
+func (msg MsgSubmitVRF) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
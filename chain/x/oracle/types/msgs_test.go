@@ -7,6 +7,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/bandprotocol/bandchain/chain/pkg/vrf"
 )
 
 var (
@@ -46,6 +48,8 @@ func TestMsgRoute(t *testing.T) {
 	require.Equal(t, "oracle", MsgActivate{}.Route())
 	require.Equal(t, "oracle", MsgAddReporter{}.Route())
 	require.Equal(t, "oracle", MsgRemoveReporter{}.Route())
+	require.Equal(t, "oracle", MsgSetVRFKey{}.Route())
+	require.Equal(t, "oracle", MsgSubmitVRF{}.Route())
 }
 
 func TestMsgType(t *testing.T) {
@@ -58,6 +62,8 @@ func TestMsgType(t *testing.T) {
 	require.Equal(t, "activate", MsgActivate{}.Type())
 	require.Equal(t, "add_reporter", MsgAddReporter{}.Type())
 	require.Equal(t, "remove_reporter", MsgRemoveReporter{}.Type())
+	require.Equal(t, "set_vrf_key", MsgSetVRFKey{}.Type())
+	require.Equal(t, "submit_vrf", MsgSubmitVRF{}.Type())
 }
 
 func TestMsgGetSigners(t *testing.T) {
@@ -68,13 +74,15 @@ func TestMsgGetSigners(t *testing.T) {
 	signers := []sdk.AccAddress{signerAcc}
 	require.Equal(t, signers, NewMsgCreateDataSource(anotherAcc, "name", "desc", []byte("exec"), signerAcc).GetSigners())
 	require.Equal(t, signers, NewMsgEditDataSource(1, anotherAcc, "name", "desc", []byte("exec"), signerAcc).GetSigners())
-	require.Equal(t, signers, NewMsgCreateOracleScript(anotherAcc, "name", "desc", []byte("code"), "schema", "url", signerAcc).GetSigners())
-	require.Equal(t, signers, NewMsgEditOracleScript(1, anotherAcc, "name", "desc", []byte("code"), "schema", "url", signerAcc).GetSigners())
-	require.Equal(t, signers, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", signerAcc).GetSigners())
+	require.Equal(t, signers, NewMsgCreateOracleScript(anotherAcc, "name", "desc", []byte("code"), "schema", "url", signerAcc, false).GetSigners())
+	require.Equal(t, signers, NewMsgEditOracleScript(1, anotherAcc, "name", "desc", []byte("code"), "schema", "url", signerAcc, StrictSchemaEdit_Keep).GetSigners())
+	require.Equal(t, signers, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", signerAcc, nil, nil).GetSigners())
 	require.Equal(t, signers, NewMsgReportData(1, []RawReport{{1, 1, []byte("data1")}, {2, 2, []byte("data2")}}, anotherVal, signerAcc).GetSigners())
 	require.Equal(t, signers, NewMsgActivate(signerVal).GetSigners())
 	require.Equal(t, signers, NewMsgAddReporter(signerVal, anotherAcc).GetSigners())
 	require.Equal(t, signers, NewMsgRemoveReporter(signerVal, anotherAcc).GetSigners())
+	require.Equal(t, signers, NewMsgSetVRFKey(signerVal, []byte("key")).GetSigners())
+	require.Equal(t, signers, NewMsgSubmitVRF(1, []byte("output"), []byte("proof"), anotherVal, signerAcc).GetSigners())
 }
 
 func TestMsgGetSignBytes(t *testing.T) {
@@ -91,15 +99,19 @@ func TestMsgGetSignBytes(t *testing.T) {
 	)
 	require.Equal(t,
 		`{"type":"oracle/CreateOracleScript","value":{"code":"Y29kZQ==","description":"desc","name":"name","owner":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","schema":"schema","sender":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","source_code_url":"url"}}`,
-		string(NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr).GetSignBytes()),
+		string(NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr, false).GetSignBytes()),
 	)
 	require.Equal(t,
 		`{"type":"oracle/EditOracleScript","value":{"code":"Y29kZQ==","description":"desc","name":"name","oracle_script_id":"1","owner":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","schema":"schema","sender":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","source_code_url":"url"}}`,
-		string(NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr).GetSignBytes()),
+		string(NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr, StrictSchemaEdit_Keep).GetSignBytes()),
+	)
+	require.Equal(t,
+		`{"type":"oracle/EditOracleScript","value":{"code":"Y29kZQ==","description":"desc","name":"name","oracle_script_id":"1","owner":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","schema":"schema","sender":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","source_code_url":"url","strict_schema":1}}`,
+		string(NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr, StrictSchemaEdit_Enable).GetSignBytes()),
 	)
 	require.Equal(t,
 		`{"type":"oracle/Request","value":{"ask_count":"10","calldata":"Y2FsbGRhdGE=","client_id":"client-id","min_count":"5","oracle_script_id":"1","sender":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4"}}`,
-		string(NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", GoodTestAddr).GetSignBytes()),
+		string(NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", GoodTestAddr, nil, nil).GetSignBytes()),
 	)
 	require.Equal(t,
 		`{"type":"oracle/Report","value":{"raw_reports":[{"data":"ZGF0YTE=","exit_code":1,"external_id":"1"},{"data":"ZGF0YTI=","exit_code":2,"external_id":"2"}],"reporter":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","request_id":"1","validator":"bandvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqx6y767"}}`,
@@ -117,6 +129,14 @@ func TestMsgGetSignBytes(t *testing.T) {
 		`{"type":"oracle/RemoveReporter","value":{"reporter":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","validator":"bandvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqx6y767"}}`,
 		string(NewMsgRemoveReporter(GoodTestValAddr, GoodTestAddr).GetSignBytes()),
 	)
+	require.Equal(t,
+		`{"type":"oracle/SetVRFKey","value":{"public_key":"a2V5","validator":"bandvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqx6y767"}}`,
+		string(NewMsgSetVRFKey(GoodTestValAddr, []byte("key")).GetSignBytes()),
+	)
+	require.Equal(t,
+		`{"type":"oracle/SubmitVRF","value":{"height":"1","output":"b3V0cHV0","proof":"cHJvb2Y=","reporter":"band1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq2vqal4","validator":"bandvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqx6y767"}}`,
+		string(NewMsgSubmitVRF(1, []byte("output"), []byte("proof"), GoodTestValAddr, GoodTestAddr).GetSignBytes()),
+	)
 }
 
 func TestMsgCreateDataSourceValidation(t *testing.T) {
@@ -145,42 +165,99 @@ func TestMsgEditDataSourceValidation(t *testing.T) {
 }
 
 func TestMsgCreateOracleScriptValidation(t *testing.T) {
+	schema := "{symbol:string}/{px:u64}"
 	performValidateTests(t, []validateTestCase{
-		{true, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(BadTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, strings.Repeat("x", 200), "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", strings.Repeat("x", 5000), []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), strings.Repeat("x", 1000), "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", strings.Repeat("x", 200), GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte{}, "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte(strings.Repeat("x", 600000)), "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", DoNotModifyBytes, "schema", "url", GoodTestAddr)},
-		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", BadTestAddr)},
+		{true, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(BadTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, strings.Repeat("x", 200), "desc", []byte("code"), schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", strings.Repeat("x", 5000), []byte("code"), schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), strings.Repeat("x", 1000), "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), schema, strings.Repeat("x", 200), GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte{}, schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte(strings.Repeat("x", 600000)), schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", DoNotModifyBytes, schema, "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), schema, "url", BadTestAddr, false)},
+		{true, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "", "url", GoodTestAddr, false)},
+		{true, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, true)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "", "url", GoodTestAddr, true)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr, false)},
+		{false, NewMsgCreateOracleScript(GoodTestAddr, "name", "desc", []byte("code"), "{a:u8}/{b:float}", "url", GoodTestAddr, false)},
 	})
 }
 
+func TestValidateSchemaBoundedTypes(t *testing.T) {
+	for _, schema := range []string{
+		"{a:[u8;256]}/{x:u8}",
+		"{a:[u8;0]}/{x:u8}",
+		"{a:[{b:u8}]}/{x:?[u8;2]}",
+		"{a:{}}/{x:u8}",
+	} {
+		require.NoError(t, ValidateSchema(schema, true), schema)
+	}
+	for _, schema := range []string{
+		"{a:[u8;257]}/{x:u8}",
+		"{a:[u8;2000000000]}/{x:u8}",
+		"{a:[{}]}/{x:u8}",
+		"{a:u8}/{x:[[u8;0]]}",
+		"{a:[{}];1]}/{x:u8}",
+		"{a:[[u8;0];1]}/{x:u8}",
+		"{a:u8}/{x:{y:?[{z:[{}]}]}}",
+	} {
+		require.Error(t, ValidateSchema(schema, true), schema)
+		require.Error(t, ValidateSchema(schema, false), schema)
+	}
+}
+
 func TestMsgEditOracleScriptValidation(t *testing.T) {
+	schema := "{symbol:string}/{px:u64}"
 	performValidateTests(t, []validateTestCase{
-		{true, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, BadTestAddr, "name", "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, strings.Repeat("x", 200), "desc", []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", strings.Repeat("x", 5000), []byte("code"), "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), strings.Repeat("x", 1000), "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", strings.Repeat("x", 200), GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte{}, "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte(strings.Repeat("x", 600000)), "schema", "url", GoodTestAddr)},
-		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "schema", "url", BadTestAddr)},
+		{true, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, BadTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, strings.Repeat("x", 200), "desc", []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", strings.Repeat("x", 5000), []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), strings.Repeat("x", 1000), "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), schema, strings.Repeat("x", 200), GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte{}, schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte(strings.Repeat("x", 600000)), schema, "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), schema, "url", BadTestAddr, StrictSchemaEdit_Keep)},
+		{true, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), DoNotModify, "url", GoodTestAddr, StrictSchemaEdit_Enable)},
+		{true, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit_Enable)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "", "url", GoodTestAddr, StrictSchemaEdit_Enable)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "{a:u8}", "url", GoodTestAddr, StrictSchemaEdit_Keep)},
+		{true, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), "", "url", GoodTestAddr, StrictSchemaEdit_Disable)},
+		{false, NewMsgEditOracleScript(1, GoodTestAddr, "name", "desc", []byte("code"), schema, "url", GoodTestAddr, StrictSchemaEdit(3))},
 	})
 }
 
 func TestMsgRequestDataValidation(t *testing.T) {
 	performValidateTests(t, []validateTestCase{
-		{true, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", GoodTestAddr)},
-		{false, NewMsgRequestData(1, []byte(strings.Repeat("x", 2000)), 10, 5, "client-id", GoodTestAddr)},
-		{false, NewMsgRequestData(1, []byte("calldata"), 2, 5, "client-id", GoodTestAddr)},
-		{false, NewMsgRequestData(1, []byte("calldata"), 0, 0, "client-id", GoodTestAddr)},
-		{false, NewMsgRequestData(1, []byte("calldata"), 10, 5, strings.Repeat("x", 300), GoodTestAddr)},
-		{false, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", BadTestAddr)},
+		{true, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", GoodTestAddr, nil, nil)},
+		{false, NewMsgRequestData(1, []byte(strings.Repeat("x", 2000)), 10, 5, "client-id", GoodTestAddr, nil, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 2, 5, "client-id", GoodTestAddr, nil, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 0, 0, "client-id", GoodTestAddr, nil, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 10, 5, strings.Repeat("x", 300), GoodTestAddr, nil, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 10, 5, "client-id", BadTestAddr, nil, nil)},
+	})
+}
+
+func TestMsgRequestDataValidatorListsValidation(t *testing.T) {
+	vals := []sdk.ValAddress{GoodTestValAddr, GoodTestValAddr2}
+	tooLong := make([]sdk.ValAddress, MaxValidatorListLength+1)
+	for idx := range tooLong {
+		tooLong[idx] = sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	}
+	performValidateTests(t, []validateTestCase{
+		{true, NewMsgRequestData(1, []byte("calldata"), 2, 1, "client-id", GoodTestAddr, vals, nil)},
+		{true, NewMsgRequestData(1, []byte("calldata"), 2, 1, "client-id", GoodTestAddr, nil, vals)},
+		{true, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, vals[:1], vals[1:])},
+		{false, NewMsgRequestData(1, []byte("calldata"), 3, 1, "client-id", GoodTestAddr, vals, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, []sdk.ValAddress{BadTestValAddr}, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, nil, []sdk.ValAddress{BadTestValAddr})},
+		{false, NewMsgRequestData(1, []byte("calldata"), 2, 1, "client-id", GoodTestAddr, []sdk.ValAddress{GoodTestValAddr, GoodTestValAddr}, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, nil, []sdk.ValAddress{GoodTestValAddr, GoodTestValAddr})},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, vals, vals[1:])},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, tooLong, nil)},
+		{false, NewMsgRequestData(1, []byte("calldata"), 1, 1, "client-id", GoodTestAddr, nil, tooLong)},
 	})
 }
 
@@ -219,3 +296,29 @@ func TestMsgRemoveReporterValidation(t *testing.T) {
 		{false, NewMsgRemoveReporter(GoodTestValAddr, GoodTestAddr)},
 	})
 }
+
+func TestMsgSetVRFKeyValidation(t *testing.T) {
+	key, err := vrf.GenerateKey()
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	performValidateTests(t, []validateTestCase{
+		{true, NewMsgSetVRFKey(GoodTestValAddr, pubKey)},
+		{false, NewMsgSetVRFKey(BadTestValAddr, pubKey)},
+		{false, NewMsgSetVRFKey(GoodTestValAddr, pubKey[:31])},
+		{false, NewMsgSetVRFKey(GoodTestValAddr, nil)},
+	})
+}
+
+func TestMsgSubmitVRFValidation(t *testing.T) {
+	output := make([]byte, vrf.OutputSize)
+	proof := make([]byte, vrf.ProofSize)
+	performValidateTests(t, []validateTestCase{
+		{true, NewMsgSubmitVRF(1, output, proof, GoodTestValAddr, GoodTestAddr)},
+		{false, NewMsgSubmitVRF(0, output, proof, GoodTestValAddr, GoodTestAddr)},
+		{false, NewMsgSubmitVRF(1, output[:31], proof, GoodTestValAddr, GoodTestAddr)},
+		{false, NewMsgSubmitVRF(1, output, proof[:63], GoodTestValAddr, GoodTestAddr)},
+		{false, NewMsgSubmitVRF(1, output, proof, BadTestValAddr, GoodTestAddr)},
+		{false, NewMsgSubmitVRF(1, output, proof, GoodTestValAddr, BadTestAddr)},
+	})
+}
@@ -21,6 +21,8 @@ const (
 	DefaultSamplingTryCount        = uint64(3)
 	DefaultOracleRewardPercentage  = uint64(70)
 	DefaultInactivePenaltyDuration = uint64(10 * time.Minute)
+	DefaultSamplingMethod          = uint64(SamplingStakeWeighted)
+	DefaultRandomnessFallback      = uint64(RandomnessFallbackNone)
 )
 
 // nolint
@@ -35,6 +37,8 @@ var (
 	KeySamplingTryCount        = []byte("SamplingTryCount")
 	KeyOracleRewardPercentage  = []byte("OracleRewardPercentage")
 	KeyInactivePenaltyDuration = []byte("InactivePenaltyDuration")
+	KeySamplingMethod          = []byte("SamplingMethod")
+	KeyRandomnessFallback      = []byte("RandomnessFallback")
 )
 
 // String implements the stringer interface for Params.
@@ -48,6 +52,8 @@ func (p Params) String() string {
   SamplingTryCount:        %d
   OracleRewardPercentage:  %d
   InactivePenaltyDuration: %d
+  SamplingMethod:          %s
+  RandomnessFallback:      %s
 `,
 		p.MaxRawRequestCount,
 		p.MaxAskCount,
@@ -57,6 +63,8 @@ func (p Params) String() string {
 		p.SamplingTryCount,
 		p.OracleRewardPercentage,
 		p.InactivePenaltyDuration,
+		SamplingMethod(p.SamplingMethod),
+		RandomnessFallback(p.RandomnessFallback),
 	)
 }
 
@@ -71,6 +79,8 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeySamplingTryCount, &p.SamplingTryCount, validateUint64("sampling try count", true)),
 		params.NewParamSetPair(KeyOracleRewardPercentage, &p.OracleRewardPercentage, validateUint64("oracle reward percentage", false)),
 		params.NewParamSetPair(KeyInactivePenaltyDuration, &p.InactivePenaltyDuration, validateUint64("inactive penalty duration", false)),
+		params.NewParamSetPair(KeySamplingMethod, &p.SamplingMethod, validateSamplingMethod),
+		params.NewParamSetPair(KeyRandomnessFallback, &p.RandomnessFallback, validateRandomnessFallback),
 	}
 }
 
@@ -85,6 +95,8 @@ func DefaultParams() Params {
 		DefaultSamplingTryCount,
 		DefaultOracleRewardPercentage,
 		DefaultInactivePenaltyDuration,
+		DefaultSamplingMethod,
+		DefaultRandomnessFallback,
 	)
 }
 
@@ -20,6 +20,7 @@ const (
 	QueryReporters        = "reporters"
 	QueryActiveValidators = "active_validators"
 	QueryPendingRequests  = "pending_requests"
+	QueryRandomness       = "randomness"
 )
 
 // QueryResult wraps querier result with HTTP status to return to application.
@@ -66,6 +67,12 @@ type QueryRequestResult struct {
 	Result  *Result  `json:"result"`
 }
 
+// QueryRandomnessResult is the struct for the result of randomness query.
+type QueryRandomnessResult struct {
+	Height      int64  `json:"height"`
+	RandomValue []byte `json:"random_value"`
+}
+
 // QueryActiveValidatorResult is the struct for the result of request active validators.
 type QueryActiveValidatorResult struct {
 	Address sdk.ValAddress `json:"address"`
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VRFKeyActivationDelay is the number of blocks after setting a VRF key before the validator
+// can contribute to the randomness beacon with it. The key is thus fixed before the proposer of
+// the previous block, who can see the contributions to come, decides the beacon input. Without
+// the delay, a validator could try many keys and set the one with the most favorable output.
+const VRFKeyActivationDelay = 2
+
+// VRFInput returns the message that validators prove with their VRF keys to contribute to the
+// randomness beacon of the block at the given height, which extends the random value of the
+// previous block.
+func VRFInput(chainID string, height int64, prevRandomValue []byte) []byte {
+	input := append([]byte(chainID), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(input, prevRandomValue...)
+}
+
+// RandomnessFallback is what oracle scripts get when they read the random value of a request
+// whose block has no VRF contributions. It is set by the RandomnessFallback governance parameter.
+type RandomnessFallback uint64
+
+const (
+	// RandomnessFallbackNone makes reading the random value fail, which fails the request.
+	RandomnessFallbackNone RandomnessFallback = iota
+	// RandomnessFallbackRollingSeed gives the rolling seed at resolve time instead. Blocks without
+	// VRF contributions extend the rolling seed with their previous block hash, so the proposers
+	// of those blocks can influence this value.
+	RandomnessFallbackRollingSeed
+)
+
+// IsValid returns whether the randomness fallback is one of the supported options.
+func (f RandomnessFallback) IsValid() bool {
+	return f <= RandomnessFallbackRollingSeed
+}
+
+// String implements the stringer interface for RandomnessFallback.
+func (f RandomnessFallback) String() string {
+	switch f {
+	case RandomnessFallbackNone:
+		return "none"
+	case RandomnessFallbackRollingSeed:
+		return "rolling-seed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint64(f))
+	}
+}
+
+func validateRandomnessFallback(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if !RandomnessFallback(v).IsValid() {
+		return fmt.Errorf("unknown randomness fallback: %d", v)
+	}
+	return nil
+}
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 var (
 	_ RequestSpec = &MsgRequestData{}
 	_ RequestSpec = &OracleRequestPacketData{}
@@ -12,4 +16,18 @@ type RequestSpec interface {
 	GetAskCount() uint64
 	GetMinCount() uint64
 	GetClientID() string
+	GetAllowedValidators() []sdk.ValAddress
+	GetDeniedValidators() []sdk.ValAddress
+}
+
+// GetAllowedValidators implements RequestSpec. Requests from IBC cannot restrict validators, so
+// any active validator may be sampled.
+func (p *OracleRequestPacketData) GetAllowedValidators() []sdk.ValAddress {
+	return nil
+}
+
+// GetDeniedValidators implements RequestSpec. Requests from IBC cannot restrict validators, so
+// no validator is denied.
+func (p *OracleRequestPacketData) GetDeniedValidators() []sdk.ValAddress {
+	return nil
 }
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SamplingMethod is the method used to pick the validators that perform an oracle request. It is
+// set by the SamplingMethod governance parameter.
+type SamplingMethod uint64
+
+const (
+	// SamplingStakeWeighted picks validators with probability proportional to their bonded stake,
+	// keeping the subset with the highest total stake among SamplingTryCount tries.
+	SamplingStakeWeighted SamplingMethod = iota
+	// SamplingUniform picks validators with equal probability regardless of their stake.
+	SamplingUniform
+	// SamplingReputationWeighted is like SamplingStakeWeighted, but each validator's stake is
+	// scaled by its recent report success rate.
+	SamplingReputationWeighted
+)
+
+// ReportSuccessRatePrecision is the value of a perfect report success rate. Each report or miss
+// moves a validator's rate 1/ReportSuccessRateWindow of the way towards this value or zero.
+const (
+	ReportSuccessRatePrecision = uint64(1000000)
+	ReportSuccessRateWindow    = uint64(20)
+)
+
+// IsValid returns whether the sampling method is one of the supported methods.
+func (m SamplingMethod) IsValid() bool {
+	return m <= SamplingReputationWeighted
+}
+
+// String implements the stringer interface for SamplingMethod.
+func (m SamplingMethod) String() string {
+	switch m {
+	case SamplingStakeWeighted:
+		return "stake-weighted"
+	case SamplingUniform:
+		return "uniform"
+	case SamplingReputationWeighted:
+		return "reputation-weighted"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint64(m))
+	}
+}
+
+func validateSamplingMethod(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if !SamplingMethod(v).IsValid() {
+		return fmt.Errorf("unknown sampling method: %d", v)
+	}
+	return nil
+}
+
+// ReportSuccessRate is the recent report success rate of a validator, out of
+// ReportSuccessRatePrecision.
+type ReportSuccessRate struct {
+	Validator sdk.ValAddress `json:"validator" yaml:"validator"`
+	Rate      uint64         `json:"rate" yaml:"rate"`
+}
+
+// NewReportSuccessRate creates a new ReportSuccessRate instance.
+func NewReportSuccessRate(validator sdk.ValAddress, rate uint64) ReportSuccessRate {
+	return ReportSuccessRate{
+		Validator: validator,
+		Rate:      rate,
+	}
+}
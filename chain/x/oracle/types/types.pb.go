@@ -29,6 +29,38 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// StrictSchemaEdit encodes how MsgEditOracleScript changes the strict schema flag.
+type StrictSchemaEdit int32
+
+const (
+	// Keep - the strict schema flag is not modified.
+	StrictSchemaEdit_Keep StrictSchemaEdit = 0
+	// Enable - request calldata and results must match the schema.
+	StrictSchemaEdit_Enable StrictSchemaEdit = 1
+	// Disable - request calldata and results are not checked against the schema.
+	StrictSchemaEdit_Disable StrictSchemaEdit = 2
+)
+
+var StrictSchemaEdit_name = map[int32]string{
+	0: "Keep",
+	1: "Enable",
+	2: "Disable",
+}
+
+var StrictSchemaEdit_value = map[string]int32{
+	"Keep":    0,
+	"Enable":  1,
+	"Disable": 2,
+}
+
+func (x StrictSchemaEdit) String() string {
+	return proto.EnumName(StrictSchemaEdit_name, int32(x))
+}
+
+func (StrictSchemaEdit) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_53e65fd95a58412c, []int{0}
+}
+
 // ResolveStatus encodes the status of an oracle request.
 type ResolveStatus int32
 
@@ -62,7 +94,7 @@ func (x ResolveStatus) String() string {
 }
 
 func (ResolveStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{0}
+	return fileDescriptor_53e65fd95a58412c, []int{1}
 }
 
 // MsgRequestData is a message for sending a data oracle request.
@@ -79,6 +111,12 @@ type MsgRequestData struct {
 	ClientID string `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	// Sender is the sender of this message.
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,6,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender,omitempty"`
+	// AllowedValidators, if not empty, is the list of the only validators that may be sampled
+	// to perform the oracle task.
+	AllowedValidators []github_com_cosmos_cosmos_sdk_types.ValAddress `protobuf:"bytes,7,rep,name=allowed_validators,json=allowedValidators,proto3,casttype=github.com/cosmos/cosmos-sdk/types.ValAddress" json:"allowed_validators,omitempty"`
+	// DeniedValidators is the list of validators that must not be sampled to perform the oracle
+	// task.
+	DeniedValidators []github_com_cosmos_cosmos_sdk_types.ValAddress `protobuf:"bytes,8,rep,name=denied_validators,json=deniedValidators,proto3,casttype=github.com/cosmos/cosmos-sdk/types.ValAddress" json:"denied_validators,omitempty"`
 }
 
 func (m *MsgRequestData) Reset()         { *m = MsgRequestData{} }
@@ -156,6 +194,20 @@ func (m *MsgRequestData) GetSender() github_com_cosmos_cosmos_sdk_types.AccAddre
 	return nil
 }
 
+func (m *MsgRequestData) GetAllowedValidators() []github_com_cosmos_cosmos_sdk_types.ValAddress {
+	if m != nil {
+		return m.AllowedValidators
+	}
+	return nil
+}
+
+func (m *MsgRequestData) GetDeniedValidators() []github_com_cosmos_cosmos_sdk_types.ValAddress {
+	if m != nil {
+		return m.DeniedValidators
+	}
+	return nil
+}
+
 // MsgReportData is a message for reporting to a data request by a validator.
 type MsgReportData struct {
 	// RequestID is the identifier of the request to report to.
@@ -418,6 +470,8 @@ type MsgCreateOracleScript struct {
 	SourceCodeURL string `protobuf:"bytes,6,opt,name=source_code_url,json=sourceCodeUrl,proto3" json:"source_code_url,omitempty"`
 	// Sender is the signer of this message.
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,7,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender,omitempty"`
+	// StrictSchema enforces that request calldata and results match the schema.
+	StrictSchema bool `protobuf:"varint,8,opt,name=strict_schema,json=strictSchema,proto3" json:"strict_schema,omitempty"`
 }
 
 func (m *MsgCreateOracleScript) Reset()         { *m = MsgCreateOracleScript{} }
@@ -502,6 +556,13 @@ func (m *MsgCreateOracleScript) GetSender() github_com_cosmos_cosmos_sdk_types.A
 	return nil
 }
 
+func (m *MsgCreateOracleScript) GetStrictSchema() bool {
+	if m != nil {
+		return m.StrictSchema
+	}
+	return false
+}
+
 // MsgEditOracleScript is a message for editing an existing oracle script.
 type MsgEditOracleScript struct {
 	// OracleScriptID is the unique identifier of the oracle script to be edited.
@@ -520,6 +581,9 @@ type MsgEditOracleScript struct {
 	SourceCodeURL string `protobuf:"bytes,7,opt,name=source_code_url,json=sourceCodeUrl,proto3" json:"source_code_url,omitempty"`
 	// Sender is the signer of this message. Must be the current oracle script's owner.
 	Sender github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,8,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender,omitempty"`
+	// StrictSchema sets whether request calldata and results must match the schema, or keeps the
+	// current setting.
+	StrictSchema StrictSchemaEdit `protobuf:"varint,9,opt,name=strict_schema,json=strictSchema,proto3,enum=bandchain.chain.x.oracle.v1.StrictSchemaEdit" json:"strict_schema,omitempty"`
 }
 
 func (m *MsgEditOracleScript) Reset()         { *m = MsgEditOracleScript{} }
@@ -611,6 +675,13 @@ func (m *MsgEditOracleScript) GetSender() github_com_cosmos_cosmos_sdk_types.Acc
 	return nil
 }
 
+func (m *MsgEditOracleScript) GetStrictSchema() StrictSchemaEdit {
+	if m != nil {
+		return m.StrictSchema
+	}
+	return StrictSchemaEdit_Keep
+}
+
 // MsgEditOracleScript is a message for activating a validator to become an oracle provider.
 type MsgActivate struct {
 	// Validator is the signer of this message and the validator to be activated.
@@ -767,6 +838,145 @@ func (m *MsgRemoveReporter) GetReporter() github_com_cosmos_cosmos_sdk_types.Acc
 	return nil
 }
 
+// MsgSetVRFKey is a message for setting the public key that a validator uses to verify its
+// contributions to the randomness beacon.
+type MsgSetVRFKey struct {
+	// Validator is the validator that wishes to set its VRF public key. This is the signer.
+	Validator github_com_cosmos_cosmos_sdk_types.ValAddress `protobuf:"bytes,1,opt,name=validator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.ValAddress" json:"validator,omitempty"`
+	// PublicKey is the VRF public key of the validator.
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (m *MsgSetVRFKey) Reset()         { *m = MsgSetVRFKey{} }
+func (m *MsgSetVRFKey) String() string { return proto.CompactTextString(m) }
+func (*MsgSetVRFKey) ProtoMessage()    {}
+func (*MsgSetVRFKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_53e65fd95a58412c, []int{9}
+}
+func (m *MsgSetVRFKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgSetVRFKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgSetVRFKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgSetVRFKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgSetVRFKey.Merge(m, src)
+}
+func (m *MsgSetVRFKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgSetVRFKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgSetVRFKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgSetVRFKey proto.InternalMessageInfo
+
+func (m *MsgSetVRFKey) GetValidator() github_com_cosmos_cosmos_sdk_types.ValAddress {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *MsgSetVRFKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// MsgSubmitVRF is a message for contributing a VRF output to the randomness beacon of a block.
+type MsgSubmitVRF struct {
+	// Height is the block height of the beacon to contribute to, which must be the height of the
+	// block that includes this message.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Output is the VRF output of the validator on the beacon input of the block.
+	Output []byte `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// Proof is the VRF proof that Output is computed with the validator's VRF private key.
+	Proof []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Validator is the address of the validator that owns this contribution.
+	Validator github_com_cosmos_cosmos_sdk_types.ValAddress `protobuf:"bytes,4,opt,name=validator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.ValAddress" json:"validator,omitempty"`
+	// Reporter is the message signer who submits this contribution for the validator.
+	Reporter github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,5,opt,name=reporter,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"reporter,omitempty"`
+}
+
+func (m *MsgSubmitVRF) Reset()         { *m = MsgSubmitVRF{} }
+func (m *MsgSubmitVRF) String() string { return proto.CompactTextString(m) }
+func (*MsgSubmitVRF) ProtoMessage()    {}
+func (*MsgSubmitVRF) Descriptor() ([]byte, []int) {
+	return fileDescriptor_53e65fd95a58412c, []int{10}
+}
+func (m *MsgSubmitVRF) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgSubmitVRF) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgSubmitVRF.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgSubmitVRF) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgSubmitVRF.Merge(m, src)
+}
+func (m *MsgSubmitVRF) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgSubmitVRF) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgSubmitVRF.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgSubmitVRF proto.InternalMessageInfo
+
+func (m *MsgSubmitVRF) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *MsgSubmitVRF) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *MsgSubmitVRF) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *MsgSubmitVRF) GetValidator() github_com_cosmos_cosmos_sdk_types.ValAddress {
+	if m != nil {
+		return m.Validator
+	}
+	return nil
+}
+
+func (m *MsgSubmitVRF) GetReporter() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Reporter
+	}
+	return nil
+}
+
 // DataSource is the data structure for storing data sources in the storage.
 type DataSource struct {
 	Owner       github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,1,opt,name=owner,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"owner,omitempty"`
@@ -779,7 +989,7 @@ func (m *DataSource) Reset()         { *m = DataSource{} }
 func (m *DataSource) String() string { return proto.CompactTextString(m) }
 func (*DataSource) ProtoMessage()    {}
 func (*DataSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{9}
+	return fileDescriptor_53e65fd95a58412c, []int{11}
 }
 func (m *DataSource) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -844,13 +1054,14 @@ type OracleScript struct {
 	Filename      string                                        `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
 	Schema        string                                        `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
 	SourceCodeURL string                                        `protobuf:"bytes,6,opt,name=source_code_url,json=sourceCodeUrl,proto3" json:"source_code_url,omitempty"`
+	StrictSchema  bool                                          `protobuf:"varint,7,opt,name=strict_schema,json=strictSchema,proto3" json:"strict_schema,omitempty"`
 }
 
 func (m *OracleScript) Reset()         { *m = OracleScript{} }
 func (m *OracleScript) String() string { return proto.CompactTextString(m) }
 func (*OracleScript) ProtoMessage()    {}
 func (*OracleScript) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{10}
+	return fileDescriptor_53e65fd95a58412c, []int{12}
 }
 func (m *OracleScript) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -921,6 +1132,13 @@ func (m *OracleScript) GetSourceCodeURL() string {
 	return ""
 }
 
+func (m *OracleScript) GetStrictSchema() bool {
+	if m != nil {
+		return m.StrictSchema
+	}
+	return false
+}
+
 // RawRequest is the data structure for storing raw requests in the storage.
 type RawRequest struct {
 	ExternalID   ExternalID   `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3,casttype=ExternalID" json:"external_id,omitempty"`
@@ -932,7 +1150,7 @@ func (m *RawRequest) Reset()         { *m = RawRequest{} }
 func (m *RawRequest) String() string { return proto.CompactTextString(m) }
 func (*RawRequest) ProtoMessage()    {}
 func (*RawRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{11}
+	return fileDescriptor_53e65fd95a58412c, []int{13}
 }
 func (m *RawRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -993,7 +1211,7 @@ func (m *RawReport) Reset()         { *m = RawReport{} }
 func (m *RawReport) String() string { return proto.CompactTextString(m) }
 func (*RawReport) ProtoMessage()    {}
 func (*RawReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{12}
+	return fileDescriptor_53e65fd95a58412c, []int{14}
 }
 func (m *RawReport) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1059,7 +1277,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{13}
+	return fileDescriptor_53e65fd95a58412c, []int{15}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1155,7 +1373,7 @@ func (m *Report) Reset()         { *m = Report{} }
 func (m *Report) String() string { return proto.CompactTextString(m) }
 func (*Report) ProtoMessage()    {}
 func (*Report) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{14}
+	return fileDescriptor_53e65fd95a58412c, []int{16}
 }
 func (m *Report) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1226,7 +1444,7 @@ func (m *OracleRequestPacketData) Reset()         { *m = OracleRequestPacketData
 func (m *OracleRequestPacketData) String() string { return proto.CompactTextString(m) }
 func (*OracleRequestPacketData) ProtoMessage()    {}
 func (*OracleRequestPacketData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{15}
+	return fileDescriptor_53e65fd95a58412c, []int{17}
 }
 func (m *OracleRequestPacketData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1313,7 +1531,7 @@ func (m *OracleResponsePacketData) Reset()         { *m = OracleResponsePacketDa
 func (m *OracleResponsePacketData) String() string { return proto.CompactTextString(m) }
 func (*OracleResponsePacketData) ProtoMessage()    {}
 func (*OracleResponsePacketData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{16}
+	return fileDescriptor_53e65fd95a58412c, []int{18}
 }
 func (m *OracleResponsePacketData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1401,7 +1619,7 @@ func (m *ValidatorStatus) Reset()         { *m = ValidatorStatus{} }
 func (m *ValidatorStatus) String() string { return proto.CompactTextString(m) }
 func (*ValidatorStatus) ProtoMessage()    {}
 func (*ValidatorStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{17}
+	return fileDescriptor_53e65fd95a58412c, []int{19}
 }
 func (m *ValidatorStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1444,6 +1662,61 @@ func (m *ValidatorStatus) GetSince() time.Time {
 	return time.Time{}
 }
 
+// VRFKey is the VRF public key that a validator uses to contribute to the randomness beacon.
+type VRFKey struct {
+	// PublicKey is the VRF public key of the validator.
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Height is the block height at which the key was set.
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *VRFKey) Reset()         { *m = VRFKey{} }
+func (m *VRFKey) String() string { return proto.CompactTextString(m) }
+func (*VRFKey) ProtoMessage()    {}
+func (*VRFKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_53e65fd95a58412c, []int{20}
+}
+func (m *VRFKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VRFKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VRFKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VRFKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VRFKey.Merge(m, src)
+}
+func (m *VRFKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *VRFKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_VRFKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VRFKey proto.InternalMessageInfo
+
+func (m *VRFKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *VRFKey) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 // Params is the data structure that keeps the parameters of the oracle module.
 type Params struct {
 	// MaxRawRequestCount is the maximum number of data source raw requests a request can make.
@@ -1465,12 +1738,18 @@ type Params struct {
 	// InactivePenaltyDuration is the duration period where a validator cannot activate back
 	// after missing an oracle report.
 	InactivePenaltyDuration uint64 `protobuf:"varint,8,opt,name=inactive_penalty_duration,json=inactivePenaltyDuration,proto3" json:"inactive_penalty_duration,omitempty"`
+	// SamplingMethod is the method used to sample validators to perform an oracle task. See
+	// SamplingMethod type for the supported methods.
+	SamplingMethod uint64 `protobuf:"varint,9,opt,name=sampling_method,json=samplingMethod,proto3" json:"sampling_method,omitempty"`
+	// RandomnessFallback is what oracle scripts get when reading the random value of a request
+	// whose block has no VRF contributions. See RandomnessFallback type for the options.
+	RandomnessFallback uint64 `protobuf:"varint,10,opt,name=randomness_fallback,json=randomnessFallback,proto3" json:"randomness_fallback,omitempty"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
 func (*Params) ProtoMessage() {}
 func (*Params) Descriptor() ([]byte, []int) {
-	return fileDescriptor_53e65fd95a58412c, []int{18}
+	return fileDescriptor_53e65fd95a58412c, []int{21}
 }
 func (m *Params) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1555,7 +1834,22 @@ func (m *Params) GetInactivePenaltyDuration() uint64 {
 	return 0
 }
 
+func (m *Params) GetSamplingMethod() uint64 {
+	if m != nil {
+		return m.SamplingMethod
+	}
+	return 0
+}
+
+func (m *Params) GetRandomnessFallback() uint64 {
+	if m != nil {
+		return m.RandomnessFallback
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("bandchain.chain.x.oracle.v1.StrictSchemaEdit", StrictSchemaEdit_name, StrictSchemaEdit_value)
 	proto.RegisterEnum("bandchain.chain.x.oracle.v1.ResolveStatus", ResolveStatus_name, ResolveStatus_value)
 	proto.RegisterType((*MsgRequestData)(nil), "bandchain.chain.x.oracle.v1.MsgRequestData")
 	proto.RegisterType((*MsgReportData)(nil), "bandchain.chain.x.oracle.v1.MsgReportData")
@@ -1566,6 +1860,8 @@ func init() {
 	proto.RegisterType((*MsgActivate)(nil), "bandchain.chain.x.oracle.v1.MsgActivate")
 	proto.RegisterType((*MsgAddReporter)(nil), "bandchain.chain.x.oracle.v1.MsgAddReporter")
 	proto.RegisterType((*MsgRemoveReporter)(nil), "bandchain.chain.x.oracle.v1.MsgRemoveReporter")
+	proto.RegisterType((*MsgSetVRFKey)(nil), "bandchain.chain.x.oracle.v1.MsgSetVRFKey")
+	proto.RegisterType((*MsgSubmitVRF)(nil), "bandchain.chain.x.oracle.v1.MsgSubmitVRF")
 	proto.RegisterType((*DataSource)(nil), "bandchain.chain.x.oracle.v1.DataSource")
 	proto.RegisterType((*OracleScript)(nil), "bandchain.chain.x.oracle.v1.OracleScript")
 	proto.RegisterType((*RawRequest)(nil), "bandchain.chain.x.oracle.v1.RawRequest")
@@ -1575,107 +1871,125 @@ func init() {
 	proto.RegisterType((*OracleRequestPacketData)(nil), "bandchain.chain.x.oracle.v1.OracleRequestPacketData")
 	proto.RegisterType((*OracleResponsePacketData)(nil), "bandchain.chain.x.oracle.v1.OracleResponsePacketData")
 	proto.RegisterType((*ValidatorStatus)(nil), "bandchain.chain.x.oracle.v1.ValidatorStatus")
+	proto.RegisterType((*VRFKey)(nil), "bandchain.chain.x.oracle.v1.VRFKey")
 	proto.RegisterType((*Params)(nil), "bandchain.chain.x.oracle.v1.Params")
 }
 
 func init() { proto.RegisterFile("x/oracle/types/types.proto", fileDescriptor_53e65fd95a58412c) }
 
 var fileDescriptor_53e65fd95a58412c = []byte{
-	// 1500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x3b, 0x6c, 0x1c, 0x55,
-	0x17, 0xf6, 0xcc, 0xec, 0xae, 0x67, 0xcf, 0xda, 0x8e, 0x33, 0x49, 0x9c, 0x8d, 0xfd, 0xcb, 0xbb,
-	0x7f, 0xf4, 0xff, 0xc1, 0x44, 0x64, 0x57, 0x09, 0x08, 0x11, 0x4b, 0x48, 0x78, 0xed, 0x24, 0x58,
-	0xc2, 0xc4, 0x8c, 0x43, 0x0a, 0x9a, 0xd1, 0xdd, 0x99, 0x9b, 0xf5, 0xc8, 0xf3, 0xe2, 0xde, 0x59,
-	0x7b, 0x5d, 0x82, 0x44, 0x9f, 0x92, 0x82, 0x22, 0x25, 0x0d, 0x0d, 0x12, 0x48, 0x54, 0xb4, 0x91,
-	0x90, 0x50, 0x0a, 0x0a, 0x44, 0xb1, 0xa0, 0x4d, 0x83, 0x44, 0x43, 0x9d, 0x0a, 0xdd, 0xc7, 0xbc,
-	0x42, 0xd8, 0x24, 0xce, 0x42, 0x42, 0x63, 0xcf, 0x79, 0xdc, 0x99, 0x73, 0xbf, 0xf3, 0x9d, 0x73,
-	0xef, 0x59, 0x58, 0x1c, 0xb4, 0x43, 0x82, 0x6c, 0x0f, 0xb7, 0xe3, 0xc3, 0x08, 0x53, 0xf1, 0xb7,
-	0x15, 0x91, 0x30, 0x0e, 0x8d, 0xa5, 0x2e, 0x0a, 0x1c, 0x7b, 0x17, 0xb9, 0x41, 0x4b, 0xfc, 0x1d,
-	0xb4, 0x84, 0x6f, 0x6b, 0xff, 0xe2, 0xe2, 0xb9, 0x78, 0xd7, 0x25, 0x8e, 0x15, 0x21, 0x12, 0x1f,
-	0xb6, 0xb9, 0x7f, 0xbb, 0x17, 0xf6, 0xc2, 0xec, 0x49, 0xbc, 0x64, 0xb1, 0xd1, 0x0b, 0xc3, 0x9e,
-	0x87, 0x85, 0x4b, 0xb7, 0x7f, 0xab, 0x1d, 0xbb, 0x3e, 0xa6, 0x31, 0xf2, 0x23, 0xe1, 0x70, 0xf6,
-	0x4b, 0x15, 0xe6, 0xb6, 0x68, 0xcf, 0xc4, 0x1f, 0xf6, 0x31, 0x8d, 0x37, 0x50, 0x8c, 0x8c, 0x77,
-	0x61, 0x5e, 0x7c, 0xc8, 0xa2, 0x36, 0x71, 0xa3, 0xd8, 0x72, 0x9d, 0xba, 0xd2, 0x54, 0x56, 0xb4,
-	0xce, 0xff, 0x46, 0xc3, 0xc6, 0xdc, 0x75, 0x6e, 0xdb, 0xe1, 0xa6, 0xcd, 0x8d, 0x07, 0x7f, 0xd2,
-	0x98, 0x73, 0x61, 0x5e, 0x76, 0x8c, 0x45, 0xd0, 0x6d, 0xe4, 0x79, 0x0e, 0x8a, 0x51, 0x5d, 0x6d,
-	0x2a, 0x2b, 0x33, 0x66, 0x2a, 0x1b, 0x4b, 0x50, 0x45, 0x74, 0xcf, 0xb2, 0xc3, 0x7e, 0x10, 0xd7,
-	0xb5, 0xa6, 0xb2, 0x52, 0x32, 0x75, 0x44, 0xf7, 0xd6, 0x99, 0xcc, 0x8c, 0xbe, 0x1b, 0x48, 0x63,
-	0x49, 0x18, 0x7d, 0x37, 0x10, 0xc6, 0x97, 0xa1, 0x6a, 0x7b, 0x2e, 0x0e, 0x78, 0x78, 0xe5, 0xa6,
-	0xb2, 0x52, 0xed, 0xcc, 0x8c, 0x86, 0x0d, 0x7d, 0x9d, 0x2b, 0x37, 0x37, 0x4c, 0x5d, 0x98, 0x37,
-	0x1d, 0x63, 0x13, 0x2a, 0x14, 0x07, 0x0e, 0x26, 0xf5, 0x0a, 0xfb, 0x7c, 0xe7, 0xe2, 0x83, 0x61,
-	0xe3, 0x42, 0xcf, 0x8d, 0x77, 0xfb, 0xdd, 0x96, 0x1d, 0xfa, 0x6d, 0x3b, 0xa4, 0x7e, 0x48, 0xe5,
-	0xbf, 0x0b, 0xd4, 0xd9, 0x93, 0x79, 0x58, 0xb3, 0xed, 0x35, 0xc7, 0x21, 0x98, 0x52, 0x53, 0xbe,
-	0x60, 0xb5, 0xf4, 0xeb, 0x9d, 0x86, 0x72, 0xf6, 0x5b, 0x15, 0x66, 0x39, 0x68, 0x51, 0x48, 0x04,
-	0x66, 0x97, 0x01, 0x88, 0x80, 0x30, 0x43, 0x6b, 0x71, 0x34, 0x6c, 0x54, 0x25, 0xb0, 0x1c, 0xa8,
-	0x4c, 0x30, 0xab, 0xd2, 0x7b, 0xd3, 0x31, 0xb6, 0xa0, 0x46, 0xd0, 0x81, 0x45, 0xf8, 0xcb, 0x68,
-	0x5d, 0x6d, 0x6a, 0x2b, 0xb5, 0x4b, 0xe7, 0x5a, 0x63, 0xb2, 0xdf, 0x32, 0xd1, 0x81, 0xf8, 0x76,
-	0xa7, 0x74, 0x77, 0xd8, 0x98, 0x32, 0x81, 0x24, 0x0a, 0x6a, 0x5c, 0x87, 0xea, 0x3e, 0xf2, 0x5c,
-	0x07, 0xc5, 0x21, 0xe1, 0x88, 0x3e, 0xf9, 0x7e, 0x6f, 0x22, 0x2f, 0xd9, 0x6f, 0xf6, 0x0e, 0x63,
-	0x0b, 0x74, 0x11, 0x1b, 0x26, 0x3c, 0x09, 0x47, 0xc2, 0x2f, 0x7d, 0x85, 0x44, 0xf0, 0x13, 0x15,
-	0x4e, 0x6c, 0xd1, 0xde, 0x3a, 0xc1, 0x28, 0xc6, 0x0c, 0xc1, 0x9d, 0xb0, 0x4f, 0x6c, 0x6c, 0x5c,
-	0x83, 0x72, 0x78, 0x10, 0x60, 0xc2, 0x21, 0x3c, 0xd2, 0x97, 0xc4, 0x7a, 0xc3, 0x80, 0x52, 0x80,
-	0x7c, 0xcc, 0x09, 0x57, 0x35, 0xf9, 0xb3, 0xd1, 0x84, 0x9a, 0x83, 0x05, 0xa7, 0xdd, 0x30, 0xe0,
-	0xe0, 0x54, 0xcd, 0xbc, 0xca, 0x58, 0x06, 0xc0, 0x03, 0x6c, 0xf7, 0x63, 0xd4, 0xf5, 0xb0, 0xd8,
-	0xad, 0x99, 0xd3, 0xe4, 0x98, 0x54, 0x9e, 0x0c, 0x93, 0xbe, 0x53, 0xe1, 0xf8, 0x16, 0xed, 0x5d,
-	0x71, 0xdc, 0x38, 0x87, 0xc2, 0x55, 0x98, 0x63, 0xd5, 0x61, 0x51, 0x2e, 0x66, 0x8c, 0x6a, 0x8e,
-	0x86, 0x8d, 0x99, 0xcc, 0x8f, 0x93, 0xaa, 0x20, 0x9b, 0x33, 0x4e, 0x26, 0x39, 0x19, 0x9a, 0xea,
-	0x84, 0xd0, 0xd4, 0xfe, 0x1a, 0xcd, 0xd2, 0xe3, 0xd0, 0x2c, 0x8f, 0x41, 0x73, 0x42, 0x75, 0xf9,
-	0xbd, 0x0a, 0xa7, 0x52, 0x56, 0xe5, 0xbb, 0xd2, 0xf3, 0xe6, 0x95, 0x01, 0x25, 0x3b, 0x74, 0x12,
-	0x46, 0xf1, 0x67, 0x63, 0x01, 0x2a, 0xd4, 0xde, 0xc5, 0x3e, 0x12, 0xdd, 0xcb, 0x94, 0x92, 0x71,
-	0x19, 0x8e, 0xc9, 0xbc, 0x33, 0x37, 0xab, 0x4f, 0x3c, 0x0e, 0x4f, 0xb5, 0x73, 0x7c, 0x34, 0x6c,
-	0xcc, 0x8a, 0xdc, 0xae, 0x87, 0x0e, 0x7e, 0xdf, 0x7c, 0xc7, 0x9c, 0xa5, 0x99, 0x48, 0xbc, 0x1c,
-	0xa0, 0xd3, 0x93, 0x01, 0xf4, 0x33, 0x8d, 0x97, 0x29, 0xa3, 0x67, 0x01, 0xce, 0x49, 0x1f, 0x11,
-	0xcf, 0x99, 0xa8, 0x49, 0x7a, 0xca, 0x8f, 0x4c, 0x4f, 0xe5, 0x71, 0xe9, 0x99, 0x7e, 0xea, 0xf4,
-	0xe8, 0x93, 0x49, 0x8f, 0x03, 0xb5, 0x2d, 0xda, 0x5b, 0xb3, 0x63, 0x77, 0x1f, 0xc5, 0xb8, 0xd8,
-	0xfa, 0x95, 0x67, 0x6f, 0xfd, 0xf2, 0x2b, 0x5f, 0x2b, 0xfc, 0x8a, 0xb0, 0xe6, 0x38, 0xa6, 0x6c,
-	0xe2, 0x13, 0xff, 0x52, 0xe1, 0x90, 0x51, 0x27, 0x75, 0xc8, 0x7c, 0xa3, 0xf0, 0xe6, 0x6a, 0x62,
-	0x3f, 0xdc, 0xc7, 0xff, 0xb2, 0xd8, 0xbf, 0x50, 0x00, 0x5e, 0x9c, 0x73, 0x71, 0x11, 0xf4, 0x5b,
-	0xae, 0x87, 0xf9, 0x4a, 0x51, 0x3f, 0xa9, 0x2c, 0xe3, 0xfd, 0x58, 0x85, 0x99, 0x17, 0xa9, 0xe3,
-	0x8e, 0x89, 0xf8, 0x6f, 0xe8, 0xbc, 0x12, 0x84, 0xaf, 0x14, 0x00, 0x7e, 0x37, 0xe3, 0x77, 0x3b,
-	0xe3, 0x4d, 0xa8, 0xe1, 0x41, 0x8c, 0x49, 0x80, 0xbc, 0xac, 0x41, 0xfe, 0x67, 0x34, 0x6c, 0xc0,
-	0x15, 0xa9, 0xe6, 0xcd, 0x31, 0x27, 0xb1, 0xe3, 0x51, 0x3e, 0x3b, 0x8f, 0xb8, 0x05, 0xa8, 0x47,
-	0xba, 0x05, 0xe4, 0xef, 0xdf, 0x5a, 0xf1, 0xfe, 0x2d, 0xe3, 0xfe, 0x48, 0x81, 0x6a, 0x7a, 0xa7,
-	0x7c, 0xd6, 0xb0, 0x97, 0xa0, 0x8a, 0x07, 0x6e, 0xcc, 0x31, 0xe4, 0x11, 0xcf, 0x9a, 0x3a, 0x53,
-	0x30, 0xa8, 0x58, 0x32, 0x73, 0x71, 0x94, 0x72, 0x31, 0xfc, 0xa6, 0xc1, 0x74, 0x02, 0xdc, 0x3f,
-	0x39, 0x81, 0x38, 0x70, 0x52, 0xde, 0xc5, 0xb1, 0x63, 0xa5, 0x45, 0x4d, 0xeb, 0x5a, 0x53, 0x3b,
-	0x5a, 0x67, 0x38, 0x91, 0xbe, 0xee, 0x66, 0xfa, 0xb6, 0xf1, 0xa3, 0xcc, 0xff, 0x61, 0x2e, 0x19,
-	0x1e, 0x76, 0xb1, 0xdb, 0xdb, 0x8d, 0x39, 0x2f, 0x35, 0x73, 0x56, 0x6a, 0xdf, 0xe6, 0x4a, 0xe3,
-	0x1a, 0xcc, 0x24, 0x6e, 0x6c, 0x8a, 0xe3, 0xdc, 0xac, 0x5d, 0x5a, 0x6c, 0x89, 0x11, 0xaf, 0x95,
-	0x8c, 0x78, 0xad, 0x1b, 0xc9, 0x88, 0xd7, 0xd1, 0xd9, 0x74, 0x70, 0xfb, 0xe7, 0x86, 0x62, 0xd6,
-	0xe4, 0x4a, 0x66, 0x2b, 0x8e, 0x4e, 0xd3, 0x63, 0x47, 0xa7, 0x6d, 0x98, 0x11, 0xc3, 0x09, 0x5f,
-	0x4d, 0xeb, 0x3a, 0x9f, 0x4e, 0x5e, 0x7a, 0xfc, 0x74, 0xc2, 0xfd, 0xe5, 0x78, 0x52, 0x23, 0xa9,
-	0x86, 0xca, 0x6c, 0xff, 0xa4, 0x40, 0x45, 0xd2, 0x6d, 0xe2, 0xfd, 0xf8, 0x3c, 0x1c, 0x77, 0x03,
-	0xab, 0x8b, 0x6f, 0x85, 0x04, 0x5b, 0x04, 0xd3, 0xd0, 0xdb, 0x17, 0x44, 0xd4, 0xcd, 0x63, 0x6e,
-	0xd0, 0xe1, 0x7a, 0x53, 0xa8, 0x1f, 0x1e, 0xbe, 0xb4, 0x67, 0x1b, 0xbe, 0xe4, 0xe6, 0x7e, 0x57,
-	0xe0, 0xb4, 0x60, 0xa4, 0xdc, 0xf5, 0x36, 0xb2, 0xf7, 0xb0, 0x18, 0x14, 0x0b, 0xd8, 0x2b, 0x63,
-	0xb1, 0x7f, 0x54, 0x15, 0xa8, 0x13, 0xaa, 0x02, 0x6d, 0xdc, 0x1c, 0x5e, 0x1a, 0x37, 0x87, 0x97,
-	0x8b, 0xe4, 0x95, 0x5b, 0xfe, 0x41, 0x85, 0x7a, 0xb2, 0x65, 0x1a, 0x85, 0x01, 0xc5, 0x47, 0xdb,
-	0x73, 0x71, 0x8e, 0x56, 0x9f, 0x66, 0x8e, 0x66, 0x5b, 0x08, 0xe8, 0x43, 0x3f, 0x25, 0x04, 0x54,
-	0x6c, 0xe1, 0xbf, 0x0f, 0xd5, 0x4e, 0x89, 0x17, 0x58, 0xa1, 0x2a, 0xb8, 0x0b, 0x67, 0x85, 0x70,
-	0x29, 0x27, 0x2e, 0x5c, 0xc7, 0x5d, 0xde, 0x63, 0x85, 0x2a, 0x5c, 0x68, 0x8c, 0xe2, 0x3e, 0xe5,
-	0x35, 0x38, 0x77, 0xe9, 0xfc, 0x78, 0xc2, 0x88, 0x25, 0x3b, 0x7c, 0x05, 0x2b, 0xea, 0x9c, 0xc8,
-	0xce, 0x22, 0x82, 0x69, 0xdf, 0x8b, 0xc5, 0x95, 0xdd, 0x94, 0x92, 0x84, 0x35, 0x82, 0x63, 0x69,
-	0x13, 0x91, 0x0b, 0x96, 0xa0, 0xea, 0x52, 0x0b, 0xb1, 0x3b, 0x1f, 0xe6, 0x60, 0xea, 0xa6, 0xee,
-	0x52, 0x7e, 0x07, 0xc4, 0xc6, 0x2a, 0x94, 0xa9, 0x1b, 0xd8, 0x82, 0xee, 0x4f, 0xda, 0x1b, 0xc4,
-	0x12, 0xf9, 0xc5, 0xcf, 0x35, 0xa8, 0x6c, 0x23, 0x82, 0x7c, 0x6a, 0x5c, 0x84, 0x53, 0x3e, 0x1a,
-	0x58, 0xb9, 0xfa, 0x97, 0xe0, 0x2a, 0x1c, 0x5c, 0xc3, 0x47, 0x83, 0xac, 0xd4, 0x05, 0xcc, 0x67,
-	0x61, 0x96, 0x2d, 0xc9, 0xa8, 0xa4, 0x72, 0xd7, 0x9a, 0x8f, 0x06, 0x6b, 0x09, 0x9b, 0x5e, 0x83,
-	0x05, 0x3c, 0x88, 0x5c, 0x82, 0xd8, 0x39, 0x6d, 0x75, 0xbd, 0xd0, 0x2e, 0xfe, 0xfe, 0x73, 0x32,
-	0xb3, 0x76, 0x98, 0x51, 0xac, 0x5a, 0x81, 0xf9, 0x2e, 0xa2, 0x38, 0x8d, 0xa4, 0x87, 0xa8, 0xe4,
-	0xe9, 0x1c, 0xd3, 0xcb, 0x28, 0xae, 0x21, 0x6a, 0x5c, 0x86, 0x33, 0x11, 0x26, 0x59, 0x2b, 0x2f,
-	0x2c, 0x11, 0xec, 0x5d, 0x88, 0x30, 0x49, 0x71, 0xcd, 0x2d, 0x7d, 0x05, 0x0c, 0x8a, 0xfc, 0xc8,
-	0x73, 0x83, 0x9e, 0x15, 0x93, 0x43, 0x19, 0x56, 0x85, 0xaf, 0x99, 0x4f, 0x2c, 0x37, 0xc8, 0xa1,
-	0x08, 0xe9, 0x0d, 0xa8, 0xcb, 0xfa, 0x24, 0xf8, 0x00, 0x11, 0xc7, 0x8a, 0x30, 0xb1, 0x71, 0x10,
-	0xa3, 0x1e, 0xe6, 0xc9, 0x2c, 0x99, 0x0b, 0xa1, 0x2c, 0x09, 0x66, 0xde, 0x4e, 0xad, 0xc6, 0x2a,
-	0x9c, 0x71, 0x03, 0x91, 0x42, 0x2b, 0xc2, 0x01, 0xf2, 0xe2, 0x43, 0xcb, 0xe9, 0x8b, 0x3d, 0xf3,
-	0xd9, 0xa0, 0x64, 0x9e, 0x4e, 0x1c, 0xb6, 0x85, 0x7d, 0x43, 0x9a, 0x57, 0xf5, 0x4f, 0xef, 0x34,
-	0xa6, 0x58, 0xaa, 0xce, 0xbf, 0x05, 0xb3, 0x05, 0x6a, 0x19, 0x3a, 0x94, 0xae, 0x47, 0x38, 0x98,
-	0x9f, 0x32, 0x6a, 0x30, 0xbd, 0xd3, 0xb7, 0x6d, 0x4c, 0xe9, 0xbc, 0xc2, 0x84, 0xab, 0xc8, 0xf5,
-	0xfa, 0x04, 0xcf, 0xab, 0x4c, 0xb8, 0xc2, 0xf0, 0xc5, 0xce, 0xbc, 0xd6, 0xd9, 0xbe, 0x3b, 0x5a,
-	0x56, 0xee, 0x8d, 0x96, 0x95, 0x5f, 0x46, 0xcb, 0xca, 0xed, 0xfb, 0xcb, 0x53, 0xf7, 0xee, 0x2f,
-	0x4f, 0xfd, 0x78, 0x7f, 0x79, 0xea, 0x83, 0xd7, 0x73, 0xdd, 0x97, 0x71, 0x9b, 0x13, 0xc8, 0x0e,
-	0xbd, 0x76, 0x4a, 0xf4, 0xb6, 0xf8, 0x5b, 0xfc, 0x01, 0xb3, 0x5b, 0xe1, 0x8e, 0xaf, 0xfe, 0x11,
-	0x00, 0x00, 0xff, 0xff, 0x9a, 0x36, 0xe7, 0x80, 0xd9, 0x14, 0x00, 0x00,
+	// 1763 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x19, 0x4b, 0x70, 0x1b, 0x49,
+	0xd5, 0x33, 0x1a, 0xc9, 0xa3, 0x27, 0x59, 0x91, 0x3b, 0xd9, 0xac, 0xd6, 0x01, 0x4b, 0x04, 0xd8,
+	0x35, 0x29, 0x22, 0x55, 0xcc, 0xa7, 0x48, 0xaa, 0xa8, 0xc2, 0x8a, 0x9d, 0xe0, 0x0a, 0x26, 0x66,
+	0xbc, 0xe4, 0xc0, 0x81, 0xa1, 0x35, 0xd3, 0x96, 0xa7, 0x3c, 0x3f, 0xba, 0x5b, 0xb6, 0x7c, 0x84,
+	0x82, 0xfb, 0x1e, 0x39, 0xee, 0x8d, 0x13, 0x47, 0xa8, 0xe2, 0xc4, 0x75, 0xab, 0xb8, 0xec, 0x81,
+	0x03, 0xc5, 0xc1, 0x50, 0xca, 0x85, 0x2a, 0x2e, 0x54, 0xc1, 0x29, 0x27, 0xaa, 0x3f, 0xd2, 0xcc,
+	0x78, 0xb3, 0x0a, 0xb1, 0x05, 0xbb, 0x7b, 0x91, 0xe7, 0xfd, 0xba, 0x5f, 0xbf, 0x4f, 0xbf, 0xf7,
+	0xda, 0xb0, 0x36, 0xee, 0x25, 0x14, 0x7b, 0x21, 0xe9, 0xf1, 0xb3, 0x94, 0x30, 0xf5, 0xdb, 0x4d,
+	0x69, 0xc2, 0x13, 0x74, 0x6b, 0x80, 0x63, 0xdf, 0x3b, 0xc2, 0x41, 0xdc, 0x55, 0xbf, 0xe3, 0xae,
+	0xe2, 0xed, 0x9e, 0xdc, 0x5b, 0x7b, 0x9b, 0x1f, 0x05, 0xd4, 0x77, 0x53, 0x4c, 0xf9, 0x59, 0x4f,
+	0xf2, 0xf7, 0x86, 0xc9, 0x30, 0xc9, 0xbe, 0xd4, 0x22, 0x6b, 0xed, 0x61, 0x92, 0x0c, 0x43, 0xa2,
+	0x58, 0x06, 0xa3, 0xc3, 0x1e, 0x0f, 0x22, 0xc2, 0x38, 0x8e, 0x52, 0xc5, 0x70, 0xfb, 0xdf, 0x25,
+	0x68, 0xec, 0xb1, 0xa1, 0x43, 0x7e, 0x3a, 0x22, 0x8c, 0x6f, 0x63, 0x8e, 0xd1, 0xf7, 0xa1, 0xa9,
+	0x36, 0x72, 0x99, 0x47, 0x83, 0x94, 0xbb, 0x81, 0xdf, 0x32, 0x3a, 0xc6, 0x46, 0xa9, 0xff, 0xa5,
+	0xc9, 0x79, 0xbb, 0xf1, 0x54, 0xd2, 0x0e, 0x24, 0x69, 0x77, 0xfb, 0xc5, 0x47, 0x30, 0x4e, 0x23,
+	0xc9, 0xc3, 0x3e, 0x5a, 0x03, 0xdb, 0xc3, 0x61, 0xe8, 0x63, 0x8e, 0x5b, 0x66, 0xc7, 0xd8, 0xa8,
+	0x3b, 0x33, 0x18, 0xdd, 0x82, 0x2a, 0x66, 0xc7, 0xae, 0x97, 0x8c, 0x62, 0xde, 0x2a, 0x75, 0x8c,
+	0x0d, 0xcb, 0xb1, 0x31, 0x3b, 0x7e, 0x28, 0x60, 0x41, 0x8c, 0x82, 0x58, 0x13, 0x2d, 0x45, 0x8c,
+	0x82, 0x58, 0x11, 0xbf, 0x02, 0x55, 0x2f, 0x0c, 0x48, 0x2c, 0xd5, 0x2b, 0x77, 0x8c, 0x8d, 0x6a,
+	0xbf, 0x3e, 0x39, 0x6f, 0xdb, 0x0f, 0x25, 0x72, 0x77, 0xdb, 0xb1, 0x15, 0x79, 0xd7, 0x47, 0xbb,
+	0x50, 0x61, 0x24, 0xf6, 0x09, 0x6d, 0x55, 0xc4, 0xf6, 0xfd, 0x7b, 0x2f, 0xce, 0xdb, 0x77, 0x87,
+	0x01, 0x3f, 0x1a, 0x0d, 0xba, 0x5e, 0x12, 0xf5, 0xbc, 0x84, 0x45, 0x09, 0xd3, 0x7f, 0xee, 0x32,
+	0xff, 0x58, 0xfb, 0x61, 0xcb, 0xf3, 0xb6, 0x7c, 0x9f, 0x12, 0xc6, 0x1c, 0xbd, 0x00, 0xfa, 0x09,
+	0x20, 0x1c, 0x86, 0xc9, 0x29, 0xf1, 0xdd, 0x13, 0x1c, 0x06, 0x3e, 0xe6, 0x09, 0x65, 0xad, 0xe5,
+	0x4e, 0xe9, 0x35, 0x96, 0x7d, 0x86, 0xc3, 0xe9, 0xb2, 0xab, 0x7a, 0xb1, 0x67, 0xb3, 0xb5, 0xd0,
+	0x8f, 0x61, 0xd5, 0x27, 0x71, 0x50, 0xdc, 0xc0, 0xbe, 0xec, 0x06, 0x4d, 0xb5, 0x56, 0xb6, 0xfe,
+	0x03, 0xeb, 0xef, 0xef, 0xb7, 0x8d, 0xdb, 0x7f, 0x30, 0x61, 0x45, 0xba, 0x3d, 0x4d, 0xa8, 0xf2,
+	0xfa, 0x7d, 0x00, 0xaa, 0x82, 0x20, 0xf3, 0xf7, 0xda, 0xe4, 0xbc, 0x5d, 0xd5, 0xa1, 0x21, 0x5d,
+	0x9d, 0x01, 0x4e, 0x55, 0x73, 0xef, 0xfa, 0x68, 0x0f, 0x6a, 0x14, 0x9f, 0xba, 0x54, 0x2e, 0xc6,
+	0x5a, 0x66, 0xa7, 0xb4, 0x51, 0xdb, 0x7c, 0xbb, 0x3b, 0x27, 0x7e, 0xbb, 0x0e, 0x3e, 0x55, 0x7b,
+	0xf7, 0xad, 0x0f, 0xce, 0xdb, 0x4b, 0x0e, 0xd0, 0x29, 0x82, 0xa1, 0xa7, 0x50, 0x9d, 0x1d, 0x5d,
+	0xc6, 0xc4, 0xa5, 0x4e, 0x9e, 0xad, 0x81, 0xf6, 0xc0, 0x56, 0xba, 0x11, 0xda, 0xb2, 0x5e, 0x6b,
+	0xbd, 0x5c, 0x04, 0xcc, 0x96, 0xd0, 0x16, 0xfc, 0xa5, 0x09, 0xd7, 0xf7, 0xd8, 0xf0, 0x21, 0x25,
+	0x98, 0x13, 0x61, 0xc1, 0x83, 0x64, 0x44, 0x3d, 0x82, 0x1e, 0x43, 0x39, 0x39, 0x8d, 0x09, 0x6d,
+	0x19, 0x97, 0xdd, 0x49, 0xc9, 0x23, 0x04, 0x56, 0x8c, 0x23, 0x22, 0x53, 0xa6, 0xea, 0xc8, 0x6f,
+	0xd4, 0x81, 0x9a, 0x4f, 0x54, 0x56, 0x06, 0x49, 0x2c, 0x8d, 0x53, 0x75, 0xf2, 0x28, 0xb4, 0x0e,
+	0x40, 0xc6, 0xc4, 0x1b, 0x71, 0x3c, 0x08, 0x89, 0x3a, 0xad, 0x93, 0xc3, 0xe4, 0x72, 0xa1, 0x7c,
+	0xc5, 0x5c, 0xd0, 0x76, 0xf8, 0xa3, 0x09, 0xab, 0x7b, 0x6c, 0xb8, 0xe3, 0x07, 0x3c, 0x67, 0x85,
+	0x47, 0xd0, 0x10, 0xf9, 0xed, 0x32, 0x09, 0x66, 0x11, 0xd5, 0x99, 0x9c, 0xb7, 0xeb, 0x19, 0x9f,
+	0x0c, 0xaa, 0x02, 0xec, 0xd4, 0xfd, 0x0c, 0xf2, 0x33, 0x6b, 0x9a, 0x0b, 0xb2, 0x66, 0xe9, 0xe3,
+	0xad, 0x69, 0xbd, 0xca, 0x9a, 0xe5, 0x39, 0xd6, 0xac, 0x2c, 0xc6, 0x9a, 0xff, 0x32, 0xe1, 0x8d,
+	0x59, 0x54, 0xe5, 0xef, 0xd5, 0x4f, 0x3a, 0xae, 0x10, 0x58, 0x5e, 0xe2, 0x4f, 0x23, 0x4a, 0x7e,
+	0xa3, 0x9b, 0x50, 0x61, 0xde, 0x11, 0x89, 0xb0, 0xba, 0x7f, 0x1d, 0x0d, 0xa1, 0xfb, 0x70, 0x4d,
+	0xfb, 0x5d, 0xb0, 0xb9, 0x23, 0x1a, 0x4a, 0xf3, 0x54, 0xfb, 0xab, 0x93, 0xf3, 0xf6, 0x8a, 0xf2,
+	0xed, 0xc3, 0xc4, 0x27, 0x3f, 0x74, 0xbe, 0xe7, 0xac, 0xb0, 0x0c, 0xa4, 0x61, 0xce, 0xa0, 0xcb,
+	0x57, 0xbd, 0xaa, 0xbf, 0x08, 0x2b, 0x8c, 0xd3, 0xc0, 0xe3, 0xae, 0x56, 0xd2, 0xee, 0x18, 0x1b,
+	0xb6, 0x53, 0x57, 0xc8, 0x03, 0x89, 0xd3, 0x56, 0xff, 0x67, 0x09, 0xae, 0xeb, 0x18, 0x2e, 0xd8,
+	0x7c, 0xd1, 0x95, 0xf0, 0x13, 0x8e, 0xe6, 0xa9, 0x0f, 0xcb, 0x2f, 0xf5, 0x61, 0xe5, 0x55, 0x3e,
+	0x5c, 0x7e, 0x6d, 0x1f, 0xda, 0x57, 0xf5, 0xa1, 0x73, 0xd1, 0x87, 0xd5, 0x8e, 0xb1, 0xd1, 0xd8,
+	0xbc, 0x3b, 0xb7, 0xb6, 0x1c, 0xe4, 0x1c, 0x2c, 0x5c, 0xfa, 0x52, 0x97, 0xfb, 0x50, 0xdb, 0x63,
+	0xc3, 0x2d, 0x8f, 0x07, 0x27, 0x98, 0x93, 0x62, 0xcd, 0x31, 0xae, 0x5e, 0x73, 0xf4, 0x2e, 0xbf,
+	0x33, 0x64, 0x77, 0xb5, 0xe5, 0xfb, 0x8e, 0xae, 0x1e, 0x0b, 0xdf, 0xa9, 0x50, 0xdd, 0xcc, 0x45,
+	0x55, 0xb7, 0xdf, 0x1b, 0xf2, 0x56, 0x77, 0x48, 0x94, 0x9c, 0x90, 0xcf, 0x98, 0xee, 0xbf, 0x30,
+	0xa0, 0xbe, 0xc7, 0x86, 0x07, 0x84, 0x3f, 0x73, 0x1e, 0x3d, 0x21, 0x67, 0x8b, 0x57, 0xfb, 0xf3,
+	0x00, 0xe9, 0x68, 0x10, 0x06, 0x9e, 0x7b, 0x4c, 0xce, 0x74, 0x4f, 0x5b, 0x55, 0x98, 0x27, 0xe4,
+	0x4c, 0xab, 0xf1, 0x42, 0xab, 0x31, 0x1a, 0x44, 0x81, 0xd0, 0x44, 0xa4, 0xda, 0x11, 0x09, 0x86,
+	0x47, 0x5c, 0xdd, 0x21, 0x8e, 0x86, 0x04, 0x3e, 0x19, 0xf1, 0x74, 0xc4, 0xf5, 0x4a, 0x1a, 0x42,
+	0x37, 0xa0, 0x9c, 0xd2, 0x24, 0x39, 0x54, 0x3d, 0x90, 0xa3, 0x80, 0xe2, 0x61, 0xac, 0x05, 0xfb,
+	0xa0, 0xbc, 0x28, 0x1f, 0xfc, 0xc6, 0x00, 0xf8, 0xf4, 0x34, 0x45, 0x6b, 0x60, 0x1f, 0x06, 0x21,
+	0x91, 0x92, 0xea, 0x5e, 0x9c, 0xc1, 0x5a, 0xdf, 0x5f, 0x9b, 0x50, 0xff, 0x34, 0x95, 0xdb, 0x39,
+	0x1a, 0xff, 0x2f, 0xca, 0xee, 0x47, 0x6a, 0xe5, 0xf2, 0xc7, 0xd6, 0xca, 0xdf, 0x1a, 0x00, 0xb2,
+	0x7b, 0x97, 0xdd, 0x3f, 0xfa, 0x36, 0xd4, 0xc8, 0x98, 0x13, 0x1a, 0xe3, 0x30, 0xab, 0x8e, 0x9f,
+	0x9b, 0x9c, 0xb7, 0x61, 0x47, 0xa3, 0x65, 0x65, 0xcc, 0x41, 0xa2, 0x81, 0xd2, 0xdf, 0xfe, 0x4b,
+	0xfa, 0x44, 0xf3, 0x52, 0x7d, 0x62, 0x7e, 0xc6, 0x2c, 0x15, 0x67, 0x4c, 0xad, 0xf7, 0xcf, 0x0c,
+	0xa8, 0xce, 0xa6, 0x8e, 0xab, 0xaa, 0x7d, 0x0b, 0xaa, 0x64, 0x1c, 0x70, 0x69, 0x68, 0xa9, 0xf1,
+	0x8a, 0x63, 0x0b, 0x84, 0xb0, 0xa7, 0xf0, 0x78, 0x4e, 0x0f, 0x2b, 0xa7, 0xc3, 0x3f, 0x4a, 0xb0,
+	0x3c, 0x35, 0xdc, 0xff, 0x73, 0xca, 0xf6, 0xe1, 0x86, 0x9e, 0xd6, 0x8a, 0x63, 0x65, 0xe9, 0xb2,
+	0x63, 0xe5, 0xf5, 0xd9, 0x72, 0xb9, 0xc9, 0x75, 0xee, 0xb8, 0xfe, 0x65, 0x68, 0x68, 0x19, 0x57,
+	0x5f, 0x82, 0x65, 0x79, 0x09, 0xae, 0x68, 0xec, 0x77, 0x25, 0x12, 0x3d, 0x86, 0xfa, 0x94, 0x4d,
+	0xbc, 0x54, 0xc8, 0x00, 0xae, 0x6d, 0xae, 0x75, 0xd5, 0x33, 0x46, 0x77, 0xfa, 0x8c, 0xd1, 0x7d,
+	0x77, 0xfa, 0x8c, 0xd1, 0xb7, 0xc5, 0xfc, 0xf8, 0xde, 0x5f, 0xdb, 0x86, 0x53, 0xd3, 0x92, 0x82,
+	0x56, 0x7c, 0x1e, 0x58, 0x9e, 0xfb, 0x3c, 0xb0, 0x0f, 0x75, 0x35, 0xbe, 0x4a, 0x69, 0x35, 0x6c,
+	0xd7, 0x36, 0xdf, 0x79, 0xf5, 0xfc, 0x2a, 0xf9, 0xf5, 0x00, 0x5b, 0xa3, 0x33, 0xcc, 0x74, 0xc6,
+	0xfe, 0x8b, 0x01, 0x15, 0x1d, 0x6e, 0x0b, 0xaf, 0x40, 0x77, 0x60, 0x35, 0x88, 0xdd, 0x01, 0x39,
+	0x4c, 0x28, 0x71, 0x29, 0x61, 0x49, 0x78, 0xa2, 0x02, 0xd1, 0x76, 0xae, 0x05, 0x71, 0x5f, 0xe2,
+	0x1d, 0x85, 0xbe, 0x38, 0x9e, 0x97, 0xae, 0x36, 0x9e, 0x4f, 0x5b, 0x66, 0x03, 0xde, 0x54, 0x11,
+	0xa9, 0x4f, 0xbd, 0x8f, 0xbd, 0x63, 0xa2, 0x9e, 0x12, 0x0a, 0xb6, 0x37, 0xe6, 0xda, 0xfe, 0x65,
+	0x59, 0x60, 0x2e, 0x28, 0x0b, 0x4a, 0xf3, 0xde, 0x9a, 0xac, 0x79, 0x6f, 0x4d, 0xe5, 0x62, 0xf0,
+	0xea, 0x23, 0xff, 0xc9, 0x84, 0xd6, 0xf4, 0xc8, 0x2c, 0x4d, 0x62, 0x46, 0x2e, 0x77, 0xe6, 0xe2,
+	0x4b, 0x8b, 0xf9, 0x3a, 0x2f, 0x2d, 0xe2, 0x08, 0x31, 0xbb, 0xf0, 0x5c, 0x16, 0x33, 0x75, 0x84,
+	0x2f, 0x5c, 0xc8, 0x1d, 0x4b, 0x26, 0x58, 0x21, 0x2b, 0x24, 0x8b, 0x8c, 0x0a, 0xc5, 0x52, 0x9e,
+	0xb2, 0x48, 0x9c, 0x64, 0xf9, 0x01, 0x34, 0x34, 0xe8, 0x32, 0x8e, 0xf9, 0x88, 0xc9, 0x1c, 0x6c,
+	0x6c, 0xde, 0x99, 0x1f, 0x30, 0x4a, 0xe4, 0x40, 0x4a, 0x88, 0xa4, 0xce, 0x81, 0xa2, 0x60, 0x51,
+	0xc2, 0x46, 0x21, 0x57, 0x43, 0x9d, 0xa3, 0x21, 0x6d, 0xd6, 0x14, 0xae, 0xcd, 0x2e, 0x11, 0x2d,
+	0x70, 0x0b, 0xaa, 0x01, 0x73, 0xb1, 0x68, 0xce, 0x89, 0x34, 0xa6, 0xed, 0xd8, 0x01, 0x93, 0xcd,
+	0x3a, 0x41, 0x0f, 0xa0, 0xcc, 0x82, 0xd8, 0x53, 0xe1, 0xfe, 0xdf, 0xde, 0x0d, 0x4a, 0x44, 0xef,
+	0xb8, 0x03, 0x15, 0xdd, 0x19, 0x16, 0x1b, 0x39, 0xe3, 0x42, 0x23, 0x97, 0xeb, 0xd8, 0xcc, 0x7c,
+	0xc7, 0xa6, 0x97, 0xf9, 0xb9, 0x05, 0x95, 0x7d, 0x4c, 0x71, 0xc4, 0xd0, 0x3d, 0x78, 0x23, 0xc2,
+	0x63, 0x37, 0x77, 0x8d, 0x68, 0x1f, 0x19, 0xd2, 0x47, 0x28, 0xc2, 0xe3, 0xec, 0xc6, 0x50, 0xde,
+	0xba, 0x0d, 0x2b, 0x42, 0x24, 0x8b, 0x48, 0x53, 0xb2, 0xd6, 0x22, 0x3c, 0xde, 0x9a, 0x06, 0xe5,
+	0xd7, 0xe1, 0x26, 0x19, 0xa7, 0x01, 0xc5, 0xa2, 0x27, 0x70, 0x07, 0x61, 0xe2, 0x15, 0x9f, 0x4a,
+	0x6f, 0x64, 0xd4, 0xbe, 0x20, 0x2a, 0xa9, 0x0d, 0x68, 0x0e, 0x30, 0x23, 0x33, 0x4d, 0x86, 0x98,
+	0xe9, 0x70, 0x6f, 0x08, 0xbc, 0xd6, 0xe2, 0x31, 0x66, 0xe8, 0x3e, 0xbc, 0x95, 0x12, 0x9a, 0x55,
+	0x84, 0x82, 0x88, 0x4a, 0x82, 0x9b, 0x29, 0xa1, 0x33, 0xf7, 0xe4, 0x44, 0xbf, 0x0a, 0x88, 0xe1,
+	0x28, 0x0d, 0x83, 0x78, 0xe8, 0x72, 0x7a, 0xa6, 0xd5, 0xaa, 0x48, 0x99, 0xe6, 0x94, 0xf2, 0x2e,
+	0x3d, 0x53, 0x2a, 0x7d, 0x0b, 0x5a, 0x3a, 0xcd, 0x29, 0x39, 0xc5, 0xe2, 0xe1, 0x9a, 0x50, 0x8f,
+	0xc4, 0x1c, 0x0f, 0x89, 0x8c, 0x09, 0xcb, 0xb9, 0x99, 0xe8, 0xcc, 0x12, 0xe4, 0xfd, 0x19, 0x15,
+	0x3d, 0x80, 0xb7, 0x82, 0x58, 0x45, 0x82, 0x9b, 0x92, 0x18, 0x87, 0xfc, 0xcc, 0xf5, 0x47, 0xea,
+	0xcc, 0x72, 0xbe, 0xb4, 0x9c, 0x37, 0xa7, 0x0c, 0xfb, 0x8a, 0xbe, 0xad, 0xc9, 0xe8, 0x1d, 0xb8,
+	0x36, 0xd3, 0x31, 0x22, 0xfc, 0x28, 0xf1, 0xe5, 0xfc, 0x68, 0x39, 0x8d, 0x29, 0x7a, 0x4f, 0x62,
+	0x51, 0x0f, 0xae, 0x53, 0x1c, 0xfb, 0x49, 0x14, 0x13, 0xc6, 0xdc, 0x43, 0x1c, 0x86, 0x03, 0xec,
+	0x1d, 0xb7, 0x40, 0x39, 0x2f, 0x23, 0x3d, 0xd2, 0x94, 0x07, 0xf6, 0xaf, 0xde, 0x6f, 0x2f, 0x89,
+	0x20, 0xb8, 0xf3, 0x0d, 0x68, 0x5e, 0x9c, 0x37, 0x91, 0x0d, 0xd6, 0x13, 0x42, 0xd2, 0xe6, 0x12,
+	0x02, 0xa8, 0xec, 0xc4, 0xe2, 0xa5, 0xa8, 0x69, 0xa0, 0x1a, 0x2c, 0x6f, 0x07, 0x4c, 0x02, 0xe6,
+	0x9d, 0xef, 0xc0, 0x4a, 0x21, 0x65, 0x84, 0xcc, 0xd3, 0x94, 0xc4, 0xcd, 0x25, 0xc1, 0x77, 0x30,
+	0xf2, 0x3c, 0xc2, 0x98, 0x12, 0x7a, 0x84, 0x83, 0x70, 0x44, 0x49, 0xd3, 0x14, 0xc0, 0x8e, 0x70,
+	0x38, 0xf1, 0x9b, 0xa5, 0xfe, 0xfe, 0x07, 0x93, 0x75, 0xe3, 0xc3, 0xc9, 0xba, 0xf1, 0xb7, 0xc9,
+	0xba, 0xf1, 0xde, 0xf3, 0xf5, 0xa5, 0x0f, 0x9f, 0xaf, 0x2f, 0xfd, 0xf9, 0xf9, 0xfa, 0xd2, 0x8f,
+	0xbe, 0x99, 0xab, 0x2a, 0x22, 0x67, 0x65, 0x62, 0x78, 0x49, 0xd8, 0x9b, 0x25, 0x70, 0x4f, 0xfd,
+	0x16, 0xff, 0xf9, 0x30, 0xa8, 0x48, 0xc6, 0xaf, 0xfd, 0x67, 0x00, 0x61, 0xc5, 0xf1, 0x3c, 0x95,
+	0x18, 0x00, 0x00,
 }
 
 func (this *MsgRequestData) Equal(that interface{}) bool {
@@ -1715,6 +2029,22 @@ func (this *MsgRequestData) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Sender, that1.Sender) {
 		return false
 	}
+	if len(this.AllowedValidators) != len(that1.AllowedValidators) {
+		return false
+	}
+	for i := range this.AllowedValidators {
+		if !bytes.Equal(this.AllowedValidators[i], that1.AllowedValidators[i]) {
+			return false
+		}
+	}
+	if len(this.DeniedValidators) != len(that1.DeniedValidators) {
+		return false
+	}
+	for i := range this.DeniedValidators {
+		if !bytes.Equal(this.DeniedValidators[i], that1.DeniedValidators[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *MsgReportData) Equal(that interface{}) bool {
@@ -1870,6 +2200,9 @@ func (this *MsgCreateOracleScript) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Sender, that1.Sender) {
 		return false
 	}
+	if this.StrictSchema != that1.StrictSchema {
+		return false
+	}
 	return true
 }
 func (this *MsgEditOracleScript) Equal(that interface{}) bool {
@@ -1915,6 +2248,9 @@ func (this *MsgEditOracleScript) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Sender, that1.Sender) {
 		return false
 	}
+	if this.StrictSchema != that1.StrictSchema {
+		return false
+	}
 	return true
 }
 func (this *MsgActivate) Equal(that interface{}) bool {
@@ -1995,6 +2331,69 @@ func (this *MsgRemoveReporter) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *MsgSetVRFKey) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MsgSetVRFKey)
+	if !ok {
+		that2, ok := that.(MsgSetVRFKey)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Validator, that1.Validator) {
+		return false
+	}
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+		return false
+	}
+	return true
+}
+func (this *MsgSubmitVRF) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MsgSubmitVRF)
+	if !ok {
+		that2, ok := that.(MsgSubmitVRF)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Height != that1.Height {
+		return false
+	}
+	if !bytes.Equal(this.Output, that1.Output) {
+		return false
+	}
+	if !bytes.Equal(this.Proof, that1.Proof) {
+		return false
+	}
+	if !bytes.Equal(this.Validator, that1.Validator) {
+		return false
+	}
+	if !bytes.Equal(this.Reporter, that1.Reporter) {
+		return false
+	}
+	return true
+}
 func (this *DataSource) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -2065,6 +2464,9 @@ func (this *OracleScript) Equal(that interface{}) bool {
 	if this.SourceCodeURL != that1.SourceCodeURL {
 		return false
 	}
+	if this.StrictSchema != that1.StrictSchema {
+		return false
+	}
 	return true
 }
 func (this *RawRequest) Equal(that interface{}) bool {
@@ -2322,14 +2724,14 @@ func (this *ValidatorStatus) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *Params) Equal(that interface{}) bool {
+func (this *VRFKey) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Params)
+	that1, ok := that.(*VRFKey)
 	if !ok {
-		that2, ok := that.(Params)
+		that2, ok := that.(VRFKey)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2341,13 +2743,40 @@ func (this *Params) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.MaxRawRequestCount != that1.MaxRawRequestCount {
-		return false
-	}
-	if this.MaxAskCount != that1.MaxAskCount {
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
 		return false
 	}
-	if this.ExpirationBlockCount != that1.ExpirationBlockCount {
+	if this.Height != that1.Height {
+		return false
+	}
+	return true
+}
+func (this *Params) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Params)
+	if !ok {
+		that2, ok := that.(Params)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxRawRequestCount != that1.MaxRawRequestCount {
+		return false
+	}
+	if this.MaxAskCount != that1.MaxAskCount {
+		return false
+	}
+	if this.ExpirationBlockCount != that1.ExpirationBlockCount {
 		return false
 	}
 	if this.BaseRequestGas != that1.BaseRequestGas {
@@ -2365,6 +2794,12 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.InactivePenaltyDuration != that1.InactivePenaltyDuration {
 		return false
 	}
+	if this.SamplingMethod != that1.SamplingMethod {
+		return false
+	}
+	if this.RandomnessFallback != that1.RandomnessFallback {
+		return false
+	}
 	return true
 }
 func (m *MsgRequestData) Marshal() (dAtA []byte, err error) {
@@ -2387,6 +2822,24 @@ func (m *MsgRequestData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeniedValidators) > 0 {
+		for iNdEx := len(m.DeniedValidators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DeniedValidators[iNdEx])
+			copy(dAtA[i:], m.DeniedValidators[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.DeniedValidators[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.AllowedValidators) > 0 {
+		for iNdEx := len(m.AllowedValidators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedValidators[iNdEx])
+			copy(dAtA[i:], m.AllowedValidators[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.AllowedValidators[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
 	if len(m.Sender) > 0 {
 		i -= len(m.Sender)
 		copy(dAtA[i:], m.Sender)
@@ -2623,6 +3076,16 @@ func (m *MsgCreateOracleScript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StrictSchema {
+		i--
+		if m.StrictSchema {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
 	if len(m.Sender) > 0 {
 		i -= len(m.Sender)
 		copy(dAtA[i:], m.Sender)
@@ -2695,6 +3158,11 @@ func (m *MsgEditOracleScript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StrictSchema != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StrictSchema))
+		i--
+		dAtA[i] = 0x48
+	}
 	if len(m.Sender) > 0 {
 		i -= len(m.Sender)
 		copy(dAtA[i:], m.Sender)
@@ -2856,6 +3324,99 @@ func (m *MsgRemoveReporter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MsgSetVRFKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgSetVRFKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgSetVRFKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Validator) > 0 {
+		i -= len(m.Validator)
+		copy(dAtA[i:], m.Validator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Validator)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgSubmitVRF) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgSubmitVRF) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgSubmitVRF) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reporter) > 0 {
+		i -= len(m.Reporter)
+		copy(dAtA[i:], m.Reporter)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Reporter)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Validator) > 0 {
+		i -= len(m.Validator)
+		copy(dAtA[i:], m.Validator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Validator)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Proof) > 0 {
+		i -= len(m.Proof)
+		copy(dAtA[i:], m.Proof)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Proof)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Output) > 0 {
+		i -= len(m.Output)
+		copy(dAtA[i:], m.Output)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Output)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DataSource) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2927,6 +3488,16 @@ func (m *OracleScript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StrictSchema {
+		i--
+		if m.StrictSchema {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.SourceCodeURL) > 0 {
 		i -= len(m.SourceCodeURL)
 		copy(dAtA[i:], m.SourceCodeURL)
@@ -3344,6 +3915,41 @@ func (m *ValidatorStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *VRFKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VRFKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VRFKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Params) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3364,6 +3970,16 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RandomnessFallback != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.RandomnessFallback))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.SamplingMethod != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SamplingMethod))
+		i--
+		dAtA[i] = 0x48
+	}
 	if m.InactivePenaltyDuration != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InactivePenaltyDuration))
 		i--
@@ -3445,6 +4061,18 @@ func (m *MsgRequestData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.AllowedValidators) > 0 {
+		for _, b := range m.AllowedValidators {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.DeniedValidators) > 0 {
+		for _, b := range m.DeniedValidators {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -3569,6 +4197,9 @@ func (m *MsgCreateOracleScript) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.StrictSchema {
+		n += 2
+	}
 	return n
 }
 
@@ -3609,6 +4240,9 @@ func (m *MsgEditOracleScript) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.StrictSchema != 0 {
+		n += 1 + sovTypes(uint64(m.StrictSchema))
+	}
 	return n
 }
 
@@ -3659,6 +4293,51 @@ func (m *MsgRemoveReporter) Size() (n int) {
 	return n
 }
 
+func (m *MsgSetVRFKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *MsgSubmitVRF) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.Output)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Proof)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Reporter)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *DataSource) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3714,6 +4393,9 @@ func (m *OracleScript) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.StrictSchema {
+		n += 2
+	}
 	return n
 }
 
@@ -3889,6 +4571,22 @@ func (m *ValidatorStatus) Size() (n int) {
 	return n
 }
 
+func (m *VRFKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
 func (m *Params) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3919,6 +4617,12 @@ func (m *Params) Size() (n int) {
 	if m.InactivePenaltyDuration != 0 {
 		n += 1 + sovTypes(uint64(m.InactivePenaltyDuration))
 	}
+	if m.SamplingMethod != 0 {
+		n += 1 + sovTypes(uint64(m.SamplingMethod))
+	}
+	if m.RandomnessFallback != 0 {
+		n += 1 + sovTypes(uint64(m.RandomnessFallback))
+	}
 	return n
 }
 
@@ -4114,19 +4818,83 @@ func (m *MsgRequestData) Unmarshal(dAtA []byte) error {
 				m.Sender = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedValidators", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedValidators = append(m.AllowedValidators, make([]byte, postIndex-iNdEx))
+			copy(m.AllowedValidators[len(m.AllowedValidators)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeniedValidators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeniedValidators = append(m.DeniedValidators, make([]byte, postIndex-iNdEx))
+			copy(m.DeniedValidators[len(m.DeniedValidators)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -5028,6 +5796,26 @@ func (m *MsgCreateOracleScript) Unmarshal(dAtA []byte) error {
 				m.Sender = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictSchema", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StrictSchema = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5330,6 +6118,25 @@ func (m *MsgEditOracleScript) Unmarshal(dAtA []byte) error {
 				m.Sender = []byte{}
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictSchema", wireType)
+			}
+			m.StrictSchema = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StrictSchema |= StrictSchemaEdit(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5373,19 +6180,261 @@ func (m *MsgActivate) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MsgActivate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MsgActivate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgActivate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgActivate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = append(m.Validator[:0], dAtA[iNdEx:postIndex]...)
+			if m.Validator == nil {
+				m.Validator = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgAddReporter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgAddReporter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = append(m.Validator[:0], dAtA[iNdEx:postIndex]...)
+			if m.Validator == nil {
+				m.Validator = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reporter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reporter = append(m.Reporter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Reporter == nil {
+				m.Reporter = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgRemoveReporter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgRemoveReporter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgRemoveReporter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = append(m.Validator[:0], dAtA[iNdEx:postIndex]...)
+			if m.Validator == nil {
+				m.Validator = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reporter", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5412,9 +6461,9 @@ func (m *MsgActivate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Validator = append(m.Validator[:0], dAtA[iNdEx:postIndex]...)
-			if m.Validator == nil {
-				m.Validator = []byte{}
+			m.Reporter = append(m.Reporter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Reporter == nil {
+				m.Reporter = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5441,7 +6490,7 @@ func (m *MsgActivate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
+func (m *MsgSetVRFKey) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5464,10 +6513,10 @@ func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MsgAddReporter: wiretype end group for non-group")
+			return fmt.Errorf("proto: MsgSetVRFKey: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MsgAddReporter: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MsgSetVRFKey: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5506,7 +6555,7 @@ func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reporter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5533,9 +6582,9 @@ func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reporter = append(m.Reporter[:0], dAtA[iNdEx:postIndex]...)
-			if m.Reporter == nil {
-				m.Reporter = []byte{}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5562,7 +6611,7 @@ func (m *MsgAddReporter) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MsgRemoveReporter) Unmarshal(dAtA []byte) error {
+func (m *MsgSubmitVRF) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5585,13 +6634,100 @@ func (m *MsgRemoveReporter) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MsgRemoveReporter: wiretype end group for non-group")
+			return fmt.Errorf("proto: MsgSubmitVRF: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MsgRemoveReporter: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MsgSubmitVRF: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
+			if m.Output == nil {
+				m.Output = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proof = append(m.Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Proof == nil {
+				m.Proof = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
@@ -5625,7 +6761,7 @@ func (m *MsgRemoveReporter) Unmarshal(dAtA []byte) error {
 				m.Validator = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Reporter", wireType)
 			}
@@ -6089,6 +7225,26 @@ func (m *OracleScript) Unmarshal(dAtA []byte) error {
 			}
 			m.SourceCodeURL = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictSchema", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StrictSchema = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -7275,6 +8431,112 @@ func (m *ValidatorStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *VRFKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VRFKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VRFKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Params) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -7456,6 +8718,44 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SamplingMethod", wireType)
+			}
+			m.SamplingMethod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SamplingMethod |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RandomnessFallback", wireType)
+			}
+			m.RandomnessFallback = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RandomnessFallback |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
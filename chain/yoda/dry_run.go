@@ -0,0 +1,188 @@
+package yoda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+	httpclient "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	"github.com/bandprotocol/bandchain/chain/yoda/executor"
+)
+
+const (
+	flagDataSourceID = "id"
+	flagFile         = "file"
+	flagKey          = "key"
+	flagRequestID    = "request-id"
+	flagExternalID   = "external-id"
+)
+
+// DryRunResult is the outcome of executing a data source script the way yoda would for a report.
+type DryRunResult struct {
+	ExitCode      uint32 `json:"exit_code"`
+	Output        string `json:"output"`
+	Version       string `json:"version"`
+	Elapsed       string `json:"elapsed"`
+	OutputSize    int    `json:"output_size"`
+	FitsRawReport bool   `json:"fits_raw_report"`
+}
+
+// DryRun executes the data source executable with the calldata through the context's executor,
+// with the environment yoda gives for the verification message signed by the given key.
+func DryRun(c *Context, exec []byte, calldata string, key keys.Info, vmsg VerificationMessage) (DryRunResult, error) {
+	env, err := GetExecEnv(key, vmsg)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+	start := time.Now()
+	result, err := c.executor.Exec(exec, calldata, env)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+	return DryRunResult{
+		ExitCode:      result.Code,
+		Output:        string(result.Output),
+		Version:       result.Version,
+		Elapsed:       time.Since(start).String(),
+		OutputSize:    len(result.Output),
+		FitsRawReport: len(result.Output) <= types.MaxDataSize,
+	}, nil
+}
+
+// getDryRunKey returns the key with the given name, or the first key if the name is empty.
+func getDryRunKey(name string) (keys.Info, error) {
+	if name != "" {
+		return keybase.Get(name)
+	}
+	infos, err := keybase.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, errors.New("No key available")
+	}
+	return infos[0], nil
+}
+
+// getDryRunExecutable returns the executable of the data source with the given ID from BandChain.
+func getDryRunExecutable(c *Context, l *Logger, id types.DataSourceID) ([]byte, error) {
+	var err error
+	c.client, err = httpclient.New(cfg.NodeURI, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+	c.fileCache, err = newFileCache()
+	if err != nil {
+		return nil, err
+	}
+	c.dataSourceCache = new(sync.Map)
+	hash, err := GetDataSourceHash(c, l, id)
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("data source %d not found", id)
+	}
+	return GetExecutable(c, l, hash)
+}
+
+func dryRunCmd(c *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dry-run [calldata] (--id [data-source-id] | --file [path])",
+		Short: "Execute a data source script the way the oracle process would and print the result",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`Execute a data source script, either a data source on BandChain or a local file, with the
+given calldata through the configured executor. The script gets the same BAND_* environment
+variables as in a report, signed by one of the oracle keys. The chain ID, validator, node and
+executor are read from the yoda configuration.
+
+Example:
+$ yoda dry-run "BTC ETH" --id 1
+$ yoda dry-run "BTC ETH" --file ./crypto_price.py --key reporter1 --request-id 42 --external-id 3
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.ChainID == "" {
+				return errors.New("Chain ID must not be empty")
+			}
+			var err error
+			c.validator, err = sdk.ValAddressFromBech32(cfg.Validator)
+			if err != nil {
+				return err
+			}
+			allowLevel, err := log.AllowLevel(cfg.LogLevel)
+			if err != nil {
+				return err
+			}
+			l := NewLogger(allowLevel)
+			id, err := cmd.Flags().GetInt64(flagDataSourceID)
+			if err != nil {
+				return err
+			}
+			file, err := cmd.Flags().GetString(flagFile)
+			if err != nil {
+				return err
+			}
+			var exec []byte
+			switch {
+			case id != 0 && file != "":
+				return fmt.Errorf("only one of --%s and --%s can be set", flagDataSourceID, flagFile)
+			case id != 0:
+				exec, err = getDryRunExecutable(c, l, types.DataSourceID(id))
+			case file != "":
+				exec, err = ioutil.ReadFile(file)
+			default:
+				return fmt.Errorf("one of --%s and --%s must be set", flagDataSourceID, flagFile)
+			}
+			if err != nil {
+				return err
+			}
+			keyName, err := cmd.Flags().GetString(flagKey)
+			if err != nil {
+				return err
+			}
+			key, err := getDryRunKey(keyName)
+			if err != nil {
+				return err
+			}
+			requestID, err := cmd.Flags().GetInt64(flagRequestID)
+			if err != nil {
+				return err
+			}
+			externalID, err := cmd.Flags().GetInt64(flagExternalID)
+			if err != nil {
+				return err
+			}
+			c.executor, err = executor.NewExecutor(cfg.Executor)
+			if err != nil {
+				return err
+			}
+			vmsg := NewVerificationMessage(cfg.ChainID, c.validator, types.RequestID(requestID), types.ExternalID(externalID))
+			result, err := DryRun(c, exec, args[0], key, vmsg)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagDataSourceID, 0, "ID of the data source on BandChain to execute")
+	cmd.Flags().String(flagFile, "", "Path to a local data source script to execute")
+	cmd.Flags().String(flagKey, "", "Name of the key to sign the verification message with, the first key if empty")
+	cmd.Flags().Int64(flagRequestID, 1, "Request ID given to the data source script")
+	cmd.Flags().Int64(flagExternalID, 1, "External ID given to the data source script")
+	return cmd
+}
@@ -0,0 +1,59 @@
+package yoda
+
+import (
+	"strings"
+	"testing"
+
+	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	"github.com/bandprotocol/bandchain/chain/yoda/executor"
+)
+
+// echoExec is an executor that outputs the calldata repeated the given number of times, along
+// with the environment it was given.
+type echoExec struct {
+	repeat int
+	env    map[string]interface{}
+}
+
+func (e *echoExec) Exec(exec []byte, arg string, env interface{}) (executor.ExecResult, error) {
+	e.env = env.(map[string]interface{})
+	return executor.ExecResult{Output: []byte(strings.Repeat(arg, e.repeat)), Code: 0, Version: "mock"}, nil
+}
+
+func TestDryRun(t *testing.T) {
+	app.SetBech32AddressPrefixesAndBip44CoinType(sdk.GetConfig())
+	keybase = keys.NewInMemory()
+	key, _, err := keybase.CreateMnemonic("reporter", keys.English, ckeys.DefaultKeyPass, keys.Secp256k1)
+	require.NoError(t, err)
+	validator, _ := sdk.ValAddressFromBech32("bandvaloper1p40yh3zkmhcv0ecqp3mcazy83sa57rgjde6wec")
+	vmsg := NewVerificationMessage("bandchain", validator, types.RequestID(42), types.ExternalID(3))
+
+	exec := &echoExec{repeat: 2}
+	result, err := DryRun(&Context{executor: exec}, []byte("script"), "beeb", key, vmsg)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), result.ExitCode)
+	require.Equal(t, "beebbeeb", result.Output)
+	require.Equal(t, "mock", result.Version)
+	require.Equal(t, 8, result.OutputSize)
+	require.True(t, result.FitsRawReport)
+
+	require.Equal(t, "bandchain", exec.env["BAND_CHAIN_ID"])
+	require.Equal(t, validator.String(), exec.env["BAND_VALIDATOR"])
+	require.Equal(t, "42", exec.env["BAND_REQUEST_ID"])
+	require.Equal(t, "3", exec.env["BAND_EXTERNAL_ID"])
+	require.Equal(t, sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, key.GetPubKey()), exec.env["BAND_REPORTER"])
+	sig := exec.env["BAND_SIGNATURE"].([]byte)
+	require.True(t, key.GetPubKey().VerifyBytes(vmsg.GetSignBytes(), sig))
+
+	exec = &echoExec{repeat: types.MaxDataSize/4 + 1}
+	result, err = DryRun(&Context{executor: exec}, []byte("script"), "beeb", key, vmsg)
+	require.NoError(t, err)
+	require.Equal(t, types.MaxDataSize+4, result.OutputSize)
+	require.False(t, result.FitsRawReport)
+}
@@ -1,6 +1,7 @@
 package yoda
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 
 	"github.com/bandprotocol/bandchain/chain/app"
+	"github.com/bandprotocol/bandchain/chain/pkg/filecache"
 	"github.com/bandprotocol/bandchain/chain/x/oracle/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
@@ -148,6 +150,10 @@ func SubmitReport(c *Context, l *Logger, keyIndex int64, reports []ReportMsgWith
 func GetExecutable(c *Context, l *Logger, hash string) ([]byte, error) {
 	resValue, err := c.fileCache.GetFile(hash)
 	if err != nil {
+		var corrupted *filecache.CorruptedFileError
+		if errors.As(err, &corrupted) {
+			l.Error(":skull: Removed corrupted data source file: %s", c, err.Error())
+		}
 		l.Debug(":magnifying_glass_tilted_left: Fetching data source hash: %s from bandchain querier", hash)
 		res, err := c.client.ABCIQueryWithOptions(fmt.Sprintf("custom/%s/%s/%s", types.StoreKey, types.QueryData, hash), nil, rpcclient.ABCIQueryOptions{})
 		if err != nil {
@@ -155,7 +161,10 @@ func GetExecutable(c *Context, l *Logger, hash string) ([]byte, error) {
 			return nil, err
 		}
 		resValue = res.Response.GetValue()
-		c.fileCache.AddFile(resValue)
+		if filename := c.fileCache.AddFile(resValue); filename != hash {
+			l.Error(":exploding_head: Data source from bandchain querier does not match hash: %s", c, hash)
+			return nil, fmt.Errorf("data source content hash %s does not match %s", filename, hash)
+		}
 	} else {
 		l.Debug(":card_file_box: Found data source hash: %s in cache file", hash)
 	}
@@ -217,6 +217,23 @@ func handleRawRequests(c *Context, l *Logger, id types.RequestID, reqs []rawRequ
 	return
 }
 
+// GetExecEnv returns the BAND_* environment variables given to data source executables, with
+// the verification message signed by the given key.
+func GetExecEnv(key keys.Info, vmsg VerificationMessage) (map[string]interface{}, error) {
+	sig, pubkey, err := keybase.Sign(key.GetName(), ckeys.DefaultKeyPass, vmsg.GetSignBytes())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"BAND_CHAIN_ID":    vmsg.ChainID,
+		"BAND_VALIDATOR":   vmsg.Validator.String(),
+		"BAND_REQUEST_ID":  strconv.Itoa(int(vmsg.RequestID)),
+		"BAND_EXTERNAL_ID": strconv.Itoa(int(vmsg.ExternalID)),
+		"BAND_REPORTER":    sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, pubkey),
+		"BAND_SIGNATURE":   sig,
+	}, nil
+}
+
 func handleRawRequest(c *Context, l *Logger, req rawRequest, key keys.Info, id types.RequestID, processingResultCh chan processingResult) {
 	c.updateHandlingGauge(1)
 	defer c.updateHandlingGauge(-1)
@@ -234,7 +251,7 @@ func handleRawRequest(c *Context, l *Logger, req rawRequest, key keys.Info, id t
 	}
 
 	vmsg := NewVerificationMessage(cfg.ChainID, c.validator, id, req.externalID)
-	sig, pubkey, err := keybase.Sign(key.GetName(), ckeys.DefaultKeyPass, vmsg.GetSignBytes())
+	env, err := GetExecEnv(key, vmsg)
 	if err != nil {
 		l.Error(":skull: Failed to sign verify message: %s", c, err.Error())
 		processingResultCh <- processingResult{
@@ -244,14 +261,7 @@ func handleRawRequest(c *Context, l *Logger, req rawRequest, key keys.Info, id t
 		return
 	}
 
-	result, err := c.executor.Exec(exec, req.calldata, map[string]interface{}{
-		"BAND_CHAIN_ID":    vmsg.ChainID,
-		"BAND_VALIDATOR":   vmsg.Validator.String(),
-		"BAND_REQUEST_ID":  strconv.Itoa(int(vmsg.RequestID)),
-		"BAND_EXTERNAL_ID": strconv.Itoa(int(vmsg.ExternalID)),
-		"BAND_REPORTER":    sdk.MustBech32ifyPubKey(sdk.Bech32PubKeyTypeAccPub, pubkey),
-		"BAND_SIGNATURE":   sig,
-	})
+	result, err := c.executor.Exec(exec, req.calldata, env)
 
 	if err != nil {
 		l.Error(":skull: Failed to execute data source script: %s", c, err.Error())
@@ -23,6 +23,7 @@ const (
 	flagRPCPollInterval  = "rpc-poll-interval"
 	flagMaxTry           = "max-try"
 	flagMaxReport        = "max-report"
+	flagMaxFileCacheSize = "max-file-cache-size"
 )
 
 // Config data structure for yoda daemon.
@@ -38,6 +39,7 @@ type Config struct {
 	MaxTry            uint64 `mapstructure:"max-try"`             // The maximum number of tries to submit a report transaction
 	MaxReport         uint64 `mapstructure:"max-report"`          // The maximum number of reports in one transaction
 	MetricsListenAddr string `mapstructure:"metrics-listen-addr"` // Address to listen on for prometheus metrics
+	MaxFileCacheSize  int64  `mapstructure:"max-file-cache-size"` // The maximum size in bytes of cached data source files, 0 for no limit
 }
 
 // Global instances.
@@ -70,7 +72,7 @@ func Main() {
 		Short: "BandChain oracle daemon to subscribe and response to oracle requests",
 	}
 
-	rootCmd.AddCommand(configCmd(), keysCmd(ctx), runCmd(ctx), version.Cmd)
+	rootCmd.AddCommand(configCmd(), keysCmd(ctx), runCmd(ctx), dryRunCmd(ctx), version.Cmd)
 	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
 		home, err := rootCmd.PersistentFlags().GetString(flags.FlagHome)
 		if err != nil {
@@ -105,6 +105,16 @@ func runImpl(c *Context, l *Logger) error {
 	}
 }
 
+// newFileCache returns the cache of data source files in the home directory, bounded by the
+// configured maximum size if set.
+func newFileCache() (filecache.Cache, error) {
+	dir := filepath.Join(viper.GetString(flags.FlagHome), "files")
+	if cfg.MaxFileCacheSize > 0 {
+		return filecache.NewWithMaxSize(dir, cfg.MaxFileCacheSize)
+	}
+	return filecache.New(dir), nil
+}
+
 func runCmd(c *Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "run",
@@ -149,7 +159,10 @@ func runCmd(c *Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			c.fileCache = filecache.New(filepath.Join(viper.GetString(flags.FlagHome), "files"))
+			c.fileCache, err = newFileCache()
+			if err != nil {
+				return err
+			}
 			c.broadcastTimeout, err = time.ParseDuration(cfg.BroadcastTimeout)
 			if err != nil {
 				return err
@@ -179,6 +192,7 @@ func runCmd(c *Context) *cobra.Command {
 	cmd.Flags().String(flagRPCPollInterval, "1s", "The duration of rpc poll interval")
 	cmd.Flags().Uint64(flagMaxTry, 5, "The maximum number of tries to submit a report transaction")
 	cmd.Flags().Uint64(flagMaxReport, 10, "The maximum number of reports in one transaction")
+	cmd.Flags().Int64(flagMaxFileCacheSize, 0, "The maximum size in bytes of cached data source files, 0 for no limit")
 	viper.BindPFlag(flags.FlagChainID, cmd.Flags().Lookup(flags.FlagChainID))
 	viper.BindPFlag(flags.FlagNode, cmd.Flags().Lookup(flags.FlagNode))
 	viper.BindPFlag(flagValidator, cmd.Flags().Lookup(flagValidator))
@@ -189,5 +203,6 @@ func runCmd(c *Context) *cobra.Command {
 	viper.BindPFlag(flagRPCPollInterval, cmd.Flags().Lookup(flagRPCPollInterval))
 	viper.BindPFlag(flagMaxTry, cmd.Flags().Lookup(flagMaxTry))
 	viper.BindPFlag(flagMaxReport, cmd.Flags().Lookup(flagMaxReport))
+	viper.BindPFlag(flagMaxFileCacheSize, cmd.Flags().Lookup(flagMaxFileCacheSize))
 	return cmd
 }
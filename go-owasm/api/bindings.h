@@ -32,6 +32,7 @@ enum Error {
   Error_BadExternalIDError = 132,
   Error_UnavailableExternalDataError = 133,
   Error_RepeatSetReturnDataError = 134,
+  Error_UnavailableRandomValueError = 135,
   Error_UnknownError = 255,
 };
 typedef int32_t Error;
@@ -51,6 +52,10 @@ typedef struct Span {
   uintptr_t cap;
 } Span;
 
+typedef struct cache_t {
+  uint8_t _private[0];
+} cache_t;
+
 typedef struct env_t {
   uint8_t _private[0];
 } env_t;
@@ -64,6 +69,13 @@ typedef struct EnvDispatcher {
   Error (*ask_external_data)(env_t*, int64_t eid, int64_t did, Span data);
   Error (*get_external_data_status)(env_t*, int64_t eid, int64_t vid, int64_t *status);
   Error (*get_external_data)(env_t*, int64_t eid, int64_t vid, Span *data);
+  int64_t (*get_request_id)(env_t*);
+  int64_t (*get_request_height)(env_t*);
+  int64_t (*get_request_time)(env_t*);
+  Error (*get_client_id)(env_t*, Span *client_id);
+  Error (*get_validator_address)(env_t*, int64_t vid, Span *address);
+  Error (*get_validator_power)(env_t*, int64_t vid, int64_t *power);
+  Error (*get_random_value)(env_t*, Span *random_value);
 } EnvDispatcher;
 
 typedef struct Env {
@@ -77,9 +89,14 @@ typedef struct RunOutput {
 
 Error do_compile(Span input, Span *output);
 
-Error do_run(Span code,
+Error do_run(cache_t *cache,
+             Span code,
              uint32_t gas_limit,
              int64_t span_size,
              bool is_prepare,
              Env env,
              RunOutput *output);
+
+cache_t *init_cache(uint32_t cache_size);
+
+void release_cache(cache_t *cache);
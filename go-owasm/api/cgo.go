@@ -18,4 +18,18 @@ package api
 // Error cGetExternalDataStatus_cgo(env_t *e, int64_t eid, int64_t vid, int64_t *status) { return cGetExternalDataStatus(e, eid, vid, status); }
 // Error cGetExternalData(env_t *e, int64_t eid, int64_t vid, Span *data);
 // Error cGetExternalData_cgo(env_t *e, int64_t eid, int64_t vid, Span *data) { return cGetExternalData(e, eid, vid, data); }
+// int64_t cGetRequestID(env_t *e);
+// int64_t cGetRequestID_cgo(env_t *e) { return cGetRequestID(e); }
+// int64_t cGetRequestHeight(env_t *e);
+// int64_t cGetRequestHeight_cgo(env_t *e) { return cGetRequestHeight(e); }
+// int64_t cGetRequestTime(env_t *e);
+// int64_t cGetRequestTime_cgo(env_t *e) { return cGetRequestTime(e); }
+// Error cGetClientID(env_t *e, Span *clientID);
+// Error cGetClientID_cgo(env_t *e, Span *clientID) { return cGetClientID(e, clientID); }
+// Error cGetValidatorAddress(env_t *e, int64_t vid, Span *address);
+// Error cGetValidatorAddress_cgo(env_t *e, int64_t vid, Span *address) { return cGetValidatorAddress(e, vid, address); }
+// Error cGetValidatorPower(env_t *e, int64_t vid, int64_t *power);
+// Error cGetValidatorPower_cgo(env_t *e, int64_t vid, int64_t *power) { return cGetValidatorPower(e, vid, power); }
+// Error cGetRandomValue(env_t *e, Span *randomValue);
+// Error cGetRandomValue_cgo(env_t *e, Span *randomValue) { return cGetRandomValue(e, randomValue); }
 import "C"
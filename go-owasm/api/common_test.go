@@ -106,3 +106,31 @@ func (env *MockEnv) GetMinCount() int64 {
 func (env *MockEnv) GetAnsCount() (int64, error) {
 	return 0, nil
 }
+
+func (env *MockEnv) GetRequestID() int64 {
+	return 0
+}
+
+func (env *MockEnv) GetRequestHeight() int64 {
+	return 0
+}
+
+func (env *MockEnv) GetRequestTime() int64 {
+	return 0
+}
+
+func (env *MockEnv) GetClientID() []byte {
+	return []byte{}
+}
+
+func (env *MockEnv) GetValidatorAddress(vid int64) ([]byte, error) {
+	return []byte("BEEB"), nil
+}
+
+func (env *MockEnv) GetValidatorPower(vid int64) (int64, error) {
+	return 1, nil
+}
+
+func (env *MockEnv) GetRandomValue() ([]byte, error) {
+	return []byte{}, nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The expected results below were recorded with the Owasm VM of owasm rev 1893224c, which the
+// chain ran before switching to the in-repo owasm crate. Oracle scripts already on chain must
+// compile to the same code and use the same gas on the current VM, or nodes of both versions
+// would disagree on results.
+
+// complexCalldata is the OBI encoding of {ids: [1, 2], calldata: "beeb"}.
+var complexCalldata = []byte("\x00\x00\x00\x02" +
+	"\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x02" +
+	"\x00\x00\x00\x04beeb")
+
+const compatSpanSize = 1 * 1024 * 1024
+
+func TestCompatCompile(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+	code, err := vm.Compile(readWasmFile("complex"), compatSpanSize)
+	require.NoError(t, err)
+	hash := sha256.Sum256(code)
+	require.Equal(t, "24107db0f0f85bdf62760b8cf1498f69d4b27bbcb4bdf20d4e21dab3c164ac0e", hex.EncodeToString(hash[:]))
+	require.Equal(t, 48691, len(code))
+	// Code rejected before must still be rejected for the same reason.
+	_, err = vm.Compile(readWasmFile("test"), compatSpanSize)
+	require.Equal(t, ErrInvalidExports, err)
+}
+
+func TestCompatGas(t *testing.T) {
+	vm, cleanup := newTestVM(t)
+	defer cleanup()
+	code, err := vm.Compile(readWasmFile("complex"), compatSpanSize)
+	require.NoError(t, err)
+
+	env := NewMockEnv(complexCalldata)
+	output, err := vm.Prepare(code, 100000, 1024, env)
+	require.NoError(t, err)
+	require.Equal(t, uint32(5434), output.GasUsed)
+	require.Equal(t, []RawRequest{
+		NewRawRequest(0, 1, []byte("beeb")),
+		NewRawRequest(1, 2, []byte("beeb")),
+	}, env.rawRequests)
+
+	env = NewMockEnv(complexCalldata)
+	output, err = vm.Execute(code, 100000, 1024, env)
+	require.NoError(t, err)
+	require.Equal(t, uint32(4906), output.GasUsed)
+	require.Equal(t, []byte{0, 0, 0, 0}, env.Retdata)
+}
+
+func TestVersionMatchesOwasmCrate(t *testing.T) {
+	manifest, err := ioutil.ReadFile("./../../owasm/Cargo.toml")
+	require.NoError(t, err)
+	version := regexp.MustCompile(`(?m)^version = "(.+)"$`).FindSubmatch(manifest)
+	require.NotNil(t, version)
+	require.Equal(t, "owasm-"+string(version[1]), Version)
+}
@@ -15,6 +15,13 @@ type EnvInterface interface {
 	AskExternalData(eid int64, did int64, data []byte) error
 	GetExternalDataStatus(eid int64, vid int64) (int64, error)
 	GetExternalData(eid int64, vid int64) ([]byte, error)
+	GetRequestID() int64
+	GetRequestHeight() int64
+	GetRequestTime() int64
+	GetClientID() []byte
+	GetValidatorAddress(vid int64) ([]byte, error)
+	GetValidatorPower(vid int64) (int64, error)
+	GetRandomValue() ([]byte, error)
 }
 
 type envIntl struct {
@@ -88,3 +95,52 @@ func cGetExternalData(e *C.env_t, eid C.int64_t, vid C.int64_t, data *C.Span) C.
 	}
 	return writeSpan(data, extData)
 }
+
+//export cGetRequestID
+func cGetRequestID(e *C.env_t) C.int64_t {
+	return C.int64_t((*(*envIntl)(unsafe.Pointer(e))).ext.GetRequestID())
+}
+
+//export cGetRequestHeight
+func cGetRequestHeight(e *C.env_t) C.int64_t {
+	return C.int64_t((*(*envIntl)(unsafe.Pointer(e))).ext.GetRequestHeight())
+}
+
+//export cGetRequestTime
+func cGetRequestTime(e *C.env_t) C.int64_t {
+	return C.int64_t((*(*envIntl)(unsafe.Pointer(e))).ext.GetRequestTime())
+}
+
+//export cGetClientID
+func cGetClientID(e *C.env_t, clientID *C.Span) C.Error {
+	data := (*(*envIntl)(unsafe.Pointer(e))).ext.GetClientID()
+	return writeSpan(clientID, data)
+}
+
+//export cGetValidatorAddress
+func cGetValidatorAddress(e *C.env_t, vid C.int64_t, address *C.Span) C.Error {
+	data, err := (*(*envIntl)(unsafe.Pointer(e))).ext.GetValidatorAddress(int64(vid))
+	if err != nil {
+		return toCError(err)
+	}
+	return writeSpan(address, data)
+}
+
+//export cGetValidatorPower
+func cGetValidatorPower(e *C.env_t, vid C.int64_t, power *C.int64_t) C.Error {
+	p, err := (*(*envIntl)(unsafe.Pointer(e))).ext.GetValidatorPower(int64(vid))
+	if err != nil {
+		return toCError(err)
+	}
+	*power = C.int64_t(p)
+	return C.Error_NoError
+}
+
+//export cGetRandomValue
+func cGetRandomValue(e *C.env_t, randomValue *C.Span) C.Error {
+	data, err := (*(*envIntl)(unsafe.Pointer(e))).ext.GetRandomValue()
+	if err != nil {
+		return toCError(err)
+	}
+	return writeSpan(randomValue, data)
+}
@@ -28,6 +28,7 @@ var (
 	ErrBadExternalID           = errors.New("bad external ID parameter")
 	ErrUnavailableExternalData = errors.New("external data is not available")
 	ErrRepeatSetReturnData     = errors.New("set return data is called more than once")
+	ErrUnavailableRandomValue  = errors.New("random value of the request is not available")
 	ErrUnknown                 = errors.New("unknown error")
 )
 
@@ -50,6 +51,8 @@ func toCError(err error) C.Error {
 		return C.Error_UnavailableExternalDataError
 	case ErrRepeatSetReturnData:
 		return C.Error_RepeatSetReturnDataError
+	case ErrUnavailableRandomValue:
+		return C.Error_UnavailableRandomValueError
 	default:
 		return C.Error_UnknownError
 	}
@@ -105,6 +108,8 @@ func toGoError(code C.Error) error {
 		return ErrUnavailableExternalData
 	case C.Error_RepeatSetReturnDataError:
 		return ErrRepeatSetReturnData
+	case C.Error_UnavailableRandomValueError:
+		return ErrUnavailableRandomValue
 	default:
 		return ErrUnknown
 	}
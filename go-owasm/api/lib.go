@@ -19,12 +19,44 @@ package api
 // int64_t cGetExternalDataStatus_cgo(env_t *e, int64_t eid, int64_t vid);
 // typedef Span (*get_external_data_fn)(env_t*, int64_t eid, int64_t vid);
 // Span cGetExternalData_cgo(env_t *e, int64_t eid, int64_t vid);
+// typedef int64_t (*get_request_id_fn)(env_t*);
+// int64_t cGetRequestID_cgo(env_t *e);
+// typedef int64_t (*get_request_height_fn)(env_t*);
+// int64_t cGetRequestHeight_cgo(env_t *e);
+// typedef int64_t (*get_request_time_fn)(env_t*);
+// int64_t cGetRequestTime_cgo(env_t *e);
+// typedef Span (*get_client_id_fn)(env_t*);
+// Span cGetClientID_cgo(env_t *e);
+// typedef Span (*get_validator_address_fn)(env_t*, int64_t vid);
+// Span cGetValidatorAddress_cgo(env_t *e, int64_t vid);
+// typedef int64_t (*get_validator_power_fn)(env_t*, int64_t vid);
+// int64_t cGetValidatorPower_cgo(env_t *e, int64_t vid);
+// typedef Span (*get_random_value_fn)(env_t*);
+// Span cGetRandomValue_cgo(env_t *e);
 import "C"
 import (
 	"unsafe"
 )
 
-func Compile(code []byte, spanSize int) ([]byte, error) {
+type RunOutput struct {
+	GasUsed uint32
+}
+
+// Vm runs Owasm code, keeping the native compilation of recently run code in its cache.
+type Vm struct {
+	cache Cache
+}
+
+// NewVm returns a new Vm whose cache keeps at most size compiled modules. Zero disables caching.
+func NewVm(size uint32) (*Vm, error) {
+	cache, err := InitCache(size)
+	if err != nil {
+		return nil, err
+	}
+	return &Vm{cache: cache}, nil
+}
+
+func (vm Vm) Compile(code []byte, spanSize int) ([]byte, error) {
 	inputSpan := copySpan(code)
 	defer freeSpan(inputSpan)
 	outputSpan := newSpan(spanSize)
@@ -33,24 +65,20 @@ func Compile(code []byte, spanSize int) ([]byte, error) {
 	return readSpan(outputSpan), err
 }
 
-type RunOutput struct {
-	GasUsed uint32
-}
-
-func Prepare(code []byte, gasLimit uint32, spanSize int64, env EnvInterface) (RunOutput, error) {
-	return run(code, gasLimit, spanSize, true, env)
+func (vm Vm) Prepare(code []byte, gasLimit uint32, spanSize int64, env EnvInterface) (RunOutput, error) {
+	return vm.run(code, gasLimit, spanSize, true, env)
 }
 
-func Execute(code []byte, gasLimit uint32, spanSize int64, env EnvInterface) (RunOutput, error) {
-	return run(code, gasLimit, spanSize, false, env)
+func (vm Vm) Execute(code []byte, gasLimit uint32, spanSize int64, env EnvInterface) (RunOutput, error) {
+	return vm.run(code, gasLimit, spanSize, false, env)
 }
 
-func run(code []byte, gasLimit uint32, spanSize int64, isPrepare bool, env EnvInterface) (RunOutput, error) {
+func (vm Vm) run(code []byte, gasLimit uint32, spanSize int64, isPrepare bool, env EnvInterface) (RunOutput, error) {
 	codeSpan := copySpan(code)
 	defer freeSpan(codeSpan)
 	envIntl := createEnvIntl(env)
 	output := C.RunOutput{}
-	err := toGoError(C.do_run(codeSpan, C.uint32_t(gasLimit), C.int64_t(spanSize), C.bool(isPrepare), C.Env{
+	err := toGoError(C.do_run(vm.cache.ptr, codeSpan, C.uint32_t(gasLimit), C.int64_t(spanSize), C.bool(isPrepare), C.Env{
 		env: (*C.env_t)(unsafe.Pointer(envIntl)),
 		dis: C.EnvDispatcher{
 			get_calldata:             C.get_calldata_fn(C.cGetCalldata_cgo),
@@ -61,6 +89,13 @@ func run(code []byte, gasLimit uint32, spanSize int64, isPrepare bool, env EnvIn
 			ask_external_data:        C.ask_external_data_fn(C.cAskExternalData_cgo),
 			get_external_data_status: C.get_external_data_status_fn(C.cGetExternalDataStatus_cgo),
 			get_external_data:        C.get_external_data_fn(C.cGetExternalData_cgo),
+			get_request_id:           C.get_request_id_fn(C.cGetRequestID_cgo),
+			get_request_height:       C.get_request_height_fn(C.cGetRequestHeight_cgo),
+			get_request_time:         C.get_request_time_fn(C.cGetRequestTime_cgo),
+			get_client_id:            C.get_client_id_fn(C.cGetClientID_cgo),
+			get_validator_address:    C.get_validator_address_fn(C.cGetValidatorAddress_cgo),
+			get_validator_power:      C.get_validator_power_fn(C.cGetValidatorPower_cgo),
+			get_random_value:         C.get_random_value_fn(C.cGetRandomValue_cgo),
 		},
 	}, &output))
 	if err != nil {
@@ -69,3 +104,19 @@ func run(code []byte, gasLimit uint32, spanSize int64, isPrepare bool, env EnvIn
 		return RunOutput{GasUsed: uint32(output.gas_used)}, nil
 	}
 }
+
+type Cache struct {
+	ptr *C.cache_t
+}
+
+func InitCache(cacheSize uint32) (Cache, error) {
+	ptr, err := C.init_cache(C.uint32_t(cacheSize))
+	if err != nil {
+		return Cache{}, err
+	}
+	return Cache{ptr: ptr}, nil
+}
+
+func ReleaseCache(cache Cache) {
+	C.release_cache(cache.ptr)
+}
@@ -0,0 +1,5 @@
+package api
+
+// Version is the version of the Owasm VM linked into this library. It must be bumped together
+// with the owasm crate, since a new VM may compile the same Wasm code differently.
+const Version = "owasm-0.1.9"
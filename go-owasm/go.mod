@@ -1,4 +1,4 @@
-module github.com/bandprotocol/bandchain/go-owasm
+module github.com/bandprotocol/go-owasm
 
 go 1.13
 
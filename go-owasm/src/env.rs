@@ -6,6 +6,11 @@ pub struct env_t {
     _private: [u8; 0],
 }
 
+#[repr(C)]
+pub struct cache_t {
+    _private: [u8; 0],
+}
+
 #[repr(C)]
 // A struct representing the set of functions Rust can call back to Golang.
 pub struct EnvDispatcher {
@@ -17,6 +22,13 @@ pub struct EnvDispatcher {
     pub ask_external_data: extern "C" fn(*mut env_t, eid: i64, did: i64, data: Span) -> Error,
     pub get_external_data_status: extern "C" fn(*mut env_t, eid: i64, vid: i64, status: &mut i64) -> Error,
     pub get_external_data: extern "C" fn(*mut env_t, eid: i64, vid: i64, data: &mut Span) -> Error,
+    pub get_request_id: extern "C" fn(*mut env_t) -> i64,
+    pub get_request_height: extern "C" fn(*mut env_t) -> i64,
+    pub get_request_time: extern "C" fn(*mut env_t) -> i64,
+    pub get_client_id: extern "C" fn(*mut env_t, client_id: &mut Span) -> Error,
+    pub get_validator_address: extern "C" fn(*mut env_t, vid: i64, address: &mut Span) -> Error,
+    pub get_validator_power: extern "C" fn(*mut env_t, vid: i64, power: &mut i64) -> Error,
+    pub get_random_value: extern "C" fn(*mut env_t, random_value: &mut Span) -> Error,
 }
 
 #[repr(C)]
@@ -2,10 +2,24 @@ mod env;
 mod span;
 mod vm;
 
-use env::{Env, RunOutput};
+use env::{cache_t, Env, RunOutput};
 use owasm::core;
+use owasm::core::cache::{Cache, CacheOptions};
 use span::Span;
 
+#[no_mangle]
+pub extern "C" fn init_cache(cache_size: u32) -> *mut cache_t {
+    Box::into_raw(Box::new(Cache::new(CacheOptions { cache_size }))) as *mut cache_t
+}
+
+#[no_mangle]
+pub extern "C" fn release_cache(cache: *mut cache_t) {
+    if !cache.is_null() {
+        // The cache is freed when the box goes out of scope.
+        let _ = unsafe { Box::from_raw(cache as *mut Cache) };
+    }
+}
+
 #[no_mangle]
 pub extern "C" fn do_compile(input: Span, output: &mut Span) -> owasm::core::error::Error {
     match core::compile(input.read()) {
@@ -19,6 +33,7 @@ pub extern "C" fn do_compile(input: Span, output: &mut Span) -> owasm::core::err
 
 #[no_mangle]
 pub extern "C" fn do_run(
+    cache: *mut cache_t,
     code: Span,
     gas_limit: u32,
     span_size: i64,
@@ -26,8 +41,10 @@ pub extern "C" fn do_run(
     env: Env,
     output: &mut RunOutput,
 ) -> owasm::core::error::Error {
+    // The cache guards its own entries, so runs from any number of threads may share it.
+    let cache = unsafe { &*(cache as *const Cache) };
     let vm_env = vm::VMEnv::new(env, span_size);
-    match core::run(code.read(), gas_limit, is_prepare, &vm_env) {
+    match core::run(cache, code.read(), gas_limit, is_prepare, &vm_env) {
         Ok(gas_used) => {
             output.gas_used = gas_used;
             owasm::core::error::Error::NoError
@@ -82,4 +82,60 @@ impl vm::Env for VMEnv {
             err => Err(err),
         }
     }
+
+    fn get_request_id(&self) -> i64 { (self.env.dis.get_request_id)(self.env.env) }
+
+    fn get_request_height(&self) -> i64 { (self.env.dis.get_request_height)(self.env.env) }
+
+    fn get_request_time(&self) -> i64 { (self.env.dis.get_request_time)(self.env.env) }
+
+    fn get_client_id(&self) -> Result<Vec<u8>, Error> {
+        let mut mem: Vec<u8> = Vec::with_capacity(self.span_size as usize);
+        let mut span = Span::create_writable(mem.as_mut_ptr(), self.span_size as usize);
+        match (self.env.dis.get_client_id)(self.env.env, &mut span) {
+            Error::NoError => {
+                unsafe {
+                    mem.set_len(span.len);
+                }
+                Ok(mem)
+            }
+            err => Err(err),
+        }
+    }
+
+    fn get_validator_address(&self, vid: i64) -> Result<Vec<u8>, Error> {
+        let mut mem: Vec<u8> = Vec::with_capacity(self.span_size as usize);
+        let mut span = Span::create_writable(mem.as_mut_ptr(), self.span_size as usize);
+        match (self.env.dis.get_validator_address)(self.env.env, vid, &mut span) {
+            Error::NoError => {
+                unsafe {
+                    mem.set_len(span.len);
+                }
+                Ok(mem)
+            }
+            err => Err(err),
+        }
+    }
+
+    fn get_validator_power(&self, vid: i64) -> Result<i64, Error> {
+        let mut power = 0;
+        match (self.env.dis.get_validator_power)(self.env.env, vid, &mut power) {
+            Error::NoError => Ok(power),
+            err => Err(err),
+        }
+    }
+
+    fn get_random_value(&self) -> Result<Vec<u8>, Error> {
+        let mut mem: Vec<u8> = Vec::with_capacity(self.span_size as usize);
+        let mut span = Span::create_writable(mem.as_mut_ptr(), self.span_size as usize);
+        match (self.env.dis.get_random_value)(self.env.env, &mut span) {
+            Error::NoError => {
+                unsafe {
+                    mem.set_len(span.len);
+                }
+                Ok(mem)
+            }
+            err => Err(err),
+        }
+    }
 }
@@ -0,0 +1,74 @@
+use crate::core::error::Error;
+
+use std::collections::HashMap;
+use std::sync::Mutex;
+use wasmer_runtime_core::Module;
+
+/// Options to create a `Cache` with.
+pub struct CacheOptions {
+    pub cache_size: u32, // Maximum number of compiled modules to keep. Zero disables caching.
+}
+
+/// A `Cache` keeps the Wasmer modules of recently run Owasm code, so running the same oracle
+/// script again skips native compilation. The least recently used module is evicted when full.
+/// The cache is shared by every run of a VM, so its entries are guarded by a mutex.
+pub struct Cache {
+    size: usize,
+    inner: Mutex<CacheInner>,
+}
+
+struct CacheInner {
+    clock: u64, // Incremented on every access, to order entries by their last use.
+    modules: HashMap<Vec<u8>, (Module, u64)>,
+}
+
+impl Cache {
+    pub fn new(options: CacheOptions) -> Self {
+        Self {
+            size: options.cache_size as usize,
+            inner: Mutex::new(CacheInner { clock: 0, modules: HashMap::new() }),
+        }
+    }
+
+    /// Returns the module of the given Owasm code, calling `compile_fn` to build it on cache miss.
+    /// The lock is not held while compiling, so runs of other code are never blocked by it.
+    pub fn get_or_compile<F>(&self, code: &[u8], compile_fn: F) -> Result<Module, Error>
+    where
+        F: FnOnce(&[u8]) -> Result<Module, Error>,
+    {
+        {
+            let mut inner = self.inner.lock().map_err(|_| Error::UnknownError)?;
+            inner.clock += 1;
+            let clock = inner.clock;
+            if let Some(entry) = inner.modules.get_mut(code) {
+                entry.1 = clock;
+                return Ok(entry.0.clone());
+            }
+        }
+        let module = compile_fn(code)?;
+        if self.size == 0 {
+            return Ok(module);
+        }
+        let mut inner = self.inner.lock().map_err(|_| Error::UnknownError)?;
+        inner.clock += 1;
+        let clock = inner.clock;
+        if inner.modules.contains_key(code) {
+            // Compiled concurrently by another run, which already saved it.
+            return Ok(module);
+        }
+        if inner.modules.len() >= self.size {
+            let oldest =
+                inner.modules.iter().min_by_key(|(_, entry)| entry.1).map(|(k, _)| k.clone());
+            if let Some(key) = oldest {
+                inner.modules.remove(&key);
+            }
+        }
+        inner.modules.insert(code.to_vec(), (module.clone(), clock));
+        Ok(module)
+    }
+
+    /// Returns the number of modules in the cache.
+    pub fn len(&self) -> usize {
+        self.inner.lock().map(|inner| inner.modules.len()).unwrap_or(0)
+    }
+}
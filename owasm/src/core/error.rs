@@ -27,6 +27,7 @@ pub enum Error {
     BadExternalIDError = 132,     // Bad external ID parameter.
     UnavailableExternalDataError = 133, // External data is not available.
     RepeatSetReturnDataError = 134, // Set return data is called more than once.
+    UnavailableRandomValueError = 135, // Random value of the request is not available.
     // Unexpected error
     UnknownError = 255,
 }
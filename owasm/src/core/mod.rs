@@ -1,6 +1,8 @@
+pub mod cache;
 pub mod error;
 pub mod vm;
 
+use cache::Cache;
 pub use error::Error;
 use parity_wasm::builder;
 use parity_wasm::elements::{self, External, ImportEntry, MemoryType, Module};
@@ -27,6 +29,13 @@ static SUPPORTED_IMPORTS: &[&str] = &[
     "env.ask_external_data",
     "env.get_external_data_status",
     "env.read_external_data",
+    "env.get_request_id",
+    "env.get_request_height",
+    "env.get_request_time",
+    "env.read_client_id",
+    "env.read_validator_address",
+    "env.get_validator_power",
+    "env.read_random_value",
 ];
 
 fn inject_memory(module: Module) -> Result<Module, Error> {
@@ -128,7 +137,25 @@ fn require_mem_range(max_range: usize, require_range: usize) -> Result<(), Error
     return Ok(());
 }
 
-pub fn run<E>(code: &[u8], gas: u32, is_prepare: bool, env: &E) -> Result<u32, Error>
+fn compile_module(code: &[u8]) -> Result<wasmer_runtime_core::Module, Error> {
+    wasmer_runtime_core::compile_with_config(
+        code,
+        &wasmer_singlepass_backend::SinglePassCompiler::new(),
+        wasmer_runtime_core::backend::CompilerConfig {
+            nan_canonicalization: true,
+            ..Default::default()
+        },
+    )
+    .map_err(|_| Error::InstantiationError)
+}
+
+pub fn run<E>(
+    cache: &Cache,
+    code: &[u8],
+    gas: u32,
+    is_prepare: bool,
+    env: &E,
+) -> Result<u32, Error>
 where
     E: vm::Env,
 {
@@ -206,18 +233,59 @@ where
                 }
                 Ok(data.len() as i64)
             }),
+            "get_request_id" => func!(|ctx: &mut Ctx| {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                vm.env.get_request_id()
+            }),
+            "get_request_height" => func!(|ctx: &mut Ctx| {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                vm.env.get_request_height()
+            }),
+            "get_request_time" => func!(|ctx: &mut Ctx| {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                vm.env.get_request_time()
+            }),
+            "read_client_id" => func!(|ctx: &mut Ctx, ptr: i64| -> Result<i64, Error> {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                let span_size = vm.env.get_span_size();
+                vm.consume_gas(span_size as u32)?;
+                require_mem_range(ctx.memory(0).size().bytes().0, (ptr + span_size) as usize)?;
+                let data = vm.env.get_client_id()?;
+                for (idx, byte) in data.iter().enumerate() {
+                    ctx.memory(0).view()[ptr as usize + idx].set(*byte)
+                }
+                Ok(data.len() as i64)
+            }),
+            "read_validator_address" => func!(|ctx: &mut Ctx, vid: i64, ptr: i64| -> Result<i64, Error> {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                let span_size = vm.env.get_span_size();
+                vm.consume_gas(span_size as u32)?;
+                require_mem_range(ctx.memory(0).size().bytes().0, (ptr + span_size) as usize)?;
+                let data = vm.env.get_validator_address(vid)?;
+                for (idx, byte) in data.iter().enumerate() {
+                    ctx.memory(0).view()[ptr as usize + idx].set(*byte)
+                }
+                Ok(data.len() as i64)
+            }),
+            "get_validator_power" => func!(|ctx: &mut Ctx, vid: i64| {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                vm.env.get_validator_power(vid)
+            }),
+            "read_random_value" => func!(|ctx: &mut Ctx, ptr: i64| -> Result<i64, Error> {
+                let vm: &mut vm::VMLogic<E> = unsafe { &mut *(ctx.data as *mut vm::VMLogic<E>) };
+                let span_size = vm.env.get_span_size();
+                vm.consume_gas(span_size as u32)?;
+                require_mem_range(ctx.memory(0).size().bytes().0, (ptr + span_size) as usize)?;
+                let data = vm.env.get_random_value()?;
+                for (idx, byte) in data.iter().enumerate() {
+                    ctx.memory(0).view()[ptr as usize + idx].set(*byte)
+                }
+                Ok(data.len() as i64)
+            }),
         },
     };
 
-    let module = wasmer_runtime_core::compile_with_config(
-        code,
-        &wasmer_singlepass_backend::SinglePassCompiler::new(),
-        wasmer_runtime_core::backend::CompilerConfig {
-            nan_canonicalization: true,
-            ..Default::default()
-        },
-    )
-    .map_err(|_| Error::InstantiationError)?;
+    let module = cache.get_or_compile(code, compile_module)?;
     let instance = module.instantiate(&import_object).map_err(|_| Error::InstantiationError)?;
     let entry = if is_prepare { "prepare" } else { "execute" };
     let function: Func<(), ()> =
@@ -479,6 +547,21 @@ mod test {
         );
         let module = get_module_from_wasm(&wasm);
         assert_eq!(check_wasm_imports(&module), Ok(()));
+        let wasm = wat2wasm(
+            r#"(module
+                (type (func (result i64)))
+                (type (func (param i64) (result i64)))
+                (type (func (param i64 i64) (result i64)))
+                (import "env" "get_request_id" (func (type 0)))
+                (import "env" "get_request_height" (func (type 0)))
+                (import "env" "get_request_time" (func (type 0)))
+                (import "env" "read_client_id" (func (type 1)))
+                (import "env" "read_validator_address" (func (type 2)))
+                (import "env" "get_validator_power" (func (type 1)))
+                (import "env" "read_random_value" (func (type 1))))"#,
+        );
+        let module = get_module_from_wasm(&wasm);
+        assert_eq!(check_wasm_imports(&module), Ok(()));
     }
 
     #[test]
@@ -498,4 +581,36 @@ mod test {
         let module = get_module_from_wasm(&wasm);
         assert_eq!(check_wasm_exports(&module), Ok(()));
     }
+
+    #[test]
+    fn test_cache_evicts_least_recently_used() {
+        let wasm1 = wat2wasm(r#"(module (func $prepare (export "prepare")))"#);
+        let wasm2 = wat2wasm(r#"(module (func $execute (export "execute")))"#);
+        let wasm3 = wat2wasm(r#"(module (memory 1))"#);
+        let cache = Cache::new(cache::CacheOptions { cache_size: 2 });
+        let compiled = std::cell::Cell::new(0);
+        let compile_fn = |code: &[u8]| {
+            compiled.set(compiled.get() + 1);
+            compile_module(code)
+        };
+        cache.get_or_compile(&wasm1, compile_fn).unwrap();
+        cache.get_or_compile(&wasm2, compile_fn).unwrap();
+        cache.get_or_compile(&wasm1, compile_fn).unwrap();
+        assert_eq!(compiled.get(), 2);
+        // wasm2 is the least recently used, so it is evicted.
+        cache.get_or_compile(&wasm3, compile_fn).unwrap();
+        assert_eq!(cache.len(), 2);
+        cache.get_or_compile(&wasm1, compile_fn).unwrap();
+        assert_eq!(compiled.get(), 3);
+        cache.get_or_compile(&wasm2, compile_fn).unwrap();
+        assert_eq!(compiled.get(), 4);
+    }
+
+    #[test]
+    fn test_cache_disabled() {
+        let wasm = wat2wasm(r#"(module (func $prepare (export "prepare")))"#);
+        let cache = Cache::new(cache::CacheOptions { cache_size: 0 });
+        cache.get_or_compile(&wasm, compile_module).unwrap();
+        assert_eq!(cache.len(), 0);
+    }
 }
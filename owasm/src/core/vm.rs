@@ -19,6 +19,22 @@ pub trait Env {
     fn get_external_data_status(&self, eid: i64, vid: i64) -> Result<i64, Error>;
     /// Returns data span with the data id `eid` from validator index `vid`.
     fn get_external_data(&self, eid: i64, vid: i64) -> Result<Vec<u8>, Error>;
+    /// Returns the ID of the current request.
+    fn get_request_id(&self) -> i64;
+    /// Returns the block height at which the current request was submitted.
+    fn get_request_height(&self) -> i64;
+    /// Returns the block time in UNIX seconds at which the current request was submitted.
+    fn get_request_time(&self) -> i64;
+    /// Returns the client ID of the current request, or returns error from VM runner.
+    fn get_client_id(&self) -> Result<Vec<u8>, Error>;
+    /// Returns the address of the validator at validator index `vid`.
+    fn get_validator_address(&self, vid: i64) -> Result<Vec<u8>, Error>;
+    /// Returns the bonded tokens at resolve time of the validator at validator index `vid`,
+    /// or error from VM runner if called on wrong period.
+    fn get_validator_power(&self, vid: i64) -> Result<i64, Error>;
+    /// Returns the random value of the randomness beacon for the current request, or error from
+    /// VM runner if the value is not available.
+    fn get_random_value(&self) -> Result<Vec<u8>, Error>;
 }
 
 /// A `VMLogic` encapsulates the runtime logic of Owasm scripts.
@@ -47,6 +47,71 @@ where
     median_by(data, T::cmp)
 }
 
+/// Returns the weighted average value of the given data set of (value, weight) pairs, or None
+/// if data is empty or the total weight is zero.
+pub fn weighted_mean<T>(data: Vec<(T, T)>) -> Option<T>
+where
+    T: Num + Copy,
+{
+    let mut sum = T::zero();
+    let mut total = T::zero();
+    for (v, w) in data {
+        sum = sum + v * w;
+        total = total + w;
+    }
+    if total == T::zero() {
+        None
+    } else {
+        Some(sum / total)
+    }
+}
+
+/// Returns the weighted median value of the given data set of (value, weight) pairs using the
+/// given compare function, or None if data has no positive weight or any weight is negative.
+/// If the weight is split evenly between two values, their average is returned.
+pub fn weighted_median_by<T, W, F>(mut data: Vec<(T, W)>, mut compare: F) -> Option<T>
+where
+    T: Num,
+    W: Num + PartialOrd + Copy,
+    F: FnMut(&T, &T) -> Ordering,
+{
+    if data.iter().any(|(_, w)| *w < W::zero()) {
+        return None;
+    }
+    data.retain(|(_, w)| *w != W::zero());
+    if data.len() == 0 {
+        return None;
+    }
+    data.sort_by(|lhs, rhs| compare(&lhs.0, &rhs.0));
+    let total = data.iter().fold(W::zero(), |acc, (_, w)| acc + *w);
+    let mut cumulative = W::zero();
+    let mut mid = 0;
+    loop {
+        cumulative = cumulative + data[mid].1;
+        if cumulative + cumulative >= total {
+            break;
+        }
+        mid = mid + 1;
+    }
+    if cumulative + cumulative == total && mid + 1 < data.len() {
+        let (rhs, _) = data.swap_remove(mid + 1);
+        let (lhs, _) = data.swap_remove(mid);
+        Some((lhs + rhs) / (T::one() + T::one()))
+    } else {
+        Some(data.swap_remove(mid).0)
+    }
+}
+
+/// Returns the weighted median value of the given data set of (value, weight) pairs, or None
+/// if data has no positive weight or any weight is negative.
+pub fn weighted_median<T, W>(data: Vec<(T, W)>) -> Option<T>
+where
+    T: Ord + Num,
+    W: Num + PartialOrd + Copy,
+{
+    weighted_median_by(data, T::cmp)
+}
+
 /// Returns the majority value of the given data set, or None if there is no majority.
 pub fn majority<T>(mut data: Vec<T>) -> Option<T>
 where
@@ -169,6 +234,72 @@ mod tests {
         assert_eq!(median_by(vals, cmp::fcmp), Some(24.6));
     }
 
+    #[test]
+    fn test_weighted_mean_empty() {
+        let vals: Vec<(i64, i64)> = vec![];
+        assert_eq!(weighted_mean(vals), None);
+        let vals = vec![(3, 0), (5, 0)];
+        assert_eq!(weighted_mean(vals), None);
+    }
+
+    #[test]
+    fn test_weighted_mean_int() {
+        let vals = vec![(3, 1), (2, 1), (5, 1), (7, 1), (2, 1), (9, 1), (1, 1)];
+        assert_eq!(weighted_mean(vals), Some(4));
+        let vals = vec![(10, 3), (20, 1)];
+        assert_eq!(weighted_mean(vals), Some(12));
+    }
+
+    #[test]
+    fn test_weighted_mean_float() {
+        let vals = vec![(1.0, 1.0), (2.0, 3.0)];
+        assert_eq!(weighted_mean(vals), Some(1.75));
+        let vals = vec![(3.0, 0.0), (4.5, 2.0)];
+        assert_eq!(weighted_mean(vals), Some(4.5));
+    }
+
+    #[test]
+    fn test_weighted_median_empty() {
+        let vals: Vec<(i64, i64)> = vec![];
+        assert_eq!(weighted_median(vals), None);
+        let vals = vec![(3, 0), (5, 0)];
+        assert_eq!(weighted_median(vals), None);
+    }
+
+    #[test]
+    fn test_weighted_median_negative_weight() {
+        let vals = vec![(3, 2), (5, -1)];
+        assert_eq!(weighted_median(vals), None);
+    }
+
+    #[test]
+    fn test_weighted_median_equal_weights() {
+        let vals = vec![(3, 1), (2, 1), (5, 1), (7, 1), (2, 1), (9, 1), (1, 1)];
+        assert_eq!(weighted_median(vals), Some(3));
+        let vals = vec![(13, 5), (36, 5), (33, 5), (45, 5)];
+        assert_eq!(weighted_median(vals), Some(34));
+    }
+
+    #[test]
+    fn test_weighted_median_int() {
+        let vals = vec![(1, 1), (2, 1), (3, 5)];
+        assert_eq!(weighted_median(vals), Some(3));
+        let vals = vec![(100, 10), (1, 1), (2, 1), (3, 1)];
+        assert_eq!(weighted_median(vals), Some(100));
+        let vals = vec![(1, 2), (5, 1), (9, 1)];
+        assert_eq!(weighted_median(vals), Some(3));
+        let vals = vec![(1, 2), (5, 0), (9, 2)];
+        assert_eq!(weighted_median(vals), Some(5));
+    }
+
+    #[test]
+    fn test_weighted_median_float() {
+        let vals = vec![(3.5, 1), (2.7, 4), (5.1, 2)];
+        assert_eq!(weighted_median_by(vals, cmp::fcmp), Some(2.7));
+        let vals = vec![(3.0, 1.5), (4.0, 1.5)];
+        assert_eq!(weighted_median_by(vals, cmp::fcmp), Some(3.5));
+    }
+
     #[test]
     fn test_majority_int() {
         let vals = vec![1, 2, 3, 1, 3, 1, 1];
@@ -55,3 +55,60 @@ pub fn get_external_data(eid: i64, vid: i64) -> Result<String, i64> {
         }
     }
 }
+
+/// Returns the ID of the oracle request.
+pub fn get_request_id() -> i64 {
+    unsafe { raw::get_request_id() }
+}
+
+/// Returns the block height at which the oracle request was submitted.
+pub fn get_request_height() -> i64 {
+    unsafe { raw::get_request_height() }
+}
+
+/// Returns the block time, in UNIX seconds, at which the oracle request was submitted.
+pub fn get_request_time() -> i64 {
+    unsafe { raw::get_request_time() }
+}
+
+/// Returns the client ID as specified when the oracle request is submitted.
+pub fn get_client_id() -> String {
+    unsafe {
+        let mut data = Vec::with_capacity(raw::get_span_size() as usize);
+        let len = raw::read_client_id(data.as_mut_ptr() as i64);
+        data.set_len(len as usize);
+        String::from_utf8_unchecked(data)
+    }
+}
+
+/// Returns the operator address, in Bech32 format, of the validator at the given validator
+/// index, which is also the index used by `get_external_data`.
+pub fn get_validator_address(vid: i64) -> String {
+    unsafe {
+        let mut data = Vec::with_capacity(raw::get_span_size() as usize);
+        let len = raw::read_validator_address(vid, data.as_mut_ptr() as i64);
+        data.set_len(len as usize);
+        String::from_utf8_unchecked(data)
+    }
+}
+
+/// Returns the amount of bonded tokens, at the time the oracle request is resolved, of the
+/// validator at the given validator index. Validators that did not report have zero power.
+/// Must only be called during execution phase.
+pub fn get_validator_power(vid: i64) -> i64 {
+    unsafe { raw::get_validator_power(vid) }
+}
+
+/// Returns the random value of the randomness beacon for the oracle request. During preparation
+/// phase, it is the value of the latest block. During execution phase, it is the value of the
+/// block at which the request was submitted, which was unknown to the requester at that time. The
+/// oracle script fails if that block has no VRF-backed value, unless the chain falls back to its
+/// rolling seed.
+pub fn get_random_value() -> Vec<u8> {
+    unsafe {
+        let mut data = Vec::with_capacity(raw::get_span_size() as usize);
+        let len = raw::read_random_value(data.as_mut_ptr() as i64);
+        data.set_len(len as usize);
+        data
+    }
+}
@@ -8,4 +8,11 @@ extern "C" {
     pub fn ask_external_data(eid: i64, did: i64, offset: i64, len: i64);
     pub fn get_external_data_status(eid: i64, vid: i64) -> i64;
     pub fn read_external_data(eid: i64, vid: i64, offset: i64) -> i64;
+    pub fn get_request_id() -> i64;
+    pub fn get_request_height() -> i64;
+    pub fn get_request_time() -> i64;
+    pub fn read_client_id(offset: i64) -> i64;
+    pub fn read_validator_address(vid: i64, offset: i64) -> i64;
+    pub fn get_validator_power(vid: i64) -> i64;
+    pub fn read_random_value(offset: i64) -> i64;
 }